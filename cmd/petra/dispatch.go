@@ -23,8 +23,7 @@ func makeDispatchFunc(
 	sender *notification.Sender,
 ) notification.DispatchFunc {
 	return func(ctx context.Context, push domain.ScheduledPush) error {
-		ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, push.UserID)
-		ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+		ctx = contexthelpers.WithAuthenticatedUserID(ctx, push.UserID)
 
 		// Recheck the user's opt-in — they may have flipped it off mid-rest.
 		prefs, err := svc.GetUserPreferences(ctx)
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/myrjola/petrapp/internal/platform/auth"
+)
+
+// profileBodyMaxBytes caps the PUT /account/profile body; a display name is
+// at most auth.MaxDisplayNameLength characters.
+const profileBodyMaxBytes = 1024
+
+// accountProfile is the JSON body of GET and PUT /account/profile.
+type accountProfile struct {
+	DisplayName string `json:"display_name"`
+}
+
+// accountProfileGET returns the authenticated user's profile.
+func (app *application) accountProfileGET(w http.ResponseWriter, r *http.Request) {
+	displayName, err := app.webAuthnHandler.DisplayName(r.Context())
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("get display name: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(accountProfile{DisplayName: displayName}); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode account profile: %w", err))
+	}
+}
+
+// accountProfilePUT updates the authenticated user's display name and
+// returns the stored profile. A name auth.ValidateDisplayName rejects gets a
+// 400 explaining why.
+func (app *application) accountProfilePUT(w http.ResponseWriter, r *http.Request) {
+	r.Body = http.MaxBytesReader(w, r.Body, profileBodyMaxBytes)
+	var req accountProfile
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Request body must be JSON with a display_name.", http.StatusBadRequest)
+		return
+	}
+	if err := app.webAuthnHandler.SetDisplayName(r.Context(), req.DisplayName); err != nil {
+		if errors.Is(err, auth.ErrInvalidDisplayName) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		app.serverError(w, r, fmt.Errorf("set display name: %w", err))
+		return
+	}
+	app.accountProfileGET(w, r)
+}
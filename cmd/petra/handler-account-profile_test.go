@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+func Test_AccountProfile(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	url := server.URL() + "/account/profile"
+
+	t.Run("valid update", func(t *testing.T) {
+		resp, putErr := putJSON(ctx, client, url, `{"display_name": "  Alex Müller "}`)
+		if putErr != nil {
+			t.Fatalf("put profile: %v", putErr)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+
+		getResp, getErr := client.Get(ctx, "/account/profile")
+		if getErr != nil {
+			t.Fatalf("get profile: %v", getErr)
+		}
+		defer func() { _ = getResp.Body.Close() }()
+		var profile accountProfile
+		if err = json.NewDecoder(getResp.Body).Decode(&profile); err != nil {
+			t.Fatalf("decode profile: %v", err)
+		}
+		if profile.DisplayName != "Alex Müller" {
+			t.Errorf("display_name = %q, want the trimmed %q", profile.DisplayName, "Alex Müller")
+		}
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		for _, body := range []string{`{"display_name": "Alex\nadmin"}`, `{"display_name": ""}`} {
+			resp, putErr := putJSON(ctx, client, url, body)
+			if putErr != nil {
+				t.Fatalf("put profile: %v", putErr)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != http.StatusBadRequest {
+				t.Errorf("%s: status = %d, want %d", body, resp.StatusCode, http.StatusBadRequest)
+			}
+		}
+	})
+}
+
+func putJSON(ctx context.Context, c *e2etest.Client, url, body string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, url, bytes.NewReader([]byte(body)))
+	if err != nil {
+		return nil, fmt.Errorf("new request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.HTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("do request: %w", err)
+	}
+	return resp, nil
+}
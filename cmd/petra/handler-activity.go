@@ -0,0 +1,79 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+const (
+	// defaultActivityLimit is how many items GET /api/activity returns when
+	// the limit parameter is absent.
+	defaultActivityLimit = 20
+	// maxActivityLimit bounds the limit parameter.
+	maxActivityLimit = 100
+)
+
+// activityItemResponse is one entry of GET /api/activity. Type is one of
+// workout_completed, workout_missed, exercise_skipped, rest_day and
+// personal_record; the other fields are present only where they apply.
+type activityItemResponse struct {
+	Date                string                  `json:"date"`
+	Type                string                  `json:"type"`
+	WorkoutType         string                  `json:"workout_type,omitempty"`
+	ExerciseID          int                     `json:"exercise_id,omitempty"`
+	ExerciseName        string                  `json:"exercise_name,omitempty"`
+	PersonalRecord      *personalRecordResponse `json:"personal_record,omitempty"`
+	PersonalRecordCount int                     `json:"personal_record_count,omitempty"`
+}
+
+// activityGET returns the user's recent activity feed, newest first: up to
+// limit (1–100, default 20) completed and missed workouts, rest days,
+// skipped exercises and personal records.
+func (app *application) activityGET(w http.ResponseWriter, r *http.Request) {
+	limit := defaultActivityLimit
+	if raw := r.URL.Query().Get("limit"); raw != "" {
+		var err error
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 || limit > maxActivityLimit {
+			http.Error(w, "Invalid limit parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	items, err := app.service.ActivityFeed(r.Context(), contexthelpers.AuthenticatedUserID(r.Context()), limit)
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("activity feed: %w", err))
+		return
+	}
+
+	resp := make([]activityItemResponse, 0, len(items))
+	for _, item := range items {
+		entry := activityItemResponse{
+			Date:                item.Date.Format(time.DateOnly),
+			Type:                string(item.Kind),
+			WorkoutType:         string(item.WorkoutType),
+			ExerciseID:          0,
+			ExerciseName:        "",
+			PersonalRecord:      nil,
+			PersonalRecordCount: item.PersonalRecordCount,
+		}
+		if item.Exercise != nil {
+			entry.ExerciseID = item.Exercise.ID
+			entry.ExerciseName = item.Exercise.Name
+		}
+		if item.PersonalRecord != nil {
+			pr := newPersonalRecordResponse(*item.PersonalRecord)
+			entry.PersonalRecord = &pr
+		}
+		resp = append(resp, entry)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode activity feed: %w", err))
+	}
+}
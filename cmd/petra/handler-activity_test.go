@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_ActivityGET checks the JSON shape and the limit parameter. Merging and
+// ordering are covered in the domain and service.
+func Test_ActivityGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/api/activity?limit=5")
+	if err != nil {
+		t.Fatalf("get activity: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var items []map[string]json.RawMessage
+	if err = json.NewDecoder(resp.Body).Decode(&items); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if items == nil || len(items) > 5 {
+		t.Errorf("items = %v, want an array of at most 5", items)
+	}
+	for _, item := range items {
+		for _, key := range []string{"date", "type"} {
+			if _, ok := item[key]; !ok {
+				t.Errorf("item is missing %q: %v", key, item)
+			}
+		}
+	}
+
+	for _, limit := range []string{"0", "101", "ten"} {
+		bad, getErr := client.Get(ctx, "/api/activity?limit="+limit)
+		if getErr != nil {
+			t.Fatalf("get activity limit=%s: %v", limit, getErr)
+		}
+		_ = bad.Body.Close()
+		if bad.StatusCode != http.StatusBadRequest {
+			t.Errorf("limit=%s: status = %d, want %d", limit, bad.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
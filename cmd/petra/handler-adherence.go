@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+// defaultAdherenceDays is the window GET /api/adherence covers when the
+// days parameter is absent.
+const defaultAdherenceDays = 28
+
+// adherenceResponse is the JSON body of GET /api/adherence.
+type adherenceResponse struct {
+	Days      int                         `json:"days"`
+	Exercises []exerciseAdherenceResponse `json:"exercises"`
+}
+
+// exerciseAdherenceResponse is one exercise's adherence. Rate is
+// on_target_sets over prescribed_sets, in [0,1].
+type exerciseAdherenceResponse struct {
+	ExerciseID     int     `json:"exercise_id"`
+	ExerciseName   string  `json:"exercise_name"`
+	PrescribedSets int     `json:"prescribed_sets"`
+	CompletedSets  int     `json:"completed_sets"`
+	OnTargetSets   int     `json:"on_target_sets"`
+	Rate           float64 `json:"rate"`
+}
+
+// adherenceGET returns prescribed-versus-completed sets per exercise over the
+// last days days (1–365, default 28), sorted by exercise name.
+func (app *application) adherenceGET(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseDaysParam(w, r, defaultAdherenceDays)
+	if !ok {
+		return
+	}
+
+	report, err := app.service.AdherenceReport(r.Context(), contexthelpers.AuthenticatedUserID(r.Context()), days)
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("adherence report: %w", err))
+		return
+	}
+
+	resp := adherenceResponse{
+		Days:      days,
+		Exercises: make([]exerciseAdherenceResponse, 0, len(report)),
+	}
+	for _, a := range report {
+		resp.Exercises = append(resp.Exercises, exerciseAdherenceResponse{
+			ExerciseID:     a.Exercise.ID,
+			ExerciseName:   a.Exercise.Name,
+			PrescribedSets: a.PrescribedSets,
+			CompletedSets:  a.CompletedSets,
+			OnTargetSets:   a.OnTargetSets,
+			Rate:           a.Rate(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode adherence: %w", err))
+	}
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_AdherenceGET seeds yesterday's workout with one exercise: one set
+// completed within its rep range, one completed below it and two never done,
+// so adherence reads one on-target set in four.
+func Test_AdherenceGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	db := server.DB()
+	var exerciseID, repMin int
+	if err = db.QueryRowContext(ctx, `
+		SELECT id, rep_min FROM exercises WHERE rep_min IS NOT NULL AND rep_min > 1
+		ORDER BY id LIMIT 1`).Scan(&exerciseID, &repMin); err != nil {
+		t.Fatalf("find exercise with a rep range: %v", err)
+	}
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	// Only the seeded workout should count.
+	if _, err = db.ExecContext(ctx, "DELETE FROM workout_sessions"); err != nil {
+		t.Fatalf("clear sessions: %v", err)
+	}
+	if _, err = db.ExecContext(ctx,
+		"INSERT INTO workout_sessions (user_id, workout_date) SELECT id, ? FROM users", yesterday); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	if _, err = db.ExecContext(ctx, `
+		INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		SELECT id, ?, 0, ? FROM users`, yesterday, exerciseID); err != nil {
+		t.Fatalf("insert slot: %v", err)
+	}
+	sets := []struct {
+		number    int
+		completed any
+	}{
+		{1, repMin},
+		{2, repMin - 1},
+		{3, nil},
+		{4, nil},
+	}
+	for _, s := range sets {
+		completedAt := any(nil)
+		if s.completed != nil {
+			completedAt = "2026-01-01T10:00:00.000Z"
+		}
+		if _, err = db.ExecContext(ctx, `
+			INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+			                           weight_kg, target_value, completed_value, completed_at)
+			SELECT id, ?, 0, ?, 40, ?, ?, ? FROM users`,
+			yesterday, s.number, repMin, s.completed, completedAt); err != nil {
+			t.Fatalf("insert set %d: %v", s.number, err)
+		}
+	}
+
+	resp, err := client.Get(ctx, "/api/adherence?days=7")
+	if err != nil {
+		t.Fatalf("get adherence: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body adherenceResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Days != 7 || len(body.Exercises) != 1 {
+		t.Fatalf("body = %+v, want 7 days and the one seeded exercise", body)
+	}
+	got := body.Exercises[0]
+	if got.ExerciseID != exerciseID || got.PrescribedSets != 4 || got.CompletedSets != 2 || got.OnTargetSets != 1 {
+		t.Errorf("adherence = %+v, want 4 prescribed, 2 completed, 1 on target", got)
+	}
+	if math.Abs(got.Rate-0.25) > 1e-9 {
+		t.Errorf("rate = %v, want 0.25", got.Rate)
+	}
+
+	bad, err := client.Get(ctx, "/api/adherence?days=0")
+	if err != nil {
+		t.Fatalf("get adherence with days=0: %v", err)
+	}
+	_ = bad.Body.Close()
+	if bad.StatusCode != http.StatusBadRequest {
+		t.Errorf("days=0 status = %d, want %d", bad.StatusCode, http.StatusBadRequest)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"html/template"
 	"log/slog"
+	"math"
 	"net/http"
 	"slices"
 	"strconv"
@@ -23,6 +24,9 @@ const (
 	exFieldStartingSeconds  = "default_starting_seconds"
 	exFieldRepMin           = "rep_min"
 	exFieldRepMax           = "rep_max"
+	exFieldStartWeight      = "default_start_weight_kg"
+	exFieldMinDaysBetween   = "min_days_between"
+	exFieldComplexity       = "complexity"
 	exFieldPrimaryMuscles   = "primary_muscles"
 	exFieldSecondaryMuscles = "secondary_muscles"
 	exFieldInstructions     = "instructions"
@@ -64,6 +68,11 @@ type exerciseEditTemplateData struct {
 	SecondsField FieldData
 	RepMinField  FieldData
 	RepMaxField  FieldData
+	// StartWeightField is blank when no start weight is configured.
+	StartWeightField FieldData
+	SpacingField     FieldData
+	// ComplexityField is blank for an unrated exercise.
+	ComplexityField FieldData
 	// Selects and line-delimited textareas (one instruction/mistake per line,
 	// resources as "Title | URL" per line) rendered through shared components.
 	CategorySelect        SelectData
@@ -241,6 +250,42 @@ func newExerciseEditData(
 			Max:      "50",
 			Nonce:    base.Nonce,
 		},
+		StartWeightField: FieldData{ //nolint:exhaustruct // labelled number input; Max/Pattern unused here.
+			Label:    "Default Start Weight (kg)",
+			Name:     exFieldStartWeight,
+			Type:     inputTypeNumber,
+			Value:    fep.value(exFieldStartWeight, startWeightValue(exercise.DefaultStartWeightKg)),
+			Error:    fep.Fields[exFieldStartWeight],
+			Required: false,
+			Hint:     "Load for a user's first-ever session of a weighted exercise; blank starts at 0 kg.",
+			Min:      "0",
+			Step:     "0.5",
+			Nonce:    base.Nonce,
+		},
+		SpacingField: FieldData{ //nolint:exhaustruct // labelled number input; Step/Pattern unused here.
+			Label:    "Minimum Days Between Sessions",
+			Name:     exFieldMinDaysBetween,
+			Type:     inputTypeNumber,
+			Value:    fep.value(exFieldMinDaysBetween, strconv.Itoa(exercise.MinDaysBetween)),
+			Error:    fep.Fields[exFieldMinDaysBetween],
+			Required: false,
+			Hint:     "Fewest days from one session with this exercise to the next; 0 for no extra spacing.",
+			Min:      "0",
+			Max:      "14",
+			Nonce:    base.Nonce,
+		},
+		ComplexityField: FieldData{ //nolint:exhaustruct // labelled number input; Step/Pattern unused here.
+			Label:    "Complexity",
+			Name:     exFieldComplexity,
+			Type:     inputTypeNumber,
+			Value:    fep.value(exFieldComplexity, complexityValue(exercise.Complexity)),
+			Error:    fep.Fields[exFieldComplexity],
+			Required: false,
+			Hint:     "Technique demand from 1 (machine) to 5 (Olympic lift); beginners get low ratings first.",
+			Min:      "1",
+			Max:      "5",
+			Nonce:    base.Nonce,
+		},
 		CategorySelect: buildCategorySelect(
 			fep.value(exFieldCategory, string(exercise.Category)), fep.Fields[exFieldCategory], base.Nonce),
 		TypeSelect: buildTypeSelect(
@@ -294,6 +339,14 @@ func (app *application) adminExerciseUpdatePOST(w http.ResponseWriter, r *http.R
 		repMin = optionalInt(r.PostForm.Get(exFieldRepMin))
 		repMax = optionalInt(r.PostForm.Get(exFieldRepMax))
 	}
+	minDaysBetween := 0
+	if n := optionalInt(r.PostForm.Get(exFieldMinDaysBetween)); n != nil {
+		minDaysBetween = *n
+	}
+	complexity := 0
+	if n := optionalInt(r.PostForm.Get(exFieldComplexity)); n != nil {
+		complexity = *n
+	}
 
 	exercise := domain.Exercise{
 		ID:                     id,
@@ -308,6 +361,9 @@ func (app *application) adminExerciseUpdatePOST(w http.ResponseWriter, r *http.R
 		DefaultStartingSeconds: defaultStartingSeconds,
 		RepMin:                 repMin,
 		RepMax:                 repMax,
+		DefaultStartWeightKg:   optionalFloat(r.PostForm.Get(exFieldStartWeight)),
+		MinDaysBetween:         minDaysBetween,
+		Complexity:             complexity,
 	}
 
 	editPath := fmt.Sprintf("/admin/exercises/%d", id)
@@ -392,8 +448,37 @@ func optionalInt(raw string) *int {
 	return &n
 }
 
-// buildCategoryOptions builds the category <select> options, marking the
-// exercise's current category as selected.
+// optionalFloat parses a form field into a *float64 like optionalInt,
+// accepting ',' as the decimal separator as parseFormWeight does. Non-finite
+// values count as unparseable.
+func optionalFloat(raw string) *float64 {
+	f, err := strconv.ParseFloat(strings.Replace(raw, ",", ".", 1), 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil
+	}
+	return &f
+}
+
+// startWeightValue renders an exercise's default start weight for its form
+// field, leaving an unset one blank.
+func startWeightValue(weightKg *float64) string {
+	if weightKg == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*weightKg, 'f', -1, 64)
+}
+
+// complexityValue renders an exercise complexity for its form field, leaving
+// an unrated (zero) exercise blank.
+func complexityValue(complexity int) string {
+	if complexity == 0 {
+		return ""
+	}
+	return strconv.Itoa(complexity)
+}
+
+// buildCategoryOptions builds the category <select> options, marking current
+// (an exercise's category, or a default choice) as selected.
 func buildCategoryOptions(current domain.Category) []selectOption {
 	return []selectOption{
 		{
@@ -505,8 +590,8 @@ func buildMuscleSelect(
 func buildExerciseErrorSummary(fep formErrorPayload, nonce template.HTMLAttr) ErrorSummaryData {
 	fieldOrder := []string{
 		exFieldName, exFieldCategory, exFieldType, exFieldStartingSeconds,
-		exFieldRepMin, exFieldRepMax, exFieldPrimaryMuscles, exFieldSecondaryMuscles,
-		exFieldInstructions, exFieldCommonMistakes, exFieldResources,
+		exFieldRepMin, exFieldRepMax, exFieldStartWeight, exFieldMinDaysBetween, exFieldComplexity,
+		exFieldPrimaryMuscles, exFieldSecondaryMuscles, exFieldInstructions, exFieldCommonMistakes, exFieldResources,
 	}
 	var items []ErrorSummaryItem
 	seen := make(map[string]bool)
@@ -1,7 +1,9 @@
 package main
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 
@@ -197,6 +199,56 @@ func Test_application_adminExercises(t *testing.T) {
 		}
 	})
 
+	// The repository rewrites the whole exercise row on save, so every column
+	// the form leaves out is wiped. The seeded start weight must survive an
+	// edit that keeps it and take a new value when changed.
+	t.Run("Edit keeps the default start weight", func(t *testing.T) {
+		var id int
+		if err = server.DB().QueryRowContext(ctx,
+			"SELECT id FROM exercises WHERE name = 'Deadlift'").Scan(&id); err != nil {
+			t.Fatalf("Find Deadlift: %v", err)
+		}
+		editURL := fmt.Sprintf("/admin/exercises/%d", id)
+
+		for _, tt := range []struct{ submit, want string }{{submit: "", want: "40"}, {submit: "42.5", want: "42.5"}} {
+			if doc, err = client.GetDoc(ctx, editURL); err != nil {
+				t.Fatalf("Failed to get exercise edit page: %v", err)
+			}
+			shown, _ := doc.Find("input[name='default_start_weight_kg']").Attr("value")
+			submit := tt.submit
+			if submit == "" {
+				submit = shown
+			}
+			var primary []string
+			doc.Find("select[name='primary_muscles'] option[selected]").Each(func(_ int, s *goquery.Selection) {
+				primary = append(primary, s.AttrOr("value", ""))
+			})
+			formData := map[string]string{
+				"name":                    "Deadlift",
+				"category":                "lower",
+				"exercise_type":           "weighted",
+				"primary_muscles":         strings.Join(primary, ","),
+				"rep_min":                 "3",
+				"rep_max":                 "8",
+				"default_start_weight_kg": submit,
+			}
+			if doc, err = client.SubmitForm(ctx, doc, editURL, formData); err != nil {
+				t.Fatalf("Failed to submit exercise update form: %v", err)
+			}
+			if doc.Find("h1").Text() != "Exercise Administration" {
+				t.Fatalf("Expected the save to succeed and return to the list, got %q", doc.Find("h1").Text())
+			}
+			var got float64
+			if err = server.DB().QueryRowContext(ctx,
+				"SELECT default_start_weight_kg FROM exercises WHERE id = ?", id).Scan(&got); err != nil {
+				t.Fatalf("Read default_start_weight_kg: %v", err)
+			}
+			if want := tt.want; strconv.FormatFloat(got, 'f', -1, 64) != want {
+				t.Errorf("default_start_weight_kg after saving %q = %v, want %s", submit, got, want)
+			}
+		}
+	})
+
 	// User input errors must surface as a 200 with a flash message, not a 500.
 	t.Run("Empty name shows validation error instead of 500", func(t *testing.T) {
 		if doc, err = client.GetDoc(ctx, "/admin/exercises"); err != nil {
@@ -0,0 +1,63 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// caloriesResponse is the JSON body of GET /api/workouts/{date}/calories.
+type caloriesResponse struct {
+	Date      string  `json:"date"`
+	Kcal      int     `json:"kcal"`
+	Minutes   float64 `json:"minutes"`
+	TonnageKg float64 `json:"tonnage_kg"`
+	MET       float64 `json:"met"`
+	Caveat    string  `json:"caveat"`
+}
+
+// caloriesGET estimates the calories burned in the completed workout on
+// {date} for the bodyweight given in kilograms by the required bodyweight_kg
+// parameter. An unfinished workout or an out-of-range bodyweight is a 400.
+func (app *application) caloriesGET(w http.ResponseWriter, r *http.Request) {
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
+		return
+	}
+	bodyweightKg, err := strconv.ParseFloat(r.URL.Query().Get("bodyweight_kg"), 64)
+	if err != nil {
+		http.Error(w, "Invalid bodyweight_kg parameter", http.StatusBadRequest)
+		return
+	}
+
+	estimate, err := app.service.EstimateCalories(r.Context(), date, bodyweightKg)
+	if err != nil {
+		var ve domain.ValidationError
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			app.notFound(w, r)
+		case errors.As(err, &ve):
+			http.Error(w, ve.Message, http.StatusBadRequest)
+		default:
+			app.serverError(w, r, fmt.Errorf("estimate calories: %w", err))
+		}
+		return
+	}
+
+	resp := caloriesResponse{
+		Date:      date.Format("2006-01-02"),
+		Kcal:      estimate.Kcal,
+		Minutes:   estimate.Minutes,
+		TonnageKg: estimate.TonnageKg,
+		MET:       estimate.MET,
+		Caveat:    estimate.Caveat,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode calorie estimate: %w", err))
+	}
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_CaloriesGET seeds an hour-long completed workout with 3000 kg lifted
+// and checks the estimate for an 80 kg user, plus the 400s for a missing
+// bodyweight and an unfinished workout.
+func Test_CaloriesGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	db := server.DB()
+	for _, stmt := range []string{
+		`INSERT INTO workout_sessions (user_id, workout_date, started_at, completed_at)
+		 SELECT id, '2026-01-05', '2026-01-05T18:00:00.000Z', '2026-01-05T19:00:00.000Z' FROM users`,
+		`INSERT INTO workout_sessions (user_id, workout_date, started_at)
+		 SELECT id, '2026-01-07', '2026-01-07T18:00:00.000Z' FROM users`,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		 SELECT id, '2026-01-05', 0, (SELECT MIN(id) FROM exercises WHERE exercise_type = 'weighted') FROM users`,
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+		                           weight_kg, target_value, completed_value, completed_at)
+		 SELECT id, '2026-01-05', 0, 1, 100, 30, 30, '2026-01-05T18:30:00.000Z' FROM users`,
+	} {
+		if _, err = db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+
+	resp, err := client.Get(ctx, "/api/workouts/2026-01-05/calories?bodyweight_kg=80")
+	if err != nil {
+		t.Fatalf("get calories: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body caloriesResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Kcal != 320 || body.Minutes != 60 || body.TonnageKg != 3000 {
+		t.Errorf("got %+v, want 320 kcal over 60 minutes and 3000 kg", body)
+	}
+	if body.Caveat == "" {
+		t.Error("caveat is empty, want an accuracy warning")
+	}
+
+	for _, path := range []string{
+		"/api/workouts/2026-01-05/calories",
+		"/api/workouts/2026-01-07/calories?bodyweight_kg=80",
+	} {
+		bad, getErr := client.Get(ctx, path)
+		if getErr != nil {
+			t.Fatalf("get %s: %v", path, getErr)
+		}
+		_ = bad.Body.Close()
+		if bad.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s status = %d, want %d", path, bad.StatusCode, http.StatusBadRequest)
+		}
+	}
+}
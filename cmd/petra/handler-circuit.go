@@ -0,0 +1,104 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+const (
+	secondsPerMinute = 60
+	// defaultCircuitMinutes is the window preselected in the circuit form.
+	defaultCircuitMinutes = 20
+)
+
+// circuitMinuteOptions lists the circuit windows offered on the home day
+// cards, shortest first, with defaultCircuitMinutes preselected. All lie
+// within domain.MinCircuitSeconds and MaxCircuitSeconds.
+func circuitMinuteOptions() []selectOption {
+	minutes := []int{10, 15, defaultCircuitMinutes, 30, 45}
+	opts := make([]selectOption, len(minutes))
+	for i, m := range minutes {
+		opts[i] = selectOption{
+			Value:    strconv.Itoa(m),
+			Label:    fmt.Sprintf("%d min circuit", m),
+			Selected: m == defaultCircuitMinutes,
+		}
+	}
+	return opts
+}
+
+// workoutCircuitPOST turns the planned workout on {date} into a circuit of
+// the submitted minutes and opens it. Responds 404 when the deployment has
+// circuits switched off, like any route that does not exist.
+func (app *application) workoutCircuitPOST(w http.ResponseWriter, r *http.Request) {
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
+		return
+	}
+	if !app.parseForm(w, r, defaultMaxFormSize) {
+		return
+	}
+
+	workoutURL := fmt.Sprintf("/workouts/%s", date.Format("2006-01-02"))
+	minutes, err := strconv.Atoi(r.PostForm.Get("minutes"))
+	if err != nil {
+		app.putFlashError(r.Context(), "Pick how many minutes the circuit should last.")
+		redirect(w, r, workoutURL)
+		return
+	}
+	err = app.service.ConvertToCircuit(r.Context(), date, minutes*secondsPerMinute)
+	switch {
+	case errors.Is(err, domain.ErrFeatureDisabled), errors.Is(err, domain.ErrNotFound):
+		app.notFound(w, r)
+		return
+	case errors.Is(err, domain.ErrAlreadyStarted):
+		app.putFlashError(r.Context(), "This workout has already started, so it can no longer become a circuit.")
+		redirect(w, r, workoutURL)
+		return
+	case err != nil:
+		app.userError(w, r, err, workoutURL)
+		return
+	}
+
+	redirect(w, r, workoutURL)
+}
+
+// workoutCircuitCompletePOST logs the rounds finished in the circuit on
+// {date} and completes the workout, then asks for feedback like
+// workoutCompletePOST.
+func (app *application) workoutCircuitCompletePOST(w http.ResponseWriter, r *http.Request) {
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
+		return
+	}
+	if !app.parseForm(w, r, defaultMaxFormSize) {
+		return
+	}
+
+	workoutURL := fmt.Sprintf("/workouts/%s", date.Format("2006-01-02"))
+	rounds, err := strconv.Atoi(r.PostForm.Get("rounds"))
+	if err != nil {
+		app.putFlashError(r.Context(), "Enter the rounds you finished as a whole number.")
+		redirect(w, r, workoutURL)
+		return
+	}
+	err = app.service.CompleteCircuit(r.Context(), date, rounds)
+	switch {
+	case errors.Is(err, domain.ErrNotFound):
+		app.notFound(w, r)
+		return
+	case errors.Is(err, domain.ErrNotCircuit):
+		app.putFlashError(r.Context(), "This workout is planned as sets, not a circuit.")
+		redirect(w, r, workoutURL)
+		return
+	case err != nil:
+		app.userError(w, r, err, workoutURL)
+		return
+	}
+
+	redirect(w, r, fmt.Sprintf("/workouts/%s/complete", date.Format("2006-01-02")))
+}
@@ -0,0 +1,117 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// startCircuitServer registers a user with a workout planned today and
+// returns the client, the server, and the home page.
+func startCircuitServer(
+	t *testing.T, lookupEnv func(string) (string, bool),
+) (*e2etest.Server, *e2etest.Client, *goquery.Document) {
+	t.Helper()
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), lookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	doc, err := client.GetDoc(ctx, "/preferences")
+	if err != nil {
+		t.Fatalf("get preferences: %v", err)
+	}
+	formData := map[string]string{time.Now().Weekday().String(): "60"}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", formData); err != nil {
+		t.Fatalf("submit preferences: %v", err)
+	}
+	return server, client, doc
+}
+
+func Test_application_circuit(t *testing.T) {
+	t.Parallel()
+
+	today := time.Now().Format("2006-01-02")
+	convertPath := "/workouts/" + today + "/circuit"
+	completePath := "/workouts/" + today + "/circuit/complete"
+
+	t.Run("convert and log rounds", func(t *testing.T) {
+		t.Parallel()
+		ctx := t.Context()
+		_, client, doc := startCircuitServer(t, testLookupEnv)
+
+		if doc.Find(`form[action="`+convertPath+`"]`).Length() == 0 {
+			t.Fatal("home page has no circuit form for today's workout")
+		}
+		doc, err := client.SubmitForm(ctx, doc, convertPath, map[string]string{"minutes": "15"})
+		if err != nil {
+			t.Fatalf("convert to circuit: %v", err)
+		}
+		if doc.Find(`form[action="`+completePath+`"]`).Length() == 0 {
+			t.Fatal("workout page has no rounds form after converting")
+		}
+		doc.Find(".exercise-sub").Each(func(_ int, s *goquery.Selection) {
+			if !strings.HasSuffix(s.Text(), "per round") {
+				t.Errorf("exercise sub-line = %q, want a per-round prescription", s.Text())
+			}
+		})
+
+		home, err := client.GetDoc(ctx, "/")
+		if err != nil {
+			t.Fatalf("get home: %v", err)
+		}
+		if home.Find(`form[action="`+convertPath+`"]`).Length() != 0 {
+			t.Error("home page still offers the circuit form for a circuit")
+		}
+		if status := home.Find(`.day[data-day="` + today + `"] .day-status`).Text(); !strings.Contains(
+			status, "15 min circuit") {
+			t.Errorf("today's status = %q, want it to mention the 15 min circuit", status)
+		}
+
+		if _, err = client.SubmitForm(ctx, doc, completePath, map[string]string{"rounds": "4"}); err != nil {
+			t.Fatalf("log rounds: %v", err)
+		}
+		if doc, err = client.GetDoc(ctx, "/workouts/"+today); err != nil {
+			t.Fatalf("get workout: %v", err)
+		}
+		if got := strings.TrimSpace(doc.Find(".workout-status").Text()); !strings.Contains(got, "Completed") {
+			t.Errorf("workout status = %q, want Completed", got)
+		}
+		if got, _ := doc.Find("#circuit-rounds").Attr("value"); got != "4" {
+			t.Errorf("rounds input value = %q, want 4", got)
+		}
+	})
+
+	t.Run("switched off", func(t *testing.T) {
+		t.Parallel()
+		lookupEnv := func(key string) (string, bool) {
+			if key == "PETRAPP_FEATURE_CIRCUITS" {
+				return "false", true
+			}
+			return testLookupEnv(key)
+		}
+		server, client, doc := startCircuitServer(t, lookupEnv)
+
+		if doc.Find(`form[action="`+convertPath+`"]`).Length() != 0 {
+			t.Error("home page offers the circuit form with circuits switched off")
+		}
+		resp, err := client.HTTPClient().PostForm(server.URL()+convertPath, url.Values{"minutes": {"15"}})
+		if err != nil {
+			t.Fatalf("post circuit: %v", err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNotFound)
+		}
+	})
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// dashboardResponse is the JSON body of GET /api/dashboard.
+type dashboardResponse struct {
+	StreakWeeks   int                          `json:"streak_weeks"`
+	NextWorkout   *dashboardNextWorkout        `json:"next_workout"`
+	RecentRecords []personalRecordResponse     `json:"recent_prs"`
+	Consistency   dashboardConsistencyResponse `json:"consistency"`
+	TrainingGap   *dashboardTrainingGap        `json:"training_gap"`
+}
+
+// dashboardNextWorkout summarises the next planned workout.
+type dashboardNextWorkout struct {
+	Date          string `json:"date"`
+	WorkoutType   string `json:"workout_type"`
+	Status        string `json:"status"`
+	ExerciseCount int    `json:"exercise_count"`
+}
+
+// dashboardConsistencyResponse is completed over planned workouts in the last
+// four weeks. Rate is 0 when nothing was planned.
+type dashboardConsistencyResponse struct {
+	Completed int     `json:"completed"`
+	Planned   int     `json:"planned"`
+	Rate      float64 `json:"rate"`
+}
+
+// dashboardTrainingGap is the time since the last completed workout. Overdue
+// is set once the gap runs past ThresholdDays, which follows the user's own
+// cadence; see domain.TrainingGapFor.
+type dashboardTrainingGap struct {
+	LastWorkout         string  `json:"last_workout"`
+	DaysSinceLast       int     `json:"days_since_last"`
+	TypicalIntervalDays float64 `json:"typical_interval_days"`
+	ThresholdDays       int     `json:"threshold_days"`
+	Overdue             bool    `json:"overdue"`
+}
+
+// dashboardGET returns the streak, next workout, recent personal records and
+// consistency in one response, so the dashboard needs a single round-trip.
+// next_workout is null when nothing is planned from today on, and
+// training_gap before the first completed workout.
+func (app *application) dashboardGET(w http.ResponseWriter, r *http.Request) {
+	summary, err := app.service.DashboardSummary(r.Context())
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("dashboard summary: %w", err))
+		return
+	}
+
+	resp := dashboardResponse{
+		StreakWeeks:   summary.StreakWeeks,
+		NextWorkout:   nil,
+		RecentRecords: make([]personalRecordResponse, 0, len(summary.RecentRecords)),
+		Consistency: dashboardConsistencyResponse{
+			Completed: summary.CompletedSessions,
+			Planned:   summary.PlannedSessions,
+			Rate:      0,
+		},
+		TrainingGap: nil,
+	}
+	if next := summary.NextWorkout; next != nil {
+		resp.NextWorkout = &dashboardNextWorkout{
+			Date:          next.Date.Format(time.DateOnly),
+			WorkoutType:   string(next.WorkoutType()),
+			Status:        string(next.Status()),
+			ExerciseCount: len(next.Slots),
+		}
+	}
+	if gap := summary.TrainingGap; gap != nil {
+		resp.TrainingGap = &dashboardTrainingGap{
+			LastWorkout:         gap.LastWorkout.Format(time.DateOnly),
+			DaysSinceLast:       gap.DaysSinceLast,
+			TypicalIntervalDays: gap.TypicalIntervalDays,
+			ThresholdDays:       gap.ThresholdDays,
+			Overdue:             gap.Overdue,
+		}
+	}
+	for _, pr := range summary.RecentRecords {
+		resp.RecentRecords = append(resp.RecentRecords, newPersonalRecordResponse(pr))
+	}
+	if summary.PlannedSessions > 0 {
+		resp.Consistency.Rate = float64(summary.CompletedSessions) / float64(summary.PlannedSessions)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode dashboard: %w", err))
+	}
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_DashboardGET checks the JSON shape for a fresh user: every field is
+// present and empty lists are arrays, not null. The aggregation itself is
+// covered in the service.
+func Test_DashboardGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/api/dashboard")
+	if err != nil {
+		t.Fatalf("get dashboard: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+	var body map[string]json.RawMessage
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	for _, key := range []string{"streak_weeks", "next_workout", "recent_prs", "consistency", "training_gap"} {
+		if _, ok := body[key]; !ok {
+			t.Errorf("body is missing %q: %v", key, body)
+		}
+	}
+	if got := string(body["recent_prs"]); got != "[]" {
+		t.Errorf("recent_prs = %s, want []", got)
+	}
+	if got := string(body["streak_weeks"]); got != "0" {
+		t.Errorf("streak_weeks = %s, want 0", got)
+	}
+}
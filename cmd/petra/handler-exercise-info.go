@@ -2,10 +2,12 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
@@ -26,16 +28,12 @@ type exerciseInfoTemplateData struct {
 
 // exerciseInfoGET handles GET requests to view exercise information.
 func (app *application) exerciseInfoGET(w http.ResponseWriter, r *http.Request) {
-	dateStr := r.PathValue("date")
-	date, err := time.Parse("2006-01-02", dateStr)
-	if err != nil {
-		app.notFound(w, r)
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
 		return
 	}
-
-	pos, err := strconv.Atoi(r.PathValue("position"))
-	if err != nil || pos < 0 {
-		app.notFound(w, r)
+	pos, ok := app.parsePositionParam(w, r)
+	if !ok {
 		return
 	}
 
@@ -117,3 +115,109 @@ func (app *application) generateExerciseProgressData(
 
 	return dataPoints, nil
 }
+
+// exerciseProgressResponse is the JSON body of GET /api/exercises/{id}/progress.
+type exerciseProgressResponse struct {
+	ExerciseID int                     `json:"exercise_id"`
+	Points     []exerciseProgressPoint `json:"points"`
+}
+
+type exerciseProgressPoint struct {
+	Date string   `json:"date"`
+	Sets []string `json:"sets"`
+}
+
+// exerciseProgressGET serves the exercise-info progress chart's dataset as
+// JSON for clients that re-poll it. Responses carry an ETag derived from the
+// user's logged sets (see progressETag); a request whose If-None-Match still
+// matches gets 304 with no body, so an unchanged chart costs one history query
+// and no encoding.
+func (app *application) exerciseProgressGET(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(r.PathValue("id"))
+	if err != nil || id <= 0 {
+		app.notFound(w, r)
+		return
+	}
+	fiveYearsAgo := time.Now().AddDate(-5, 0, 0)
+	progress, err := app.service.GetExerciseSetsForExerciseSince(r.Context(), id, fiveYearsAgo)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			app.notFound(w, r)
+			return
+		}
+		app.serverError(w, r, fmt.Errorf("get exercise progress: %w", err))
+		return
+	}
+
+	etag := progressETag(id, progress)
+	w.Header().Set("ETag", etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	resp := exerciseProgressResponse{
+		ExerciseID: id,
+		Points:     make([]exerciseProgressPoint, 0, len(progress.Entries)),
+	}
+	for _, entry := range progress.Entries {
+		sets := make([]string, 0, len(entry.Sets))
+		for _, set := range entry.Sets {
+			if desc := progress.Exercise.FormatSetDescription(set); desc != "" {
+				sets = append(sets, desc)
+			}
+		}
+		resp.Points = append(resp.Points, exerciseProgressPoint{
+			Date: entry.Date.Format(time.DateOnly),
+			Sets: sets,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode exercise progress: %w", err))
+		return
+	}
+}
+
+// progressETag is a weak validator over the chart's inputs: the newest set
+// completion time and the completed-set count. Completing or editing a set
+// stamps a fresh CompletedAt, and un-completing one drops the count, so
+// either moves the tag without hashing the whole history.
+func progressETag(exerciseID int, progress domain.ExerciseProgress) string {
+	var (
+		latest time.Time
+		count  int
+	)
+	for _, entry := range progress.Entries {
+		for _, set := range entry.Sets {
+			count++
+			if set.CompletedAt != nil && set.CompletedAt.After(latest) {
+				latest = *set.CompletedAt
+			}
+		}
+	}
+	var stamp int64
+	if !latest.IsZero() {
+		stamp = latest.UnixNano()
+	}
+	return fmt.Sprintf(`W/"%d-%d-%d"`, exerciseID, stamp, count)
+}
+
+// etagMatches reports whether an If-None-Match header names etag, using the
+// weak comparison RFC 9110 prescribes for conditional GETs.
+func etagMatches(ifNoneMatch, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for candidate := range strings.SplitSeq(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == want {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"net/http"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -164,8 +165,8 @@ func Test_application_exerciseInfo(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Request failed: %v", err)
 		}
-		if resp.StatusCode != http.StatusNotFound {
-			t.Errorf("Expected status 404 for invalid exercise ID, got %d", resp.StatusCode)
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("Expected status 400 for invalid exercise ID, got %d", resp.StatusCode)
 		}
 	})
 
@@ -180,3 +181,78 @@ func Test_application_exerciseInfo(t *testing.T) {
 		}
 	})
 }
+
+// Test_application_exerciseProgressGET_conditional checks the progress JSON's
+// ETag round-trip: a matching If-None-Match gets 304 until a new set is
+// logged, after which the chart is served fresh with a new tag.
+func Test_application_exerciseProgressGET_conditional(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	doc, err := client.Register(ctx)
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if doc, err = client.GetDoc(ctx, "/preferences"); err != nil {
+		t.Fatalf("GetDoc /preferences: %v", err)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+		time.Now().Weekday().String(): "60",
+	}); err != nil {
+		t.Fatalf("save schedule: %v", err)
+	}
+	today := time.Now().Format(time.DateOnly)
+	if _, err = client.SubmitForm(ctx, doc, "/workouts/"+today+"/start", nil); err != nil {
+		t.Fatalf("start workout: %v", err)
+	}
+	var exerciseID int
+	if err = server.DB().QueryRowContext(ctx,
+		`SELECT exercise_id FROM exercise_slots WHERE workout_date = ? AND position = 0`, today,
+	).Scan(&exerciseID); err != nil {
+		t.Fatalf("query first slot: %v", err)
+	}
+	url := "/api/exercises/" + strconv.Itoa(exerciseID) + "/progress"
+
+	get := func(ifNoneMatch string) (int, string) {
+		t.Helper()
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodGet, server.URL()+url, nil)
+		if reqErr != nil {
+			t.Fatalf("build request: %v", reqErr)
+		}
+		if ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", ifNoneMatch)
+		}
+		resp, doErr := client.HTTPClient().Do(req)
+		if doErr != nil {
+			t.Fatalf("GET %s: %v", url, doErr)
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode, resp.Header.Get("ETag")
+	}
+
+	status, etag := get("")
+	if status != http.StatusOK || etag == "" {
+		t.Fatalf("first GET = %d with ETag %q, want 200 and a tag", status, etag)
+	}
+	if status, _ = get(etag); status != http.StatusNotModified {
+		t.Errorf("GET with matching If-None-Match = %d, want 304", status)
+	}
+
+	if _, err = server.DB().ExecContext(ctx,
+		`UPDATE exercise_sets SET completed_value = 5, completed_at = STRFTIME('%Y-%m-%dT%H:%M:%fZ')
+         WHERE workout_date = ? AND position = 0 AND set_number = 1`, today); err != nil {
+		t.Fatalf("complete a set: %v", err)
+	}
+	status, fresh := get(etag)
+	if status != http.StatusOK {
+		t.Errorf("GET after a new set = %d, want 200", status)
+	}
+	if fresh == etag {
+		t.Errorf("ETag unchanged after a new set: %q", fresh)
+	}
+}
@@ -47,6 +47,11 @@ type exerciseSetTemplateData struct {
 	LastTimeDate         time.Time        // Date of the most recent prior session; zero when no history.
 	LastTimeSummary      string           // Pre-formatted prior-session figures (e.g. "58 kg × 12"); "" hides the line.
 	HasLastTime          bool             // Whether to render the "Last time" reference line.
+	AddedLoadKg          float64          // Prescribed load for an outgrown bodyweight exercise; 0 hides the load input.
+	RepsInReserve        *int             // Effort guidance for rep-based sets; nil hides it (timed holds).
+	RestSeconds          int              // Inter-set rest in effect (user override or goal-derived); 0 when none.
+	WarmupSummary        string           // Pre-formatted warmup ramp; "" keeps the generic hint.
+	SideImbalanceNote    string           // Pre-formatted left/right gap; "" when the sides are balanced.
 }
 
 // formatLastTimeSummary renders the figures for the "Last time" reference line
@@ -68,6 +73,8 @@ func formatLastTimeSummary(exercise domain.Exercise, sets []domain.Set) string {
 	switch {
 	case exercise.HasWeight() && last.WeightKg != nil:
 		return fmt.Sprintf("%s kg × %s", formatFloat(*last.WeightKg), value)
+	case last.WeightKg != nil && *last.WeightKg > 0:
+		return fmt.Sprintf("+%s kg × %s", formatFloat(*last.WeightKg), value)
 	case exercise.IsTimed():
 		return "held " + value
 	default:
@@ -75,6 +82,34 @@ func formatLastTimeSummary(exercise domain.Exercise, sets []domain.Set) string {
 	}
 }
 
+// formatSideImbalance renders the "Side balance" line for a flagged
+// imbalance, e.g. "Left 40% behind right on volume". The volume gap wins when
+// both crossed the threshold. Returns "" when the sides are balanced.
+func formatSideImbalance(si domain.SideImbalance) string {
+	if !si.Imbalanced() {
+		return ""
+	}
+	weaker, stronger := "Left", "right"
+	if si.WeakerSide == domain.SideRight {
+		weaker, stronger = "Right", "left"
+	}
+	measure, gap := "volume", si.VolumeGap
+	if !si.VolumeImbalanced {
+		measure, gap = "top weight", si.WeightGap
+	}
+	return fmt.Sprintf("%s %.0f%% behind %s on %s", weaker, gap*100, stronger, measure)
+}
+
+// formatWarmupRamp renders warmup sets for the warmup row, e.g.
+// "40 kg × 8 · 60 kg × 5 · 80 kg × 3". Returns "" for an empty ramp.
+func formatWarmupRamp(ramp []domain.WarmupSet) string {
+	parts := make([]string, len(ramp))
+	for i, w := range ramp {
+		parts[i] = fmt.Sprintf("%s kg × %d", formatFloat(w.WeightKg), w.Reps)
+	}
+	return strings.Join(parts, " · ")
+}
+
 func prepareSetsDisplay(exercise domain.Exercise, sets []domain.Set) []setDisplay {
 	unit := exercise.SetValueUnit()
 	displays := make([]setDisplay, len(sets))
@@ -171,6 +206,10 @@ func (app *application) exerciseSetGET(w http.ResponseWriter, r *http.Request) {
 	// Get workout session
 	session, err := app.service.GetSession(r.Context(), date)
 	if err != nil {
+		if errors.Is(err, domain.ErrNotFound) {
+			app.notFound(w, r)
+			return
+		}
 		app.serverError(w, r, err)
 		return
 	}
@@ -216,6 +255,21 @@ func (app *application) exerciseSetGET(w http.ResponseWriter, r *http.Request) {
 		lastSummary = formatLastTimeSummary(exerciseSlot.Exercise, lastHistory.Sets)
 	}
 
+	imbalance, hasImbalance, err := app.service.SideImbalance(r.Context(), date, exerciseSlot.Exercise.ID)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	sideImbalanceNote := ""
+	if hasImbalance {
+		sideImbalanceNote = formatSideImbalance(imbalance)
+	}
+
+	addedLoadKg := 0.0
+	if exerciseSlot.Exercise.LoadModel() == domain.LoadBodyweight {
+		addedLoadKg = currentSetTarget.WeightKg
+	}
+
 	data := exerciseSetTemplateData{
 		BaseTemplateData:     newBaseTemplateData(r),
 		Date:                 date,
@@ -234,6 +288,11 @@ func (app *application) exerciseSetGET(w http.ResponseWriter, r *http.Request) {
 		LastTimeDate:         lastHistory.Date,
 		LastTimeSummary:      lastSummary,
 		HasLastTime:          hasLast && lastSummary != "",
+		AddedLoadKg:          addedLoadKg,
+		RepsInReserve:        domain.RepsInReserveFor(exerciseSlot.Exercise, session.Goal, session.IsDeload),
+		RestSeconds:          exerciseSlot.RestSeconds(session.Goal, session.IsDeload),
+		WarmupSummary:        formatWarmupRamp(session.WarmupRamp(pos, currentSetTarget.WeightKg)),
+		SideImbalanceNote:    sideImbalanceNote,
 	}
 
 	for i := range data.SetsDisplay {
@@ -327,10 +386,21 @@ func (app *application) recordSetCompletionWithWeight(
 		return false
 	}
 
+	flags := domain.SetFlags{
+		TechnicalFailure: r.PostForm.Get("technical_failure") != "",
+		Warmup:           r.PostForm.Get("warmup_set") != "",
+		Side:             nil,
+	}
+	if raw := r.PostForm.Get("side"); raw != "" {
+		side := domain.Side(raw)
+		flags.Side = &side
+	}
 	err = app.service.RecordSet(
-		r.Context(), params.Date, params.Position, params.SetIndex, signal, &weight, reps)
+		r.Context(), params.Date, params.Position, params.SetIndex, signal, &weight, reps, flags)
 	if err != nil {
-		app.serverError(w, r, fmt.Errorf("record set completion: %w", err))
+		// An unknown side flashes on the workout page, a known-good flash target.
+		app.userError(w, r, fmt.Errorf("record set completion: %w", err),
+			"/workouts/"+params.Date.Format("2006-01-02"))
 		return false
 	}
 
@@ -344,15 +414,20 @@ func (app *application) recordSetCompletionWithWeight(
 		slog.Int("set_index", params.SetIndex),
 		slog.String(signalFormField, signalStr),
 		slog.Float64("weight", weight),
-		slog.Int("reps", reps))
+		slog.Int("reps", reps),
+		slog.Bool("technical_failure", flags.TechnicalFailure),
+		slog.Bool("warmup_set", flags.Warmup),
+		slog.String("side", r.PostForm.Get("side")))
 	return true
 }
 
 // recordBodyweightSetCompletion handles parsing and persisting a bodyweight set
-// completion from form data. Time-based sets go through recordTimedSetCompletion.
+// completion from form data, plus the added load when the form carried one
+// (outgrown exercises). Time-based sets go through recordTimedSetCompletion.
 func (app *application) recordBodyweightSetCompletion(
 	w http.ResponseWriter, r *http.Request,
 	params exerciseSetParams,
+	exercise domain.Exercise,
 ) bool {
 	completedValueStr := r.PostForm.Get("completed_value")
 	if completedValueStr == "" {
@@ -369,6 +444,18 @@ func (app *application) recordBodyweightSetCompletion(
 		app.serverError(w, r, fmt.Errorf("update completed value: %w", err))
 		return false
 	}
+	if raw := r.PostForm.Get("weight"); raw != "" {
+		var weight float64
+		if weight, err = parseFormWeight(raw, false, exercise); err != nil {
+			app.serverError(w, r, err)
+			return false
+		}
+		if err = app.service.UpdateSetWeight(
+			r.Context(), params.Date, params.Position, params.SetIndex, weight); err != nil {
+			app.serverError(w, r, fmt.Errorf("update added load: %w", err))
+			return false
+		}
+	}
 	return true
 }
 
@@ -403,6 +490,7 @@ func (app *application) recordTimedSetCompletion(
 		signal,
 		nil,
 		completedSeconds,
+		domain.SetFlags{},
 	); err != nil {
 		app.serverError(w, r, fmt.Errorf("record timed set completion: %w", err))
 		return false
@@ -444,6 +532,12 @@ func (app *application) exerciseSetUpdatePOST(w http.ResponseWriter, r *http.Req
 	}
 	exerciseSlot := session.Slots[params.Position]
 	exercise := exerciseSlot.Exercise
+	// Completions only fill the slot's prescribed sets, so a set index outside
+	// them is a buggy or hostile client rather than a server fault.
+	if params.SetIndex < 0 || params.SetIndex >= len(exerciseSlot.Sets) {
+		http.Error(w, "Invalid set index", http.StatusBadRequest)
+		return
+	}
 
 	switch exercise.LoadModel() {
 	case domain.LoadWeighted:
@@ -455,7 +549,7 @@ func (app *application) exerciseSetUpdatePOST(w http.ResponseWriter, r *http.Req
 			return
 		}
 	case domain.LoadBodyweight:
-		if !app.recordBodyweightSetCompletion(w, r, params) {
+		if !app.recordBodyweightSetCompletion(w, r, params, exercise) {
 			return
 		}
 	case domain.LoadUnknown:
@@ -488,3 +582,49 @@ func (app *application) exerciseSetWarmupCompletePOST(w http.ResponseWriter, r *
 
 	redirect(w, r, fmt.Sprintf("/workouts/%s/exercises/%d", date.Format("2006-01-02"), pos))
 }
+
+// exerciseRestPOST saves the user's own inter-set rest for the exercise in the
+// slot at position. The override follows the exercise, not the slot, so it
+// also applies in future sessions. An out-of-range value flashes on the
+// workout page, which is a known-good flash target.
+func (app *application) exerciseRestPOST(w http.ResponseWriter, r *http.Request) {
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
+		return
+	}
+	pos, ok := app.parsePositionParam(w, r)
+	if !ok {
+		return
+	}
+	if !app.parseForm(w, r, defaultMaxFormSize) {
+		return
+	}
+
+	session, err := app.service.GetSession(r.Context(), date)
+	if err != nil {
+		app.serverError(w, r, err)
+		return
+	}
+	if pos >= len(session.Slots) {
+		app.notFound(w, r)
+		return
+	}
+	exerciseID := session.Slots[pos].Exercise.ID
+
+	safeURL := "/workouts/" + date.Format("2006-01-02")
+	seconds, err := strconv.Atoi(r.PostForm.Get("rest_seconds"))
+	if err != nil {
+		app.userError(w, r, domain.ValidationError{Message: "Rest must be a whole number of seconds."}, safeURL)
+		return
+	}
+	if err = app.service.SetRestSeconds(r.Context(), exerciseID, seconds); err != nil {
+		app.userError(w, r, fmt.Errorf("set rest seconds: %w", err), safeURL)
+		return
+	}
+
+	app.logger.LogAttrs(r.Context(), slog.LevelInfo, "rest override saved",
+		slog.Int("exercise_id", exerciseID),
+		slog.Int("rest_seconds", seconds))
+
+	redirect(w, r, fmt.Sprintf("/workouts/%s/exercises/%d", date.Format("2006-01-02"), pos))
+}
@@ -940,6 +940,120 @@ func Test_application_exerciseSet_assisted_storage(t *testing.T) {
 	}
 }
 
+// Test_application_exerciseSet_sideImbalance verifies that the set form
+// records which side a unilateral set worked and that a lopsided pair of
+// sides surfaces as a "Side balance" note on the exercise page.
+func Test_application_exerciseSet_sideImbalance(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = t.Context()
+		doc *goquery.Document
+		err error
+	)
+
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	formData := map[string]string{time.Now().Weekday().String(): "60"}
+	if doc, err = client.GetDoc(ctx, "/preferences"); err != nil {
+		t.Fatalf("get preferences: %v", err)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", formData); err != nil {
+		t.Fatalf("submit preferences: %v", err)
+	}
+	today := time.Now().Format("2006-01-02")
+	if _, err = client.SubmitForm(ctx, doc, "/workouts/"+today+"/start", nil); err != nil {
+		t.Fatalf("start workout: %v", err)
+	}
+
+	// Attach a One-Arm Dumbbell Row slot with two placeholder sets, as in
+	// Test_application_exerciseSet_assisted_storage.
+	db := server.DB()
+	var rowID int
+	if err = db.QueryRowContext(ctx,
+		`SELECT id FROM exercises WHERE name = 'One-Arm Dumbbell Row'`).Scan(&rowID); err != nil {
+		t.Fatalf("get One-Arm Dumbbell Row id: %v", err)
+	}
+	var (
+		slotUserID int
+		slotPos    int
+	)
+	if err = db.QueryRowContext(ctx,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id,
+            warmup_completed_at)
+         SELECT user_id, workout_date,
+                COALESCE((SELECT MAX(position)+1 FROM exercise_slots
+                          WHERE workout_user_id = ws.user_id AND workout_date = ws.workout_date), 0),
+                ?, STRFTIME('%Y-%m-%dT%H:%M:%fZ')
+         FROM workout_sessions ws WHERE workout_date = ?
+         RETURNING workout_user_id, position`, rowID, today,
+	).Scan(&slotUserID, &slotPos); err != nil {
+		t.Fatalf("insert row slot: %v", err)
+	}
+	for setNum := 1; setNum <= 2; setNum++ {
+		if _, err = db.ExecContext(ctx,
+			`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+                weight_kg, target_value)
+             VALUES (?, ?, ?, ?, 20.0, 10)`, slotUserID, today, slotPos, setNum); err != nil {
+			t.Fatalf("insert placeholder set %d: %v", setNum, err)
+		}
+	}
+
+	slotPath := "/workouts/" + today + "/exercises/" + strconv.Itoa(slotPos)
+	if doc, err = client.GetDoc(ctx, slotPath); err != nil {
+		t.Fatalf("get exercise set: %v", err)
+	}
+	if got := doc.Find(".side-balance").Length(); got != 0 {
+		t.Fatalf("side balance note rendered before any per-side set, found %d", got)
+	}
+
+	// The left side manages 6 reps where the right manages 10 at the same load.
+	for _, set := range []struct{ side, reps string }{{"left", "6"}, {"right", "10"}} {
+		setForm := doc.Find("form").FilterFunction(func(_ int, s *goquery.Selection) bool {
+			return s.Find("select[name='side']").Length() > 0
+		}).First()
+		if setForm.Length() == 0 {
+			t.Fatalf("expected a side select on the %s set form", set.side)
+		}
+		action, _ := setForm.Attr("action")
+		if doc, err = client.SubmitForm(ctx, doc, action, map[string]string{
+			"weight": "20",
+			"reps":   set.reps,
+			"side":   set.side,
+			"signal": "on_target",
+		}); err != nil {
+			t.Fatalf("submit %s set: %v", set.side, err)
+		}
+	}
+
+	var side string
+	if err = db.QueryRowContext(ctx,
+		`SELECT side FROM exercise_sets
+         WHERE workout_user_id = ? AND workout_date = ? AND position = ? AND set_number = 1`,
+		slotUserID, today, slotPos).Scan(&side); err != nil {
+		t.Fatalf("query set 1 side: %v", err)
+	}
+	if side != "left" {
+		t.Errorf("set 1 side = %q, want %q", side, "left")
+	}
+
+	if doc, err = client.GetDoc(ctx, slotPath); err != nil {
+		t.Fatalf("get exercise set after per-side sets: %v", err)
+	}
+	note := strings.TrimSpace(doc.Find(".side-balance").Text())
+	if !strings.Contains(note, "Left 40% behind right on volume") {
+		t.Errorf("side balance note = %q, want it to flag the left side 40%% behind on volume", note)
+	}
+}
+
 // Test_ExerciseSet_RestChipAfterCompletedSet verifies that completing a
 // weighted set renders a rest countdown chip with a future
 // data-rest-end-at-ms timestamp.
@@ -1857,3 +1971,178 @@ func Test_application_exerciseSet_edit_completed_bodyweight_set(t *testing.T) {
 		t.Errorf("completed_value = %v, want 12 after edit", completed)
 	}
 }
+
+// Test_application_exerciseSet_rest_override verifies that saving a rest
+// override from the exercise page is reflected back on that page, and that an
+// out-of-range value is rejected with a banner instead of being stored.
+func Test_application_exerciseSet_rest_override(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = t.Context()
+		err error
+		doc *goquery.Document
+	)
+
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	formData := map[string]string{
+		time.Now().Weekday().String(): "60",
+	}
+	if doc, err = client.GetDoc(ctx, "/preferences"); err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", formData); err != nil {
+		t.Fatalf("Failed to submit form: %v", err)
+	}
+	today := time.Now().Format("2006-01-02")
+	if _, err = client.SubmitForm(ctx, doc, "/workouts/"+today+"/start", nil); err != nil {
+		t.Fatalf("Failed to submit start workout form: %v", err)
+	}
+
+	slotURL := "/workouts/" + today + "/exercises/0"
+	restAction := slotURL + "/rest"
+	if doc, err = client.GetDoc(ctx, slotURL); err != nil {
+		t.Fatalf("Failed to get exercise page: %v", err)
+	}
+	derived, _ := doc.Find("#rest-seconds").Attr("value")
+
+	if doc, err = client.SubmitForm(ctx, doc, restAction, map[string]string{"rest_seconds": "75"}); err != nil {
+		t.Fatalf("Failed to submit rest override: %v", err)
+	}
+	if got, _ := doc.Find("#rest-seconds").Attr("value"); got != "75" {
+		t.Errorf("rest input after override = %q, want %q (derived was %q)", got, "75", derived)
+	}
+
+	if doc, err = client.SubmitForm(ctx, doc, restAction, map[string]string{"rest_seconds": "5"}); err != nil {
+		t.Fatalf("Failed to submit out-of-range rest: %v", err)
+	}
+	if !strings.Contains(doc.Text(), "Rest must be between 10 and 600 seconds.") {
+		t.Error("out-of-range rest did not surface the validation banner")
+	}
+	if doc, err = client.GetDoc(ctx, slotURL); err != nil {
+		t.Fatalf("Failed to get exercise page: %v", err)
+	}
+	if got, _ := doc.Find("#rest-seconds").Attr("value"); got != "75" {
+		t.Errorf("rest input after rejected override = %q, want the stored %q", got, "75")
+	}
+}
+
+// Test_application_exerciseSet_malformedPosition checks the exercise route
+// answers a bad position with a client error instead of a server error: 400
+// when it isn't a non-negative number, 404 when it is one but names no slot.
+func Test_application_exerciseSet_malformedPosition(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	doc, err := client.GetDoc(ctx, "/preferences")
+	if err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+		time.Now().Weekday().String(): "60",
+	}); err != nil {
+		t.Fatalf("Failed to submit schedule: %v", err)
+	}
+	today := time.Now().Format(time.DateOnly)
+	if _, err = client.SubmitForm(ctx, doc, "/workouts/"+today+"/start", nil); err != nil {
+		t.Fatalf("Failed to start workout: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		path string
+		want int
+	}{
+		{name: "non-numeric", path: "/workouts/" + today + "/exercises/abc", want: http.StatusBadRequest},
+		{name: "negative", path: "/workouts/" + today + "/exercises/-1", want: http.StatusBadRequest},
+		{name: "numeric past the last slot", path: "/workouts/" + today + "/exercises/99999", want: http.StatusNotFound},
+		{name: "numeric on a day without a workout", path: "/workouts/2020-01-01/exercises/0", want: http.StatusNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			resp, getErr := client.Get(ctx, tt.path)
+			if getErr != nil {
+				t.Fatalf("GET %s: %v", tt.path, getErr)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != tt.want {
+				t.Errorf("GET %s: status = %d, want %d", tt.path, resp.StatusCode, tt.want)
+			}
+		})
+	}
+}
+
+// Test_application_exerciseSet_outOfRangeSetIndex checks a completion POST
+// naming a set the slot does not prescribe is rejected with 400, and that the
+// prescribed sets are left untouched.
+func Test_application_exerciseSet_outOfRangeSetIndex(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+	doc, err := client.GetDoc(ctx, "/preferences")
+	if err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+		time.Now().Weekday().String(): "60",
+	}); err != nil {
+		t.Fatalf("Failed to submit schedule: %v", err)
+	}
+	today := time.Now().Format(time.DateOnly)
+	if _, err = client.SubmitForm(ctx, doc, "/workouts/"+today+"/start", nil); err != nil {
+		t.Fatalf("Failed to start workout: %v", err)
+	}
+
+	form := url.Values{"weight": {"20"}, "reps": {"8"}, "completed_value": {"8"}}.Encode()
+	for _, setIndex := range []string{"-1", "99", "5000"} {
+		path := "/workouts/" + today + "/exercises/0/sets/" + setIndex + "/update"
+		req, reqErr := http.NewRequestWithContext(ctx, http.MethodPost, server.URL()+path, strings.NewReader(form))
+		if reqErr != nil {
+			t.Fatalf("Build POST %s: %v", path, reqErr)
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		resp, doErr := client.HTTPClient().Do(req)
+		if doErr != nil {
+			t.Fatalf("POST %s: %v", path, doErr)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("POST %s: status = %d, want %d", path, resp.StatusCode, http.StatusBadRequest)
+		}
+	}
+
+	var completed int
+	if err = server.DB().QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM exercise_sets WHERE workout_date = ? AND completed_value IS NOT NULL`,
+		today).Scan(&completed); err != nil {
+		t.Fatalf("count completed sets: %v", err)
+	}
+	if completed != 0 {
+		t.Errorf("completed sets after rejected POSTs = %d, want 0", completed)
+	}
+}
@@ -2,19 +2,37 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"log/slog"
 	"net/http"
 	"strconv"
 	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/service"
 )
 
 // healthCheckTimeout bounds the readiness probe so a wedged database connection
 // can't hang the health endpoint (and, in turn, Fly's machine health checks).
 const healthCheckTimeout = 2 * time.Second
 
+// healthCheckPath is where the readiness probe is mounted. Middleware that
+// treats the probe specially (maintenance mode, access logging) matches on it.
+const healthCheckPath = "/api/healthy"
+
+// healthResponse is the readiness probe's JSON body. OpenAI is omitted when
+// the OpenAI check is not enabled or the database check fails, since the
+// instance is not ready either way.
+type healthResponse struct {
+	Status string               `json:"status"`
+	OpenAI service.OpenAIStatus `json:"openai,omitempty"`
+}
+
 // healthy is a readiness probe: it confirms the database is reachable before
 // reporting OK. A failed probe returns 503 so orchestration treats the instance
 // as not-ready instead of routing traffic to a process that can't serve queries.
+// OpenAI reachability is reported alongside when enabled (probed in the
+// background, see Service.OpenAIHealth) but never fails readiness or delays
+// it — workouts don't depend on it.
 func (app *application) healthy(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithTimeout(r.Context(), healthCheckTimeout)
 	defer cancel()
@@ -23,10 +41,10 @@ func (app *application) healthy(w http.ResponseWriter, r *http.Request) {
 	if err := app.service.HealthCheck(ctx); err != nil {
 		app.logger.LogAttrs(ctx, slog.LevelError, "health check failed", slog.Any("error", err))
 		w.WriteHeader(http.StatusServiceUnavailable)
-		_, _ = w.Write([]byte(`{"status":"unavailable"}`))
+		_ = json.NewEncoder(w).Encode(healthResponse{Status: "unavailable", OpenAI: ""})
 		return
 	}
-	_, _ = w.Write([]byte(`{"status":"ok"}`))
+	_ = json.NewEncoder(w).Encode(healthResponse{Status: "ok", OpenAI: app.service.OpenAIHealth(ctx)})
 }
 
 // testTimeout sleeps for the sleep_ms query-parameter duration so the timeout
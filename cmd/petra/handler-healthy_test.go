@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/repository"
 	"github.com/myrjola/petrapp/internal/petra/service"
@@ -76,3 +78,103 @@ func Test_application_healthy_databaseDown(t *testing.T) {
 		t.Errorf("status: got %d, want %d", rec.Code, http.StatusServiceUnavailable)
 	}
 }
+
+func Test_application_healthy_openai(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name       string
+		stubStatus int
+		want       service.OpenAIStatus
+	}{
+		{name: "valid key", stubStatus: http.StatusOK, want: service.OpenAIStatusOK},
+		{name: "rejected key", stubStatus: http.StatusUnauthorized, want: service.OpenAIStatusUnavailable},
+		{name: "rate limited", stubStatus: http.StatusTooManyRequests, want: service.OpenAIStatusUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var hits atomic.Int32
+			stub := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				hits.Add(1)
+				if r.URL.Path != "/models" {
+					t.Errorf("stub path: got %q, want /models", r.URL.Path)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.stubStatus)
+				if tt.stubStatus == http.StatusOK {
+					_, _ = w.Write([]byte(`{"object":"list","data":[]}`))
+					return
+				}
+				_, _ = w.Write([]byte(`{"error":{"message":"nope","type":"error"}}`))
+			}))
+			t.Cleanup(stub.Close)
+
+			app, db := newHealthTestApp(t)
+			t.Cleanup(func() { _ = db.Close() })
+			app.service = service.NewService(db, app.logger, "test-key").
+				WithOpenAIHealthCheck(true).
+				WithOpenAIBaseURL(stub.URL)
+
+			// The probe runs in the background, so poll until it has hit the
+			// stub and the verdict is in. Every poll must report ready.
+			deadline := time.Now().Add(5 * time.Second)
+			for {
+				body := getHealthy(t, app)
+				if hits.Load() == 1 && body.OpenAI == tt.want {
+					break
+				}
+				if time.Now().After(deadline) {
+					t.Fatalf("body: got %+v, want openai %q", body, tt.want)
+				}
+				time.Sleep(10 * time.Millisecond)
+			}
+			// The verdict is cached rather than probed again.
+			if body := getHealthy(t, app); body.OpenAI != tt.want {
+				t.Errorf("cached openai: got %q, want %q", body.OpenAI, tt.want)
+			}
+			if got := hits.Load(); got != 1 {
+				t.Errorf("stub hits: got %d, want 1 (later probes should be cached)", got)
+			}
+		})
+	}
+}
+
+func Test_application_healthy_openaiCheckOff(t *testing.T) {
+	t.Parallel()
+
+	stub := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		t.Error("OpenAI probed with the health check off")
+	}))
+	t.Cleanup(stub.Close)
+
+	app, db := newHealthTestApp(t)
+	t.Cleanup(func() { _ = db.Close() })
+	app.service = service.NewService(db, app.logger, "test-key").WithOpenAIBaseURL(stub.URL)
+
+	if body := getHealthy(t, app); body.OpenAI != "" {
+		t.Errorf("openai: got %q, want it omitted", body.OpenAI)
+	}
+}
+
+// getHealthy calls the readiness probe, checks it reports ready, and returns
+// the decoded body.
+func getHealthy(t *testing.T, app *application) healthResponse {
+	t.Helper()
+	req := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/api/healthy", nil)
+	rec := httptest.NewRecorder()
+	app.healthy(rec, req)
+
+	if rec.Code != http.StatusOK {
+		t.Errorf("status: got %d, want %d (OpenAI must not fail readiness)", rec.Code, http.StatusOK)
+	}
+	var body healthResponse
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("unmarshal body %q: %v", rec.Body.String(), err)
+	}
+	if body.Status != "ok" {
+		t.Errorf("status field: got %q, want %q", body.Status, "ok")
+	}
+	return body
+}
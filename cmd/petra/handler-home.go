@@ -31,6 +31,12 @@ type homeTemplateData struct {
 	Header PageHeaderData
 	// Days contains the workout sessions for the current week.
 	Days []dayView
+	// AdHocCategories are the focus choices offered when starting an extra
+	// workout on an unscheduled day, full body preselected.
+	AdHocCategories []selectOption
+	// CircuitMinuteOptions are the windows offered when turning a planned
+	// workout into a circuit (see dayView.CanConvertToCircuit).
+	CircuitMinuteOptions []selectOption
 	// MuscleBalance summarises weekly volume per muscle group, grouped by region.
 	// Empty for unauthenticated users; Regions is empty when the week has no exercises.
 	MuscleBalance muscleBalanceView
@@ -38,7 +44,8 @@ type homeTemplateData struct {
 	WeekInBlock int
 	// MesocycleLength is the total number of weeks in the mesocycle block.
 	MesocycleLength int
-	// IsDeloadWeek reports whether the current week is the last (deload) week.
+	// IsDeloadWeek reports whether the current week is a deload: the last
+	// week of the mesocycle, or a week the planner deloaded automatically.
 	IsDeloadWeek bool
 	// DeloadEnabled reports whether the deload feature is enabled for this user.
 	DeloadEnabled bool
@@ -126,6 +133,12 @@ type dayView struct {
 	DifficultyStars []bool
 	// Action contains the workout action data for this day
 	Action *workoutAction
+	// CircuitMinutes is the time window of a workout converted to a circuit,
+	// or 0 for a workout prescribed as sets.
+	CircuitMinutes int
+	// CanConvertToCircuit offers the circuit form: circuits are switched on
+	// and the day has a planned workout that has not started.
+	CanConvertToCircuit bool
 }
 
 // workoutAction represents an action that can be taken on a workout day.
@@ -278,7 +291,7 @@ func shouldShowRibbon(status string) bool {
 	}
 }
 
-func toDays(sessions []domain.Session, preferences domain.Preferences) []dayView {
+func toDays(sessions []domain.Session, preferences domain.Preferences, circuitsEnabled bool) []dayView {
 	today := time.Now()
 	days := make([]dayView, len(sessions))
 
@@ -293,23 +306,27 @@ func toDays(sessions []domain.Session, preferences domain.Preferences) []dayView
 		difficultyStars := prepareDifficultyStars(session.DifficultyRating)
 		status, statusLabel := calculateDisplayStatus(workoutStatus, isToday, isPast)
 		action := calculateWorkoutAction(status, isToday)
+		canConvert := circuitsEnabled && status != statusUnscheduled && action != nil && action.StartWorkout &&
+			len(session.Slots) > 0 && !session.IsCircuit()
 
 		days[i] = dayView{
-			Date:               date,
-			Name:               date.Format("Monday"),
-			IsToday:            isToday,
-			IsPast:             isPast,
-			IsScheduled:        isScheduled,
-			Status:             status,
-			StatusLabel:        statusLabel,
-			CompletedSets:      completedSets,
-			TotalSets:          totalSets,
-			ProgressPercent:    progressPercent,
-			ShouldShowProgress: totalSets > 0 && status != statusUnscheduled,
-			ShouldShowRibbon:   shouldShowRibbon(status),
-			DifficultyRating:   session.DifficultyRating,
-			DifficultyStars:    difficultyStars,
-			Action:             action,
+			Date:                date,
+			Name:                date.Format("Monday"),
+			IsToday:             isToday,
+			IsPast:              isPast,
+			IsScheduled:         isScheduled,
+			Status:              status,
+			StatusLabel:         statusLabel,
+			CompletedSets:       completedSets,
+			TotalSets:           totalSets,
+			ProgressPercent:     progressPercent,
+			ShouldShowProgress:  totalSets > 0 && status != statusUnscheduled,
+			ShouldShowRibbon:    shouldShowRibbon(status),
+			DifficultyRating:    session.DifficultyRating,
+			DifficultyStars:     difficultyStars,
+			Action:              action,
+			CircuitMinutes:      session.CircuitSeconds() / secondsPerMinute,
+			CanConvertToCircuit: canConvert,
 		}
 	}
 
@@ -377,15 +394,17 @@ func muscleGroupSlug(name string) string {
 func (app *application) home(w http.ResponseWriter, r *http.Request) {
 	base := newBaseTemplateData(r)
 	data := homeTemplateData{
-		BaseTemplateData: base,
-		Header:           PageHeaderData{Title: "This Week", Subtitle: "", Nonce: base.Nonce},
-		Days:             nil,
-		MuscleBalance:    muscleBalanceView{Regions: nil},
-		WeekInBlock:      0,
-		MesocycleLength:  0,
-		IsDeloadWeek:     false,
-		DeloadEnabled:    false,
-		DevMode:          app.devMode,
+		BaseTemplateData:     base,
+		Header:               PageHeaderData{Title: "This Week", Subtitle: "", Nonce: base.Nonce},
+		Days:                 nil,
+		AdHocCategories:      buildCategoryOptions(domain.CategoryFullBody),
+		CircuitMinuteOptions: circuitMinuteOptions(),
+		MuscleBalance:        muscleBalanceView{Regions: nil},
+		WeekInBlock:          0,
+		MesocycleLength:      0,
+		IsDeloadWeek:         false,
+		DeloadEnabled:        false,
+		DevMode:              app.devMode,
 	}
 
 	// Only fetch workout data for authenticated users.
@@ -434,13 +453,13 @@ func (app *application) homePopulateAuthenticated(w http.ResponseWriter, r *http
 	monday := time.Date(y, m, d, 0, 0, 0, 0, time.UTC).AddDate(0, 0, mondayOffset)
 
 	weekInBlock := preferences.WeekInBlock(monday)
-	isDeload := preferences.IsDeloadWeek(monday)
+	isDeload := preferences.IsDeloadWeek(monday) || plan.IsDeloadWeek()
 	data.WeekInBlock = weekInBlock + 1
 	data.MesocycleLength = preferences.MesocycleLength
 	data.IsDeloadWeek = isDeload
 	data.DeloadEnabled = preferences.DeloadEnabled
 
-	data.Days = toDays(sessions, preferences)
+	data.Days = toDays(sessions, preferences, app.service.Features().Circuits)
 	data.MuscleBalance = toMuscleBalance(volumes)
 	return true
 }
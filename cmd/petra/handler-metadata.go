@@ -0,0 +1,24 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// metadataGET serves the canonical muscle groups, categories, exercise types,
+// and session goals as JSON so clients can build filters without hardcoding
+// enum values. The lists are not user-specific, so the endpoint needs no
+// authentication; the service caches them for the life of the process.
+func (app *application) metadataGET(w http.ResponseWriter, r *http.Request) {
+	md, err := app.service.CatalogMetadata(r.Context())
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("catalog metadata: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(md); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode catalog metadata: %w", err))
+		return
+	}
+}
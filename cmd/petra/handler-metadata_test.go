@@ -0,0 +1,65 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_MetadataGET_ListsEnumValues verifies /api/metadata returns the canonical
+// enum values from both the database (muscle groups) and the domain constants,
+// and that it is reachable without authentication.
+func Test_MetadataGET_ListsEnumValues(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+
+	resp, err := server.Client().Get(ctx, "/api/metadata")
+	if err != nil {
+		t.Fatalf("get metadata: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q, want application/json", ct)
+	}
+
+	var body struct {
+		MuscleGroups  []string `json:"muscle_groups"`
+		Categories    []string `json:"categories"`
+		ExerciseTypes []string `json:"exercise_types"`
+		SessionGoals  []string `json:"session_goals"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+
+	checks := []struct {
+		field string
+		got   []string
+		want  []string
+	}{
+		{"muscle_groups", body.MuscleGroups, []string{"Chest", "Quads", "Lats"}},
+		{"categories", body.Categories, []string{"full_body", "upper", "lower"}},
+		{"exercise_types", body.ExerciseTypes, []string{"weighted", "bodyweight", "assisted", "time_based"}},
+		{"session_goals", body.SessionGoals, []string{"strength", "hypertrophy"}},
+	}
+	for _, c := range checks {
+		for _, w := range c.want {
+			if !slices.Contains(c.got, w) {
+				t.Errorf("%s = %v, missing %q", c.field, c.got, w)
+			}
+		}
+	}
+}
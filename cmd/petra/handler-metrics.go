@@ -0,0 +1,20 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+)
+
+// metricsGET serves the service's metrics in the Prometheus text exposition
+// format. Admin-only: latencies say how much history users have built up.
+func (app *application) metricsGET(w http.ResponseWriter, r *http.Request) {
+	// Buffer so a write error still leaves room for a clean 500.
+	var buf bytes.Buffer
+	if err := app.service.WriteMetrics(&buf); err != nil {
+		app.serverError(w, r, fmt.Errorf("write metrics: %w", err))
+		return
+	}
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = buf.WriteTo(w)
+}
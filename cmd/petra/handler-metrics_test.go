@@ -0,0 +1,71 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_MetricsGET plans a week through the home page and checks that the
+// admin metrics endpoint shows the planning latency observation.
+func Test_MetricsGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	doc, err := client.GetDoc(ctx, "/preferences")
+	if err != nil {
+		t.Fatalf("get preferences: %v", err)
+	}
+	// Saving the schedule lands on the home page, which plans the week.
+	if _, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+		time.Now().Weekday().String(): "60",
+	}); err != nil {
+		t.Fatalf("submit preferences: %v", err)
+	}
+
+	if _, err = server.DB().ExecContext(ctx, "UPDATE users SET is_admin = 1"); err != nil {
+		t.Fatalf("promote user to admin: %v", err)
+	}
+	resp, err := client.Get(ctx, "/api/metrics")
+	if err != nil {
+		t.Fatalf("get metrics: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("read body: %v", err)
+	}
+	if !strings.Contains(string(body), "# TYPE petra_plan_week_duration_seconds histogram") {
+		t.Errorf("metrics missing the week planning histogram; got:\n%s", body)
+	}
+	// Saving the schedule and rendering home may each plan, so any count
+	// above zero will do.
+	var count int
+	for line := range strings.Lines(string(body)) {
+		if v, ok := strings.CutPrefix(line, "petra_plan_week_duration_seconds_count "); ok {
+			if count, err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				t.Fatalf("parse count %q: %v", v, err)
+			}
+		}
+	}
+	if count == 0 {
+		t.Errorf("week planning count = 0, want an observation; got:\n%s", body)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// defaultMuscleBalanceDays is the window GET /api/muscle-balance covers
+// when the days parameter is absent.
+const defaultMuscleBalanceDays = 45
+
+// muscleBalanceResponse is the JSON body of GET /api/muscle-balance.
+// MostTrained and LeastTrained name the extremes picked by
+// domain.MuscleBalanceExtremes, and are empty when there is nothing to pick.
+type muscleBalanceResponse struct {
+	Days         int                          `json:"days"`
+	Groups       []muscleBalanceGroupResponse `json:"groups"`
+	MostTrained  string                       `json:"most_trained"`
+	LeastTrained string                       `json:"least_trained"`
+}
+
+// muscleBalanceGroupResponse is one radar spoke. Score is the group's
+// completed volume relative to the most-trained group, in [0,1]. CatalogGap
+// marks a group no catalog exercise trains, and Note explains it.
+type muscleBalanceGroupResponse struct {
+	Name          string  `json:"name"`
+	CompletedSets float64 `json:"completed_sets"`
+	Score         float64 `json:"score"`
+	CatalogGap    bool    `json:"catalog_gap"`
+	Note          string  `json:"note,omitempty"`
+}
+
+// muscleBalanceGET returns per-muscle-group balance scores for a radar chart
+// over the last days days (1–365, default 45). Every muscle group is listed,
+// untrained ones with a zero score.
+func (app *application) muscleBalanceGET(w http.ResponseWriter, r *http.Request) {
+	days, ok := parseDaysParam(w, r, defaultMuscleBalanceDays)
+	if !ok {
+		return
+	}
+
+	scores, err := app.service.MuscleBalance(r.Context(), days)
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("muscle balance: %w", err))
+		return
+	}
+
+	resp := muscleBalanceResponse{
+		Days:         days,
+		Groups:       make([]muscleBalanceGroupResponse, 0, len(scores)),
+		MostTrained:  "",
+		LeastTrained: "",
+	}
+	if most, least, ok := domain.MuscleBalanceExtremes(scores); ok {
+		resp.MostTrained, resp.LeastTrained = most.Name, least.Name
+	}
+	for _, s := range scores {
+		resp.Groups = append(resp.Groups, muscleBalanceGroupResponse{
+			Name:          s.Name,
+			CompletedSets: s.CompletedVolume,
+			Score:         s.Score,
+			CatalogGap:    s.CatalogGap,
+			Note:          s.Note(),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode muscle balance: %w", err))
+	}
+}
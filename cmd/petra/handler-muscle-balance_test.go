@@ -0,0 +1,111 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_MuscleBalanceGET seeds one completed chest set and checks that every
+// muscle group is listed, the trained group tops the scale and an untrained
+// one reads zero.
+func Test_MuscleBalanceGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	db := server.DB()
+	var exerciseID int
+	if err = db.QueryRowContext(ctx, `
+		SELECT exercise_id FROM exercise_muscle_groups
+		WHERE muscle_group_name = 'Chest' AND is_primary = 1
+		  AND exercise_id NOT IN (SELECT exercise_id FROM exercise_muscle_groups WHERE muscle_group_name = 'Calves')
+		ORDER BY exercise_id LIMIT 1`).Scan(&exerciseID); err != nil {
+		t.Fatalf("find chest exercise: %v", err)
+	}
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	if _, err = db.ExecContext(ctx,
+		"INSERT INTO workout_sessions (user_id, workout_date) SELECT id, ? FROM users", yesterday); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	if _, err = db.ExecContext(ctx, `
+		INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		SELECT id, ?, 0, ? FROM users`, yesterday, exerciseID); err != nil {
+		t.Fatalf("insert slot: %v", err)
+	}
+	if _, err = db.ExecContext(ctx, `
+		INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+		                           weight_kg, target_value, completed_value, completed_at)
+		SELECT id, ?, 0, 1, 40, 8, 8, '2026-01-01T10:00:00.000Z' FROM users`, yesterday); err != nil {
+		t.Fatalf("insert completed set: %v", err)
+	}
+	var groupCount int
+	if err = db.QueryRowContext(ctx, "SELECT COUNT(*) FROM muscle_groups").Scan(&groupCount); err != nil {
+		t.Fatalf("count muscle groups: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/api/muscle-balance?days=45")
+	if err != nil {
+		t.Fatalf("get muscle balance: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body muscleBalanceResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Days != 45 {
+		t.Errorf("days = %d, want 45", body.Days)
+	}
+	if len(body.Groups) != groupCount {
+		t.Errorf("got %d groups, want all %d muscle groups", len(body.Groups), groupCount)
+	}
+	scores := make(map[string]float64, len(body.Groups))
+	for _, g := range body.Groups {
+		if g.Score < 0 || g.Score > 1 {
+			t.Errorf("%s score = %v, want within [0,1]", g.Name, g.Score)
+		}
+		scores[g.Name] = g.Score
+	}
+	if got, ok := scores["Chest"]; !ok || got != 1 {
+		t.Errorf("Chest score = %v (present %t), want 1 for the most-trained group", got, ok)
+	}
+	if got, ok := scores["Calves"]; !ok || got != 0 {
+		t.Errorf("Calves score = %v (present %t), want 0 for an untrained group", got, ok)
+	}
+	// Groups tied at the top go to the name that sorts first.
+	for name, score := range scores {
+		if score == 1 && name < body.MostTrained {
+			t.Errorf("most_trained = %q, want %q, the first of the groups tied at 1", body.MostTrained, name)
+		}
+	}
+	if scores[body.MostTrained] != 1 {
+		t.Errorf("most_trained = %q, want a group scoring 1", body.MostTrained)
+	}
+	if body.LeastTrained == "" || scores[body.LeastTrained] != 0 {
+		t.Errorf("least_trained = %q, want an untrained group", body.LeastTrained)
+	}
+
+	bad, err := client.Get(ctx, "/api/muscle-balance?days=0")
+	if err != nil {
+		t.Fatalf("get muscle balance with days=0: %v", err)
+	}
+	_ = bad.Body.Close()
+	if bad.StatusCode != http.StatusBadRequest {
+		t.Errorf("days=0 status = %d, want %d", bad.StatusCode, http.StatusBadRequest)
+	}
+}
@@ -27,17 +27,17 @@ func Test_application_notFound(t *testing.T) {
 
 	client := server.Client()
 
-	t.Run("Invalid exercise ID returns custom 404", func(t *testing.T) {
+	t.Run("Exercise on a day without a workout returns custom 404", func(t *testing.T) {
 		// Register a user first (required for mustSession routes)
 		if _, err = client.Register(ctx); err != nil {
 			t.Fatalf("Failed to register: %v", err)
 		}
 
-		// Test invalid exercise info route - should trigger our custom 404
+		// A well-formed position on a day without a workout - should trigger our custom 404
 		var resp *http.Response
-		resp, err = client.Get(ctx, "/workouts/2024-01-01/exercises/invalid-id/info")
+		resp, err = client.Get(ctx, "/workouts/2024-01-01/exercises/0/info")
 		if err != nil {
-			t.Fatalf("Failed to get invalid exercise info: %v", err)
+			t.Fatalf("Failed to get unknown exercise info: %v", err)
 		}
 		if err = resp.Body.Close(); err != nil {
 			t.Fatalf("Failed to close response body: %v", err)
@@ -45,14 +45,14 @@ func Test_application_notFound(t *testing.T) {
 
 		// Verify we get a 404 status code
 		if resp.StatusCode != http.StatusNotFound {
-			t.Errorf("Expected status code %d for invalid exercise ID, got %d", http.StatusNotFound, resp.StatusCode)
+			t.Errorf("Expected status code %d for unknown exercise, got %d", http.StatusNotFound, resp.StatusCode)
 		}
 
 		// Get the document to check content (need to parse 404 responses manually)
 		var resp404 *http.Response
-		resp404, err = client.Get(ctx, "/workouts/2024-01-01/exercises/invalid-id/info")
+		resp404, err = client.Get(ctx, "/workouts/2024-01-01/exercises/0/info")
 		if err != nil {
-			t.Fatalf("Failed to get 404 response for invalid exercise ID: %v", err)
+			t.Fatalf("Failed to get 404 response for unknown exercise: %v", err)
 		}
 		defer func() {
 			if err = resp404.Body.Close(); err != nil {
@@ -61,17 +61,17 @@ func Test_application_notFound(t *testing.T) {
 		}()
 
 		if resp404.StatusCode != http.StatusNotFound {
-			t.Errorf("Expected 404 status for invalid exercise ID, got %d", resp404.StatusCode)
+			t.Errorf("Expected 404 status for unknown exercise, got %d", resp404.StatusCode)
 		}
 
 		var doc *goquery.Document
 		doc, err = goquery.NewDocumentFromReader(resp404.Body)
 		if err != nil {
-			t.Fatalf("Failed to parse 404 document for invalid exercise ID: %v", err)
+			t.Fatalf("Failed to parse 404 document for unknown exercise: %v", err)
 		}
 
 		// Check for custom 404 content
-		checkCustom404Content(t, doc, "invalid exercise ID")
+		checkCustom404Content(t, doc, "unknown exercise")
 	})
 
 	t.Run("Invalid date returns custom 404", func(t *testing.T) {
@@ -182,7 +182,7 @@ func Test_application_notFound_template_content(t *testing.T) {
 	}
 
 	// Get a 404 page to test
-	resp404, err := client.Get(ctx, "/workouts/2024-01-01/exercises/nonexistent/info")
+	resp404, err := client.Get(ctx, "/workouts/2024-01-01/exercises/0/info")
 	if err != nil {
 		t.Fatalf("Failed to get 404 response: %v", err)
 	}
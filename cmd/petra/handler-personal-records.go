@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// personalRecordResponse is one entry in the JSON body of GET /api/prs.
+type personalRecordResponse struct {
+	ExerciseID   int      `json:"exercise_id"`
+	ExerciseName string   `json:"exercise_name"`
+	Date         string   `json:"date"`
+	WeightKg     *float64 `json:"weight_kg,omitempty"`
+	Value        int      `json:"value"`
+	Unit         string   `json:"unit"`
+}
+
+// personalRecordsGET lists the user's current personal record on every
+// exercise they have logged, sorted by exercise name. Value is in Unit
+// ("reps" or "seconds"); WeightKg is omitted for exercises without load.
+func (app *application) personalRecordsGET(w http.ResponseWriter, r *http.Request) {
+	records, err := app.service.ListPersonalRecords(r.Context())
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("list personal records: %w", err))
+		return
+	}
+
+	resp := make([]personalRecordResponse, 0, len(records))
+	for _, pr := range records {
+		resp = append(resp, newPersonalRecordResponse(pr))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode personal records: %w", err))
+	}
+}
+
+// newPersonalRecordResponse converts a record to its JSON shape, shared by
+// /api/prs and the dashboard's recent records.
+func newPersonalRecordResponse(pr domain.PersonalRecord) personalRecordResponse {
+	return personalRecordResponse{
+		ExerciseID:   pr.Exercise.ID,
+		ExerciseName: pr.Exercise.Name,
+		Date:         pr.Date.Format(time.DateOnly),
+		WeightKg:     pr.WeightKg,
+		Value:        pr.Value,
+		Unit:         pr.Exercise.SetValueUnit(),
+	}
+}
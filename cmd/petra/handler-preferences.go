@@ -43,6 +43,12 @@ type workoutDurationOption struct {
 	Label string // Display label
 }
 
+// aggressivenessOption is one progression-speed choice in the schedule panel.
+type aggressivenessOption struct {
+	Value float64
+	Label string
+}
+
 type preferencesTemplateData struct {
 	BaseTemplateData
 
@@ -56,8 +62,26 @@ type preferencesTemplateData struct {
 	MesocycleLength          int
 	MesocycleLengthOptions   []int
 	MesocycleAnchor          time.Time
-	Flash                    BannerData
-	FlashByPanel             map[string]BannerData
+	// DeloadInterval is the stored weeks of progress before an early deload;
+	// 0 selects the automatic option.
+	DeloadInterval        int
+	DeloadIntervalOptions []int
+	// DefaultDeloadInterval is the interval the automatic option stands for.
+	DefaultDeloadInterval  int
+	MaxExercisesPerSession int
+	MaxExercisesOptions    []int
+	LighterWeekends        bool
+	PreserveExerciseOrder  bool
+	RoundWeightsDown       bool
+	Beginner               bool
+	// ProgressionAggressiveness is the effective multiplier, so an unset
+	// preference selects the standard option.
+	ProgressionAggressiveness float64
+	AggressivenessOptions     []aggressivenessOption
+	// PreferredWorkoutTime is the reminder time as HH:MM, or "" for none.
+	PreferredWorkoutTime string
+	Flash                BannerData
+	FlashByPanel         map[string]BannerData
 }
 
 func getWorkoutDurationOptions() []workoutDurationOption {
@@ -92,6 +116,77 @@ func parseMesocycleLength(value string) int {
 	return n
 }
 
+// deloadIntervalOptions lists the early-deload intervals offered in the
+// recovery panel, shortest first. "Automatic" (0) is rendered separately.
+func deloadIntervalOptions() []int {
+	opts := make([]int, 0, domain.MaxDeloadInterval-domain.MinDeloadInterval+1)
+	for n := domain.MinDeloadInterval; n <= domain.MaxDeloadInterval; n++ {
+		opts = append(opts, n)
+	}
+	return opts
+}
+
+// parseDeloadInterval reads the early-deload interval, falling back to 0
+// (automatic) for anything outside the offered options.
+func parseDeloadInterval(value string) int {
+	n, err := strconv.Atoi(value)
+	if err != nil || domain.ValidateDeloadInterval(n) != nil {
+		return 0
+	}
+	return n
+}
+
+// maxExercisesOptions lists the per-workout exercise caps offered in the
+// schedule panel, smallest first. "No limit" (0) is rendered separately.
+func maxExercisesOptions() []int {
+	opts := make([]int, 0, domain.MaxExercisesPerSessionLimit)
+	for n := 1; n <= domain.MaxExercisesPerSessionLimit; n++ {
+		opts = append(opts, n)
+	}
+	return opts
+}
+
+// aggressivenessOptions lists the progression speeds offered in the schedule
+// panel, slowest first, spanning the domain's clamp band.
+func aggressivenessOptions() []aggressivenessOption {
+	return []aggressivenessOption{
+		{Value: domain.MinProgressionAggressiveness, Label: "Maintain (smallest steps)"},
+		{Value: 0.75, Label: "Gentle"},
+		{Value: domain.DefaultProgressionAggressiveness, Label: "Standard"},
+		{Value: 1.5, Label: "Faster"},
+		{Value: domain.MaxProgressionAggressiveness, Label: "Fastest (double steps)"},
+	}
+}
+
+// parseProgressionAggressiveness reads the progression multiplier, falling
+// back to the default when the input is absent or not a number. Values outside
+// the allowed band are clamped into it.
+func parseProgressionAggressiveness(value string) float64 {
+	m, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return domain.DefaultProgressionAggressiveness
+	}
+	prefs := domain.Preferences{ProgressionAggressiveness: m} //nolint:exhaustruct // Only used to clamp m.
+	return prefs.EffectiveProgressionAggressiveness()
+}
+
+// parseMaxExercises reads the exercise cap. An absent value means no limit
+// (0); anything else that is not a whole number in range returns a
+// domain.ValidationError.
+func parseMaxExercises(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, domain.ValidationError{Message: "Exercises per workout must be a whole number, or no limit."}
+	}
+	if err = domain.ValidateMaxExercisesPerSession(n); err != nil {
+		return 0, err //nolint:wrapcheck // A ValidationError shown to the user as is.
+	}
+	return n, nil
+}
+
 func parseMinutes(value string) int {
 	minutes, err := strconv.Atoi(value)
 	if err != nil {
@@ -139,23 +234,41 @@ func (app *application) preferencesGET(w http.ResponseWriter, r *http.Request) {
 			Subtitle: "Select the days you're planning to go to the gym",
 			Nonce:    base.Nonce,
 		},
-		Weekdays:                 preferencesToWeekdays(prefs),
-		DurationOptions:          getWorkoutDurationOptions(),
-		VAPIDPublicKey:           app.vapidPublicKey,
-		PushSubscriptionCount:    subCount,
-		RestNotificationsEnabled: prefs.RestNotificationsEnabled,
-		DeloadEnabled:            prefs.DeloadEnabled,
-		MesocycleLength:          prefs.MesocycleLength,
-		MesocycleLengthOptions:   []int{4, 5, 6, 7},
-		MesocycleAnchor:          prefs.MesocycleAnchor,
-		Flash:                    pageTopFlash,
-		FlashByPanel:             flashByPanel,
+		Weekdays:                  preferencesToWeekdays(prefs),
+		DurationOptions:           getWorkoutDurationOptions(),
+		VAPIDPublicKey:            app.vapidPublicKey,
+		PushSubscriptionCount:     subCount,
+		RestNotificationsEnabled:  prefs.RestNotificationsEnabled,
+		DeloadEnabled:             prefs.DeloadEnabled,
+		MesocycleLength:           prefs.MesocycleLength,
+		MesocycleLengthOptions:    []int{4, 5, 6, 7},
+		MesocycleAnchor:           prefs.MesocycleAnchor,
+		DeloadInterval:            prefs.DeloadInterval,
+		DeloadIntervalOptions:     deloadIntervalOptions(),
+		DefaultDeloadInterval:     domain.DefaultDeloadInterval,
+		MaxExercisesPerSession:    prefs.MaxExercisesPerSession,
+		MaxExercisesOptions:       maxExercisesOptions(),
+		LighterWeekends:           prefs.LighterWeekends,
+		PreserveExerciseOrder:     prefs.PreserveExerciseOrder,
+		RoundWeightsDown:          prefs.RoundWeightsDown,
+		Beginner:                  prefs.Beginner,
+		ProgressionAggressiveness: prefs.EffectiveProgressionAggressiveness(),
+		AggressivenessOptions:     aggressivenessOptions(),
+		PreferredWorkoutTime:      "",
+		Flash:                     pageTopFlash,
+		FlashByPanel:              flashByPanel,
+	}
+
+	if prefs.PreferredWorkoutTime != nil {
+		data.PreferredWorkoutTime = prefs.PreferredWorkoutTime.String()
 	}
 
 	app.render(w, r, http.StatusOK, "preferences", data)
 }
 
-// preferencesScheduleSavePOST persists the weekday-minutes selection. On
+// preferencesScheduleSavePOST persists the weekday-minutes selection, the
+// per-workout exercise cap, the progression speed, the reminder time and the
+// lighter-weekends, exercise-order, weight-rounding and beginner toggles. On
 // success, the user is redirected to home so they see the regenerated week.
 func (app *application) preferencesScheduleSavePOST(w http.ResponseWriter, r *http.Request) {
 	if !app.parseForm(w, r, defaultMaxFormSize) {
@@ -174,6 +287,26 @@ func (app *application) preferencesScheduleSavePOST(w http.ResponseWriter, r *ht
 	prefs.Minutes[time.Friday] = parseMinutes(r.Form.Get("friday_minutes"))
 	prefs.Minutes[time.Saturday] = parseMinutes(r.Form.Get("saturday_minutes"))
 	prefs.Minutes[time.Sunday] = parseMinutes(r.Form.Get("sunday_minutes"))
+	if prefs.MaxExercisesPerSession, err = parseMaxExercises(r.Form.Get("max_exercises")); err != nil {
+		app.putFlashErrorWithAnchor(r.Context(), err.Error(), scheduleAnchor)
+		redirect(w, r, "/preferences#"+scheduleAnchor)
+		return
+	}
+	prefs.LighterWeekends = r.Form.Get("lighter_weekends") == "on"
+	prefs.PreserveExerciseOrder = r.Form.Get("preserve_exercise_order") == "on"
+	prefs.RoundWeightsDown = r.Form.Get("round_weights_down") == "on"
+	prefs.Beginner = r.Form.Get("beginner") == "on"
+	prefs.ProgressionAggressiveness = parseProgressionAggressiveness(r.Form.Get("progression_aggressiveness"))
+	prefs.PreferredWorkoutTime = nil
+	if raw := r.Form.Get("preferred_workout_time"); raw != "" {
+		at, parseErr := domain.ParseTimeOfDay(raw)
+		if parseErr != nil {
+			app.putFlashErrorWithAnchor(r.Context(), parseErr.Error(), scheduleAnchor)
+			redirect(w, r, "/preferences#"+scheduleAnchor)
+			return
+		}
+		prefs.PreferredWorkoutTime = &at
+	}
 
 	if prefs.IsEmpty() {
 		app.putFlashErrorWithAnchor(r.Context(),
@@ -198,8 +331,8 @@ func (app *application) preferencesScheduleSavePOST(w http.ResponseWriter, r *ht
 	redirect(w, r, "/")
 }
 
-// preferencesDeloadSavePOST persists the deload-enable toggle and mesocycle
-// length. On success, the user lands at the recovery panel with a success
+// preferencesDeloadSavePOST persists the deload-enable toggle, mesocycle
+// length and early-deload interval. On success, the user lands at the recovery panel with a success
 // banner inside it.
 func (app *application) preferencesDeloadSavePOST(w http.ResponseWriter, r *http.Request) {
 	if !app.parseForm(w, r, defaultMaxFormSize) {
@@ -213,6 +346,7 @@ func (app *application) preferencesDeloadSavePOST(w http.ResponseWriter, r *http
 	}
 	prefs.DeloadEnabled = r.Form.Get("deload_enabled") == "on"
 	prefs.MesocycleLength = parseMesocycleLength(r.Form.Get("mesocycle_length"))
+	prefs.DeloadInterval = parseDeloadInterval(r.Form.Get("deload_interval"))
 
 	if err = app.service.SaveUserPreferences(r.Context(), prefs); err != nil {
 		app.serverError(w, r, fmt.Errorf("save user preferences: %w", err))
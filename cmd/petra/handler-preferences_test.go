@@ -524,6 +524,46 @@ func TestPreferencesPOST_RejectsEmptySchedule(t *testing.T) {
 	}
 }
 
+func TestPreferencesPOST_RejectsInvalidMaxExercises(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	client := server.Client()
+
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	for _, tt := range []struct{ value, want string }{
+		{value: "lots", want: "must be a whole number"},
+		{value: "99", want: "must be between 1 and"},
+	} {
+		doc, getErr := client.GetDoc(ctx, "/preferences")
+		if getErr != nil {
+			t.Fatalf("Failed to get preferences: %v", getErr)
+		}
+		doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+			"monday_minutes": "60",
+			"max_exercises":  tt.value,
+		})
+		if err != nil {
+			t.Fatalf("Failed to submit preferences form with max_exercises %q: %v", tt.value, err)
+		}
+		if doc.Url.Path != "/preferences" {
+			t.Errorf("max_exercises %q: expected to stay on /preferences, got %q", tt.value, doc.Url.Path)
+		}
+		banner := doc.Find("form[aria-labelledby='schedule-title'] .banner--error")
+		if !strings.Contains(banner.Text(), tt.want) {
+			t.Errorf("max_exercises %q: banner = %q, want it to contain %q", tt.value, banner.Text(), tt.want)
+		}
+	}
+}
+
 func verifySelected(t *testing.T, doc *goquery.Document, weekdays map[string]int) {
 	t.Helper()
 	form, err := e2etest.FindForm(doc, "/preferences/schedule")
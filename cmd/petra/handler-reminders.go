@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// reminderResponse is one entry of GET /api/admin/reminders.
+type reminderResponse struct {
+	UserID int    `json:"user_id"`
+	Date   string `json:"date"`
+	At     string `json:"at"`
+}
+
+// remindersGET lists the workout reminders due right now across all users.
+// Nothing delivers them yet, so the endpoint is admin-only: it shows who a
+// reminder job would nudge.
+func (app *application) remindersGET(w http.ResponseWriter, r *http.Request) {
+	reminders, err := app.service.UpcomingReminders(r.Context(), time.Now())
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("upcoming reminders: %w", err))
+		return
+	}
+
+	resp := make([]reminderResponse, 0, len(reminders))
+	for _, rem := range reminders {
+		resp = append(resp, reminderResponse{
+			UserID: rem.UserID,
+			Date:   rem.Date.Format(time.DateOnly),
+			At:     rem.At.Format(time.RFC3339),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode reminders: %w", err))
+	}
+}
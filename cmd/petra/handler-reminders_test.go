@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_RemindersGET saves a midnight reminder time with today as a workout
+// day, so the reminder is already due, and checks that the admin endpoint
+// lists it and that non-admins cannot read it.
+func Test_RemindersGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	today := strings.ToLower(time.Now().UTC().Weekday().String())
+	resp := postShimForm(t, server, client, "/preferences/schedule", neturl.Values{
+		today + "_minutes":       []string{"60"},
+		"preferred_workout_time": []string{"00:00"},
+	})
+	_ = resp.Body.Close()
+	doc, err := client.GetDoc(ctx, "/preferences")
+	if err != nil {
+		t.Fatalf("get preferences: %v", err)
+	}
+	if got, _ := doc.Find(`input[name="preferred_workout_time"]`).Attr("value"); got != "00:00" {
+		t.Errorf("preferred_workout_time input = %q, want 00:00", got)
+	}
+
+	forbidden, err := client.Get(ctx, "/api/admin/reminders")
+	if err != nil {
+		t.Fatalf("get reminders as non-admin: %v", err)
+	}
+	_ = forbidden.Body.Close()
+	if forbidden.Request.URL.Path != "/forbidden" {
+		t.Errorf("non-admin landed on %s, want /forbidden", forbidden.Request.URL.Path)
+	}
+
+	var userID int
+	if err = server.DB().QueryRowContext(ctx,
+		"UPDATE users SET is_admin = 1 WHERE TRUE RETURNING id").Scan(&userID); err != nil {
+		t.Fatalf("promote user to admin: %v", err)
+	}
+	got, err := client.Get(ctx, "/api/admin/reminders")
+	if err != nil {
+		t.Fatalf("get reminders: %v", err)
+	}
+	defer func() { _ = got.Body.Close() }()
+	if got.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", got.StatusCode, http.StatusOK)
+	}
+	var reminders []reminderResponse
+	if err = json.NewDecoder(got.Body).Decode(&reminders); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if len(reminders) != 1 || reminders[0].UserID != userID {
+		t.Errorf("reminders = %+v, want one for user %d", reminders, userID)
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/myrjola/petrapp/internal/e2etest"
@@ -66,3 +67,54 @@ func Test_application_schedulePOST_preservesRestNotificationsEnabled(t *testing.
 		t.Error("rest_notifications_enabled was cleared by /schedule submit; should be preserved")
 	}
 }
+
+// An onboarding submission with every day left as a rest day must be turned
+// back with a banner rather than saved: with no workout day the planner can
+// never produce a session.
+func Test_application_schedulePOST_rejectsAllRestDays(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	client := server.Client()
+
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	scheduleDoc, err := client.GetDoc(ctx, "/schedule")
+	if err != nil {
+		t.Fatalf("Failed to get schedule: %v", err)
+	}
+	formData := map[string]string{}
+	for _, day := range []string{"monday", "tuesday", "wednesday", "thursday", "friday", "saturday", "sunday"} {
+		formData[day+"_minutes"] = "0"
+	}
+	doc, err := client.SubmitForm(ctx, scheduleDoc, "/schedule", formData)
+	if err != nil {
+		t.Fatalf("Failed to submit schedule form: %v", err)
+	}
+
+	if doc.Url.Path != "/schedule" {
+		t.Errorf("Expected to stay on /schedule, got %q", doc.Url.Path)
+	}
+	banner := doc.Find(".banner--error")
+	if !strings.Contains(banner.Text(), "at least one workout day") {
+		t.Errorf("Expected an error banner asking for at least one workout day, got %q", banner.Text())
+	}
+
+	var workoutDays int
+	if err = server.DB().QueryRowContext(ctx, `
+		SELECT COUNT(*) FROM workout_preferences
+		WHERE monday_minutes + tuesday_minutes + wednesday_minutes + thursday_minutes
+		    + friday_minutes + saturday_minutes + sunday_minutes > 0`).Scan(&workoutDays); err != nil {
+		t.Fatalf("Failed to query preferences: %v", err)
+	}
+	if workoutDays != 0 {
+		t.Errorf("Expected the all-rest-days schedule not to be saved over the empty default, found %d rows", workoutDays)
+	}
+}
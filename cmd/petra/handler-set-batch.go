@@ -0,0 +1,94 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// setBatchBodyMaxBytes caps the POST /api/workouts/{date}/sets:batch body,
+// comfortably above domain.MaxSetBatchSize items.
+const setBatchBodyMaxBytes = 64 << 10
+
+// setBatchItem is one element of the POST /api/workouts/{date}/sets:batch
+// body, a JSON array. Weight is in kilograms and omitted for bodyweight and
+// time-based exercises; for those, reps holds seconds or reps.
+type setBatchItem struct {
+	ExerciseID int      `json:"exerciseID"`
+	SetNumber  int      `json:"setNumber"`
+	Reps       int      `json:"reps"`
+	Weight     *float64 `json:"weight"`
+}
+
+// setBatchItemResult reports whether the item at Index was valid.
+type setBatchItemResult struct {
+	Index int    `json:"index"`
+	OK    bool   `json:"ok"`
+	Error string `json:"error,omitempty"`
+}
+
+// setBatchResponse is the JSON body of POST /api/workouts/{date}/sets:batch.
+// Applied is false when any item was invalid, in which case no set was saved.
+type setBatchResponse struct {
+	Applied bool                 `json:"applied"`
+	Results []setBatchItemResult `json:"results"`
+}
+
+// setBatchPOST completes many sets of the workout on {date} at once, for
+// clients that log offline and sync later. The batch is all or nothing: it is
+// a 200 when every item was saved and a 422 with per-item results when any
+// item was invalid and nothing was saved.
+func (app *application) setBatchPOST(w http.ResponseWriter, r *http.Request) {
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, setBatchBodyMaxBytes)
+	var req []setBatchItem
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Request body must be a JSON array of sets.", http.StatusBadRequest)
+		return
+	}
+
+	items := make([]domain.SetCompletion, len(req))
+	for i, it := range req {
+		items[i] = domain.SetCompletion{
+			ExerciseID: it.ExerciseID,
+			SetNumber:  it.SetNumber,
+			Reps:       it.Reps,
+			WeightKg:   it.Weight,
+		}
+	}
+	itemErrs, err := app.service.RecordSetBatch(r.Context(), date, items)
+	if err != nil {
+		var ve domain.ValidationError
+		switch {
+		case errors.Is(err, domain.ErrNotFound):
+			app.notFound(w, r)
+		case errors.As(err, &ve):
+			http.Error(w, ve.Message, http.StatusBadRequest)
+		default:
+			app.serverError(w, r, fmt.Errorf("record set batch: %w", err))
+		}
+		return
+	}
+
+	resp := setBatchResponse{Applied: true, Results: make([]setBatchItemResult, len(itemErrs))}
+	for i, itemErr := range itemErrs {
+		resp.Results[i] = setBatchItemResult{Index: i, OK: itemErr == nil, Error: ""}
+		if itemErr != nil {
+			resp.Applied = false
+			resp.Results[i].Error = itemErr.Error()
+		}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if !resp.Applied {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+	}
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode set batch response: %w", err))
+	}
+}
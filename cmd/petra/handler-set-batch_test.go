@@ -0,0 +1,127 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_SetBatchPOST seeds a started workout with a weighted exercise (two
+// sets) and a bodyweight exercise (one set). A batch with one invalid item
+// must report each item's validity and save nothing; the valid batch must
+// then save every set.
+func Test_SetBatchPOST(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	db := server.DB()
+	var weightedID, bodyweightID int
+	if err = db.QueryRowContext(ctx, `SELECT
+		(SELECT MIN(id) FROM exercises WHERE exercise_type = 'weighted'),
+		(SELECT MIN(id) FROM exercises WHERE exercise_type = 'bodyweight')`).Scan(&weightedID, &bodyweightID); err != nil {
+		t.Fatalf("pick exercises: %v", err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO workout_sessions (user_id, workout_date, started_at)
+		 SELECT id, '2026-01-07', '2026-01-07T18:00:00.000Z' FROM users`,
+		fmt.Sprintf(`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		 SELECT id, '2026-01-07', 0, %d FROM users`, weightedID),
+		fmt.Sprintf(`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		 SELECT id, '2026-01-07', 1, %d FROM users`, bodyweightID),
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number, weight_kg, target_value)
+		 SELECT id, '2026-01-07', 0, 1, 60, 8 FROM users`,
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number, weight_kg, target_value)
+		 SELECT id, '2026-01-07', 0, 2, 60, 8 FROM users`,
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number, target_value)
+		 SELECT id, '2026-01-07', 1, 1, 12 FROM users`,
+	} {
+		if _, err = db.ExecContext(ctx, stmt); err != nil {
+			t.Fatalf("seed: %v", err)
+		}
+	}
+	completedSets := func() int {
+		t.Helper()
+		var n int
+		if err = db.QueryRowContext(ctx, `SELECT COUNT(*) FROM exercise_sets
+			WHERE workout_date = '2026-01-07' AND completed_at IS NOT NULL`).Scan(&n); err != nil {
+			t.Fatalf("count completed sets: %v", err)
+		}
+		return n
+	}
+	post := func(items string) (int, setBatchResponse) {
+		t.Helper()
+		resp, postErr := postJSON(ctx, client, server.URL()+"/api/workouts/2026-01-07/sets:batch",
+			bytes.NewReader([]byte(items)))
+		if postErr != nil {
+			t.Fatalf("post batch: %v", postErr)
+		}
+		defer func() { _ = resp.Body.Close() }()
+		var body setBatchResponse
+		if resp.StatusCode == http.StatusOK || resp.StatusCode == http.StatusUnprocessableEntity {
+			if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+				t.Fatalf("decode body: %v", err)
+			}
+		}
+		return resp.StatusCode, body
+	}
+
+	status, body := post(fmt.Sprintf(`[
+		{"exerciseID": %[1]d, "setNumber": 1, "reps": 8, "weight": 62.5},
+		{"exerciseID": %[1]d, "setNumber": 3, "reps": 8, "weight": 62.5},
+		{"exerciseID": %[2]d, "setNumber": 1, "reps": 12, "weight": 10},
+		{"exerciseID": 999999, "setNumber": 1, "reps": 5}
+	]`, weightedID, bodyweightID))
+	if status != http.StatusUnprocessableEntity {
+		t.Fatalf("mixed batch status = %d, want %d", status, http.StatusUnprocessableEntity)
+	}
+	wantOK := []bool{true, false, false, false}
+	if body.Applied || len(body.Results) != len(wantOK) {
+		t.Fatalf("mixed batch = %+v, want %d unapplied results", body, len(wantOK))
+	}
+	for i, res := range body.Results {
+		if res.Index != i || res.OK != wantOK[i] || (res.Error == "") == !wantOK[i] {
+			t.Errorf("result %d = %+v, want ok=%v with an error only when invalid", i, res, wantOK[i])
+		}
+	}
+	if n := completedSets(); n != 0 {
+		t.Fatalf("completed sets after rejected batch = %d, want 0", n)
+	}
+
+	status, body = post(fmt.Sprintf(`[
+		{"exerciseID": %[1]d, "setNumber": 1, "reps": 8, "weight": 62.5},
+		{"exerciseID": %[1]d, "setNumber": 2, "reps": 7, "weight": 62.5},
+		{"exerciseID": %[2]d, "setNumber": 1, "reps": 12}
+	]`, weightedID, bodyweightID))
+	if status != http.StatusOK || !body.Applied {
+		t.Fatalf("valid batch = %d %+v, want 200 and applied", status, body)
+	}
+	if n := completedSets(); n != 3 {
+		t.Errorf("completed sets after valid batch = %d, want 3", n)
+	}
+	var weight float64
+	if err = db.QueryRowContext(ctx, `SELECT weight_kg FROM exercise_sets
+		WHERE workout_date = '2026-01-07' AND position = 0 AND set_number = 2`).Scan(&weight); err != nil {
+		t.Fatalf("read weight: %v", err)
+	}
+	if weight != 62.5 {
+		t.Errorf("set 2 weight = %v, want 62.5", weight)
+	}
+
+	if status, _ = post(`[]`); status != http.StatusBadRequest {
+		t.Errorf("empty batch status = %d, want %d", status, http.StatusBadRequest)
+	}
+}
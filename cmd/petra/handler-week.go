@@ -0,0 +1,83 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// weekPreviewResponse is the JSON body of GET /api/week.
+type weekPreviewResponse struct {
+	Monday   string               `json:"monday"`
+	Sessions []weekPreviewSession `json:"sessions"`
+}
+
+type weekPreviewSession struct {
+	Date      string                `json:"date"`
+	Category  domain.Category       `json:"category"`
+	Goal      domain.SessionGoal    `json:"goal"`
+	IsDeload  bool                  `json:"is_deload"`
+	Exercises []weekPreviewExercise `json:"exercises"`
+}
+
+type weekPreviewExercise struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+	Sets int    `json:"sets"`
+}
+
+// weekPreviewGET previews the planner's workouts for the week containing the
+// start query parameter (YYYY-MM-DD, default today). Only scheduled days are
+// listed, ordered by date. Nothing is persisted.
+func (app *application) weekPreviewGET(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	if raw := r.URL.Query().Get("start"); raw != "" {
+		var err error
+		if start, err = time.Parse(time.DateOnly, raw); err != nil {
+			http.Error(w, "Invalid start parameter", http.StatusBadRequest)
+			return
+		}
+	}
+
+	week, err := app.service.GenerateWeek(r.Context(), start)
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("generate week: %w", err))
+		return
+	}
+
+	resp := weekPreviewResponse{
+		Monday:   domain.MondayOf(start).Format(time.DateOnly),
+		Sessions: make([]weekPreviewSession, 0, len(week)),
+	}
+	for _, sess := range week {
+		exercises := make([]weekPreviewExercise, 0, len(sess.Slots))
+		for _, slot := range sess.Slots {
+			exercises = append(exercises, weekPreviewExercise{
+				ID:   slot.Exercise.ID,
+				Name: slot.Exercise.Name,
+				Sets: len(slot.Sets),
+			})
+		}
+		resp.Sessions = append(resp.Sessions, weekPreviewSession{
+			Date:      sess.Date.Format(time.DateOnly),
+			Category:  sess.WorkoutType(),
+			Goal:      sess.Goal,
+			IsDeload:  sess.IsDeload,
+			Exercises: exercises,
+		})
+	}
+	slices.SortFunc(resp.Sessions, func(a, b weekPreviewSession) int {
+		return strings.Compare(a.Date, b.Date)
+	})
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode week preview: %w", err))
+		return
+	}
+}
@@ -0,0 +1,95 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_WeekPreviewGET_ListsScheduledDays saves a Monday+Thursday schedule and
+// previews a fixed week: only those two days may come back, in date order.
+func Test_WeekPreviewGET_ListsScheduledDays(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	doc, err := client.Register(ctx)
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	doc, err = client.GetDoc(ctx, "/preferences")
+	if err != nil {
+		t.Fatalf("GetDoc /preferences: %v", err)
+	}
+	if _, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+		"monday_minutes":   "60",
+		"thursday_minutes": "45",
+	}); err != nil {
+		t.Fatalf("save schedule: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/api/week?start=2030-01-09")
+	if err != nil {
+		t.Fatalf("get week: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	var body struct {
+		Monday   string `json:"monday"`
+		Sessions []struct {
+			Date      string `json:"date"`
+			Exercises []struct {
+				Name string `json:"name"`
+			} `json:"exercises"`
+		} `json:"sessions"`
+	}
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Monday != "2030-01-07" {
+		t.Errorf("monday = %q, want 2030-01-07", body.Monday)
+	}
+	var dates []string
+	for _, s := range body.Sessions {
+		dates = append(dates, s.Date)
+		if len(s.Exercises) == 0 {
+			t.Errorf("%s: no exercises in preview", s.Date)
+		}
+	}
+	if len(dates) != 2 || dates[0] != "2030-01-07" || dates[1] != "2030-01-10" {
+		t.Errorf("session dates = %v, want [2030-01-07 2030-01-10]", dates)
+	}
+}
+
+func Test_WeekPreviewGET_InvalidStartIsBadRequest(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/api/week?start=not-a-date")
+	if err != nil {
+		t.Fatalf("get week: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// workoutCategoryResponse is the JSON body of GET /api/workouts/{date}/category.
+// Category is one of full_body, upper or lower.
+type workoutCategoryResponse struct {
+	Date     string `json:"date"`
+	Category string `json:"category"`
+	Label    string `json:"label"`
+	Reason   string `json:"reason"`
+}
+
+// workoutCategoryGET explains the split the planner chose for the workout on
+// {date}, so users can see why a day is upper, lower or full body.
+func (app *application) workoutCategoryGET(w http.ResponseWriter, r *http.Request) {
+	date, ok := app.parseDateParam(w, r)
+	if !ok {
+		return
+	}
+
+	decision, err := app.service.WorkoutCategory(r.Context(), date)
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("workout category: %w", err))
+		return
+	}
+
+	resp := workoutCategoryResponse{
+		Date:     date.Format("2006-01-02"),
+		Category: string(decision.Category),
+		Label:    decision.Category.Label(),
+		Reason:   decision.Reason,
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode workout category: %w", err))
+	}
+}
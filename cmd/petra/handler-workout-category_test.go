@@ -0,0 +1,54 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_WorkoutCategoryGET schedules Monday and Tuesday and checks that
+// Tuesday, the day after a workout day, is explained as upper body.
+func Test_WorkoutCategoryGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	// SQLite needs the WHERE to tell the upsert's ON CONFLICT from a join.
+	if _, err = server.DB().ExecContext(ctx, `
+		INSERT INTO workout_preferences (user_id, monday_minutes, tuesday_minutes)
+		SELECT id, 60, 60 FROM users WHERE true
+		ON CONFLICT (user_id) DO UPDATE SET monday_minutes = 60, tuesday_minutes = 60`); err != nil {
+		t.Fatalf("seed preferences: %v", err)
+	}
+
+	// 2026-01-06 is a Tuesday.
+	resp, err := client.Get(ctx, "/api/workouts/2026-01-06/category")
+	if err != nil {
+		t.Fatalf("get workout category: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body workoutCategoryResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Date != "2026-01-06" || body.Category != "upper" || body.Label != "Upper Body" {
+		t.Errorf("got %+v, want an upper-body Tuesday", body)
+	}
+	if !strings.Contains(body.Reason, "the day before, Monday, is a workout day") {
+		t.Errorf("reason = %q, want it to name Monday's workout", body.Reason)
+	}
+}
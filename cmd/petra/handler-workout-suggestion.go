@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// workoutSuggestionResponse is the JSON body of GET /api/workout-suggestion.
+// Category is one of full_body, upper or lower, or "rest" for a rest day.
+type workoutSuggestionResponse struct {
+	Category string `json:"category"`
+	Label    string `json:"label"`
+	Reason   string `json:"reason"`
+}
+
+// workoutSuggestionGET answers "what should I train today?" with a split, or
+// rest, based on the user's last few days of training.
+func (app *application) workoutSuggestionGET(w http.ResponseWriter, r *http.Request) {
+	suggestion, err := app.service.SuggestWorkout(r.Context())
+	if err != nil {
+		app.serverError(w, r, fmt.Errorf("suggest workout: %w", err))
+		return
+	}
+
+	resp := workoutSuggestionResponse{
+		Category: "rest",
+		Label:    "Rest",
+		Reason:   suggestion.Reason,
+	}
+	if !suggestion.Rest {
+		resp.Category = string(suggestion.Category)
+		resp.Label = suggestion.Category.Label()
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err = json.NewEncoder(w).Encode(resp); err != nil {
+		app.serverError(w, r, fmt.Errorf("encode workout suggestion: %w", err))
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_WorkoutSuggestionGET seeds a completed upper-body set yesterday and
+// checks that today's suggestion switches to the lower body.
+func Test_WorkoutSuggestionGET(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	db := server.DB()
+	var exerciseID int
+	if err = db.QueryRowContext(ctx,
+		"SELECT id FROM exercises WHERE category = 'upper' ORDER BY id LIMIT 1").Scan(&exerciseID); err != nil {
+		t.Fatalf("find upper-body exercise: %v", err)
+	}
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format(time.DateOnly)
+	if _, err = db.ExecContext(ctx,
+		"INSERT INTO workout_sessions (user_id, workout_date) SELECT id, ? FROM users", yesterday); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	if _, err = db.ExecContext(ctx, `
+		INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		SELECT id, ?, 0, ? FROM users`, yesterday, exerciseID); err != nil {
+		t.Fatalf("insert slot: %v", err)
+	}
+	if _, err = db.ExecContext(ctx, `
+		INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+		                           weight_kg, target_value, completed_value, completed_at)
+		SELECT id, ?, 0, 1, 40, 8, 8, '2026-01-01T10:00:00.000Z' FROM users`, yesterday); err != nil {
+		t.Fatalf("insert completed set: %v", err)
+	}
+
+	resp, err := client.Get(ctx, "/api/workout-suggestion")
+	if err != nil {
+		t.Fatalf("get workout suggestion: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	var body workoutSuggestionResponse
+	if err = json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		t.Fatalf("decode body: %v", err)
+	}
+	if body.Category != "lower" || body.Label != "Lower Body" {
+		t.Errorf("got category %q (%q), want lower after an upper-body day", body.Category, body.Label)
+	}
+	if body.Reason == "" {
+		t.Error("reason is empty, want an explanation")
+	}
+}
@@ -24,7 +24,12 @@ type workoutTemplateData struct {
 	TotalCount      int
 	ProgressPercent int
 	ProgressState   string
-	Flash           BannerData
+	// CircuitMinutes is the time window of a circuit workout, or 0 for one
+	// prescribed as sets; a circuit logs rounds instead of finishing.
+	CircuitMinutes int
+	// CircuitRounds prefills the rounds input with what was logged, if any.
+	CircuitRounds int
+	Flash         BannerData
 }
 
 // workoutExerciseView is the per-exercise row rendered on the workout overview.
@@ -137,22 +142,34 @@ func (app *application) workoutCompletePOST(w http.ResponseWriter, r *http.Reque
 	redirect(w, r, fmt.Sprintf("/workouts/%s/complete", date.Format("2006-01-02")))
 }
 
+// workoutStartPOST starts the workout on {date}, planning one on the spot
+// when the day has none. The optional category field picks the focus of such
+// an extra workout; without it the planner chooses.
 func (app *application) workoutStartPOST(w http.ResponseWriter, r *http.Request) {
 	// Parse date from URL path
 	date, ok := app.parseDateParam(w, r)
 	if !ok {
 		return
 	}
+	if !app.parseForm(w, r, defaultMaxFormSize) {
+		return
+	}
 
 	// Start the workout session
-	if err := app.service.StartSession(r.Context(), date); err != nil {
+	var err error
+	if category := r.PostForm.Get("category"); category != "" {
+		err = app.service.StartAdHocSession(r.Context(), date, domain.Category(category))
+	} else {
+		err = app.service.StartSession(r.Context(), date)
+	}
+	if err != nil {
 		if errors.Is(err, domain.ErrNotFound) {
 			flash := app.popFlash(r.Context())
 			data := newWorkoutNotFoundTemplateData(r, date, flash.Message)
 			app.render(w, r, http.StatusNotFound, "workout-not-found", data)
 			return
 		}
-		app.serverError(w, r, err)
+		app.userError(w, r, err, fmt.Sprintf("/workouts/%s", date.Format("2006-01-02")))
 		return
 	}
 
@@ -227,9 +244,10 @@ func newWorkoutTemplateData(
 	for i, es := range session.Slots {
 		exerciseViews = append(
 			exerciseViews,
-			newWorkoutExerciseView(i, es, session.Goal, session.IsDeload),
+			newWorkoutExerciseView(i, es, session.Goal, session.IsDeload, session.IsCircuit()),
 		)
 	}
+	circuitRounds, _ := session.CircuitRounds()
 
 	base := newBaseTemplateData(r)
 	return workoutTemplateData{
@@ -244,6 +262,8 @@ func newWorkoutTemplateData(
 		TotalCount:       total,
 		ProgressPercent:  progressPercent,
 		ProgressState:    progressState,
+		CircuitMinutes:   session.CircuitSeconds() / secondsPerMinute,
+		CircuitRounds:    circuitRounds,
 		Flash: BannerData{
 			Variant: BannerVariantError,
 			Message: flashMessage,
@@ -268,9 +288,10 @@ func finishNoteFor(incomplete int) string {
 
 // newWorkoutExerciseView shapes one ExerciseSlot into a workoutExerciseView,
 // including the sub-line copy and the per-set dot indicator. pos is the
-// 0-based slot index in Session.Slots.
+// 0-based slot index in Session.Slots; in a circuit the sub-line gives the
+// per-round prescription instead of a set count.
 func newWorkoutExerciseView(
-	pos int, es domain.ExerciseSlot, pt domain.SessionGoal, isDeload bool,
+	pos int, es domain.ExerciseSlot, pt domain.SessionGoal, isDeload, circuit bool,
 ) workoutExerciseView {
 	dots := make([]workoutExerciseDot, len(es.Sets))
 	for j, s := range es.Sets {
@@ -281,6 +302,12 @@ func newWorkoutExerciseView(
 	switch {
 	case len(es.Sets) == 0:
 		subLine = "no sets planned"
+	case circuit:
+		subLine = es.Exercise.FormatSetValue(es.Sets[0].TargetValue) // "8" (reps) or "30s" (timed)
+		if !es.Exercise.IsTimed() {
+			subLine += " reps"
+		}
+		subLine += " per round"
 	case completedSets == 0:
 		subLine = fmt.Sprintf("%d sets", len(es.Sets))
 		if target := es.Exercise.TargetRangeText(); target != "" {
@@ -488,6 +488,76 @@ func Test_application_startExtraWorkoutOnUnscheduledToday(t *testing.T) {
 	}
 }
 
+func Test_application_startExtraWorkoutWithChosenCategory(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ctx = t.Context()
+		doc *goquery.Document
+		err error
+	)
+
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("Failed to start server: %v", err)
+	}
+	client := server.Client()
+
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("Failed to register: %v", err)
+	}
+
+	// Schedule a non-today weekday at 60 min so today is unscheduled.
+	nonToday := time.Monday
+	if time.Now().Weekday() == time.Monday {
+		nonToday = time.Tuesday
+	}
+	if doc, err = client.GetDoc(ctx, "/preferences"); err != nil {
+		t.Fatalf("Failed to get preferences: %v", err)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/preferences/schedule", map[string]string{
+		nonToday.String(): "60",
+	}); err != nil {
+		t.Fatalf("Failed to submit preferences: %v", err)
+	}
+
+	todayStr := time.Now().Format("2006-01-02")
+	form := doc.Find(`form[action="/workouts/` + todayStr + `/start"]`)
+	if got, _ := form.Find(`select[name="category"] option[selected]`).Attr("value"); got != "full_body" {
+		t.Errorf("preselected focus = %q, want full_body", got)
+	}
+	if doc, err = client.SubmitForm(ctx, doc, "/workouts/"+todayStr+"/start", map[string]string{
+		"category": "upper",
+	}); err != nil {
+		t.Fatalf("Failed to start extra workout: %v", err)
+	}
+	if doc.Find("a.exercise").Length() == 0 {
+		t.Fatal("Expected exercises on workout page after starting ad-hoc session")
+	}
+
+	rows, err := server.DB().QueryContext(ctx, `
+		SELECT DISTINCT e.category
+		FROM exercise_slots es
+		JOIN exercises e ON e.id = es.exercise_id
+		WHERE es.workout_date = ?`, todayStr)
+	if err != nil {
+		t.Fatalf("query slot categories: %v", err)
+	}
+	defer func() { _ = rows.Close() }()
+	for rows.Next() {
+		var category string
+		if err = rows.Scan(&category); err != nil {
+			t.Fatalf("scan category: %v", err)
+		}
+		if category != "upper" {
+			t.Errorf("extra workout has a %s exercise, want only upper body", category)
+		}
+	}
+	if err = rows.Err(); err != nil {
+		t.Fatalf("rows: %v", err)
+	}
+}
+
 func TestWorkoutFeedbackPOST_BadDifficultyParamReturns404(t *testing.T) {
 	t.Parallel()
 
@@ -4,7 +4,9 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"strconv"
 
 	"github.com/myrjola/petrapp/internal/platform/auth"
 )
@@ -42,6 +44,17 @@ func (app *application) beginLogin(w http.ResponseWriter, r *http.Request) {
 
 func (app *application) finishLogin(w http.ResponseWriter, r *http.Request) {
 	if err := app.webAuthnHandler.FinishLogin(r); err != nil {
+		if lockedErr, ok := errors.AsType[*auth.LockedOutError](err); ok {
+			retryAfter := int(math.Ceil(lockedErr.RetryAfter.Seconds()))
+			w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusTooManyRequests)
+			response := map[string]any{"error": "locked_out", "retryAfterSeconds": retryAfter}
+			if err = json.NewEncoder(w).Encode(response); err != nil {
+				app.logger.ErrorContext(r.Context(), "failed to encode locked out response", "error", err)
+			}
+			return
+		}
 		// Check if the error is due to an unknown credential.
 		var unknownCredErr *auth.UnknownCredentialError
 		if errors.As(err, &unknownCredErr) {
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/e2etest"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+func Test_finishLogin_LockoutAfterFailedAttempts(t *testing.T) {
+	t.Parallel()
+
+	const cooldown = 300 * time.Millisecond
+	lookupEnv := func(key string) (string, bool) {
+		switch key {
+		case "PETRAPP_LOGIN_LOCKOUT_THRESHOLD":
+			return "2", true
+		case "PETRAPP_LOGIN_LOCKOUT_COOLDOWN":
+			return cooldown.String(), true
+		default:
+			return testLookupEnv(key)
+		}
+	}
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), lookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	if _, err = client.Logout(ctx); err != nil {
+		t.Fatalf("logout: %v", err)
+	}
+
+	finishBogus := func() *http.Response {
+		t.Helper()
+		resp, postErr := postJSON(ctx, client, server.URL()+"/api/login/finish", bytes.NewReader([]byte(`{}`)))
+		if postErr != nil {
+			t.Fatalf("post login finish: %v", postErr)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+	for i := range 2 {
+		if resp := finishBogus(); resp.StatusCode == http.StatusTooManyRequests {
+			t.Fatalf("attempt %d locked out before reaching the threshold", i+1)
+		}
+	}
+	resp := finishBogus()
+	if resp.StatusCode != http.StatusTooManyRequests {
+		t.Fatalf("attempt past threshold: status = %d, want %d", resp.StatusCode, http.StatusTooManyRequests)
+	}
+	if resp.Header.Get("Retry-After") == "" {
+		t.Error("lockout response has no Retry-After header")
+	}
+	if _, err = client.Login(ctx); err == nil {
+		t.Fatal("valid login during lockout succeeded, want it refused")
+	}
+
+	time.Sleep(cooldown)
+	if _, err = client.Login(ctx); err != nil {
+		t.Fatalf("login after cooldown: %v", err)
+	}
+}
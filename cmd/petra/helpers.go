@@ -19,9 +19,19 @@ import (
 // helpers branch on it to negotiate the stack-navigator wire protocol.
 const stackNavHeaderValue = "stacknav"
 
+// maxAnalysisDays bounds the days parameter of every analysis endpoint to a
+// year of sessions, so no request can scan a user's whole history.
+const maxAnalysisDays = 365
+
 func (app *application) serverError(w http.ResponseWriter, r *http.Request, err error) {
 	app.logger.LogAttrs(r.Context(), slog.LevelError, "server error", slog.Any("error", err))
+	app.respondServerError(w, r)
+}
 
+// respondServerError writes the server-error response without logging, for
+// callers that have already logged the failure in their own shape
+// (recoverPanic).
+func (app *application) respondServerError(w http.ResponseWriter, r *http.Request) {
 	if r.Header.Get("X-Requested-With") == stackNavHeaderValue {
 		// Drive the shim's "200 + X-Location ⇒ navigate" path so the user
 		// sees the error page instead of a silent reload on the form page.
@@ -263,13 +273,33 @@ func (app *application) parseForm(w http.ResponseWriter, r *http.Request, maxByt
 
 // parsePositionParam parses the "position" path parameter from the request
 // URL. Returns the parsed position and true on success, or zero and false on
-// failure (sending HTTP 404 automatically). Negative values are rejected.
+// failure (sending HTTP 400 automatically). A non-numeric or negative position
+// is a malformed URL rather than a missing slot; callers still answer 404 for
+// a well-formed position past the end of the session.
 func (app *application) parsePositionParam(w http.ResponseWriter, r *http.Request) (int, bool) {
 	posStr := r.PathValue("position")
 	pos, err := strconv.Atoi(posStr)
 	if err != nil || pos < 0 {
-		app.notFound(w, r)
+		http.Error(w, "Invalid position parameter", http.StatusBadRequest)
 		return 0, false
 	}
 	return pos, true
 }
+
+// parseDaysParam parses the optional "days" query parameter that sets an
+// analysis endpoint's window, defaulting to defaultDays when it is absent.
+// Returns the window and true on success, or zero and false when it is not a
+// number in 1–maxAnalysisDays (sending HTTP 400 automatically). Every endpoint
+// taking a window goes through here so they all reject the same values.
+func parseDaysParam(w http.ResponseWriter, r *http.Request, defaultDays int) (int, bool) {
+	raw := r.URL.Query().Get("days")
+	if raw == "" {
+		return defaultDays, true
+	}
+	days, err := strconv.Atoi(raw)
+	if err != nil || days < 1 || days > maxAnalysisDays {
+		http.Error(w, "Invalid days parameter", http.StatusBadRequest)
+		return 0, false
+	}
+	return days, true
+}
@@ -12,7 +12,9 @@ import (
 
 	"github.com/alexedwards/scs/v2"
 	"github.com/alexedwards/scs/v2/memstore"
+	"github.com/myrjola/petrapp/internal/e2etest"
 	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
 )
 
 // newTestSessionManager builds an in-memory scs session manager for tests
@@ -355,3 +357,45 @@ func Test_recoverPanic_StackNavRequest_NavigatesToErrorPage(t *testing.T) {
 		t.Errorf("X-Location = %q, want %q", got, want)
 	}
 }
+
+// Test_parseDaysParam_AnalysisEndpointsShareBounds checks that every endpoint
+// taking an analysis window accepts and rejects the same days values.
+func Test_parseDaysParam_AnalysisEndpointsShareBounds(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), testLookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	client := server.Client()
+	if _, err = client.Register(ctx); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	wants := map[string]int{
+		"":    http.StatusOK,
+		"1":   http.StatusOK,
+		"365": http.StatusOK,
+		"0":   http.StatusBadRequest,
+		"366": http.StatusBadRequest,
+		"-7":  http.StatusBadRequest,
+		"abc": http.StatusBadRequest,
+	}
+	for _, path := range []string{"/api/adherence", "/api/muscle-balance"} {
+		for days, want := range wants {
+			target := path
+			if days != "" {
+				target += "?days=" + days
+			}
+			resp, getErr := client.Get(ctx, target)
+			if getErr != nil {
+				t.Fatalf("get %s: %v", target, getErr)
+			}
+			_ = resp.Body.Close()
+			if resp.StatusCode != want {
+				t.Errorf("GET %s status = %d, want %d", target, resp.StatusCode, want)
+			}
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"syscall"
 	"time"
@@ -60,6 +61,13 @@ type application struct {
 	// request. notification.IdleMonitor reads it to gate process exit so the
 	// Fly Machine can scale to zero between workouts.
 	lastRequestAt *atomic.Int64
+	// corsOrigins are the origins allowed to call /api/* cross-origin with
+	// credentials. Empty keeps the API same-origin only.
+	corsOrigins []string
+	// csrfProtection is the cross-origin request guard for pages and forms.
+	csrfProtection *http.CrossOriginProtection
+	// apiCSRFProtection is the guard for /api/*, trusting corsOrigins.
+	apiCSRFProtection *http.CrossOriginProtection
 }
 
 type config struct {
@@ -102,6 +110,65 @@ type config struct {
 	// Stored as a string env var because envstruct only handles strings;
 	// parsed inside run().
 	NotificationIdleTimeoutSec string `env:"PETRAPP_NOTIFICATION_IDLE_TIMEOUT_SECONDS" envDefault:"300"`
+	// CORSAllowedOrigins is a comma-separated list of origins, such as
+	// https://app.example.com, allowed to call /api/* with credentials from a
+	// separately hosted frontend. Empty keeps the API same-origin only.
+	CORSAllowedOrigins string `env:"PETRAPP_CORS_ALLOWED_ORIGINS" envDefault:""`
+	// OpenAIMonthlyTokenCap is each user's monthly OpenAI token allowance,
+	// counted from the API's usage fields; 0 disables the cap. Parsed inside
+	// run() like NotificationIdleTimeoutSec.
+	OpenAIMonthlyTokenCap string `env:"PETRAPP_OPENAI_MONTHLY_TOKEN_CAP" envDefault:"2000000"`
+	// OpenAIFallbackModel is a cheaper OpenAI model, such as gpt-5-mini, tried
+	// once when the primary model is rate-limited or out of quota. Empty
+	// disables the fallback.
+	OpenAIFallbackModel string `env:"PETRAPP_OPENAI_FALLBACK_MODEL" envDefault:""`
+	// OpenAIHealthCheck, when "true", makes the readiness probe report
+	// whether the OpenAI key works, from a background models-list call
+	// cached for five minutes. Off by default. Parsed inside run().
+	OpenAIHealthCheck string `env:"PETRAPP_OPENAI_HEALTH_CHECK" envDefault:"false"`
+	// LoginLockoutThreshold is how many consecutive failed passkey logins a
+	// session or client IP may make before it is locked out; 0 disables the
+	// lockout. Parsed inside run().
+	LoginLockoutThreshold string `env:"PETRAPP_LOGIN_LOCKOUT_THRESHOLD" envDefault:"5"`
+	// LoginLockoutCooldown is how long a login lockout lasts, as a Go duration
+	// such as 15m.
+	LoginLockoutCooldown string `env:"PETRAPP_LOGIN_LOCKOUT_COOLDOWN" envDefault:"15m"`
+	// AnalysisIncludeToday, when "true", makes analyses such as the
+	// dashboard's consistency figures count today, in-progress workout and
+	// all. Defaults to excluding it. Parsed inside run().
+	AnalysisIncludeToday string `env:"PETRAPP_ANALYSIS_INCLUDE_TODAY" envDefault:"false"`
+	// SessionCleanupInterval is how often expired login sessions are pruned
+	// from the session store, as a Go duration such as 12h. Parsed inside
+	// run(); see parseSessionCleanupInterval for the bounds.
+	SessionCleanupInterval string `env:"PETRAPP_SESSION_CLEANUP_INTERVAL" envDefault:"24h"`
+}
+
+// featureConfig switches features off per deployment. Each field is a
+// PETRAPP_FEATURE_* boolean, stored as a string because envstruct only
+// handles strings and parsed by loadFeatures. Every feature defaults to on.
+type featureConfig struct {
+	Circuits           string `env:"PETRAPP_FEATURE_CIRCUITS" envDefault:"true"`
+	MuscleGroupRecency string `env:"PETRAPP_FEATURE_MUSCLE_GROUP_RECENCY" envDefault:"true"`
+}
+
+// loadFeatures reads the PETRAPP_FEATURE_* variables into service.Features.
+func loadFeatures(lookupEnv func(string) (string, bool)) (service.Features, error) {
+	var cfg featureConfig
+	if err := envstruct.Populate(&cfg, lookupEnv); err != nil {
+		return service.Features{}, fmt.Errorf("populate feature config: %w", err)
+	}
+	circuits, err := strconv.ParseBool(cfg.Circuits)
+	if err != nil {
+		return service.Features{}, fmt.Errorf("parse PETRAPP_FEATURE_CIRCUITS: %w", err)
+	}
+	recency, err := strconv.ParseBool(cfg.MuscleGroupRecency)
+	if err != nil {
+		return service.Features{}, fmt.Errorf("parse PETRAPP_FEATURE_MUSCLE_GROUP_RECENCY: %w", err)
+	}
+	return service.Features{
+		Circuits:           circuits,
+		MuscleGroupRecency: recency,
+	}, nil
 }
 
 func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (string, bool)) error {
@@ -117,6 +184,14 @@ func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (strin
 	if err = envstruct.Populate(&cfg, lookupEnv); err != nil {
 		return fmt.Errorf("populate config: %w", err)
 	}
+	features, err := loadFeatures(lookupEnv)
+	if err != nil {
+		return err
+	}
+	opts, err := parseServiceOptions(&cfg)
+	if err != nil {
+		return err
+	}
 
 	if cfg.PProfAddr != "" {
 		pprofserver.Launch(ctx, cfg.PProfAddr, logger)
@@ -139,7 +214,14 @@ func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (strin
 	}()
 	logger.LogAttrs(ctx, slog.LevelInfo, "connected to db")
 
-	sessionManager := initializeSessionManager(db)
+	cleanupInterval, err := parseSessionCleanupInterval(&cfg)
+	if err != nil {
+		return err
+	}
+	sessionManager, stopSessionCleanup := initializeSessionManager(ctx, db, cleanupInterval)
+	// Deferred after db.Close, so it runs first: the store stops pruning
+	// before the database goes away.
+	defer stopSessionCleanup()
 
 	// Bind the listener first so we know the actual port before configuring WebAuthn.
 	// This matters when port 0 is used (e.g. in tests): the RP origin must match the URL
@@ -167,6 +249,11 @@ func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (strin
 	); err != nil {
 		return fmt.Errorf("new webauthn handler: %w", err)
 	}
+	lockout, err := parseLockoutConfig(&cfg)
+	if err != nil {
+		return err
+	}
+	webAuthnHandler.ConfigureLockout(lockout)
 
 	flightRecorderService, err := startFlightRecorder(ctx, cfg.TracesDirectory, logger)
 	if err != nil {
@@ -177,12 +264,18 @@ func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (strin
 		return err
 	}
 
-	notif, err := buildNotificationStack(ctx, &cfg, db, logger)
+	notif, err := buildNotificationStack(ctx, &cfg, features, opts, db, logger)
 	if err != nil {
 		return err
 	}
 	go notif.idleMonitor.Run(ctx)
 
+	corsOrigins := parseCORSOrigins(cfg.CORSAllowedOrigins)
+	apiCSRFProtection, err := newAPICrossOriginProtection(corsOrigins)
+	if err != nil {
+		return fmt.Errorf("configure cross-origin protection: %w", err)
+	}
+
 	app := newApplication(
 		logger,
 		webAuthnHandler,
@@ -195,6 +288,8 @@ func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (strin
 		devMode,
 		cfg.VAPIDPublic,
 		notif.lastRequestAt,
+		corsOrigins,
+		apiCSRFProtection,
 	)
 
 	routes, err := app.routes()
@@ -205,6 +300,27 @@ func run(ctx context.Context, logger *slog.Logger, lookupEnv func(string) (strin
 	return app.configureAndStartServer(ctx, listener, actualAddr, cfg.TLSCert, cfg.TLSKey, routes)
 }
 
+// parseLockoutConfig reads the failed-login lockout settings. The Fly-Client-IP
+// header is only trusted on Fly, where the proxy sets it.
+func parseLockoutConfig(cfg *config) (auth.LockoutConfig, error) {
+	threshold, err := strconv.Atoi(cfg.LoginLockoutThreshold)
+	if err != nil {
+		return auth.LockoutConfig{}, fmt.Errorf("parse PETRAPP_LOGIN_LOCKOUT_THRESHOLD: %w", err)
+	}
+	cooldown, err := time.ParseDuration(cfg.LoginLockoutCooldown)
+	if err != nil {
+		return auth.LockoutConfig{}, fmt.Errorf("parse PETRAPP_LOGIN_LOCKOUT_COOLDOWN: %w", err)
+	}
+	if threshold > 0 && cooldown <= 0 {
+		return auth.LockoutConfig{}, fmt.Errorf("PETRAPP_LOGIN_LOCKOUT_COOLDOWN must be positive: got %s", cooldown)
+	}
+	return auth.LockoutConfig{
+		Threshold:        threshold,
+		Cooldown:         cooldown,
+		TrustFlyClientIP: cfg.FlyAppName != "",
+	}, nil
+}
+
 // startFlightRecorder builds and starts the flight recorder when a traces
 // directory is configured, returning nil (and no error) when tracing is off.
 func startFlightRecorder(
@@ -262,29 +378,35 @@ func newApplication(
 	devMode bool,
 	vapidPublicKey string,
 	lastRequestAt *atomic.Int64,
+	corsOrigins []string,
+	apiCSRFProtection *http.CrossOriginProtection,
 ) *application {
 	app := &application{
-		logger:          logger,
-		webAuthnHandler: webAuthnHandler,
-		sessionManager:  sessionManager,
-		templateFS:      templateFS,
-		staticFS:        staticFS,
-		assets:          assets,
-		parsedTemplates: newTemplateCache(),
-		service:         svc,
-		flightRecorder:  flightRecorderService,
-		devMode:         devMode,
-		vapidPublicKey:  vapidPublicKey,
-		lastRequestAt:   lastRequestAt,
+		logger:            logger,
+		webAuthnHandler:   webAuthnHandler,
+		sessionManager:    sessionManager,
+		templateFS:        templateFS,
+		staticFS:          staticFS,
+		assets:            assets,
+		parsedTemplates:   newTemplateCache(),
+		service:           svc,
+		flightRecorder:    flightRecorderService,
+		devMode:           devMode,
+		vapidPublicKey:    vapidPublicKey,
+		lastRequestAt:     lastRequestAt,
+		corsOrigins:       corsOrigins,
+		csrfProtection:    http.NewCrossOriginProtection(),
+		apiCSRFProtection: apiCSRFProtection,
 	}
 	webAuthnHandler.InternalErrorHandler = app.serverError
 	return app
 }
 
 const (
-	// sessionStoreCleanupInterval bounds how often expired sessions are
-	// pruned from sqlite3store. Daily is plenty given Lifetime below.
-	sessionStoreCleanupInterval = 24 * time.Hour
+	// minSessionCleanupInterval is the shortest PETRAPP_SESSION_CLEANUP_INTERVAL
+	// accepted. Sessions live for days (see sessionLifetime), so pruning more
+	// often than this only adds write load.
+	minSessionCleanupInterval = time.Minute
 
 	// sessionLifetime keeps users logged in across mid-workout sessions
 	// so a 7am passkey login doesn't expire before the evening's lift.
@@ -328,11 +450,13 @@ type notificationStack struct {
 }
 
 // buildNotificationStack wires Sender + Scheduler + IdleMonitor and returns the
-// Scheduler-aware Service plus the lastRequestAt atomic the stamping middleware
-// updates.
+// Scheduler-aware Service, with features switched and opts applied, plus the
+// lastRequestAt atomic the stamping middleware updates.
 func buildNotificationStack(
 	ctx context.Context,
 	cfg *config,
+	features service.Features,
+	opts serviceOptions,
 	db *sqlitekit.Database,
 	logger *slog.Logger,
 ) (*notificationStack, error) {
@@ -354,7 +478,12 @@ func buildNotificationStack(
 	}
 	sender := notification.NewSender(senderCfg)
 
-	baseService := service.NewService(db, logger, cfg.OpenAIAPIKey)
+	baseService := service.NewService(db, logger, cfg.OpenAIAPIKey).
+		WithOpenAIMonthlyTokenCap(opts.openAIMonthlyTokenCap).
+		WithOpenAIFallbackModel(cfg.OpenAIFallbackModel).
+		WithAnalysisIncludeToday(opts.analysisIncludeToday).
+		WithOpenAIHealthCheck(opts.openAIHealthCheck).
+		WithFeatures(features)
 
 	scheduler := notification.NewScheduler(notification.SchedulerConfig{
 		Repo:     baseService.Repos().ScheduledPushes,
@@ -403,18 +532,81 @@ func buildNotificationStack(
 	}, nil
 }
 
-func initializeSessionManager(dbs *sqlitekit.Database) *scs.SessionManager {
+// serviceOptions holds the typed service settings parsed from config.
+type serviceOptions struct {
+	openAIMonthlyTokenCap int64
+	analysisIncludeToday  bool
+	openAIHealthCheck     bool
+}
+
+// parseServiceOptions reads the PETRAPP_OPENAI_MONTHLY_TOKEN_CAP,
+// PETRAPP_ANALYSIS_INCLUDE_TODAY and PETRAPP_OPENAI_HEALTH_CHECK settings.
+func parseServiceOptions(cfg *config) (serviceOptions, error) {
+	tokenCap, err := strconv.ParseInt(cfg.OpenAIMonthlyTokenCap, 10, 64)
+	if err != nil {
+		return serviceOptions{}, fmt.Errorf("parse PETRAPP_OPENAI_MONTHLY_TOKEN_CAP: %w", err)
+	}
+	includeToday, err := strconv.ParseBool(cfg.AnalysisIncludeToday)
+	if err != nil {
+		return serviceOptions{}, fmt.Errorf("parse PETRAPP_ANALYSIS_INCLUDE_TODAY: %w", err)
+	}
+	healthCheck, err := strconv.ParseBool(cfg.OpenAIHealthCheck)
+	if err != nil {
+		return serviceOptions{}, fmt.Errorf("parse PETRAPP_OPENAI_HEALTH_CHECK: %w", err)
+	}
+	return serviceOptions{
+		openAIMonthlyTokenCap: tokenCap,
+		analysisIncludeToday:  includeToday,
+		openAIHealthCheck:     healthCheck,
+	}, nil
+}
+
+// parseSessionCleanupInterval reads PETRAPP_SESSION_CLEANUP_INTERVAL, which
+// must be at least minSessionCleanupInterval.
+func parseSessionCleanupInterval(cfg *config) (time.Duration, error) {
+	interval, err := time.ParseDuration(cfg.SessionCleanupInterval)
+	if err != nil {
+		return 0, fmt.Errorf("parse PETRAPP_SESSION_CLEANUP_INTERVAL: %w", err)
+	}
+	if interval < minSessionCleanupInterval {
+		return 0, fmt.Errorf("PETRAPP_SESSION_CLEANUP_INTERVAL must be at least %s: got %s",
+			minSessionCleanupInterval, interval)
+	}
+	return interval, nil
+}
+
+// newSessionStore returns the SQLite session store, pruning expired sessions
+// every cleanupInterval until ctx is done or the returned stop is called,
+// whichever comes first. stop waits for the cleanup goroutine to exit, so
+// calling it before closing the database keeps the goroutine off a closed
+// handle.
+func newSessionStore(
+	ctx context.Context, dbs *sqlitekit.Database, cleanupInterval time.Duration,
+) (*sqlite3store.SQLite3Store, func()) {
+	store := sqlite3store.NewWithCleanupInterval(dbs.ReadWrite, cleanupInterval)
+	// StopCleanup blocks once the goroutine has exited, so it must run once.
+	stop := sync.OnceFunc(store.StopCleanup)
+	context.AfterFunc(ctx, stop)
+	return store, stop
+}
+
+// initializeSessionManager builds the scs session manager over the SQLite
+// store. The returned func stops the store's cleanup; see newSessionStore.
+func initializeSessionManager(
+	ctx context.Context, dbs *sqlitekit.Database, cleanupInterval time.Duration,
+) (*scs.SessionManager, func()) {
 	// gob.Register is idempotent, so calling it per initializeSessionManager is safe.
 	gob.Register(flashEntry{})       //nolint:exhaustruct // gob.Register only needs the type, value fields are unused.
 	gob.Register(formErrorPayload{}) //nolint:exhaustruct // gob.Register only needs the type, value fields are unused.
 	sessionManager := scs.New()
-	sessionManager.Store = sqlite3store.NewWithCleanupInterval(dbs.ReadWrite, sessionStoreCleanupInterval)
+	store, stopCleanup := newSessionStore(ctx, dbs, cleanupInterval)
+	sessionManager.Store = store
 	sessionManager.Lifetime = sessionLifetime
 	sessionManager.Cookie.Persist = true
 	sessionManager.Cookie.Secure = true
 	sessionManager.Cookie.HttpOnly = true
 	sessionManager.Cookie.SameSite = http.SameSiteLaxMode
-	return sessionManager
+	return sessionManager, stopCleanup
 }
 
 func main() {
@@ -11,8 +11,12 @@ import (
 	"testing"
 	"time"
 
+	"github.com/myrjola/petrapp/internal/petra/service"
+	"github.com/myrjola/petrapp/internal/platform/auth"
 	"github.com/myrjola/petrapp/internal/platform/obs/errorrecorder"
 	"github.com/myrjola/petrapp/internal/platform/obs/logging"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
 )
 
 // TestRecorderProducesDumpFileOnError verifies the end-to-end wiring:
@@ -82,3 +86,149 @@ func TestRecorderProducesDumpFileOnError(t *testing.T) {
 		t.Errorf("dump missing session_hash; content=%q", content)
 	}
 }
+
+func Test_parseSessionCleanupInterval(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		raw     string
+		want    time.Duration
+		wantErr bool
+	}{
+		{raw: "24h", want: 24 * time.Hour},
+		{raw: "90m", want: 90 * time.Minute},
+		{raw: "1m", want: time.Minute},
+		{raw: "59s", wantErr: true},
+		{raw: "0", wantErr: true},
+		{raw: "-1h", wantErr: true},
+		{raw: "daily", wantErr: true},
+	}
+	for _, tt := range tests {
+		cfg := config{SessionCleanupInterval: tt.raw} //nolint:exhaustruct // Only the interval is read.
+		got, err := parseSessionCleanupInterval(&cfg)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("parseSessionCleanupInterval(%q) = %s, %v; want %s, error %t", tt.raw, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func Test_parseServiceOptions(t *testing.T) {
+	t.Parallel()
+
+	valid := config{ //nolint:exhaustruct // Only the service settings are read.
+		OpenAIMonthlyTokenCap: "2000000",
+		AnalysisIncludeToday:  "true",
+		OpenAIHealthCheck:     "false",
+	}
+	got, err := parseServiceOptions(&valid)
+	want := serviceOptions{openAIMonthlyTokenCap: 2000000, analysisIncludeToday: true, openAIHealthCheck: false}
+	if err != nil || got != want {
+		t.Errorf("parseServiceOptions = %+v, %v; want %+v", got, err, want)
+	}
+
+	for i, broken := range []func(*config){
+		func(c *config) { c.OpenAIMonthlyTokenCap = "lots" },
+		func(c *config) { c.AnalysisIncludeToday = "sometimes" },
+		func(c *config) { c.OpenAIHealthCheck = "maybe" },
+	} {
+		cfg := valid
+		broken(&cfg)
+		if _, err = parseServiceOptions(&cfg); err == nil {
+			t.Errorf("parseServiceOptions with broken setting %d = nil error, want a parse error", i)
+		}
+	}
+}
+
+func Test_loadFeatures(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    service.Features
+		wantErr bool
+	}{
+		{name: "defaults to all on", env: nil, want: service.AllFeatures()},
+		{
+			name: "switches off",
+			env:  map[string]string{"PETRAPP_FEATURE_CIRCUITS": "false", "PETRAPP_FEATURE_MUSCLE_GROUP_RECENCY": "0"},
+			want: service.Features{Circuits: false, MuscleGroupRecency: false},
+		},
+		{name: "rejects non-booleans", env: map[string]string{"PETRAPP_FEATURE_CIRCUITS": "maybe"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := loadFeatures(func(key string) (string, bool) {
+				v, ok := tt.env[key]
+				return v, ok
+			})
+			if (err != nil) != tt.wantErr || got != tt.want {
+				t.Errorf("loadFeatures = %+v, %v; want %+v, error %t", got, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+// Test_newSessionStore_CleanupFollowsIntervalAndContext checks that the
+// store prunes expired sessions on the configured interval, and that
+// cancelling its context stops the pruning.
+func Test_newSessionStore_CleanupFollowsIntervalAndContext(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	db, err := sqlitekit.NewDatabase(ctx, sqlitekit.Config{
+		URL:          ":memory:",
+		Schema:       auth.SchemaSQL,
+		Fixtures:     "",
+		Logger:       testkit.NewLogger(testkit.NewWriter(t)),
+		Premigration: nil,
+	})
+	if err != nil {
+		t.Fatalf("create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	insertExpired := func(token string) {
+		t.Helper()
+		if _, insertErr := db.ReadWrite.ExecContext(ctx,
+			"INSERT INTO sessions (token, data, expiry) VALUES (?, x'00', julianday('now') - 1)", token,
+		); insertErr != nil {
+			t.Fatalf("insert expired session: %v", insertErr)
+		}
+	}
+	exists := func(token string) bool {
+		t.Helper()
+		var n int
+		if queryErr := db.ReadWrite.QueryRowContext(ctx,
+			"SELECT COUNT(*) FROM sessions WHERE token = ?", token,
+		).Scan(&n); queryErr != nil {
+			t.Fatalf("count sessions: %v", queryErr)
+		}
+		return n > 0
+	}
+
+	const interval = 10 * time.Millisecond
+	storeCtx, cancel := context.WithCancel(ctx)
+	_, stop := newSessionStore(storeCtx, db, interval)
+	t.Cleanup(stop)
+
+	insertExpired("before-cancel")
+	deadline := time.Now().Add(5 * time.Second)
+	for exists("before-cancel") {
+		if time.Now().After(deadline) {
+			t.Fatal("expired session not pruned within 5s at a 10ms interval")
+		}
+		time.Sleep(interval)
+	}
+
+	cancel()
+	// Stopping is idempotent: this returns once the cleanup goroutine has
+	// exited, whether or not the context's AfterFunc got there first.
+	stop()
+	insertExpired("after-cancel")
+	time.Sleep(20 * interval)
+	if !exists("after-cancel") {
+		t.Error("expired session pruned after the store's context was cancelled")
+	}
+}
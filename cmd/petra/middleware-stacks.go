@@ -7,12 +7,16 @@ import "net/http"
 // step (auth, CSRF, maintenance mode, panic recovery, etc.).
 
 // withoutMaintenanceModeStack is the base of every other stack: tracing,
-// security headers, CSRF, common context, and the request timeout. Wraps
+// security headers, CORS for /api/*, CSRF, common context, and the request timeout. Wraps
 // stampLastRequest at the outside so the idle monitor sees every request,
 // including ones that 404 inside the file server or short-circuit on CSRF.
+// Panic recovery sits inside the timeout: http.TimeoutHandler re-panics a
+// handler's panic on its own goroutine, losing the original stack, and
+// recovering there keeps the trace_id and the 500 in the access log. The outer
+// recoverPanic on each stack still catches panics in session middleware.
 func (app *application) withoutMaintenanceModeStack(next http.Handler) http.Handler {
-	return app.stampLastRequest(app.logAndTraceRequest(secureHeaders(app.crossOriginProtection(
-		commonContext(app.timeout(next))))))
+	return app.stampLastRequest(app.logAndTraceRequest(secureHeaders(app.cors(app.crossOriginProtection(
+		commonContext(app.timeout(app.recoverPanic(next))))))))
 }
 
 // sharedStack adds maintenance mode and the bfcache-busting cookie on top of
@@ -3,11 +3,13 @@ package main
 import (
 	"context"
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"log/slog"
 	"net/http"
 	"runtime/debug"
 	"runtime/trace"
+	"slices"
 	"strings"
 	"time"
 
@@ -155,14 +157,22 @@ func (app *application) logAndTraceRequest(next http.Handler) http.Handler {
 			next.ServeHTTP(sw, r)
 		}
 
-		// Log request completion.
+		// Log request completion. Method, URI, and trace_id (the correlation
+		// id) ride along from the context attrs set above. Readiness polls
+		// arrive every few seconds and would drown the info log, so a
+		// healthy probe logs at debug; a failing one still logs as an error.
 		duration := time.Since(start)
 		level := slog.LevelInfo
-		if sw.statusCode >= http.StatusInternalServerError {
+		switch {
+		case sw.statusCode >= http.StatusInternalServerError:
 			level = slog.LevelError
+		case path == healthCheckPath:
+			level = slog.LevelDebug
 		}
 		app.logger.LogAttrs(r.Context(), level, "request completed",
-			slog.Int("status_code", sw.statusCode), slog.Duration("duration", duration))
+			slog.String("path", path),
+			slog.Int("status_code", sw.statusCode),
+			slog.Duration("duration", duration))
 
 		// Capture a flight recorder dump for timed-out or user-noticeably-slow
 		// requests. Admin routes are exempt because their 30s timeout budget
@@ -179,13 +189,31 @@ func (app *application) logAndTraceRequest(next http.Handler) http.Handler {
 	})
 }
 
+// recoverPanic turns a handler panic into a logged error and a clean 500 (or
+// the shim's /error navigation) instead of net/http's bare stack dump and a
+// dropped connection. It logs the panic value and stack with the request's
+// context attrs, so when it runs inside logAndTraceRequest the line carries
+// the trace_id, and the completion log records the 500. A flight-recorder
+// snapshot is taken when the recorder is enabled. http.ErrAbortHandler is
+// re-panicked: it is net/http's sentinel for deliberately aborting a response.
 func (app *application) recoverPanic(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
-			if excp := recover(); excp != nil {
-				err := fmt.Errorf("panic: %v\n%s", excp, string(debug.Stack()))
-				app.serverError(w, r, err)
+			excp := recover()
+			if excp == nil {
+				return
+			}
+			if err, ok := excp.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+				panic(excp)
 			}
+			ctx := r.Context()
+			app.logger.LogAttrs(ctx, slog.LevelError, "recovered panic",
+				slog.Any("panic", excp),
+				slog.String("stack", string(debug.Stack())))
+			if app.flightRecorder != nil {
+				go app.flightRecorder.CapturePanicTrace(context.WithoutCancel(ctx))
+			}
+			app.respondServerError(w, r)
 		}()
 
 		next.ServeHTTP(w, r)
@@ -233,9 +261,91 @@ func commonContext(next http.Handler) http.Handler {
 }
 
 // crossOriginProtection implements CSRF protection using Go 1.25's CrossOriginProtection.
+// Requests to /api/* go through apiCSRFProtection, which trusts the CORS
+// origins (see newAPICrossOriginProtection); every other route goes through
+// csrfProtection, which trusts none, so a CORS origin can't post HTML forms.
 func (app *application) crossOriginProtection(next http.Handler) http.Handler {
+	pages := app.csrfProtection.Handler(next)
+	api := app.apiCSRFProtection.Handler(next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/api/") {
+			api.ServeHTTP(w, r)
+			return
+		}
+		pages.ServeHTTP(w, r)
+	})
+}
+
+// newAPICrossOriginProtection builds the CSRF guard for /api/*. A
+// CORS-allowed origin is there to make credentialed, state-changing API calls,
+// which CrossOriginProtection would otherwise reject as cross-origin, so each
+// one is added as a trusted origin. An origin that isn't a bare
+// scheme://host[:port] is a configuration error.
+func newAPICrossOriginProtection(corsOrigins []string) (*http.CrossOriginProtection, error) {
 	protection := http.NewCrossOriginProtection()
-	return protection.Handler(next)
+	for _, origin := range corsOrigins {
+		if err := protection.AddTrustedOrigin(origin); err != nil {
+			return nil, fmt.Errorf("trust CORS origin %q: %w", origin, err)
+		}
+	}
+	return protection, nil
+}
+
+// parseCORSOrigins splits the comma-separated PETRAPP_CORS_ALLOWED_ORIGINS
+// value, dropping blanks and trailing slashes. Empty means same-origin only.
+func parseCORSOrigins(raw string) []string {
+	var origins []string
+	for origin := range strings.SplitSeq(raw, ",") {
+		if origin = strings.TrimSuffix(strings.TrimSpace(origin), "/"); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+	return origins
+}
+
+const (
+	corsAllowMethods = "GET, POST, PUT, DELETE"
+	corsAllowHeaders = "Content-Type, If-None-Match"
+	// corsMaxAge is how long, in seconds, a browser may reuse a preflight verdict.
+	corsMaxAge = "600"
+)
+
+// cors lets a frontend hosted on one of the configured origins call /api/*
+// with credentials. Other paths, and requests from any other origin, get no
+// CORS headers, so browsers keep them same-origin. Vary: Origin is always set
+// on /api/* so a shared cache never serves one origin's answer to another.
+func (app *application) cors(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/api/") {
+			next.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Add("Vary", "Origin")
+		if origin := r.Header.Get("Origin"); app.corsAllowed(origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// corsAllowed reports whether origin is one of the configured CORS origins.
+func (app *application) corsAllowed(origin string) bool {
+	return origin != "" && slices.Contains(app.corsOrigins, origin)
+}
+
+// corsPreflight answers OPTIONS /api/* preflights. The cors middleware has
+// already set the origin headers for an allowed origin; anything else is
+// refused with 403 and no CORS headers, which the browser treats as a denial.
+func (app *application) corsPreflight(w http.ResponseWriter, r *http.Request) {
+	if !app.corsAllowed(r.Header.Get("Origin")) || r.Header.Get("Access-Control-Request-Method") == "" {
+		http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+		return
+	}
+	w.Header().Set("Access-Control-Allow-Methods", corsAllowMethods)
+	w.Header().Set("Access-Control-Allow-Headers", corsAllowHeaders)
+	w.Header().Set("Access-Control-Max-Age", corsMaxAge)
+	w.WriteHeader(http.StatusNoContent)
 }
 
 // Per-request timeout budget. The handler timeout is shorter than the write
@@ -275,7 +385,7 @@ func (app *application) maintenanceMode(next http.Handler) http.Handler {
 
 		// Exclude health endpoints, admin authentication paths, and static files from maintenance checks.
 		path := r.URL.Path
-		if path == "/api/healthy" ||
+		if path == healthCheckPath ||
 			path == "/admin/feature-flags" ||
 			path == "/api/login/start" ||
 			path == "/api/login/finish" {
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"bytes"
+	"encoding/json"
 	"fmt"
 	"log/slog"
 	"net/http"
@@ -10,7 +12,10 @@ import (
 	"testing/synctest"
 	"time"
 
+	"github.com/myrjola/petrapp/internal/e2etest"
 	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/obs/logging"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
 )
 
 type timeoutResponseWriter struct {
@@ -185,3 +190,234 @@ func Test_mustAdmin_AuthenticatedAdmin_CallsNext(t *testing.T) {
 		t.Error("inner handler MUST be called for an admin user")
 	}
 }
+
+func Test_application_logAndTraceRequest_logsCompletion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		path      string
+		status    int
+		wantLevel string // Empty means no line at info level.
+	}{
+		{name: "regular request logs at info", path: "/workouts", status: http.StatusTeapot, wantLevel: "INFO"},
+		{name: "server error logs at error", path: "/workouts", status: http.StatusBadGateway, wantLevel: "ERROR"},
+		{name: "healthy probe drops to debug", path: healthCheckPath, status: http.StatusOK, wantLevel: ""},
+		{name: "failing probe still logs", path: healthCheckPath, status: http.StatusServiceUnavailable, wantLevel: "ERROR"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var buf bytes.Buffer
+			opts := &slog.HandlerOptions{Level: slog.LevelInfo} //nolint:exhaustruct // AddSource/ReplaceAttr default.
+			logger := slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, opts)))
+			app := &application{logger: logger} //nolint:exhaustruct // only logger needed.
+			handler := app.logAndTraceRequest(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(tt.status)
+			}))
+
+			r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, tt.path, nil)
+			handler.ServeHTTP(httptest.NewRecorder(), r)
+
+			var line map[string]any
+			for raw := range strings.Lines(buf.String()) {
+				var entry map[string]any
+				if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+					t.Fatalf("unmarshal log line %q: %v", raw, err)
+				}
+				if entry["msg"] == "request completed" {
+					line = entry
+				}
+			}
+			if tt.wantLevel == "" {
+				if line != nil {
+					t.Errorf("got completion line %v, want none at info", line)
+				}
+				return
+			}
+			if line == nil {
+				t.Fatalf("no completion line in %q", buf.String())
+			}
+			if line["level"] != tt.wantLevel {
+				t.Errorf("level = %v, want %s", line["level"], tt.wantLevel)
+			}
+			if got, ok := line["status_code"].(float64); !ok || int(got) != tt.status {
+				t.Errorf("status_code = %v, want %d", line["status_code"], tt.status)
+			}
+			if got, ok := line["duration"].(float64); !ok || got < 0 {
+				t.Errorf("duration = %v, want a non-negative number", line["duration"])
+			}
+			if line["method"] != http.MethodGet || line["path"] != tt.path {
+				t.Errorf("method, path = %v, %v; want GET, %s", line["method"], line["path"], tt.path)
+			}
+			if id, _ := line["trace_id"].(string); id == "" {
+				t.Errorf("trace_id missing from %v", line)
+			}
+		})
+	}
+}
+
+func Test_application_recoverPanic_logsAndReturns500(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	app := newTestApplicationForTemplateRender(t)
+	app.logger = slog.New(logging.NewContextHandler(slog.NewJSONHandler(&buf, nil)))
+	panicking := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic("simulated handler panic")
+	})
+	// Mirrors withoutMaintenanceModeStack: recovery inside the access log.
+	handler := app.logAndTraceRequest(app.recoverPanic(panicking))
+
+	w := httptest.NewRecorder()
+	r := httptest.NewRequestWithContext(t.Context(), http.MethodGet, "/workouts", nil)
+	handler.ServeHTTP(w, r)
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status = %d, want %d", w.Code, http.StatusInternalServerError)
+	}
+	var recovered, completed map[string]any
+	for raw := range strings.Lines(buf.String()) {
+		var entry map[string]any
+		if err := json.Unmarshal([]byte(raw), &entry); err != nil {
+			t.Fatalf("unmarshal log line %q: %v", raw, err)
+		}
+		switch entry["msg"] {
+		case "recovered panic":
+			recovered = entry
+		case "request completed":
+			completed = entry
+		}
+	}
+	if recovered == nil {
+		t.Fatalf("no recovered-panic line in %q", buf.String())
+	}
+	if recovered["panic"] != "simulated handler panic" {
+		t.Errorf("panic = %v, want the panic value", recovered["panic"])
+	}
+	if stack, _ := recovered["stack"].(string); !strings.Contains(stack, "Test_application_recoverPanic_logsAndReturns500") {
+		t.Errorf("stack does not reach the panicking handler:\n%s", stack)
+	}
+	if id, _ := recovered["trace_id"].(string); id == "" || completed == nil || completed["trace_id"] != id {
+		t.Errorf("trace_id %q missing or not shared with the completion line %v", id, completed)
+	}
+	if got, _ := completed["status_code"].(float64); int(got) != http.StatusInternalServerError {
+		t.Errorf("completion status_code = %v, want 500", completed["status_code"])
+	}
+}
+
+func Test_application_recoverPanic_repanicsErrAbortHandler(t *testing.T) {
+	t.Parallel()
+
+	app := &application{logger: slog.New(slog.DiscardHandler)} //nolint:exhaustruct // only logger needed.
+	aborting := http.HandlerFunc(func(_ http.ResponseWriter, _ *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	defer func() {
+		if got := recover(); got != http.ErrAbortHandler { //nolint:errorlint // recover returns the value as panicked.
+			t.Errorf("recovered %v, want http.ErrAbortHandler to propagate", got)
+		}
+	}()
+	app.recoverPanic(aborting).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func Test_cors(t *testing.T) {
+	t.Parallel()
+
+	const (
+		allowed    = "https://app.example.com"
+		disallowed = "https://evil.example.com"
+	)
+	lookupEnv := func(key string) (string, bool) {
+		if key == "PETRAPP_CORS_ALLOWED_ORIGINS" {
+			return allowed + ", https://other.example.com", true
+		}
+		return testLookupEnv(key)
+	}
+	ctx := t.Context()
+	server, err := e2etest.StartServer(t, testkit.NewWriter(t), lookupEnv, run)
+	if err != nil {
+		t.Fatalf("start server: %v", err)
+	}
+	send := func(method, path, origin string) *http.Response {
+		t.Helper()
+		req, reqErr := http.NewRequestWithContext(ctx, method, server.URL()+path, strings.NewReader("{}"))
+		if reqErr != nil {
+			t.Fatalf("new request: %v", reqErr)
+		}
+		req.Header.Set("Origin", origin)
+		req.Header.Set("Sec-Fetch-Site", "cross-site")
+		if method == http.MethodOptions {
+			req.Header.Set("Access-Control-Request-Method", http.MethodPost)
+			req.Header.Set("Access-Control-Request-Headers", "content-type")
+		}
+		resp, doErr := http.DefaultClient.Do(req)
+		if doErr != nil {
+			t.Fatalf("%s %s: %v", method, path, doErr)
+		}
+		_ = resp.Body.Close()
+		return resp
+	}
+
+	t.Run("preflight from an allowed origin", func(t *testing.T) {
+		t.Parallel()
+		resp := send(http.MethodOptions, "/api/week", allowed)
+		if resp.StatusCode != http.StatusNoContent {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusNoContent)
+		}
+		if got := resp.Header.Get("Access-Control-Allow-Origin"); got != allowed {
+			t.Errorf("Access-Control-Allow-Origin = %q, want %q", got, allowed)
+		}
+		if got := resp.Header.Get("Access-Control-Allow-Credentials"); got != "true" {
+			t.Errorf("Access-Control-Allow-Credentials = %q, want true", got)
+		}
+		if got := resp.Header.Get("Access-Control-Allow-Methods"); !strings.Contains(got, http.MethodPost) {
+			t.Errorf("Access-Control-Allow-Methods = %q, want it to include POST", got)
+		}
+		if got := resp.Header.Get("Access-Control-Allow-Headers"); !strings.Contains(got, "Content-Type") {
+			t.Errorf("Access-Control-Allow-Headers = %q, want it to include Content-Type", got)
+		}
+	})
+
+	t.Run("preflight from a disallowed origin", func(t *testing.T) {
+		t.Parallel()
+		resp := send(http.MethodOptions, "/api/week", disallowed)
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+		}
+		for _, h := range []string{"Access-Control-Allow-Origin", "Access-Control-Allow-Credentials",
+			"Access-Control-Allow-Methods"} {
+			if got := resp.Header.Get(h); got != "" {
+				t.Errorf("%s = %q, want unset", h, got)
+			}
+		}
+	})
+
+	t.Run("CSRF trusts allowed origins only", func(t *testing.T) {
+		t.Parallel()
+		if resp := send(http.MethodPost, "/api/reports", allowed); resp.StatusCode == http.StatusForbidden {
+			t.Errorf("POST from allowed origin: status = %d, want it past CSRF", resp.StatusCode)
+		} else if got := resp.Header.Get("Access-Control-Allow-Origin"); got != allowed {
+			t.Errorf("POST from allowed origin: Access-Control-Allow-Origin = %q, want %q", got, allowed)
+		}
+		if resp := send(http.MethodPost, "/api/reports", disallowed); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("POST from disallowed origin: status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+		}
+	})
+
+	t.Run("CSRF trusts allowed origins for API routes only", func(t *testing.T) {
+		t.Parallel()
+		if resp := send(http.MethodPost, "/preferences/schedule", allowed); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("form POST from allowed origin: status = %d, want %d", resp.StatusCode, http.StatusForbidden)
+		}
+	})
+
+	t.Run("non-API paths get no CORS headers", func(t *testing.T) {
+		t.Parallel()
+		if got := send(http.MethodGet, "/", allowed).Header.Get("Access-Control-Allow-Origin"); got != "" {
+			t.Errorf("Access-Control-Allow-Origin on / = %q, want unset", got)
+		}
+	})
+}
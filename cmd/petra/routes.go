@@ -19,6 +19,8 @@ func (app *application) routes() (*http.ServeMux, error) {
 		app.mustSessionStack(http.HandlerFunc(app.exerciseSetUpdatePOST)))
 	mux.Handle("POST /workouts/{date}/exercises/{position}/warmup/complete",
 		app.mustSessionStack(http.HandlerFunc(app.exerciseSetWarmupCompletePOST)))
+	mux.Handle("POST /workouts/{date}/exercises/{position}/rest",
+		app.mustSessionStack(http.HandlerFunc(app.exerciseRestPOST)))
 	mux.Handle("GET /workouts/{date}/exercises/{position}/info",
 		app.mustSessionStack(http.HandlerFunc(app.exerciseInfoGET)))
 	mux.Handle("GET /workouts/{date}/exercises/{position}/swap",
@@ -31,6 +33,10 @@ func (app *application) routes() (*http.ServeMux, error) {
 		app.mustSessionStack(http.HandlerFunc(app.workoutAddExercisePOST)))
 	mux.Handle("POST /workouts/{date}/feedback/{difficulty}",
 		app.mustSessionStack(http.HandlerFunc(app.workoutFeedbackPOST)))
+	mux.Handle("POST /workouts/{date}/circuit",
+		app.mustSessionStack(http.HandlerFunc(app.workoutCircuitPOST)))
+	mux.Handle("POST /workouts/{date}/circuit/complete",
+		app.mustSessionStack(http.HandlerFunc(app.workoutCircuitCompletePOST)))
 
 	mux.Handle("GET /schedule", app.mustSessionStack(http.HandlerFunc(app.scheduleGET)))
 	mux.Handle("POST /schedule", app.mustSessionStack(http.HandlerFunc(app.schedulePOST)))
@@ -49,6 +55,9 @@ func (app *application) routes() (*http.ServeMux, error) {
 	mux.Handle("POST /preferences/mesocycle/start-deload-now",
 		app.mustSessionStack(http.HandlerFunc(app.preferencesStartDeloadNowPOST)))
 
+	mux.Handle("GET /account/profile", app.mustSessionStack(http.HandlerFunc(app.accountProfileGET)))
+	mux.Handle("PUT /account/profile", app.mustSessionStack(http.HandlerFunc(app.accountProfilePUT)))
+
 	app.registerAPIRoutes(mux)
 
 	mux.Handle("GET /admin/exercises", app.mustAdminStack(http.HandlerFunc(app.adminExercisesGET)))
@@ -112,7 +121,25 @@ func (app *application) registerAPIRoutes(mux *http.ServeMux) {
 	mux.Handle("POST /api/login/finish", app.noStoreSessionStack(http.HandlerFunc(app.finishLogin)))
 	mux.Handle("POST /api/logout", app.noStoreSessionStack(http.HandlerFunc(app.logout)))
 
-	mux.Handle("GET /api/healthy", app.sessionStack(http.HandlerFunc(app.healthy)))
+	mux.Handle("GET "+healthCheckPath, app.sessionStack(http.HandlerFunc(app.healthy)))
+	mux.Handle("GET /api/metadata", app.sessionStack(http.HandlerFunc(app.metadataGET)))
+	mux.Handle("GET /api/week", app.mustSessionStack(http.HandlerFunc(app.weekPreviewGET)))
+	mux.Handle("GET /api/exercises/{id}/progress",
+		app.mustSessionStack(http.HandlerFunc(app.exerciseProgressGET)))
+	mux.Handle("GET /api/prs", app.mustSessionStack(http.HandlerFunc(app.personalRecordsGET)))
+	mux.Handle("GET /api/dashboard", app.mustSessionStack(http.HandlerFunc(app.dashboardGET)))
+	mux.Handle("GET /api/activity", app.mustSessionStack(http.HandlerFunc(app.activityGET)))
+	mux.Handle("GET /api/muscle-balance", app.mustSessionStack(http.HandlerFunc(app.muscleBalanceGET)))
+	mux.Handle("GET /api/adherence", app.mustSessionStack(http.HandlerFunc(app.adherenceGET)))
+	mux.Handle("GET /api/admin/reminders", app.mustAdminStack(http.HandlerFunc(app.remindersGET)))
+	mux.Handle("GET /api/metrics", app.mustAdminStack(http.HandlerFunc(app.metricsGET)))
+	mux.Handle("GET /api/workout-suggestion", app.mustSessionStack(http.HandlerFunc(app.workoutSuggestionGET)))
+	mux.Handle("GET /api/workouts/{date}/calories", app.mustSessionStack(http.HandlerFunc(app.caloriesGET)))
+	mux.Handle("GET /api/workouts/{date}/category", app.mustSessionStack(http.HandlerFunc(app.workoutCategoryGET)))
+	mux.Handle("POST /api/workouts/{date}/sets:batch", app.mustSessionStack(http.HandlerFunc(app.setBatchPOST)))
+	// CORS preflights for every API route; the cors middleware in the base
+	// stack decorates the actual responses.
+	mux.Handle("OPTIONS /api/", app.noAuthStack(http.HandlerFunc(app.corsPreflight)))
 	mux.Handle("POST /api/reports", app.noAuthStack(http.HandlerFunc(app.reportingAPI)))
 	mux.Handle("POST /api/vitals", app.noAuthStack(http.HandlerFunc(app.vitalsPOST)))
 	mux.Handle("GET /api/test/timeout", app.noAuthStack(http.HandlerFunc(app.testTimeout)))
@@ -0,0 +1,233 @@
+// Command seeddemo creates a demo account with about six months of plausible
+// training history, generated through the workout service (the same planner,
+// progression, and recording paths the web app uses) rather than over HTTP.
+//
+// Usage:
+//
+//	PETRAPP_SQLITE_URL=./petrapp.sqlite3 seeddemo [--weeks 26] [--seed 1] [--user-id N]
+//
+// Accounts sign in with passkeys, which cannot be minted offline. To get a
+// demo account you can log into, register through the browser first and pass
+// its user ID with --user-id; without it a fresh passkey-less user is created,
+// which is enough for screenshots via the admin tools and for local queries.
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	mathrand "math/rand/v2"
+	"os"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/petra/repository"
+	"github.com/myrjola/petrapp/internal/petra/service"
+	"github.com/myrjola/petrapp/internal/platform/auth"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+const (
+	defaultWeeks   = 26
+	demoName       = "Demo Athlete"
+	webAuthnIDSize = 64
+	// missedWeekChance is the probability that a whole week is skipped —
+	// holidays, illness — so the history isn't implausibly perfect.
+	missedWeekChance = 0.1
+	seedTimeout      = 5 * time.Minute
+)
+
+// seedStats summarises what seedDemo wrote.
+type seedStats struct {
+	Sessions    int
+	Sets        int
+	MissedWeeks int
+}
+
+func main() {
+	if err := run(os.Stdout, os.Args[1:]); err != nil {
+		os.Exit(1)
+	}
+}
+
+func run(w io.Writer, args []string) error {
+	logger := testkit.NewLogger(w)
+	ctx, cancel := context.WithTimeout(context.Background(), seedTimeout)
+	defer cancel()
+
+	fs := flag.NewFlagSet("seeddemo", flag.ContinueOnError)
+	fs.SetOutput(w)
+	weeks := fs.Int("weeks", defaultWeeks, "number of past weeks of history to generate")
+	seed := fs.Uint64("seed", 1, "random seed; the same seed reproduces the same history")
+	userID := fs.Int("user-id", 0, "existing user to backfill instead of creating a new one")
+	if err := fs.Parse(args); err != nil {
+		return fmt.Errorf("parse flags: %w", err)
+	}
+
+	sqliteURL, ok := os.LookupEnv("PETRAPP_SQLITE_URL")
+	if !ok {
+		logger.LogAttrs(ctx, slog.LevelError, "PETRAPP_SQLITE_URL not set")
+		return errors.New("PETRAPP_SQLITE_URL not set")
+	}
+	db, err := sqlitekit.NewDatabase(ctx, sqlitekit.Config{
+		URL:          sqliteURL,
+		Schema:       auth.SchemaSQL + "\n" + repository.SchemaSQL,
+		Fixtures:     repository.FixturesSQL,
+		Logger:       logger,
+		Premigration: nil,
+	})
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error creating database",
+			slog.String("url", sqliteURL), slog.Any("error", err))
+		return fmt.Errorf("create database: %w", err)
+	}
+	defer func() {
+		if closeErr := db.Close(); closeErr != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "failed to close database", slog.Any("error", closeErr))
+		}
+	}()
+
+	if *userID == 0 {
+		if *userID, err = createDemoUser(ctx, db); err != nil {
+			logger.LogAttrs(ctx, slog.LevelError, "error creating demo user", slog.Any("error", err))
+			return err
+		}
+		logger.LogAttrs(ctx, slog.LevelWarn,
+			"created a passkey-less demo user; to log in, register in the browser and re-run with --user-id",
+			slog.Int("user_id", *userID), slog.String("display_name", demoName))
+	}
+
+	svc := service.NewService(db, logger, "")
+	rng := mathrand.New(mathrand.NewPCG(*seed, *seed)) //nolint:gosec // demo data, not security-sensitive.
+	stats, err := seedDemo(contexthelpers.WithAuthenticatedUserID(ctx, *userID), svc, rng, *weeks, time.Now())
+	if err != nil {
+		logger.LogAttrs(ctx, slog.LevelError, "error seeding demo history", slog.Any("error", err))
+		return err
+	}
+
+	logger.LogAttrs(ctx, slog.LevelInfo, "demo account seeded",
+		slog.Int("user_id", *userID),
+		slog.Int("sessions", stats.Sessions),
+		slog.Int("sets", stats.Sets),
+		slog.Int("missed_weeks", stats.MissedWeeks))
+	return nil
+}
+
+// createDemoUser inserts a passkey-less user and returns its ID.
+func createDemoUser(ctx context.Context, db *sqlitekit.Database) (int, error) {
+	webAuthnID := make([]byte, webAuthnIDSize)
+	_, _ = rand.Read(webAuthnID) // crypto/rand.Read never returns an error.
+	var id int
+	if err := db.ReadWrite.QueryRowContext(ctx,
+		"INSERT INTO users (webauthn_user_id, display_name) VALUES (?, ?) RETURNING id",
+		webAuthnID, demoName).Scan(&id); err != nil {
+		return 0, fmt.Errorf("insert demo user: %w", err)
+	}
+	return id, nil
+}
+
+// seedDemo saves a Mon/Wed/Fri schedule and then works out every scheduled
+// day of the weeks full weeks before now's week, skipping the occasional
+// week. Each set follows the progression's recommendation and reports a
+// signal biased towards "too light", so working weights climb over time the
+// way they do for a consistent beginner. ctx must carry the user.
+func seedDemo(
+	ctx context.Context, svc *service.Service, rng *mathrand.Rand, weeks int, now time.Time,
+) (seedStats, error) {
+	var stats seedStats
+	if err := svc.SaveUserPreferences(ctx, domain.Preferences{ //nolint:exhaustruct // Rest days and deload use defaults.
+		Minutes: [7]int{time.Monday: 60, time.Wednesday: 60, time.Friday: 60},
+	}); err != nil {
+		return stats, fmt.Errorf("save preferences: %w", err)
+	}
+
+	thisMonday := domain.MondayOf(now)
+	for week := weeks; week >= 1; week-- {
+		if rng.Float64() < missedWeekChance {
+			stats.MissedWeeks++
+			continue
+		}
+		monday := thisMonday.AddDate(0, 0, -7*week)
+		for _, offset := range []int{0, 2, 4} { // Mon, Wed, Fri
+			date := monday.AddDate(0, 0, offset)
+			sets, err := workOut(ctx, svc, rng, date)
+			if err != nil {
+				return stats, fmt.Errorf("work out %s: %w", date.Format(time.DateOnly), err)
+			}
+			stats.Sessions++
+			stats.Sets += sets
+		}
+	}
+	return stats, nil
+}
+
+// workOut starts, performs, rates, and completes the session on date,
+// returning the number of sets recorded.
+func workOut(ctx context.Context, svc *service.Service, rng *mathrand.Rand, date time.Time) (int, error) {
+	if err := svc.StartSession(ctx, date); err != nil {
+		return 0, fmt.Errorf("start session: %w", err)
+	}
+	sess, err := svc.GetSession(ctx, date)
+	if err != nil {
+		return 0, fmt.Errorf("get session: %w", err)
+	}
+	recorded := 0
+	for pos, slot := range sess.Slots {
+		if err = svc.MarkWarmupComplete(ctx, date, pos); err != nil {
+			return recorded, fmt.Errorf("warmup slot %d: %w", pos, err)
+		}
+		for i, set := range slot.Sets {
+			var target domain.SetTarget
+			if target, err = svc.NextSetTarget(ctx, date, slot.Exercise.ID); err != nil {
+				return recorded, fmt.Errorf("next set target slot %d: %w", pos, err)
+			}
+			var (
+				weightKg *float64
+				signal   *domain.Signal
+				value    = set.TargetValue
+			)
+			switch slot.Exercise.LoadModel() {
+			case domain.LoadWeighted:
+				weightKg = &target.WeightKg
+				value = target.TargetValue
+			case domain.LoadTimed:
+				value = target.TargetValue
+			case domain.LoadBodyweight, domain.LoadUnknown:
+			}
+			if !sess.IsDeload {
+				s := pickSignal(rng)
+				signal = &s
+			}
+			if err = svc.RecordSet(ctx, date, pos, i, signal, weightKg, value, domain.SetFlags{}); err != nil {
+				return recorded, fmt.Errorf("record set %d of slot %d: %w", i, pos, err)
+			}
+			recorded++
+		}
+	}
+	if err = svc.SaveFeedback(ctx, date, 2+rng.IntN(3)); err != nil { //nolint:mnd // ratings 2–4 of 1–5.
+		return recorded, fmt.Errorf("save feedback: %w", err)
+	}
+	if err = svc.CompleteSession(ctx, date); err != nil {
+		return recorded, fmt.Errorf("complete session: %w", err)
+	}
+	return recorded, nil
+}
+
+// pickSignal biases towards progress: 40% too light, 50% on target, 10% too
+// heavy.
+func pickSignal(rng *mathrand.Rand) domain.Signal {
+	switch r := rng.Float64(); {
+	case r < 0.4: //nolint:mnd // see doc comment.
+		return domain.SignalTooLight
+	case r < 0.9: //nolint:mnd // see doc comment.
+		return domain.SignalOnTarget
+	default:
+		return domain.SignalTooHeavy
+	}
+}
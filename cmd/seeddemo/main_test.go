@@ -0,0 +1,93 @@
+package main
+
+import (
+	mathrand "math/rand/v2"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/repository"
+	"github.com/myrjola/petrapp/internal/petra/service"
+	"github.com/myrjola/petrapp/internal/platform/auth"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+func Test_seedDemo(t *testing.T) {
+	t.Parallel()
+
+	ctx := t.Context()
+	logger := testkit.NewLogger(testkit.NewWriter(t))
+	db, err := sqlitekit.NewDatabase(ctx, sqlitekit.Config{
+		URL:          ":memory:",
+		Schema:       auth.SchemaSQL + "\n" + repository.SchemaSQL,
+		Fixtures:     repository.FixturesSQL,
+		Logger:       logger,
+		Premigration: nil,
+	})
+	if err != nil {
+		t.Fatalf("create db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	userID, err := createDemoUser(ctx, db)
+	if err != nil {
+		t.Fatalf("createDemoUser: %v", err)
+	}
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
+
+	const weeks = 8
+	now := time.Date(2026, 6, 17, 12, 0, 0, 0, time.UTC)
+	svc := service.NewService(db, logger, "")
+	stats, err := seedDemo(ctx, svc, mathrand.New(mathrand.NewPCG(7, 7)), weeks, now)
+	if err != nil {
+		t.Fatalf("seedDemo: %v", err)
+	}
+
+	if want := 3 * (weeks - stats.MissedWeeks); stats.Sessions != want {
+		t.Errorf("Sessions = %d, want %d (3 per non-missed week)", stats.Sessions, want)
+	}
+	if stats.Sets == 0 {
+		t.Error("Sets = 0, want recorded sets")
+	}
+
+	var completed int
+	if err = db.ReadOnly.QueryRowContext(ctx,
+		`SELECT COUNT(*) FROM workout_sessions
+		 WHERE user_id = ? AND completed_at IS NOT NULL AND workout_date < ?`,
+		userID, now.Format(time.DateOnly)).Scan(&completed); err != nil {
+		t.Fatalf("count sessions: %v", err)
+	}
+	if completed != stats.Sessions {
+		t.Errorf("completed sessions in DB = %d, want %d", completed, stats.Sessions)
+	}
+
+	// Progression: at least one weighted exercise performed repeatedly must
+	// end heavier than it started.
+	var progressed int
+	if err = db.ReadOnly.QueryRowContext(ctx, `
+		WITH per_session AS (
+			SELECT we.exercise_id, we.workout_date, MAX(es.weight_kg) AS top_kg
+			FROM exercise_slots we
+			JOIN exercise_sets es
+			  ON es.workout_user_id = we.workout_user_id
+			 AND es.workout_date    = we.workout_date
+			 AND es.position        = we.position
+			WHERE we.workout_user_id = ? AND es.weight_kg > 0
+			GROUP BY we.exercise_id, we.workout_date
+		),
+		ranked AS (
+			SELECT exercise_id, top_kg,
+			       ROW_NUMBER() OVER (PARTITION BY exercise_id ORDER BY workout_date)      AS first_rank,
+			       ROW_NUMBER() OVER (PARTITION BY exercise_id ORDER BY workout_date DESC) AS last_rank
+			FROM per_session
+		)
+		SELECT COUNT(*) FROM ranked f JOIN ranked l USING (exercise_id)
+		WHERE f.first_rank = 1 AND l.last_rank = 1 AND l.top_kg > f.top_kg`,
+		userID).Scan(&progressed); err != nil {
+		t.Fatalf("query progression: %v", err)
+	}
+	if progressed == 0 {
+		t.Error("no weighted exercise progressed over the seeded history")
+	}
+}
@@ -0,0 +1,152 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+	"time"
+)
+
+// ActivityKind says what an ActivityItem records.
+type ActivityKind string
+
+const (
+	// ActivityWorkoutCompleted is a completed workout.
+	ActivityWorkoutCompleted ActivityKind = "workout_completed"
+	// ActivityWorkoutMissed is a past workout that was never started.
+	ActivityWorkoutMissed ActivityKind = "workout_missed"
+	// ActivityExerciseSkipped is an exercise left untouched in a past workout
+	// that was otherwise started.
+	ActivityExerciseSkipped ActivityKind = "exercise_skipped"
+	// ActivityRestDay is a past rest day.
+	ActivityRestDay ActivityKind = "rest_day"
+	// ActivityPersonalRecord is the day a current personal record was set.
+	ActivityPersonalRecord ActivityKind = "personal_record"
+)
+
+// dayOrder orders items on the same day: the workout first, then what
+// happened in it.
+func (k ActivityKind) dayOrder() int {
+	switch k {
+	case ActivityPersonalRecord:
+		return 1
+	case ActivityExerciseSkipped:
+		return 2 //nolint:mnd // Position in the order.
+	case ActivityWorkoutCompleted, ActivityWorkoutMissed, ActivityRestDay:
+	}
+	return 0
+}
+
+// ActivityItem is one entry in a user's recent activity feed.
+type ActivityItem struct {
+	Date time.Time
+	Kind ActivityKind
+	// WorkoutType is set on workout items.
+	WorkoutType Category
+	// Exercise is set on exercise_skipped and personal_record items.
+	Exercise *Exercise
+	// PersonalRecord is set on personal_record items.
+	PersonalRecord *PersonalRecord
+	// PersonalRecordCount is, on a completed workout, how many of the current
+	// personal records were set that day.
+	PersonalRecordCount int
+}
+
+// ActivityFeed merges sessions and records into a feed of what happened
+// before today, plus a workout completed today, newest first. Items on the
+// same day put the workout first, then personal records, then skipped
+// exercises, each sorted by exercise name. At most limit items are returned;
+// a limit of zero or less returns them all. Sessions today that are not
+// completed are still open, so they are left out, as are future sessions. A
+// past workout that was started but never completed has no workout item of
+// its own, only its skipped exercises.
+func ActivityFeed(sessions []Session, records []PersonalRecord, today time.Time, limit int) []ActivityItem {
+	recordsOn := make(map[time.Time]int)
+	items := make([]ActivityItem, 0, len(sessions)+len(records))
+	for _, pr := range records {
+		if pr.Date.After(today) {
+			continue
+		}
+		recordsOn[pr.Date]++
+		items = append(items, ActivityItem{
+			Date:                pr.Date,
+			Kind:                ActivityPersonalRecord,
+			WorkoutType:         "",
+			Exercise:            &pr.Exercise,
+			PersonalRecord:      &pr,
+			PersonalRecordCount: 0,
+		})
+	}
+	for _, s := range sessions {
+		status := s.Status()
+		if s.Date.After(today) || (s.Date.Equal(today) && status != SessionCompleted) {
+			continue
+		}
+		item := ActivityItem{
+			Date:                s.Date,
+			Kind:                ActivityRestDay,
+			WorkoutType:         "",
+			Exercise:            nil,
+			PersonalRecord:      nil,
+			PersonalRecordCount: 0,
+		}
+		switch {
+		case len(s.Slots) == 0:
+		case status == SessionNotStarted:
+			item.Kind = ActivityWorkoutMissed
+			item.WorkoutType = s.WorkoutType()
+		case status == SessionInProgress:
+			items = append(items, skippedExercises(s)...)
+			continue
+		default:
+			item.Kind = ActivityWorkoutCompleted
+			item.WorkoutType = s.WorkoutType()
+			item.PersonalRecordCount = recordsOn[s.Date]
+			items = append(items, skippedExercises(s)...)
+		}
+		items = append(items, item)
+	}
+	slices.SortFunc(items, compareActivity)
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
+// skippedExercises returns an item for every slot in s without a completed set.
+func skippedExercises(s Session) []ActivityItem {
+	var items []ActivityItem
+	for _, slot := range s.Slots {
+		if slot.CompletionState() != ExerciseSlotNotStarted {
+			continue
+		}
+		items = append(items, ActivityItem{
+			Date:                s.Date,
+			Kind:                ActivityExerciseSkipped,
+			WorkoutType:         "",
+			Exercise:            &slot.Exercise,
+			PersonalRecord:      nil,
+			PersonalRecordCount: 0,
+		})
+	}
+	return items
+}
+
+// compareActivity orders items newest first, then by kind, then by exercise
+// name and ID, so the feed is stable across calls.
+func compareActivity(a, b ActivityItem) int {
+	if c := b.Date.Compare(a.Date); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(a.Kind.dayOrder(), b.Kind.dayOrder()); c != 0 {
+		return c
+	}
+	var aName, bName string
+	var aID, bID int
+	if a.Exercise != nil {
+		aName, aID = a.Exercise.Name, a.Exercise.ID
+	}
+	if b.Exercise != nil {
+		bName, bID = b.Exercise.Name, b.Exercise.ID
+	}
+	return cmp.Or(cmp.Compare(aName, bName), cmp.Compare(aID, bID))
+}
@@ -0,0 +1,96 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_ActivityFeed(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	day := func(offset int) time.Time { return today.AddDate(0, 0, offset) }
+	squat := domain.Exercise{ID: 1, Name: "Squat", Category: domain.CategoryLower}       //nolint:exhaustruct // Identity only.
+	bench := domain.Exercise{ID: 2, Name: "Bench Press", Category: domain.CategoryUpper} //nolint:exhaustruct // Identity only.
+	row := domain.Exercise{ID: 3, Name: "Barbell Row", Category: domain.CategoryUpper}   //nolint:exhaustruct // Identity only.
+	done, doneAt := 5, today
+	slot := func(ex domain.Exercise, completed bool) domain.ExerciseSlot {
+		set := domain.Set{TargetValue: 5} //nolint:exhaustruct // Completion only.
+		if completed {
+			set.CompletedValue, set.CompletedAt = &done, &doneAt
+		}
+		return domain.ExerciseSlot{Exercise: ex, Sets: []domain.Set{set}} //nolint:exhaustruct // Sets only.
+	}
+	sess := func(offset int, started, completed bool, slots ...domain.ExerciseSlot) domain.Session {
+		s := domain.Session{Date: day(offset), Slots: slots} //nolint:exhaustruct // Status and slots only.
+		if started {
+			s.StartedAt = s.Date.Add(time.Hour)
+		}
+		if completed {
+			s.CompletedAt = s.Date.Add(2 * time.Hour)
+		}
+		return s
+	}
+	sessions := []domain.Session{
+		sess(2, false, false, slot(squat, false)),                                     // Future: left out.
+		sess(0, true, false, slot(squat, true), slot(bench, false)),                   // Today, still open: left out.
+		sess(-1, false, false),                                                        // Rest day.
+		sess(-2, true, true, slot(squat, true)),                                       // Completed, one PR.
+		sess(-3, false, false, slot(bench, false)),                                    // Missed.
+		sess(-4, true, true, slot(bench, true), slot(row, false), slot(squat, false)), // Completed, two skipped.
+		sess(-5, true, false, slot(row, true), slot(bench, false)),                    // Abandoned: one skipped.
+	}
+	w := 100.0
+	records := []domain.PersonalRecord{
+		{Exercise: squat, Date: day(-2), WeightKg: &w, Value: 5},
+		{Exercise: bench, Date: day(-4), WeightKg: &w, Value: 5},
+		{Exercise: row, Date: day(-5), WeightKg: &w, Value: 5},
+	}
+
+	type want struct {
+		offset   int
+		kind     domain.ActivityKind
+		exercise string
+	}
+	wants := []want{
+		{-1, domain.ActivityRestDay, ""},
+		{-2, domain.ActivityWorkoutCompleted, ""},
+		{-2, domain.ActivityPersonalRecord, "Squat"},
+		{-3, domain.ActivityWorkoutMissed, ""},
+		{-4, domain.ActivityWorkoutCompleted, ""},
+		{-4, domain.ActivityPersonalRecord, "Bench Press"},
+		{-4, domain.ActivityExerciseSkipped, "Barbell Row"},
+		{-4, domain.ActivityExerciseSkipped, "Squat"},
+		{-5, domain.ActivityPersonalRecord, "Barbell Row"},
+		{-5, domain.ActivityExerciseSkipped, "Bench Press"},
+	}
+
+	feed := domain.ActivityFeed(sessions, records, today, 0)
+	if len(feed) != len(wants) {
+		t.Fatalf("len(feed) = %d, want %d: %+v", len(feed), len(wants), feed)
+	}
+	for i, w := range wants {
+		got := feed[i]
+		name := ""
+		if got.Exercise != nil {
+			name = got.Exercise.Name
+		}
+		if !got.Date.Equal(day(w.offset)) || got.Kind != w.kind || name != w.exercise {
+			t.Errorf("feed[%d] = (%s, %s, %q), want (%s, %s, %q)", i,
+				got.Date.Format(time.DateOnly), got.Kind, name, day(w.offset).Format(time.DateOnly), w.kind, w.exercise)
+		}
+	}
+	if got := feed[1]; got.WorkoutType != domain.CategoryLower || got.PersonalRecordCount != 1 {
+		t.Errorf("completed workout = (%s, %d PRs), want (lower, 1 PR)", got.WorkoutType, got.PersonalRecordCount)
+	}
+	if got := feed[3].WorkoutType; got != domain.CategoryUpper {
+		t.Errorf("missed workout type = %s, want upper", got)
+	}
+
+	limited := domain.ActivityFeed(sessions, records, today, 3)
+	if len(limited) != 3 || limited[2].Kind != domain.ActivityPersonalRecord {
+		t.Errorf("ActivityFeed(limit 3) = %+v, want the first three items", limited)
+	}
+}
@@ -0,0 +1,76 @@
+package domain
+
+import (
+	"cmp"
+	"slices"
+)
+
+// ExerciseAdherence is how closely a user followed the prescription for one
+// exercise over a set of sessions. PrescribedSets counts the working sets
+// planned, CompletedSets those logged, and OnTargetSets those logged within
+// the exercise's rep range; see setOnTarget.
+type ExerciseAdherence struct {
+	Exercise       Exercise
+	PrescribedSets int
+	CompletedSets  int
+	OnTargetSets   int
+}
+
+// Rate is the fraction of prescribed sets completed on target, in [0,1]. It
+// is 0 when nothing was prescribed.
+func (a ExerciseAdherence) Rate() float64 {
+	if a.PrescribedSets == 0 {
+		return 0
+	}
+	return float64(a.OnTargetSets) / float64(a.PrescribedSets)
+}
+
+// AdherenceByExercise totals prescribed-versus-completed working sets per
+// exercise across sessions, sorted by exercise name. Warmup sets are not
+// prescribed work and are skipped. Sets in sessions never completed still
+// count as prescribed, so a missed workout lowers adherence; callers pick the
+// window, and should leave out sessions that may still be in progress.
+func AdherenceByExercise(sessions []Session) []ExerciseAdherence {
+	byID := make(map[int]*ExerciseAdherence)
+	for _, s := range sessions {
+		for _, slot := range s.Slots {
+			a, ok := byID[slot.Exercise.ID]
+			if !ok {
+				a = &ExerciseAdherence{Exercise: slot.Exercise, PrescribedSets: 0, CompletedSets: 0, OnTargetSets: 0}
+				byID[slot.Exercise.ID] = a
+			}
+			for _, set := range slot.Sets {
+				if !set.IsWorking() {
+					continue
+				}
+				a.PrescribedSets++
+				if set.CompletedAt == nil || set.CompletedValue == nil {
+					continue
+				}
+				a.CompletedSets++
+				if setOnTarget(slot.Exercise, set) {
+					a.OnTargetSets++
+				}
+			}
+		}
+	}
+	result := make([]ExerciseAdherence, 0, len(byID))
+	for _, a := range byID {
+		result = append(result, *a)
+	}
+	slices.SortFunc(result, func(a, b ExerciseAdherence) int {
+		return cmp.Or(cmp.Compare(a.Exercise.Name, b.Exercise.Name), cmp.Compare(a.Exercise.ID, b.Exercise.ID))
+	})
+	return result
+}
+
+// setOnTarget reports whether a completed set met its prescription: within
+// [RepMin, RepMax] when the exercise has a rep range, otherwise at least the
+// set's own target, which is how timed exercises are prescribed.
+func setOnTarget(ex Exercise, set Set) bool {
+	done := *set.CompletedValue
+	if ex.RepMin != nil && ex.RepMax != nil {
+		return done >= *ex.RepMin && done <= *ex.RepMax
+	}
+	return done >= set.TargetValue
+}
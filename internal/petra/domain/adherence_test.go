@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_AdherenceByExercise(t *testing.T) {
+	t.Parallel()
+
+	repMin, repMax := 8, 12
+	squat := domain.Exercise{ID: 1, Name: "Squat", RepMin: &repMin, RepMax: &repMax} //nolint:exhaustruct // Rep range only.
+	plank := domain.Exercise{ID: 2, Name: "Plank"}                                   //nolint:exhaustruct // No rep range.
+	at := time.Date(2026, 5, 13, 10, 0, 0, 0, time.UTC)
+	set := func(target int, completed *int, warmup bool) domain.Set {
+		s := domain.Set{TargetValue: target, CompletedValue: completed, Warmup: warmup} //nolint:exhaustruct // Completion only.
+		if completed != nil {
+			s.CompletedAt = &at
+		}
+		return s
+	}
+	reps := func(n int) *int { return &n }
+
+	sessions := []domain.Session{
+		{ //nolint:exhaustruct // Slots only.
+			Slots: []domain.ExerciseSlot{
+				{ //nolint:exhaustruct // Exercise and sets only.
+					Exercise: squat,
+					Sets: []domain.Set{
+						set(5, reps(5), true),    // Warmup: not prescribed.
+						set(10, reps(10), false), // On target.
+						set(10, reps(6), false),  // Completed below the range.
+						set(10, nil, false),      // Not completed.
+					},
+				},
+				{ //nolint:exhaustruct // Exercise and sets only.
+					Exercise: plank,
+					Sets:     []domain.Set{set(60, reps(60), false), set(60, reps(45), false)},
+				},
+			},
+		},
+		{ //nolint:exhaustruct // Slots only.
+			Slots: []domain.ExerciseSlot{
+				{Exercise: squat, Sets: []domain.Set{set(10, reps(12), false)}}, //nolint:exhaustruct // Sets only.
+			},
+		},
+	}
+
+	got := domain.AdherenceByExercise(sessions)
+	if len(got) != 2 || got[0].Exercise.Name != "Plank" || got[1].Exercise.Name != "Squat" {
+		t.Fatalf("AdherenceByExercise = %+v, want Plank then Squat", got)
+	}
+	plankGot, squatGot := got[0], got[1]
+	if plankGot.PrescribedSets != 2 || plankGot.CompletedSets != 2 || plankGot.OnTargetSets != 1 {
+		t.Errorf("Plank = %+v, want 2 prescribed, 2 completed, 1 on target", plankGot)
+	}
+	if squatGot.PrescribedSets != 4 || squatGot.CompletedSets != 3 || squatGot.OnTargetSets != 2 {
+		t.Errorf("Squat = %+v, want 4 prescribed, 3 completed, 2 on target", squatGot)
+	}
+	if rate := squatGot.Rate(); math.Abs(rate-0.5) > 1e-9 {
+		t.Errorf("Squat Rate() = %v, want 0.5", rate)
+	}
+	if rate := (domain.ExerciseAdherence{}).Rate(); rate != 0 { //nolint:exhaustruct // Nothing prescribed.
+		t.Errorf("empty Rate() = %v, want 0", rate)
+	}
+}
@@ -0,0 +1,106 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultDeloadInterval is how many weeks in a row a lift may gain weight
+	// before an automatic deload when Preferences.DeloadInterval is zero: the
+	// middle of the four to six weeks of straight progression lifters usually
+	// sustain before fatigue outruns recovery.
+	DefaultDeloadInterval = 5
+	// MinDeloadInterval and MaxDeloadInterval bound a chosen DeloadInterval
+	// to that four to six week band.
+	MinDeloadInterval = 4
+	MaxDeloadInterval = 6
+)
+
+// EffectiveDeloadInterval returns DeloadInterval, with zero (automatic)
+// meaning DefaultDeloadInterval. NewPlanner derives
+// Planner.AutoDeloadAfterWeeks from it.
+func (p Preferences) EffectiveDeloadInterval() int {
+	if p.DeloadInterval == 0 {
+		return DefaultDeloadInterval
+	}
+	return p.DeloadInterval
+}
+
+// ValidateDeloadInterval reports whether n is an acceptable
+// Preferences.DeloadInterval: zero (automatic) or MinDeloadInterval through
+// MaxDeloadInterval. The error is a ValidationError safe to show the user.
+func ValidateDeloadInterval(n int) error {
+	if n != 0 && (n < MinDeloadInterval || n > MaxDeloadInterval) {
+		return ValidationError{Message: fmt.Sprintf(
+			"Deload after must be between %d and %d weeks, or automatic.", MinDeloadInterval, MaxDeloadInterval,
+		)}
+	}
+	return nil
+}
+
+// ProgressionStreakWeeks returns the longest run, across weighted exercises,
+// of consecutive calendar weeks before the week of before in which the
+// exercise's heaviest completed working set was heavier than the week before.
+// Deload sessions are left out, so a deload week ends every run, as does a
+// week without the exercise: sparse history never builds a streak. Sessions
+// may be in any order.
+func ProgressionStreakWeeks(sessions []Session, before time.Time) int {
+	monday := MondayOf(before)
+	// heaviest[exerciseID][weeksAgo] is the top working weight that week;
+	// weeksAgo 1 is the week before monday's.
+	heaviest := make(map[int]map[int]float64)
+	for _, s := range sessions {
+		if s.IsDeload || !s.Date.Before(monday) {
+			continue
+		}
+		weeksAgo := int(monday.Sub(MondayOf(s.Date)).Hours()/hoursPerDay) / 7
+		for _, slot := range s.Slots {
+			if !slot.Exercise.HasWeight() {
+				continue
+			}
+			for _, set := range slot.Sets {
+				if !set.IsWorking() || set.CompletedValue == nil || set.WeightKg == nil {
+					continue
+				}
+				weeks, ok := heaviest[slot.Exercise.ID]
+				if !ok {
+					weeks = make(map[int]float64)
+					heaviest[slot.Exercise.ID] = weeks
+				}
+				if top, seen := weeks[weeksAgo]; !seen || *set.WeightKg > top {
+					weeks[weeksAgo] = *set.WeightKg
+				}
+			}
+		}
+	}
+
+	longest := 0
+	for _, weeks := range heaviest {
+		streak := 0
+		for ago := 1; ; ago++ {
+			this, ok := weeks[ago]
+			prev, prevOK := weeks[ago+1]
+			if !ok || !prevOK || this <= prev {
+				break
+			}
+			streak++
+		}
+		longest = max(longest, streak)
+	}
+	return longest
+}
+
+// AutoDeloadDue reports whether the planner should make the week it plans a
+// deload ahead of the mesocycle calendar because deloads are on and a lift
+// has gained weight for AutoDeloadAfterWeeks weeks straight (see
+// ProgressionStreak).
+func (wp *Planner) AutoDeloadDue() bool {
+	return wp.Prefs.DeloadEnabled && wp.AutoDeloadAfterWeeks > 0 && wp.ProgressionStreak >= wp.AutoDeloadAfterWeeks
+}
+
+// isDeloadWeek reports whether the week starting monday is a deload, either
+// on the mesocycle calendar or automatically (see AutoDeloadDue).
+func (wp *Planner) isDeloadWeek(monday time.Time) bool {
+	return wp.Prefs.IsDeloadWeek(monday) || wp.AutoDeloadDue()
+}
@@ -0,0 +1,139 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_ProgressionStreakWeeks(t *testing.T) {
+	t.Parallel()
+
+	monday := monday2026Date().AddDate(0, 0, 42)
+	bench := domain.Exercise{ //nolint:exhaustruct // ID and type only.
+		ID: 1, ExerciseType: domain.ExerciseTypeWeighted,
+	}
+	pushUp := domain.Exercise{ //nolint:exhaustruct // ID and type only.
+		ID: 2, ExerciseType: domain.ExerciseTypeBodyweight,
+	}
+	// session is a completed single-set workout weeksAgo weeks before
+	// monday's week; weight 0 leaves the set without a weight.
+	session := func(weeksAgo int, ex domain.Exercise, weight float64, deload bool) domain.Session {
+		set := domain.Set{CompletedValue: new(8)} //nolint:exhaustruct // Completion and weight only.
+		if weight > 0 {
+			set.WeightKg = new(weight)
+		}
+		return domain.Session{ //nolint:exhaustruct // Date, deload flag and slots only.
+			Date:     monday.AddDate(0, 0, -7*weeksAgo+2),
+			IsDeload: deload,
+			Slots:    []domain.ExerciseSlot{{Exercise: ex, Sets: []domain.Set{set}}}, //nolint:exhaustruct // Sets only.
+		}
+	}
+
+	tests := []struct {
+		name     string
+		sessions []domain.Session
+		want     int
+	}{
+		{name: "no history", sessions: nil, want: 0},
+		{
+			name: "rising every week",
+			sessions: []domain.Session{
+				session(1, bench, 70, false), session(2, bench, 67.5, false),
+				session(3, bench, 65, false), session(4, bench, 62.5, false),
+			},
+			want: 3,
+		},
+		{
+			name: "stall ends the run",
+			sessions: []domain.Session{
+				session(1, bench, 70, false), session(2, bench, 67.5, false),
+				session(3, bench, 67.5, false), session(4, bench, 65, false),
+			},
+			want: 1,
+		},
+		{
+			name: "missed week ends the run",
+			sessions: []domain.Session{
+				session(1, bench, 70, false), session(2, bench, 67.5, false),
+				session(4, bench, 65, false), session(5, bench, 62.5, false),
+			},
+			want: 1,
+		},
+		{
+			name: "deload week ends the run",
+			sessions: []domain.Session{
+				session(1, bench, 70, false), session(2, bench, 60, true),
+				session(3, bench, 67.5, false), session(4, bench, 65, false),
+			},
+			want: 0,
+		},
+		{
+			name: "current week is not counted",
+			sessions: []domain.Session{
+				session(0, bench, 72.5, false), session(1, bench, 70, false), session(2, bench, 67.5, false),
+			},
+			want: 1,
+		},
+		{
+			name:     "bodyweight exercises are ignored",
+			sessions: []domain.Session{session(1, pushUp, 0, false), session(2, pushUp, 0, false)},
+			want:     0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := domain.ProgressionStreakWeeks(tt.sessions, monday); got != tt.want {
+				t.Errorf("ProgressionStreakWeeks = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlanner_Plan_AutoDeloadFollowsProgressionStreak(t *testing.T) {
+	t.Parallel()
+
+	monday := monday2026Date()
+	tests := []struct {
+		name     string
+		deload   bool
+		interval int
+		streak   int
+		want     bool
+	}{
+		{name: "streak reaches automatic interval", deload: true, streak: domain.DefaultDeloadInterval, want: true},
+		{name: "streak below automatic interval", deload: true, streak: domain.DefaultDeloadInterval - 1, want: false},
+		{name: "streak reaches chosen interval", deload: true, interval: domain.MinDeloadInterval,
+			streak: domain.MinDeloadInterval, want: true},
+		{name: "streak below chosen interval", deload: true, interval: domain.MaxDeloadInterval,
+			streak: domain.DefaultDeloadInterval, want: false},
+		{name: "deloads off", deload: false, streak: domain.DefaultDeloadInterval, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := prefs(time.Monday, time.Wednesday, time.Friday)
+			p.DeloadEnabled = tt.deload
+			p.DeloadInterval = tt.interval
+			p.MesocycleLength = 4
+			p.MesocycleAnchor = monday // Week 0 of the calendar: never a deload.
+			wp := domain.NewPlanner(p, seedExercises(), seedTargets())
+			wp.ProgressionStreak = tt.streak
+
+			plan, err := wp.Plan(monday)
+			if err != nil {
+				t.Fatalf("Plan: %v", err)
+			}
+			if got := plan.IsDeloadWeek(); got != tt.want {
+				t.Errorf("plan.IsDeloadWeek() = %t, want %t", got, tt.want)
+			}
+			for _, s := range planSessions(plan) {
+				if s.IsDeload != tt.want {
+					t.Errorf("session %s IsDeload = %t, want %t", s.Date.Format(time.DateOnly), s.IsDeload, tt.want)
+				}
+			}
+		})
+	}
+}
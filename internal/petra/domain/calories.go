@@ -0,0 +1,84 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+)
+
+// Calorie-estimate constants. The MET bounds come from the Compendium of
+// Physical Activities: 3.5 METs for light resistance training (code 02054)
+// up to 6.0 for vigorous effort (code 02050). Where a session sits between
+// them is judged by its density, the tonnage lifted per minute.
+const (
+	caloriesBaseMET = 3.5
+	caloriesMaxMET  = 6.0
+	// caloriesKgPerMinutePerMET is the density that adds one MET over the
+	// base: 100 kg a minute (6 t in an hour) reads as moderate effort.
+	caloriesKgPerMinutePerMET = 100.0
+	// MinBodyweightKg and MaxBodyweightKg bound the bodyweight an estimate
+	// accepts.
+	MinBodyweightKg = 30
+	MaxBodyweightKg = 300
+)
+
+// CalorieEstimateCaveat accompanies every CalorieEstimate. The MET method
+// ignores rest length, muscle mass, sex, age and fitness, so the figure is a
+// ballpark, not a measurement.
+const CalorieEstimateCaveat = "A rough estimate from average MET values for strength training; " +
+	"actual expenditure varies with rest times, muscle mass, age and fitness, and can differ by 30% or more."
+
+// CalorieEstimate is EstimateCalories' result. Kcal is rounded to the nearest
+// kilocalorie; the other fields show the inputs it came from.
+type CalorieEstimate struct {
+	Kcal      int
+	Minutes   float64
+	TonnageKg float64
+	MET       float64
+	Caveat    string
+}
+
+// TonnageKg returns the load moved across the session's completed sets:
+// weight × reps, summed. Bodyweight, timed and assisted sets carry no added
+// load and contribute nothing, and circuit sets, whose completed value counts
+// rounds, are skipped.
+func (s *Session) TonnageKg() float64 {
+	total := 0.0
+	for _, slot := range s.Slots {
+		for _, set := range slot.Sets {
+			if set.CompletedValue == nil || set.WeightKg == nil || *set.WeightKg <= 0 || set.DurationSeconds != nil {
+				continue
+			}
+			total += *set.WeightKg * float64(*set.CompletedValue)
+		}
+	}
+	return total
+}
+
+// EstimateCalories estimates the energy a completed session burned with the
+// MET formula kcal = MET × bodyweight (kg) × duration (h), with the duration
+// from Session.Duration. MET starts at caloriesBaseMET and rises by one per
+// caloriesKgPerMinutePerMET of tonnage a minute, capped at caloriesMaxMET, so
+// the estimate grows with both duration and tonnage. Returns a ValidationError
+// when the session is not finished or bodyweightKg is out of range.
+func EstimateCalories(s Session, bodyweightKg float64) (CalorieEstimate, error) {
+	if bodyweightKg < MinBodyweightKg || bodyweightKg > MaxBodyweightKg {
+		return CalorieEstimate{}, ValidationError{Message: fmt.Sprintf(
+			"Bodyweight must be between %d and %d kg.", MinBodyweightKg, MaxBodyweightKg)}
+	}
+	duration, _ := s.Duration()
+	if duration <= 0 {
+		return CalorieEstimate{}, ValidationError{Message: "Finish the workout to estimate the calories it burned."}
+	}
+
+	minutes := duration.Minutes()
+	tonnage := s.TonnageKg()
+	met := min(caloriesBaseMET+tonnage/minutes/caloriesKgPerMinutePerMET, caloriesMaxMET)
+	const minutesPerHour = 60
+	return CalorieEstimate{
+		Kcal:      int(math.Round(met * bodyweightKg * minutes / minutesPerHour)),
+		Minutes:   minutes,
+		TonnageKg: tonnage,
+		MET:       met,
+		Caveat:    CalorieEstimateCaveat,
+	}, nil
+}
@@ -0,0 +1,65 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_EstimateCalories(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 5, 13, 18, 0, 0, 0, time.UTC)
+	session := func(minutes int, weightKg float64, reps int) domain.Session {
+		return domain.Session{ //nolint:exhaustruct // Timing and sets only.
+			StartedAt:   start,
+			CompletedAt: start.Add(time.Duration(minutes) * time.Minute),
+			Slots: []domain.ExerciseSlot{{ //nolint:exhaustruct // Sets only.
+				Sets: []domain.Set{{WeightKg: &weightKg, CompletedValue: &reps}}, //nolint:exhaustruct // Completion.
+			}},
+		}
+	}
+	estimate := func(s domain.Session) domain.CalorieEstimate {
+		t.Helper()
+		got, err := domain.EstimateCalories(s, 80)
+		if err != nil {
+			t.Fatalf("EstimateCalories: %v", err)
+		}
+		return got
+	}
+
+	base := estimate(session(60, 100, 30))
+	// 3000 kg over 60 minutes is 50 kg/min: 3.5 + 0.5 METs × 80 kg × 1 h.
+	if base.Kcal != 320 || base.TonnageKg != 3000 || base.MET != 4 {
+		t.Errorf("estimate = %+v, want 320 kcal from 3000 kg at 4 METs", base)
+	}
+	if base.Caveat == "" {
+		t.Error("Caveat is empty, want an accuracy warning")
+	}
+	if longer := estimate(session(90, 100, 45)); longer.Kcal <= base.Kcal {
+		t.Errorf("longer session at the same density = %d kcal, want more than %d", longer.Kcal, base.Kcal)
+	}
+	if heavier := estimate(session(60, 200, 30)); heavier.Kcal <= base.Kcal {
+		t.Errorf("heavier session of the same length = %d kcal, want more than %d", heavier.Kcal, base.Kcal)
+	}
+	if capped := estimate(session(60, 1000, 1000)); capped.MET != 6 {
+		t.Errorf("MET = %v for an implausibly dense session, want the 6.0 cap", capped.MET)
+	}
+	skewed := session(60, 100, 30)
+	skewed.StartedAt, skewed.CompletedAt = skewed.CompletedAt, skewed.StartedAt
+	if got := estimate(skewed); got.Minutes != 60 || got.Kcal != base.Kcal {
+		t.Errorf("inverted timestamps = %v min, %d kcal, want 60 min, %d kcal", got.Minutes, got.Kcal, base.Kcal)
+	}
+
+	var ve domain.ValidationError
+	unfinished := session(60, 100, 30)
+	unfinished.CompletedAt = time.Time{}
+	if _, err := domain.EstimateCalories(unfinished, 80); !errors.As(err, &ve) {
+		t.Errorf("unfinished session err = %v, want a ValidationError", err)
+	}
+	if _, err := domain.EstimateCalories(session(60, 100, 30), 10); !errors.As(err, &ve) {
+		t.Errorf("10 kg bodyweight err = %v, want a ValidationError", err)
+	}
+}
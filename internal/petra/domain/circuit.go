@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// Circuit window bounds. Under five minutes a circuit is a single pass, not
+// rounds; past an hour it is no longer a session this app plans.
+const (
+	MinCircuitSeconds = 5 * 60
+	MaxCircuitSeconds = 60 * 60
+)
+
+// A circuit session is prescribed as timed rounds instead of sets: every slot
+// holds exactly one set whose DurationSeconds is the shared time window and
+// whose TargetValue is the reps (or seconds, for timed exercises) to perform
+// per round. The user cycles through the slots until the window runs out and
+// logs how many rounds they finished, stored as each set's CompletedValue —
+// one round is one pass through every slot, so the count is shared.
+
+// IsCircuit reports whether the session is prescribed as timed rounds.
+func (s *Session) IsCircuit() bool {
+	return len(s.Slots) > 0 && len(s.Slots[0].Sets) > 0 && s.Slots[0].Sets[0].DurationSeconds != nil
+}
+
+// CircuitSeconds returns the circuit's time window, or 0 for a session
+// prescribed as sets.
+func (s *Session) CircuitSeconds() int {
+	if !s.IsCircuit() {
+		return 0
+	}
+	return *s.Slots[0].Sets[0].DurationSeconds
+}
+
+// ConvertToCircuit turns a planned session into a circuit of durationSeconds.
+// Each slot collapses to its first set, which keeps its weight and target as
+// the per-round prescription. Returns ErrAlreadyStarted once the session has
+// begun — logged sets are never rewritten — and a ValidationError when the
+// window is out of bounds or the session has no exercises to cycle through.
+func (s *Session) ConvertToCircuit(durationSeconds int) error {
+	if !s.StartedAt.IsZero() {
+		return ErrAlreadyStarted
+	}
+	if durationSeconds < MinCircuitSeconds || durationSeconds > MaxCircuitSeconds {
+		return ValidationError{Message: fmt.Sprintf("Circuit length must be between %d and %d minutes.",
+			MinCircuitSeconds/60, MaxCircuitSeconds/60)}
+	}
+	if len(s.Slots) == 0 {
+		return ValidationError{Message: "Add an exercise before turning the workout into a circuit."}
+	}
+	for i := range s.Slots {
+		s.Slots[i].Sets = circuitSets(s.Slots[i].Sets, durationSeconds)
+	}
+	return nil
+}
+
+// circuitSets collapses a slot's prescribed sets to the single per-round set
+// of a circuit of durationSeconds, keeping the first set's weight and target.
+func circuitSets(sets []Set, durationSeconds int) []Set {
+	d := durationSeconds
+	set := Set{ //nolint:exhaustruct // A planned set has no completion data yet.
+		TargetValue:     1,
+		DurationSeconds: &d,
+	}
+	if len(sets) > 0 {
+		set.WeightKg = sets[0].WeightKg
+		set.TargetValue = sets[0].TargetValue
+	}
+	return []Set{set}
+}
+
+// RecordCircuitRounds logs the rounds completed within the circuit's window
+// on every slot. Returns ErrNotCircuit for a session prescribed as sets,
+// ErrNotStarted before the session has begun, and a ValidationError for a
+// negative count. Recording again overwrites the previous count.
+func (s *Session) RecordCircuitRounds(rounds int, now time.Time) error {
+	if !s.IsCircuit() {
+		return ErrNotCircuit
+	}
+	if s.StartedAt.IsZero() {
+		return ErrNotStarted
+	}
+	if rounds < 0 {
+		return ValidationError{Message: "Rounds completed cannot be negative."}
+	}
+	for i := range s.Slots {
+		for j := range s.Slots[i].Sets {
+			set := &s.Slots[i].Sets[j]
+			r, t := rounds, now
+			set.CompletedValue = &r
+			set.CompletedAt = &t
+		}
+	}
+	return nil
+}
+
+// CircuitRounds returns the rounds logged for the circuit. ok is false for a
+// session prescribed as sets or a circuit with no rounds logged yet.
+func (s *Session) CircuitRounds() (int, bool) {
+	if !s.IsCircuit() || s.Slots[0].Sets[0].CompletedValue == nil {
+		return 0, false
+	}
+	return *s.Slots[0].Sets[0].CompletedValue, true
+}
+
+// ConvertToCircuit turns the session for date into a circuit.
+func (wp *WeekPlan) ConvertToCircuit(date time.Time, durationSeconds int) error {
+	s := wp.SessionOn(date)
+	if s == nil {
+		return ErrNotFound
+	}
+	return s.ConvertToCircuit(durationSeconds)
+}
+
+// RecordCircuitRounds logs the rounds completed in the circuit for date.
+func (wp *WeekPlan) RecordCircuitRounds(date time.Time, rounds int, now time.Time) error {
+	s := wp.SessionOn(date)
+	if s == nil {
+		return ErrNotFound
+	}
+	return s.RecordCircuitRounds(rounds, now)
+}
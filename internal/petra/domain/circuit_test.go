@@ -0,0 +1,124 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_Session_Circuit(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 5, 27, 9, 0, 0, 0, time.UTC)
+
+	t.Run("converting keeps each slot's first set as the round prescription", func(t *testing.T) {
+		t.Parallel()
+		sess := seedTestSession()
+		w := 40.0
+		sess.Slots[0].Sets[0].WeightKg = &w
+		if sess.IsCircuit() {
+			t.Fatal("IsCircuit = true before conversion")
+		}
+		if err := sess.ConvertToCircuit(15 * 60); err != nil {
+			t.Fatalf("ConvertToCircuit: %v", err)
+		}
+		if !sess.IsCircuit() || sess.CircuitSeconds() != 15*60 {
+			t.Fatalf("IsCircuit = %t, CircuitSeconds = %d, want a 900 s circuit",
+				sess.IsCircuit(), sess.CircuitSeconds())
+		}
+		squat, plank := sess.Slots[0].Sets, sess.Slots[1].Sets
+		if len(squat) != 1 || len(plank) != 1 {
+			t.Fatalf("sets per slot = %d, %d, want 1, 1", len(squat), len(plank))
+		}
+		if squat[0].TargetValue != 5 || squat[0].WeightKg == nil || *squat[0].WeightKg != 40 {
+			t.Errorf("squat round = %d reps @ %v, want 5 @ 40", squat[0].TargetValue, squat[0].WeightKg)
+		}
+		if plank[0].TargetValue != 30 {
+			t.Errorf("plank round = %d s, want 30", plank[0].TargetValue)
+		}
+		if squat[0].DurationSeconds == plank[0].DurationSeconds {
+			t.Error("slots share one DurationSeconds pointer; want a copy per set")
+		}
+	})
+
+	t.Run("rounds are logged on every slot once started", func(t *testing.T) {
+		t.Parallel()
+		sess := seedTestSession()
+		if err := sess.ConvertToCircuit(20 * 60); err != nil {
+			t.Fatalf("ConvertToCircuit: %v", err)
+		}
+		if err := sess.RecordCircuitRounds(3, now); !errors.Is(err, domain.ErrNotStarted) {
+			t.Errorf("RecordCircuitRounds before Start: error = %v, want ErrNotStarted", err)
+		}
+		if err := sess.Start(now); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		var ve domain.ValidationError
+		if err := sess.RecordCircuitRounds(-1, now); !errors.As(err, &ve) {
+			t.Errorf("RecordCircuitRounds(-1): error = %v, want a ValidationError", err)
+		}
+		if _, ok := sess.CircuitRounds(); ok {
+			t.Error("CircuitRounds ok = true before any rounds were logged")
+		}
+		if err := sess.RecordCircuitRounds(6, now); err != nil {
+			t.Fatalf("RecordCircuitRounds: %v", err)
+		}
+		if rounds, ok := sess.CircuitRounds(); !ok || rounds != 6 {
+			t.Errorf("CircuitRounds = (%d, %t), want (6, true)", rounds, ok)
+		}
+		for i, slot := range sess.Slots {
+			if slot.CompletionState() != domain.ExerciseSlotCompleted {
+				t.Errorf("slot %d state = %q, want completed", i, slot.CompletionState())
+			}
+		}
+	})
+
+	t.Run("added and swapped exercises join the circuit", func(t *testing.T) {
+		t.Parallel()
+		sess := seedTestSession()
+		if err := sess.ConvertToCircuit(12 * 60); err != nil {
+			t.Fatalf("ConvertToCircuit: %v", err)
+		}
+		sets := []domain.Set{{TargetValue: 8}, {TargetValue: 8}, {TargetValue: 8}} //nolint:exhaustruct // Targets only.
+		added := domain.Exercise{ID: 99, Name: "Row"}                              //nolint:exhaustruct // ID only.
+		if err := sess.AddExercise(added, sets); err != nil {
+			t.Fatalf("AddExercise: %v", err)
+		}
+		swapped := domain.Exercise{ID: 98, Name: "Lunge"} //nolint:exhaustruct // ID only.
+		if err := sess.SwapExerciseInSlot(0, swapped, sets); err != nil {
+			t.Fatalf("SwapExerciseInSlot: %v", err)
+		}
+		for i, slot := range sess.Slots {
+			if len(slot.Sets) != 1 || slot.Sets[0].DurationSeconds == nil || *slot.Sets[0].DurationSeconds != 12*60 {
+				t.Errorf("slot %d sets = %+v, want one 720 s round", i, slot.Sets)
+			}
+		}
+		if got := sess.Slots[len(sess.Slots)-1].Sets[0].TargetValue; got != 8 {
+			t.Errorf("added round target = %d, want 8", got)
+		}
+	})
+
+	t.Run("refusals", func(t *testing.T) {
+		t.Parallel()
+		sess := seedTestSession()
+		var ve domain.ValidationError
+		if err := sess.ConvertToCircuit(domain.MaxCircuitSeconds + 1); !errors.As(err, &ve) {
+			t.Errorf("ConvertToCircuit(too long): error = %v, want a ValidationError", err)
+		}
+		if err := sess.Start(now); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		if err := sess.RecordCircuitRounds(2, now); !errors.Is(err, domain.ErrNotCircuit) {
+			t.Errorf("RecordCircuitRounds on sets: error = %v, want ErrNotCircuit", err)
+		}
+		if err := sess.ConvertToCircuit(20 * 60); !errors.Is(err, domain.ErrAlreadyStarted) {
+			t.Errorf("ConvertToCircuit after Start: error = %v, want ErrAlreadyStarted", err)
+		}
+		rest := domain.Session{Date: now} //nolint:exhaustruct // A rest day has no slots.
+		if err := rest.ConvertToCircuit(20 * 60); !errors.As(err, &ve) {
+			t.Errorf("ConvertToCircuit on a rest day: error = %v, want a ValidationError", err)
+		}
+	})
+}
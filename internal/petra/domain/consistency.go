@@ -0,0 +1,129 @@
+package domain
+
+import (
+	"math"
+	"slices"
+	"time"
+)
+
+// CompletedWeekStreak counts the consecutive weeks, walking back from the week
+// containing today, in which at least one of sessions was completed. The
+// current week only extends the streak: a week still in progress without a
+// completed workout yet does not break it, so counting starts from last week
+// in that case. sessions may be in any order.
+func CompletedWeekStreak(sessions []Session, today time.Time) int {
+	completedWeeks := make(map[time.Time]bool)
+	for _, s := range sessions {
+		if s.Status() == SessionCompleted {
+			completedWeeks[MondayOf(s.Date)] = true
+		}
+	}
+	monday := MondayOf(today)
+	if !completedWeeks[monday] {
+		monday = monday.AddDate(0, 0, -7)
+	}
+	streak := 0
+	for completedWeeks[monday] {
+		streak++
+		monday = monday.AddDate(0, 0, -7)
+	}
+	return streak
+}
+
+// SessionConsistency counts the workouts planned on dates in [from, to) and
+// how many of them were completed. Rest days (sessions without slots) are not
+// planned workouts and are skipped.
+func SessionConsistency(sessions []Session, from, to time.Time) (completed, planned int) {
+	for _, s := range sessions {
+		if len(s.Slots) == 0 || s.Date.Before(from) || !s.Date.Before(to) {
+			continue
+		}
+		planned++
+		if s.Status() == SessionCompleted {
+			completed++
+		}
+	}
+	return completed, planned
+}
+
+// NextWorkout returns the earliest session on or after today that has
+// exercises and is not yet completed, including one already in progress
+// today. ok is false when nothing upcoming is planned.
+func NextWorkout(sessions []Session, today time.Time) (Session, bool) {
+	var (
+		next  Session
+		found bool
+	)
+	for _, s := range sessions {
+		if len(s.Slots) == 0 || s.Date.Before(today) || s.Status() == SessionCompleted {
+			continue
+		}
+		if !found || s.Date.Before(next.Date) {
+			next, found = s, true
+		}
+	}
+	return next, found
+}
+
+// Training-gap constants. A gap is a lapse once it runs well past the user's
+// own cadence, so someone who trains every ten days on purpose is not nagged
+// after a week.
+const (
+	// DefaultTrainingGapDays is the shortest gap ever flagged, and the one
+	// used until the user has an interval of their own.
+	DefaultTrainingGapDays = 7
+	// trainingGapCadenceMultiple is how many typical intervals a gap must
+	// exceed to be flagged.
+	trainingGapCadenceMultiple = 1.5
+	// trainingGapIntervals is how many of the latest intervals make up the
+	// typical one, so a change of routine shows through within weeks.
+	trainingGapIntervals = 6
+)
+
+// TrainingGap describes the time since the user's last completed workout
+// against their usual cadence.
+type TrainingGap struct {
+	LastWorkout   time.Time
+	DaysSinceLast int
+	// TypicalIntervalDays is the mean number of days between the latest
+	// completed workouts, or 0 with fewer than two of them.
+	TypicalIntervalDays float64
+	// ThresholdDays is the gap beyond which Overdue is set.
+	ThresholdDays int
+	Overdue       bool
+}
+
+// TrainingGapFor measures the gap from the last workout completed before
+// today to today. The threshold is trainingGapCadenceMultiple times the
+// typical interval, but never under DefaultTrainingGapDays. ok is false when
+// no workout was completed before today. sessions may be in any order.
+func TrainingGapFor(sessions []Session, today time.Time) (gap TrainingGap, ok bool) {
+	var dates []time.Time
+	for _, s := range sessions {
+		if s.Status() == SessionCompleted && s.Date.Before(today) {
+			dates = append(dates, s.Date)
+		}
+	}
+	if len(dates) == 0 {
+		return TrainingGap{}, false
+	}
+	slices.SortFunc(dates, time.Time.Compare)
+	dates = slices.CompactFunc(dates, time.Time.Equal)
+
+	last := dates[len(dates)-1]
+	gap = TrainingGap{
+		LastWorkout:         last,
+		DaysSinceLast:       int(today.Sub(last).Hours() / hoursPerDay),
+		TypicalIntervalDays: 0,
+		ThresholdDays:       DefaultTrainingGapDays,
+		Overdue:             false,
+	}
+	if intervals := min(len(dates)-1, trainingGapIntervals); intervals > 0 {
+		first := dates[len(dates)-1-intervals]
+		gap.TypicalIntervalDays = last.Sub(first).Hours() / hoursPerDay / float64(intervals)
+		gap.ThresholdDays = max(DefaultTrainingGapDays,
+			int(math.Ceil(gap.TypicalIntervalDays*trainingGapCadenceMultiple)))
+	}
+	gap.Overdue = gap.DaysSinceLast > gap.ThresholdDays
+	return gap, true
+}
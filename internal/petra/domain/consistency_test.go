@@ -0,0 +1,127 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_CompletedWeekStreak(t *testing.T) {
+	t.Parallel()
+
+	// Wednesday 2026-05-13; its week starts Monday 2026-05-11.
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	done := func(date time.Time) domain.Session {
+		return domain.Session{Date: date, CompletedAt: date.Add(time.Hour)} //nolint:exhaustruct // Only status matters.
+	}
+	planned := func(date time.Time) domain.Session {
+		return domain.Session{Date: date} //nolint:exhaustruct // Only status matters.
+	}
+	weeksAgo := func(n int) time.Time { return today.AddDate(0, 0, -7*n) }
+
+	tests := []struct {
+		name     string
+		sessions []domain.Session
+		want     int
+	}{
+		{"no sessions", nil, 0},
+		{"current week in progress does not break the streak",
+			[]domain.Session{planned(today), done(weeksAgo(1)), done(weeksAgo(2))}, 2},
+		{"current week counts once completed",
+			[]domain.Session{done(today), done(weeksAgo(1))}, 2},
+		{"a week without a completed workout ends the streak",
+			[]domain.Session{done(weeksAgo(1)), planned(weeksAgo(2)), done(weeksAgo(3))}, 1},
+		{"two workouts in one week count once",
+			[]domain.Session{done(weeksAgo(1)), done(weeksAgo(1).AddDate(0, 0, 2))}, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := domain.CompletedWeekStreak(tt.sessions, today); got != tt.want {
+				t.Errorf("CompletedWeekStreak = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_SessionConsistency_And_NextWorkout(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	slots := []domain.ExerciseSlot{{}} //nolint:exhaustruct // Any slot makes a planned workout.
+	sess := func(offset int, completed bool) domain.Session {
+		s := domain.Session{Date: today.AddDate(0, 0, offset), Slots: slots} //nolint:exhaustruct // Status and slots only.
+		if completed {
+			s.CompletedAt = s.Date.Add(time.Hour)
+		}
+		return s
+	}
+	rest := domain.Session{Date: today.AddDate(0, 0, -1)} //nolint:exhaustruct // A rest day has no slots.
+	sessions := []domain.Session{
+		sess(5, false), sess(2, false), sess(0, true), rest,
+		sess(-3, true), sess(-5, false), sess(-30, true),
+	}
+
+	completed, planned := domain.SessionConsistency(sessions, today.AddDate(0, 0, -28), today)
+	if completed != 1 || planned != 2 {
+		t.Errorf("SessionConsistency = %d/%d, want 1/2", completed, planned)
+	}
+
+	next, ok := domain.NextWorkout(sessions, today)
+	if !ok || !next.Date.Equal(today.AddDate(0, 0, 2)) {
+		t.Errorf("NextWorkout = (%s, %t), want the uncompleted session in two days", next.Date, ok)
+	}
+	if _, ok = domain.NextWorkout(sessions[2:], today); ok {
+		t.Error("NextWorkout ok = true with only completed and past sessions")
+	}
+}
+
+func Test_TrainingGapFor(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	slots := []domain.ExerciseSlot{{}} //nolint:exhaustruct // Any slot makes a planned workout.
+	// completedEvery returns count workouts completed days apart, the latest
+	// lastAgo days before today.
+	completedEvery := func(days, count, lastAgo int) []domain.Session {
+		sessions := make([]domain.Session, 0, count)
+		for i := range count {
+			date := today.AddDate(0, 0, -lastAgo-i*days)
+			sessions = append(sessions, domain.Session{ //nolint:exhaustruct // Status and slots only.
+				Date: date, Slots: slots, CompletedAt: date.Add(time.Hour),
+			})
+		}
+		return sessions
+	}
+
+	tests := []struct {
+		name          string
+		sessions      []domain.Session
+		wantDays      int
+		wantThreshold int
+		wantOverdue   bool
+	}{
+		{"10-day cadence, due today", completedEvery(10, 5, 10), 10, 15, false},
+		{"10-day cadence, well overdue", completedEvery(10, 5, 16), 16, 15, true},
+		{"3-day cadence keeps the 7-day floor", completedEvery(3, 5, 8), 8, 7, true},
+		{"single workout uses the default", completedEvery(1, 1, 6), 6, 7, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			gap, ok := domain.TrainingGapFor(tt.sessions, today)
+			if !ok {
+				t.Fatal("TrainingGapFor ok = false, want true")
+			}
+			if gap.DaysSinceLast != tt.wantDays || gap.ThresholdDays != tt.wantThreshold || gap.Overdue != tt.wantOverdue {
+				t.Errorf("gap = %d days, threshold %d, overdue %t; want %d, %d, %t",
+					gap.DaysSinceLast, gap.ThresholdDays, gap.Overdue, tt.wantDays, tt.wantThreshold, tt.wantOverdue)
+			}
+		})
+	}
+
+	if _, ok := domain.TrainingGapFor(nil, today); ok {
+		t.Error("TrainingGapFor(nil) ok = true, want false")
+	}
+}
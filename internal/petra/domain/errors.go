@@ -17,6 +17,10 @@ var ErrNotFound = errors.New("not found")
 // "already there" code path (idempotent retry, lazy-create race recovery).
 var ErrAlreadyExists = errors.New("already exists")
 
+// ErrFeatureDisabled is returned by the service when a caller asks for a
+// feature the deployment has switched off.
+var ErrFeatureDisabled = errors.New("feature disabled")
+
 // Aggregate-method sentinels. Each is returned by a Session method when an
 // invariant is violated; callers use errors.Is to branch.
 var (
@@ -26,6 +30,7 @@ var (
 	ErrSetIndexOutOfBounds      = errors.New("set index out of bounds")
 	ErrExerciseAlreadyInSession = errors.New("exercise already in session")
 	ErrInvalidDifficultyRating  = errors.New("difficulty rating must be 1-5")
+	ErrNotCircuit               = errors.New("session is not a circuit")
 )
 
 // ValidationError is a domain validation failure carrying a message that is
@@ -9,6 +9,7 @@ package domain
 import (
 	"fmt"
 	"math"
+	"slices"
 	"strconv"
 )
 
@@ -22,6 +23,11 @@ const (
 	CategoryLower    Category = "lower"
 )
 
+// Categories returns every defined Category in display order.
+func Categories() []Category {
+	return []Category{CategoryFullBody, CategoryUpper, CategoryLower}
+}
+
 // IsValid reports whether c is one of the defined Category values.
 func (c Category) IsValid() bool {
 	switch c {
@@ -112,6 +118,11 @@ func formatTimedSet(set Set) string {
 // LoadModel reports how this exercise's sets are loaded and measured.
 func (e Exercise) LoadModel() LoadModel { return e.behavior().load }
 
+// ExerciseTypes returns every defined ExerciseType in display order.
+func ExerciseTypes() []ExerciseType {
+	return []ExerciseType{ExerciseTypeWeighted, ExerciseTypeBodyweight, ExerciseTypeAssisted, ExerciseTypeTime}
+}
+
 // IsValid reports whether et is one of the defined ExerciseType values.
 func (et ExerciseType) IsValid() bool {
 	_, ok := exerciseBehaviors[et]
@@ -144,8 +155,29 @@ type Exercise struct {
 	DefaultStartingSeconds *int         `json:"default_starting_seconds,omitempty"`
 	RepMin                 *int         `json:"rep_min,omitempty"`
 	RepMax                 *int         `json:"rep_max,omitempty"`
+	// DefaultStartWeightKg seeds the first-ever session of a weighted
+	// exercise so a newcomer doesn't start from an empty 0 kg. Nil means no
+	// seed is configured and the 0 kg fallback applies. Ignored once the
+	// user has any recorded weight for the exercise.
+	DefaultStartWeightKg *float64 `json:"default_start_weight_kg,omitempty"`
+	// MinDaysBetween is the fewest days the planner leaves between two
+	// sessions with this exercise, for lifts like heavy deadlifts that need
+	// more spacing than muscle-group recovery gives. 0 means no spacing
+	// beyond the planner's one-use-per-week rule.
+	MinDaysBetween int `json:"min_days_between,omitempty"`
+	// Complexity rates how much technique the movement demands, from
+	// MinComplexity (a machine press) to MaxComplexity (a Turkish get-up).
+	// The planner steers beginners away from complex movements; 0 means
+	// unrated and is never held against the exercise.
+	Complexity int `json:"complexity,omitempty"`
 }
 
+const (
+	// MinComplexity and MaxComplexity bound Exercise.Complexity when rated.
+	MinComplexity = 1
+	MaxComplexity = 5
+)
+
 // IsTimed returns true if this exercise uses duration targets instead of rep counts.
 func (e Exercise) IsTimed() bool { return e.behavior().load == LoadTimed }
 
@@ -156,6 +188,33 @@ func (e Exercise) IsTimed() bool { return e.behavior().load == LoadTimed }
 // ExerciseType is added.
 func (e Exercise) HasWeight() bool { return e.behavior().load == LoadWeighted }
 
+// IsCompound reports whether the exercise is a compound movement, working two
+// or more primary muscle groups. Validate requires primary muscle groups, but
+// a row that predates the check or was edited by hand can lack them; such an
+// exercise is classed by its category instead, where only full body implies
+// several muscle groups, so a compound lift is not silently demoted to
+// isolation. Callers with a logger should flag the gap; see
+// MissingMuscleGroups.
+func (e Exercise) IsCompound() bool {
+	if e.MissingMuscleGroups() {
+		return e.Category == CategoryFullBody
+	}
+	return len(e.PrimaryMuscleGroups) > 1
+}
+
+// MissingMuscleGroups reports whether the exercise lacks the primary muscle
+// groups Validate requires, a data gap that leaves volume scoring blind to it.
+func (e Exercise) MissingMuscleGroups() bool { return len(e.PrimaryMuscleGroups) == 0 }
+
+// StartWeightKg returns the weight a user with no history for this exercise
+// starts at: DefaultStartWeightKg when configured, otherwise 0.
+func (e Exercise) StartWeightKg() float64 {
+	if e.DefaultStartWeightKg == nil {
+		return 0
+	}
+	return *e.DefaultStartWeightKg
+}
+
 // FormatSetValue returns the user-visible string for a set's target or
 // completed value. Reps render as "%d"; seconds render as "%ds". The unit
 // choice is driven by ExerciseType — display layers must call this rather
@@ -229,8 +288,9 @@ func (e Exercise) FormatSetDescription(set Set) string {
 // struct-shaping guarantees it. Field keys MUST match the form input names.
 func (e Exercise) Validate() error {
 	const (
-		repBoundMin = 1
-		repBoundMax = 50
+		repBoundMin       = 1
+		repBoundMax       = 50
+		minDaysBetweenMax = 14
 	)
 	var fe FieldErrors
 
@@ -250,6 +310,20 @@ func (e Exercise) Validate() error {
 	if len(e.PrimaryMuscleGroups) == 0 {
 		fe.Add("primary_muscles", "At least one primary muscle group is required.")
 	}
+	if slices.ContainsFunc(e.SecondaryMuscleGroups, func(mg string) bool {
+		return slices.Contains(e.PrimaryMuscleGroups, mg)
+	}) {
+		fe.Add("secondary_muscles", "A muscle group can't be both primary and secondary.")
+	}
+	if e.DefaultStartWeightKg != nil && *e.DefaultStartWeightKg < 0 {
+		fe.Add("default_start_weight_kg", "Default start weight must be 0 kg or more, or blank when unset.")
+	}
+	if e.MinDaysBetween < 0 || e.MinDaysBetween > minDaysBetweenMax {
+		fe.Add("min_days_between", "Minimum days between sessions must be a whole number between 0 and 14.")
+	}
+	if e.Complexity != 0 && (e.Complexity < MinComplexity || e.Complexity > MaxComplexity) {
+		fe.Add("complexity", "Complexity must be a whole number between 1 and 5, or blank when unrated.")
+	}
 	for _, res := range e.Resources {
 		if res.Title == "" || res.URL == "" {
 			fe.Add("resources", "Each resource needs both a title and a URL.")
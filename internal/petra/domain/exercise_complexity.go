@@ -0,0 +1,26 @@
+package domain
+
+const (
+	// BeginnerComfortableComplexity is the highest Exercise.Complexity the
+	// planner gives a beginner without holding it back.
+	BeginnerComfortableComplexity = 2
+
+	// BeginnerComplexityPenalty is the score the planner takes off a
+	// candidate for a beginner per complexity point above
+	// BeginnerComfortableComplexity. A Turkish get-up at 5 loses nine, enough
+	// that a simpler movement for the same muscles wins, yet a muscle far
+	// under its weekly target can still pull in the only exercise that works
+	// it. A multiple of 0.5 so scores stay exact (see pickBestExerciseIdx).
+	BeginnerComplexityPenalty = 3.0
+)
+
+// complexityPenalty returns how much to take off ex's score for a user who
+// is a beginner: BeginnerComplexityPenalty for each complexity point above
+// BeginnerComfortableComplexity, and nothing for other users or unrated
+// exercises.
+func complexityPenalty(ex Exercise, beginner bool) float64 {
+	if !beginner || ex.Complexity <= BeginnerComfortableComplexity {
+		return 0
+	}
+	return float64(ex.Complexity-BeginnerComfortableComplexity) * BeginnerComplexityPenalty
+}
@@ -0,0 +1,45 @@
+package domain
+
+import "time"
+
+// performedTooRecently reports whether ex was last performed fewer than its
+// MinDaysBetween days before date, so planning it on date would crowd it.
+func (wp *Planner) performedTooRecently(ex Exercise, date time.Time) bool {
+	if ex.MinDaysBetween <= 0 {
+		return false
+	}
+	last, ok := wp.LastPerformed[ex.ID]
+	return ok && date.Before(last.AddDate(0, 0, ex.MinDaysBetween))
+}
+
+// MaxMinDaysBetween returns the largest MinDaysBetween across exercises: how
+// far back LastPerformedDates needs history for the spacing rule to hold.
+func MaxMinDaysBetween(exercises []Exercise) int {
+	longest := 0
+	for _, ex := range exercises {
+		longest = max(longest, ex.MinDaysBetween)
+	}
+	return longest
+}
+
+// LastPerformedDates maps each exercise ID to the latest session date before
+// before on which at least one of its sets was completed. Planned-only slots
+// do not count. Use it to fill Planner.LastPerformed; sessions may be in any
+// order.
+func LastPerformedDates(sessions []Session, before time.Time) map[int]time.Time {
+	last := make(map[int]time.Time)
+	for _, s := range sessions {
+		if !s.Date.Before(before) {
+			continue
+		}
+		for _, slot := range s.Slots {
+			if slot.CompletedSetCount() == 0 {
+				continue
+			}
+			if prev, ok := last[slot.Exercise.ID]; !ok || s.Date.After(prev) {
+				last[slot.Exercise.ID] = s.Date
+			}
+		}
+	}
+	return last
+}
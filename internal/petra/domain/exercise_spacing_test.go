@@ -0,0 +1,45 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_LastPerformedDates(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	at := today.Add(-time.Hour)
+	slot := func(exerciseID int, completed bool) domain.ExerciseSlot {
+		set := domain.Set{} //nolint:exhaustruct // Only completion matters.
+		if completed {
+			set.CompletedAt = &at
+		}
+		return domain.ExerciseSlot{ //nolint:exhaustruct // Exercise ID and sets only.
+			Exercise: domain.Exercise{ID: exerciseID}, //nolint:exhaustruct // ID only.
+			Sets:     []domain.Set{set},
+		}
+	}
+	session := func(offset int, slots ...domain.ExerciseSlot) domain.Session {
+		return domain.Session{Date: today.AddDate(0, 0, offset), Slots: slots} //nolint:exhaustruct // Date and slots.
+	}
+
+	got := domain.LastPerformedDates([]domain.Session{
+		session(-5, slot(1, true), slot(2, true)),
+		session(-2, slot(1, true), slot(2, false)),
+		session(-4, slot(1, true)),
+		session(0, slot(3, true)),
+	}, today)
+
+	want := map[int]time.Time{1: today.AddDate(0, 0, -2), 2: today.AddDate(0, 0, -5)}
+	if len(got) != len(want) {
+		t.Fatalf("LastPerformedDates = %v, want %v", got, want)
+	}
+	for id, date := range want {
+		if !got[id].Equal(date) {
+			t.Errorf("exercise %d last performed %v, want %v", id, got[id], date)
+		}
+	}
+}
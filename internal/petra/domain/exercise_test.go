@@ -72,6 +72,42 @@ func Test_Exercise_HasWeight(t *testing.T) {
 	}
 }
 
+func Test_Exercise_IsCompound(t *testing.T) {
+	t.Parallel()
+
+	mkExercise := func(category domain.Category, primary ...string) domain.Exercise {
+		return domain.Exercise{ //nolint:exhaustruct // Only Category and PrimaryMuscleGroups are read.
+			Category:            category,
+			PrimaryMuscleGroups: primary,
+		}
+	}
+
+	cases := []struct {
+		name        string
+		exercise    domain.Exercise
+		want        bool
+		wantMissing bool
+	}{
+		{"two primaries", mkExercise(domain.CategoryLower, "Quads", "Glutes"), true, false},
+		{"one primary", mkExercise(domain.CategoryFullBody, "Biceps"), false, false},
+		{"no primaries, full body", mkExercise(domain.CategoryFullBody), true, true},
+		{"no primaries, upper", mkExercise(domain.CategoryUpper), false, true},
+		{"no primaries, lower", mkExercise(domain.CategoryLower), false, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tc.exercise.IsCompound(); got != tc.want {
+				t.Errorf("IsCompound() = %v, want %v", got, tc.want)
+			}
+			if got := tc.exercise.MissingMuscleGroups(); got != tc.wantMissing {
+				t.Errorf("MissingMuscleGroups() = %v, want %v", got, tc.wantMissing)
+			}
+		})
+	}
+}
+
 func Test_Exercise_LoadModel(t *testing.T) {
 	t.Parallel()
 
@@ -225,11 +261,15 @@ func Test_Exercise_FormatSetDescription(t *testing.T) {
 	completed := func(v int) *int { return &v }
 	mkSet := func(w *float64, c *int) domain.Set {
 		return domain.Set{
-			WeightKg:       w,
-			TargetValue:    0,
-			CompletedValue: c,
-			CompletedAt:    nil,
-			Signal:         nil,
+			WeightKg:         w,
+			TargetValue:      0,
+			CompletedValue:   c,
+			CompletedAt:      nil,
+			Signal:           nil,
+			Side:             nil,
+			DurationSeconds:  nil,
+			TechnicalFailure: false,
+			Warmup:           false,
 		}
 	}
 
@@ -298,9 +338,9 @@ func Test_SetTarget_AbsWeightKg(t *testing.T) {
 		t    domain.SetTarget
 		want float64
 	}{
-		{"positive weight", domain.SetTarget{WeightKg: 50, TargetValue: 0}, 50},
-		{"negative weight (assisted convention)", domain.SetTarget{WeightKg: -10, TargetValue: 0}, 10},
-		{"zero weight", domain.SetTarget{WeightKg: 0, TargetValue: 0}, 0},
+		{"positive weight", domain.SetTarget{WeightKg: 50, TargetValue: 0, Stalled: false}, 50},
+		{"negative weight (assisted convention)", domain.SetTarget{WeightKg: -10, TargetValue: 0, Stalled: false}, 10},
+		{"zero weight", domain.SetTarget{WeightKg: 0, TargetValue: 0, Stalled: false}, 0},
 	}
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -409,6 +449,11 @@ func Test_Exercise_Validate(t *testing.T) {
 			func() domain.Exercise { e := validWeighted(); e.PrimaryMuscleGroups = nil; return e }(),
 			true, "primary_muscles", "At least one primary muscle group is required.",
 		},
+		{
+			"muscle both primary and secondary",
+			func() domain.Exercise { e := validWeighted(); e.SecondaryMuscleGroups = []string{"Chest"}; return e }(),
+			true, "secondary_muscles", "A muscle group can't be both primary and secondary.",
+		},
 		{
 			"missing rep range",
 			func() domain.Exercise { e := validWeighted(); e.RepMin = nil; e.RepMax = nil; return e }(),
@@ -424,6 +469,21 @@ func Test_Exercise_Validate(t *testing.T) {
 			func() domain.Exercise { e := validWeighted(); e.RepMin = intPtr(12); e.RepMax = intPtr(8); return e }(),
 			true, "rep_min", "Min reps must be less than or equal to max reps.",
 		},
+		{
+			"negative default start weight",
+			func() domain.Exercise { e := validWeighted(); e.DefaultStartWeightKg = new(-5.0); return e }(),
+			true, "default_start_weight_kg", "Default start weight must be 0 kg or more, or blank when unset.",
+		},
+		{
+			"min days between out of range",
+			func() domain.Exercise { e := validWeighted(); e.MinDaysBetween = 15; return e }(),
+			true, "min_days_between", "Minimum days between sessions must be a whole number between 0 and 14.",
+		},
+		{
+			"complexity out of range",
+			func() domain.Exercise { e := validWeighted(); e.Complexity = 6; return e }(),
+			true, "complexity", "Complexity must be a whole number between 1 and 5, or blank when unrated.",
+		},
 	}
 
 	for _, tc := range cases {
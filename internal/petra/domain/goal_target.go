@@ -0,0 +1,123 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MaxWeeklyOneRepMaxGain is the steepest estimated-1RM climb PlanToTarget
+// treats as achievable, as a fraction of the current estimate per week.
+// Linear progression on a main lift tops out around 2.5 kg a week at a
+// 100 kg max and only slows from there, so a plan demanding more is flagged
+// as too aggressive rather than promised.
+const MaxWeeklyOneRepMaxGain = 0.025
+
+// maxRepsForOneRepMaxEstimate caps which sets feed EstimatedOneRepMax:
+// Epley drifts badly past a dozen reps, where endurance rather than strength
+// limits the set.
+const maxRepsForOneRepMaxEstimate = 12
+
+// EstimateOneRepMax returns the Epley estimate of the one-rep max behind a
+// set of reps at weight: weight × (1 + reps/30). A single rep is its own max.
+func EstimateOneRepMax(weight float64, reps int) float64 {
+	if reps == 1 {
+		return weight
+	}
+	return weight * (1 + float64(reps)/30)
+}
+
+// epleyRepsTolerance absorbs float error when inverting Epley, so 100 kg × 10
+// estimated at 133.33 kg projects back to 10 reps at 100 kg rather than 9.
+const epleyRepsTolerance = 1e-9
+
+// EstimateRepsAt inverts EstimateOneRepMax: the whole reps a lifter with
+// oneRepMax is expected to manage at weight. Weight at the max is a single;
+// anything heavier projects to zero reps.
+func EstimateRepsAt(oneRepMax, weight float64) int {
+	if weight > oneRepMax {
+		return 0
+	}
+	reps := int(math.Floor(30*(oneRepMax/weight-1) + epleyRepsTolerance))
+	return max(reps, 1)
+}
+
+// WeightProjection is what a proposed weight on an exercise would likely
+// mean against its prescribed rep range, worked out by ProjectWeight.
+type WeightProjection struct {
+	WeightKg      float64
+	EstimatedReps int
+	RepMin        int
+	RepMax        int
+	// Feasible reports whether EstimatedReps reaches RepMin.
+	Feasible bool
+	// Note explains the projection to the user in one sentence.
+	Note string
+}
+
+// ProjectWeight estimates, by reverse Epley from oneRepMax, how many reps the
+// user would get at weightKg and whether that lands in the repMin–repMax
+// range. A weight that falls short of repMin is too heavy for the target
+// reps; one that clears repMax is feasible but light enough to go heavier.
+func ProjectWeight(oneRepMax, weightKg float64, repMin, repMax int) WeightProjection {
+	reps := EstimateRepsAt(oneRepMax, weightKg)
+	var note string
+	switch {
+	case reps < repMin:
+		note = fmt.Sprintf("%.1f kg is too heavy for target reps: expect about %d, short of %d–%d.",
+			weightKg, reps, repMin, repMax)
+	case reps > repMax:
+		note = fmt.Sprintf("%.1f kg should be comfortable: expect about %d reps, past %d–%d; try heavier.",
+			weightKg, reps, repMin, repMax)
+	default:
+		note = fmt.Sprintf("%.1f kg looks realistic: expect about %d reps, within %d–%d.",
+			weightKg, reps, repMin, repMax)
+	}
+	return WeightProjection{
+		WeightKg:      weightKg,
+		EstimatedReps: reps,
+		RepMin:        repMin,
+		RepMax:        repMax,
+		Feasible:      reps >= repMin,
+		Note:          note,
+	}
+}
+
+// EstimatedOneRepMax returns the best Epley estimate across history's
+// completed, positively loaded working sets of at most
+// maxRepsForOneRepMaxEstimate reps. ok is false when no set qualifies.
+func EstimatedOneRepMax(history []ExerciseSetHistory) (oneRepMax float64, ok bool) {
+	for _, h := range history {
+		for _, set := range h.Sets {
+			if !set.IsWorking() || set.CompletedValue == nil || set.WeightKg == nil || *set.WeightKg <= 0 {
+				continue
+			}
+			reps := *set.CompletedValue
+			if reps <= 0 || reps > maxRepsForOneRepMaxEstimate {
+				continue
+			}
+			oneRepMax = math.Max(oneRepMax, EstimateOneRepMax(*set.WeightKg, reps))
+			ok = true
+		}
+	}
+	return oneRepMax, ok
+}
+
+// PlanToTarget returns the weekly estimated-1RM gain needed to go from
+// currentOneRepMax on from to targetKg by byDate, and whether that pace is
+// within MaxWeeklyOneRepMaxGain of the current estimate. A target already
+// reached needs no gain and is feasible. A byDate that is not after from
+// leaves no weeks to train: weeklyIncrement is then the whole gap and the
+// plan is infeasible.
+func PlanToTarget(currentOneRepMax, targetKg float64, from, byDate time.Time) (weeklyIncrement float64, feasible bool) {
+	gap := targetKg - currentOneRepMax
+	if gap <= 0 {
+		return 0, true
+	}
+	weeks := byDate.Sub(from).Hours() / (7 * hoursPerDay)
+	if weeks <= 0 {
+		return gap, false
+	}
+	weeklyIncrement = gap / weeks
+	return weeklyIncrement, weeklyIncrement <= currentOneRepMax*MaxWeeklyOneRepMaxGain
+}
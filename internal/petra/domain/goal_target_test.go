@@ -0,0 +1,116 @@
+package domain_test
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func TestEstimatedOneRepMax(t *testing.T) {
+	t.Parallel()
+
+	set := func(weight float64, reps int) domain.Set {
+		return domain.Set{ //nolint:exhaustruct // Only load and completed reps matter to the estimate.
+			WeightKg: &weight, CompletedValue: &reps,
+		}
+	}
+	backoff := set(100, 3)
+	backoff.Warmup = true // Marked out of analysis: would otherwise be 110.
+	history := []domain.ExerciseSetHistory{
+		{Date: time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC), Sets: []domain.Set{
+			set(80, 5),  // 93.33
+			set(60, 20), // Too many reps to trust.
+			set(-20, 8), // Assistance, not load.
+			set(90, 1),  // 90: a single is its own max.
+			backoff,
+		}},
+	}
+	got, ok := domain.EstimatedOneRepMax(history)
+	if !ok || math.Abs(got-80*(1+5.0/30)) > 1e-9 {
+		t.Errorf("EstimatedOneRepMax = (%v, %t), want (93.33, true)", got, ok)
+	}
+	if _, ok = domain.EstimatedOneRepMax(nil); ok {
+		t.Error("EstimatedOneRepMax(nil) ok = true, want false")
+	}
+}
+
+func TestPlanToTarget(t *testing.T) {
+	t.Parallel()
+
+	from := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name         string
+		current      float64
+		target       float64
+		byDate       time.Time
+		wantWeekly   float64
+		wantFeasible bool
+	}{
+		{"reachable", 90, 100, from.AddDate(0, 0, 7*10), 1, true},
+		{"too aggressive", 90, 120, from.AddDate(0, 0, 7*4), 7.5, false},
+		{"already there", 105, 100, from.AddDate(0, 0, 7), 0, true},
+		{"deadline passed", 90, 100, from, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			weekly, feasible := domain.PlanToTarget(tt.current, tt.target, from, tt.byDate)
+			if math.Abs(weekly-tt.wantWeekly) > 1e-9 || feasible != tt.wantFeasible {
+				t.Errorf("PlanToTarget = (%v, %t), want (%v, %t)", weekly, feasible, tt.wantWeekly, tt.wantFeasible)
+			}
+		})
+	}
+}
+
+func TestEstimateRepsAt(t *testing.T) {
+	t.Parallel()
+
+	oneRepMax := domain.EstimateOneRepMax(100, 10) // 133.33
+	tests := []struct {
+		weight float64
+		want   int
+	}{
+		{100, 10}, // Round-trips the set it was estimated from.
+		{120, 3},
+		{oneRepMax, 1},
+		{140, 0},
+	}
+	for _, tt := range tests {
+		if got := domain.EstimateRepsAt(oneRepMax, tt.weight); got != tt.want {
+			t.Errorf("EstimateRepsAt(%.2f, %v) = %d, want %d", oneRepMax, tt.weight, got, tt.want)
+		}
+	}
+}
+
+func TestProjectWeight(t *testing.T) {
+	t.Parallel()
+
+	oneRepMax := domain.EstimateOneRepMax(100, 10) // 133.33
+	tests := []struct {
+		name         string
+		weight       float64
+		wantReps     int
+		wantFeasible bool
+		wantNote     string
+	}{
+		{"too heavy", 125, 2, false, "too heavy for target reps"},
+		{"within range", 105, 8, true, "looks realistic"},
+		{"light", 90, 14, true, "try heavier"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := domain.ProjectWeight(oneRepMax, tt.weight, 8, 12)
+			if got.EstimatedReps != tt.wantReps || got.Feasible != tt.wantFeasible {
+				t.Errorf("ProjectWeight(%v) = %d reps, feasible %t; want %d, %t",
+					tt.weight, got.EstimatedReps, got.Feasible, tt.wantReps, tt.wantFeasible)
+			}
+			if !strings.Contains(got.Note, tt.wantNote) {
+				t.Errorf("note %q does not contain %q", got.Note, tt.wantNote)
+			}
+		})
+	}
+}
@@ -17,3 +17,23 @@ type ExerciseSetHistory struct {
 	Date time.Time
 	Sets []Set
 }
+
+// HasRecordedWeight reports whether any set in h carries a weight.
+func (h ExerciseSetHistory) HasRecordedWeight() bool {
+	for _, s := range h.Sets {
+		if s.WeightKg != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// HasCompletedSet reports whether any set in h was completed.
+func (h ExerciseSetHistory) HasCompletedSet() bool {
+	for _, s := range h.Sets {
+		if s.CompletedValue != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -89,3 +89,21 @@ func (p Preferences) SetCountFor(date time.Time) int {
 	progress := p.MesocycleRampProgress(date)
 	return baseWeeklySets + int(math.Round(progress*float64(peakWeeklySets-baseWeeklySets)))
 }
+
+// SetCountOn returns the per-exercise working-set count the planner
+// prescribes for a session on date: SetCountFor's mesocycle count, one set
+// lighter on Saturday and Sunday when LighterWeekends is on. The weekend cut
+// is taken after the mesocycle ramp and floored like the deload cut, so a
+// weekend session in a deload week still keeps deloadSetFloor sets.
+func (p Preferences) SetCountOn(date time.Time) int {
+	sets := p.SetCountFor(date)
+	if p.LighterWeekends && isWeekend(date.Weekday()) {
+		return deloadSets(sets)
+	}
+	return sets
+}
+
+// isWeekend reports whether weekday is Saturday or Sunday.
+func isWeekend(weekday time.Weekday) bool {
+	return weekday == time.Saturday || weekday == time.Sunday
+}
@@ -173,3 +173,34 @@ func TestSetCountFor(t *testing.T) {
 		})
 	}
 }
+
+func TestSetCountOn_LighterWeekends(t *testing.T) {
+	t.Parallel()
+
+	anchor := time.Date(2026, time.May, 4, 0, 0, 0, 0, time.UTC) // Monday, week 0.
+	peak := anchor.AddDate(0, 0, 14)                             // Week 2 of 4: peak 4 sets.
+	deload := anchor.AddDate(0, 0, 21)                           // Week 3 of 4: deload.
+
+	tests := []struct {
+		name    string
+		date    time.Time
+		lighter bool
+		want    int
+	}{
+		{"weekday unchanged", peak, true, 4},
+		{"Saturday one set lighter", peak.AddDate(0, 0, 5), true, 3},
+		{"Sunday one set lighter", peak.AddDate(0, 0, 6), true, 3},
+		{"weekend unchanged with the preference off", peak.AddDate(0, 0, 5), false, 4},
+		{"deload-week weekend keeps the floor", deload.AddDate(0, 0, 5), true, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			prefs := mesoPrefs(anchor, 4, true)
+			prefs.LighterWeekends = tt.lighter
+			if got := prefs.SetCountOn(tt.date); got != tt.want {
+				t.Errorf("SetCountOn = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package domain
 
+import (
+	"fmt"
+	"math"
+)
+
 // MuscleGroupTarget stores the weekly hard-set range for a tracked muscle
 // group: MinSets is the floor (≈ MEV, minimum effective volume) the planner
 // drives toward, MaxSets the ceiling (≈ MRV, maximum recoverable volume)
@@ -124,6 +129,23 @@ const (
 	SecondarySetFraction = 0.5
 )
 
+// muscleGroupShares returns the fraction of a set ex credits to each muscle
+// group it works, with every group appearing once. A group listed as both
+// primary and secondary counts as primary, and a repeated name counts once,
+// so an inconsistent exercise record can't inflate a muscle's volume. Every
+// volume tally in the package (planner scoring, the weekly balance, the MRV
+// cap) credits through this.
+func muscleGroupShares(ex Exercise) map[string]float64 {
+	shares := make(map[string]float64, len(ex.PrimaryMuscleGroups)+len(ex.SecondaryMuscleGroups))
+	for _, mg := range ex.SecondaryMuscleGroups {
+		shares[mg] = SecondarySetFraction
+	}
+	for _, mg := range ex.PrimaryMuscleGroups {
+		shares[mg] = PrimarySetFraction
+	}
+	return shares
+}
+
 // WeeklyMuscleGroupVolume aggregates planned-vs-completed weekly volume per
 // muscle group across the supplied sessions. One entry is returned for
 // every muscle group in groupNames, sorted to match groupNames' order.
@@ -165,21 +187,15 @@ func WeeklyMuscleGroupVolume(
 // WeeklyPlannedVolume returns the running planned volume per
 // muscle group across the supplied sessions. Each set in the plan
 // contributes PrimarySetFraction to every primary muscle group on its
-// exercise and SecondarySetFraction to every secondary. Muscle groups
-// with zero contributions do not appear in the map. The result is the
-// running tally the target-aware planner uses to score subsequent
-// picks against the configured weekly targets.
+// exercise and SecondarySetFraction to every secondary (see
+// muscleGroupShares). Muscle groups with zero contributions do not appear
+// in the map. The result is the running tally the target-aware planner
+// uses to score subsequent picks against the configured weekly targets.
 func WeeklyPlannedVolume(sessions []Session) map[string]float64 {
 	volume := make(map[string]float64)
 	for _, sess := range sessions {
 		for _, ex := range sess.Slots {
-			n := float64(len(ex.Sets))
-			for _, mg := range ex.Exercise.PrimaryMuscleGroups {
-				volume[mg] += n * PrimarySetFraction
-			}
-			for _, mg := range ex.Exercise.SecondaryMuscleGroups {
-				volume[mg] += n * SecondarySetFraction
-			}
+			applyVolume(volume, ex.Exercise, float64(len(ex.Sets)))
 		}
 	}
 	return volume
@@ -187,8 +203,8 @@ func WeeklyPlannedVolume(sessions []Session) map[string]float64 {
 
 // aggregateMuscleGroupVolume walks every set in the supplied sessions and totals the
 // volume for each muscle group, accumulating into the planned and completed
-// maps. Primary contributions count as PrimarySetFraction, secondary as
-// SecondarySetFraction. Muscle group names not present in known are silently skipped
+// maps. Each set credits every muscle group once, at its muscleGroupShares
+// fraction. Muscle group names not present in known are silently skipped
 // — they cannot occur in production due to FK constraints, but the guard keeps
 // tests safe when synthetic exercises reference unknown groups.
 func aggregateMuscleGroupVolume(
@@ -198,38 +214,97 @@ func aggregateMuscleGroupVolume(
 ) {
 	for _, sess := range sessions {
 		for _, ex := range sess.Slots {
+			shares := muscleGroupShares(ex.Exercise)
 			for _, set := range ex.Sets {
-				done := set.CompletedAt != nil
-				creditMuscleGroups(ex.Exercise.PrimaryMuscleGroups, PrimarySetFraction, done, known, planned, completed)
-				creditMuscleGroups(
-					ex.Exercise.SecondaryMuscleGroups,
-					SecondarySetFraction,
-					done,
-					known,
-					planned,
-					completed,
-				)
+				for mg, fraction := range shares {
+					if _, ok := known[mg]; !ok {
+						continue
+					}
+					planned[mg] += fraction
+					if set.CompletedAt != nil {
+						completed[mg] += fraction
+					}
+				}
 			}
 		}
 	}
 }
 
-// creditMuscleGroups credits volume to each muscle group in names, both to planned
-// and (when done) to completed. Groups missing from known are ignored.
-func creditMuscleGroups(
-	names []string,
-	fraction float64,
-	done bool,
-	known map[string]struct{},
-	planned, completed map[string]float64,
-) {
-	for _, mg := range names {
-		if _, ok := known[mg]; !ok {
+// MuscleBalanceScore is one spoke of the muscle-balance radar: a muscle
+// group's completed volume scaled against the most-trained group's, so Score
+// lies in [0,1].
+type MuscleBalanceScore struct {
+	Name            string
+	CompletedVolume float64
+	Score           float64
+	// CatalogGap is set when no catalog exercise trains the group as a
+	// primary mover, so the user cannot raise a low score by adding one.
+	// MuscleBalanceScores leaves it false; see MarkCatalogGaps.
+	CatalogGap bool
+}
+
+// Note returns the insight to show beside the score, or "" when there is
+// none. A catalog gap gets its own note rather than advice to add exercises,
+// since there is no exercise to add.
+func (s MuscleBalanceScore) Note() string {
+	if s.CatalogGap {
+		return fmt.Sprintf("No exercise in the catalog trains %s as a primary muscle yet, "+
+			"so there is nothing to add for it; the catalog needs one first.", s.Name)
+	}
+	return ""
+}
+
+// MarkCatalogGaps sets CatalogGap on every score whose muscle group none of
+// exercises lists as a primary muscle group.
+func MarkCatalogGaps(scores []MuscleBalanceScore, exercises []Exercise) {
+	covered := make(map[string]bool)
+	for _, ex := range exercises {
+		for _, mg := range ex.PrimaryMuscleGroups {
+			covered[mg] = true
+		}
+	}
+	for i := range scores {
+		scores[i].CatalogGap = !covered[scores[i].Name]
+	}
+}
+
+// MuscleBalanceScores normalises each entry's CompletedVolume against the
+// largest in volumes, keeping volumes' order. Every entry is kept, so an
+// untrained muscle group scores 0; when nothing was completed at all every
+// score is 0.
+func MuscleBalanceScores(volumes []MuscleGroupVolume) []MuscleBalanceScore {
+	var peak float64
+	for _, v := range volumes {
+		peak = math.Max(peak, v.CompletedVolume)
+	}
+	scores := make([]MuscleBalanceScore, 0, len(volumes))
+	for _, v := range volumes {
+		score := MuscleBalanceScore{Name: v.Name, CompletedVolume: v.CompletedVolume, Score: 0, CatalogGap: false}
+		if peak > 0 {
+			score.Score = v.CompletedVolume / peak
+		}
+		scores = append(scores, score)
+	}
+	return scores
+}
+
+// MuscleBalanceExtremes picks the most- and least-trained muscle groups out of
+// scores. Ties go to the name that sorts first, so the choice does not depend
+// on the order of scores. Groups with a CatalogGap are never the least
+// trained, since nothing can be done about them. ok is false when scores has
+// no group to compare.
+func MuscleBalanceExtremes(scores []MuscleBalanceScore) (most, least MuscleBalanceScore, ok bool) {
+	var haveLeast bool
+	for _, s := range scores {
+		if !ok || s.Score > most.Score || (s.Score == most.Score && s.Name < most.Name) {
+			most, ok = s, true
+		}
+		if s.CatalogGap {
 			continue
 		}
-		planned[mg] += fraction
-		if done {
-			completed[mg] += fraction
+		if !haveLeast || s.Score < least.Score || (s.Score == least.Score && s.Name < least.Name) {
+			least, haveLeast = s, true
 		}
 	}
+	return most, least, ok && haveLeast
 }
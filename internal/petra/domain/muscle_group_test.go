@@ -1,6 +1,8 @@
 package domain_test
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -106,6 +108,47 @@ func TestWeeklyPlannedVolume(t *testing.T) {
 	}
 }
 
+// Test_WeeklyMuscleGroupVolume_NoDoubleCounting guards the duplicate-prone
+// shapes an exercise record can take: a muscle listed as both primary and
+// secondary, or the same name twice. Each set still credits each muscle once.
+func Test_WeeklyMuscleGroupVolume_NoDoubleCounting(t *testing.T) {
+	t.Parallel()
+
+	messy := domain.Exercise{ //nolint:exhaustruct // test fixture only needs these fields
+		ID:                    1,
+		Name:                  "Dip",
+		PrimaryMuscleGroups:   []string{"Chest", "Triceps", "Chest"},
+		SecondaryMuscleGroups: []string{"Triceps", "Shoulders", "Shoulders"},
+	}
+	sess := domain.Session{ //nolint:exhaustruct // test fixture only needs these fields
+		Date: time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC),
+		Slots: []domain.ExerciseSlot{
+			{ //nolint:exhaustruct // WarmupCompletedAt unused in this test.
+				Exercise: messy,
+				Sets:     make([]domain.Set, 3),
+			},
+		},
+	}
+
+	want := map[string]float64{
+		"Chest":     3 * domain.PrimarySetFraction,
+		"Triceps":   3 * domain.PrimarySetFraction, // Primary wins over the secondary listing.
+		"Shoulders": 3 * domain.SecondarySetFraction,
+	}
+	names := []string{"Chest", "Triceps", "Shoulders"}
+	for _, v := range domain.WeeklyMuscleGroupVolume([]domain.Session{sess}, nil, names) {
+		if v.PlannedVolume != want[v.Name] {
+			t.Errorf("WeeklyMuscleGroupVolume %s = %v, want %v", v.Name, v.PlannedVolume, want[v.Name])
+		}
+	}
+	got := domain.WeeklyPlannedVolume([]domain.Session{sess})
+	for mg, w := range want {
+		if got[mg] != w {
+			t.Errorf("WeeklyPlannedVolume[%q] = %v, want %v", mg, got[mg], w)
+		}
+	}
+}
+
 func Test_RegionFor_DeltHeads(t *testing.T) {
 	t.Parallel()
 
@@ -168,3 +211,77 @@ func Test_MuscleGroupVolume_Status(t *testing.T) {
 		}
 	}
 }
+
+func Test_MuscleBalanceScores(t *testing.T) {
+	t.Parallel()
+
+	volumes := []domain.MuscleGroupVolume{
+		{Name: "Chest", CompletedVolume: 8},  //nolint:exhaustruct // Only completed volume is scored.
+		{Name: "Quads", CompletedVolume: 4},  //nolint:exhaustruct // Only completed volume is scored.
+		{Name: "Calves", CompletedVolume: 0}, //nolint:exhaustruct // Only completed volume is scored.
+	}
+	got := domain.MuscleBalanceScores(volumes)
+	want := []float64{1, 0.5, 0}
+	if len(got) != len(want) {
+		t.Fatalf("got %d scores, want %d", len(got), len(want))
+	}
+	for i, s := range got {
+		if s.Name != volumes[i].Name || s.Score != want[i] {
+			t.Errorf("score %d = %s %v, want %s %v", i, s.Name, s.Score, volumes[i].Name, want[i])
+		}
+	}
+
+	for _, s := range domain.MuscleBalanceScores([]domain.MuscleGroupVolume{{Name: "Chest"}}) { //nolint:exhaustruct // Untrained.
+		if s.Score != 0 {
+			t.Errorf("untrained %s score = %v, want 0", s.Name, s.Score)
+		}
+	}
+}
+
+func Test_MarkCatalogGaps_MissingGlutes(t *testing.T) {
+	t.Parallel()
+
+	catalog := []domain.Exercise{
+		{Name: "Squat", PrimaryMuscleGroups: []string{"Quads"}}, //nolint:exhaustruct // Only primaries are read.
+		{Name: "Bench", PrimaryMuscleGroups: []string{"Chest"}}, //nolint:exhaustruct // Only primaries are read.
+	}
+	scores := domain.MuscleBalanceScores([]domain.MuscleGroupVolume{
+		{Name: "Quads", CompletedVolume: 6}, //nolint:exhaustruct // Only completed volume is scored.
+		{Name: "Glutes"},                    //nolint:exhaustruct // Untrained.
+	})
+	domain.MarkCatalogGaps(scores, catalog)
+
+	if scores[0].CatalogGap || scores[0].Note() != "" {
+		t.Errorf("Quads: CatalogGap = %v, Note = %q, want no gap", scores[0].CatalogGap, scores[0].Note())
+	}
+	if !scores[1].CatalogGap {
+		t.Fatal("Glutes: CatalogGap = false, want true")
+	}
+	if note := scores[1].Note(); !strings.Contains(note, "catalog") || !strings.Contains(note, "Glutes") {
+		t.Errorf("Glutes note = %q, want a catalog gap insight naming Glutes", note)
+	}
+}
+
+func Test_MuscleBalanceExtremes_Ties(t *testing.T) {
+	t.Parallel()
+
+	scores := []domain.MuscleBalanceScore{
+		{Name: "Triceps", Score: 1},                     //nolint:exhaustruct // Name and score only.
+		{Name: "Chest", Score: 1},                       //nolint:exhaustruct // Name and score only.
+		{Name: "Calves", Score: 0.2},                    //nolint:exhaustruct // Name and score only.
+		{Name: "Abs", Score: 0.2},                       //nolint:exhaustruct // Name and score only.
+		{Name: "Adductors", Score: 0, CatalogGap: true}, //nolint:exhaustruct // Untrainable, never least.
+	}
+	// Every order of the same scores must pick the same groups.
+	for i := range scores {
+		rotated := append(slices.Clone(scores[i:]), scores[:i]...)
+		most, least, ok := domain.MuscleBalanceExtremes(rotated)
+		if !ok || most.Name != "Chest" || least.Name != "Abs" {
+			t.Errorf("rotation %d: extremes = (%s, %s, %t), want (Chest, Abs, true)", i, most.Name, least.Name, ok)
+		}
+	}
+
+	if _, _, ok := domain.MuscleBalanceExtremes(nil); ok {
+		t.Error("MuscleBalanceExtremes(nil) ok = true, want false")
+	}
+}
@@ -0,0 +1,84 @@
+package domain
+
+import "time"
+
+const (
+	// DefaultMuscleGroupRecencyPenalty is the score NewPlanner subtracts from
+	// a candidate for each of its primary muscle groups trained in the
+	// previous session: worth a little more than one working set below a
+	// muscle's floor, so it steers picks between comparable candidates without
+	// starving a muscle that is well under its weekly target. A multiple of
+	// 0.5 so scores stay exact (see pickBestExerciseIdx).
+	DefaultMuscleGroupRecencyPenalty = 4.0
+
+	// MuscleGroupRecencyLookbackDays is how far back history has to reach for
+	// MuscleGroupsLastTrainedDates to find the previous session. A session
+	// further back than this is long enough ago that its muscles have
+	// recovered.
+	MuscleGroupRecencyLookbackDays = 7
+)
+
+// MuscleGroupsLastTrainedDates maps each primary muscle group to the latest
+// session date before before on which a set of an exercise working it was
+// completed. Planned-only slots do not count. Use it to fill
+// Planner.MuscleGroupsLastTrained; sessions may be in any order.
+func MuscleGroupsLastTrainedDates(sessions []Session, before time.Time) map[string]time.Time {
+	last := make(map[string]time.Time)
+	for _, s := range sessions {
+		if !s.Date.Before(before) {
+			continue
+		}
+		for _, slot := range s.Slots {
+			if slot.CompletedSetCount() == 0 {
+				continue
+			}
+			markTrained(last, slot.Exercise, s.Date)
+		}
+	}
+	return last
+}
+
+// markTrained records date as the last day ex's primary muscle groups were
+// trained, unless last already holds a later one.
+func markTrained(last map[string]time.Time, ex Exercise, date time.Time) {
+	for _, mg := range ex.PrimaryMuscleGroups {
+		if prev, ok := last[mg]; !ok || date.After(prev) {
+			last[mg] = date
+		}
+	}
+}
+
+// previousSessionMuscleGroups returns the primary muscle groups trained in
+// the most recent session before date according to lastTrained, and nil when
+// lastTrained has none before date.
+func previousSessionMuscleGroups(lastTrained map[string]time.Time, date time.Time) map[string]bool {
+	var latest time.Time
+	for _, d := range lastTrained {
+		if d.Before(date) && d.After(latest) {
+			latest = d
+		}
+	}
+	if latest.IsZero() {
+		return nil
+	}
+	mgs := make(map[string]bool)
+	for mg, d := range lastTrained {
+		if d.Equal(latest) {
+			mgs[mg] = true
+		}
+	}
+	return mgs
+}
+
+// recencyPenalty returns how much to take off ex's score for working again
+// the primary muscle groups of the previous session: penalty for each of
+// ex's primary muscle groups in recentMGs.
+func recencyPenalty(ex Exercise, recentMGs map[string]bool, penalty float64) float64 {
+	var total float64
+	for _, mg := range ex.PrimaryMuscleGroups {
+		if recentMGs[mg] {
+			total += penalty
+		}
+	}
+	return total
+}
@@ -0,0 +1,44 @@
+package domain_test
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_MuscleGroupsLastTrainedDates(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	at := today.Add(-time.Hour)
+	slot := func(completed bool, mgs ...string) domain.ExerciseSlot {
+		set := domain.Set{} //nolint:exhaustruct // Only completion matters.
+		if completed {
+			set.CompletedAt = &at
+		}
+		return domain.ExerciseSlot{ //nolint:exhaustruct // Exercise muscle groups and sets only.
+			Exercise: domain.Exercise{PrimaryMuscleGroups: mgs}, //nolint:exhaustruct // Muscle groups only.
+			Sets:     []domain.Set{set},
+		}
+	}
+	session := func(offset int, slots ...domain.ExerciseSlot) domain.Session {
+		return domain.Session{Date: today.AddDate(0, 0, offset), Slots: slots} //nolint:exhaustruct // Date and slots.
+	}
+
+	got := domain.MuscleGroupsLastTrainedDates([]domain.Session{
+		session(-1, slot(true, "Chest", "Triceps"), slot(false, "Quads")),
+		session(-3, slot(true, "Quads"), slot(true, "Chest")),
+		session(0, slot(true, "Biceps")),
+	}, today)
+
+	want := map[string]time.Time{
+		"Chest":   today.AddDate(0, 0, -1),
+		"Triceps": today.AddDate(0, 0, -1),
+		"Quads":   today.AddDate(0, 0, -3),
+	}
+	if !maps.EqualFunc(got, want, time.Time.Equal) {
+		t.Errorf("MuscleGroupsLastTrainedDates = %v, want %v", got, want)
+	}
+}
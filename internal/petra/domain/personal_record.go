@@ -0,0 +1,54 @@
+package domain
+
+import "time"
+
+// PersonalRecord is a user's current best completed set on one exercise.
+// For exercises that carry weight (HasWeight) the record is the heaviest
+// load, with Value the reps done at it; for assisted exercises, whose loads
+// are negative, that is the least assistance. For bodyweight exercises it is
+// the most reps and for timed ones the longest hold, and WeightKg is nil.
+// Date is the first session the record was reached.
+type PersonalRecord struct {
+	Exercise Exercise
+	Date     time.Time
+	WeightKg *float64
+	Value    int
+}
+
+// PersonalRecordFor picks ex's record out of history. Only completed working
+// sets (Set.IsWorking) with a positive value count. A weight tie goes to the set with more reps,
+// and any remaining tie to the earlier date, so re-matching a record doesn't
+// move its date. ok is false when no set qualifies.
+func PersonalRecordFor(ex Exercise, history []ExerciseSetHistory) (pr PersonalRecord, ok bool) {
+	for _, h := range history {
+		for _, set := range h.Sets {
+			if !set.IsWorking() || set.CompletedValue == nil || *set.CompletedValue <= 0 {
+				continue
+			}
+			if ex.HasWeight() && set.WeightKg == nil {
+				continue
+			}
+			if !ok || beatsRecord(ex, set, h.Date, pr) {
+				pr = PersonalRecord{Exercise: ex, Date: h.Date, WeightKg: nil, Value: *set.CompletedValue}
+				if ex.HasWeight() {
+					w := *set.WeightKg
+					pr.WeightKg = &w
+				}
+				ok = true
+			}
+		}
+	}
+	return pr, ok
+}
+
+// beatsRecord reports whether set, done on date, displaces pr.
+func beatsRecord(ex Exercise, set Set, date time.Time, pr PersonalRecord) bool {
+	value := *set.CompletedValue
+	if ex.HasWeight() && *set.WeightKg != *pr.WeightKg {
+		return *set.WeightKg > *pr.WeightKg
+	}
+	if value != pr.Value {
+		return value > pr.Value
+	}
+	return date.Before(pr.Date)
+}
@@ -0,0 +1,110 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func TestPersonalRecordFor(t *testing.T) {
+	t.Parallel()
+
+	day := func(d int) time.Time { return time.Date(2026, 4, d, 0, 0, 0, 0, time.UTC) }
+	done := func(weight *float64, reps int) domain.Set {
+		return domain.Set{ //nolint:exhaustruct // Only load and completed value matter to records.
+			WeightKg: weight, CompletedValue: &reps,
+		}
+	}
+	kg := func(w float64) *float64 { return &w }
+	warmup := func(weight *float64, reps int) domain.Set {
+		set := done(weight, reps)
+		set.Warmup = true
+		return set
+	}
+	planned := domain.Set{ //nolint:exhaustruct // Never completed: must not count.
+		WeightKg: kg(200), TargetValue: 5,
+	}
+
+	weighted := domain.Exercise{ //nolint:exhaustruct // Only the type matters to records.
+		ID: 1, ExerciseType: domain.ExerciseTypeWeighted,
+	}
+	bodyweight := domain.Exercise{ //nolint:exhaustruct // Only the type matters to records.
+		ID: 2, ExerciseType: domain.ExerciseTypeBodyweight,
+	}
+	assisted := domain.Exercise{ //nolint:exhaustruct // Only the type matters to records.
+		ID: 3, ExerciseType: domain.ExerciseTypeAssisted,
+	}
+
+	tests := []struct {
+		name      string
+		ex        domain.Exercise
+		history   []domain.ExerciseSetHistory
+		wantDate  time.Time
+		wantKg    *float64
+		wantValue int
+	}{
+		{
+			name: "weighted: heaviest load wins, reps break the tie, first date sticks",
+			ex:   weighted,
+			history: []domain.ExerciseSetHistory{
+				{Date: day(1), Sets: []domain.Set{done(kg(100), 3), done(kg(90), 8), planned}},
+				{Date: day(8), Sets: []domain.Set{done(kg(100), 5)}},
+				{Date: day(15), Sets: []domain.Set{done(kg(100), 5), done(kg(95), 6)}},
+			},
+			wantDate: day(8), wantKg: kg(100), wantValue: 5,
+		},
+		{
+			name: "weighted: warmup and back-off sets are left out of the max weight",
+			ex:   weighted,
+			history: []domain.ExerciseSetHistory{
+				{Date: day(1), Sets: []domain.Set{warmup(kg(60), 8), done(kg(100), 5), warmup(kg(110), 2)}},
+				{Date: day(8), Sets: []domain.Set{warmup(kg(120), 1), done(kg(95), 5)}},
+			},
+			wantDate: day(1), wantKg: kg(100), wantValue: 5,
+		},
+		{
+			name: "bodyweight: most reps",
+			ex:   bodyweight,
+			history: []domain.ExerciseSetHistory{
+				{Date: day(1), Sets: []domain.Set{done(nil, 12)}},
+				{Date: day(8), Sets: []domain.Set{done(nil, 15), done(nil, 11)}},
+			},
+			wantDate: day(8), wantKg: nil, wantValue: 15,
+		},
+		{
+			name: "assisted: least assistance",
+			ex:   assisted,
+			history: []domain.ExerciseSetHistory{
+				{Date: day(1), Sets: []domain.Set{done(kg(-30), 8)}},
+				{Date: day(8), Sets: []domain.Set{done(kg(-20), 6)}},
+			},
+			wantDate: day(8), wantKg: kg(-20), wantValue: 6,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			pr, ok := domain.PersonalRecordFor(tt.ex, tt.history)
+			if !ok {
+				t.Fatal("PersonalRecordFor ok = false, want a record")
+			}
+			if !pr.Date.Equal(tt.wantDate) || pr.Value != tt.wantValue {
+				t.Errorf("record = %s × %d, want %s × %d",
+					pr.Date.Format(time.DateOnly), pr.Value, tt.wantDate.Format(time.DateOnly), tt.wantValue)
+			}
+			switch {
+			case tt.wantKg == nil && pr.WeightKg != nil:
+				t.Errorf("WeightKg = %v, want nil", *pr.WeightKg)
+			case tt.wantKg != nil && (pr.WeightKg == nil || *pr.WeightKg != *tt.wantKg):
+				t.Errorf("WeightKg = %v, want %v", pr.WeightKg, *tt.wantKg)
+			}
+		})
+	}
+
+	if _, ok := domain.PersonalRecordFor(weighted, []domain.ExerciseSetHistory{
+		{Date: day(1), Sets: []domain.Set{planned}},
+	}); ok {
+		t.Error("PersonalRecordFor ok = true with no completed set")
+	}
+}
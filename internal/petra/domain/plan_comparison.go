@@ -0,0 +1,142 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// Thresholds for the coaching notes ComparePlanToActual writes. Within them
+// the workout counts as done to plan; a set or two either way is noise.
+const (
+	comparisonLowSetRatio     = 0.75
+	comparisonHighSetRatio    = 1.25
+	comparisonLowAttainment   = 0.9
+	comparisonHighAttainment  = 1.1
+	comparisonPercentMultiple = 100
+)
+
+// PlanComparison is what ComparePlanToActual found when holding a logged
+// session up against the one the planner prescribes for the same day.
+type PlanComparison struct {
+	// PlannedSets counts the prescribed working sets; CompletedSets counts the
+	// sets logged as done in the actual session.
+	PlannedSets   int
+	CompletedSets int
+	// Attainment is the logged reps (or seconds) over their targets across
+	// the completed sets, so 1.0 means every set hit its target exactly. It
+	// is 0 when nothing comparable was logged. Circuit sets are skipped, as
+	// their completed value counts rounds.
+	Attainment float64
+	// MissedMuscleGroups are primary muscle groups the prescription trains
+	// that no completed set in the actual session did, sorted by name.
+	MissedMuscleGroups []string
+	// ExtraMuscleGroups are primary muscle groups the actual session trained
+	// that the prescription left out, sorted by name.
+	ExtraMuscleGroups []string
+	// Notes are short coaching remarks on the differences, one per finding.
+	// A session done to plan gets a single note saying so.
+	Notes []string
+}
+
+// ComparePlanToActual diffs the actual session a user logged against the
+// prescribed session the planner would have generated for the same day.
+// Volume is compared as working sets, intensity as how close the completed
+// sets came to their targets, and coverage as the primary muscle groups
+// trained. Only completed sets count toward what the user actually did.
+func ComparePlanToActual(prescribed, actual Session) PlanComparison {
+	c := PlanComparison{
+		PlannedSets:        0,
+		CompletedSets:      0,
+		Attainment:         0,
+		MissedMuscleGroups: []string{},
+		ExtraMuscleGroups:  []string{},
+		Notes:              []string{},
+	}
+
+	plannedGroups := make(map[string]bool)
+	for _, slot := range prescribed.Slots {
+		c.PlannedSets += len(slot.Sets)
+		for _, mg := range slot.Exercise.PrimaryMuscleGroups {
+			plannedGroups[mg] = true
+		}
+	}
+
+	actualGroups := make(map[string]bool)
+	var target, done int
+	for _, slot := range actual.Slots {
+		completed := 0
+		for _, set := range slot.Sets {
+			if set.CompletedValue == nil {
+				continue
+			}
+			completed++
+			if set.DurationSeconds == nil && set.TargetValue > 0 {
+				target += set.TargetValue
+				done += *set.CompletedValue
+			}
+		}
+		c.CompletedSets += completed
+		if completed > 0 {
+			for _, mg := range slot.Exercise.PrimaryMuscleGroups {
+				actualGroups[mg] = true
+			}
+		}
+	}
+	if target > 0 {
+		c.Attainment = float64(done) / float64(target)
+	}
+	for mg := range plannedGroups {
+		if !actualGroups[mg] {
+			c.MissedMuscleGroups = append(c.MissedMuscleGroups, mg)
+		}
+	}
+	for mg := range actualGroups {
+		if !plannedGroups[mg] {
+			c.ExtraMuscleGroups = append(c.ExtraMuscleGroups, mg)
+		}
+	}
+	slices.Sort(c.MissedMuscleGroups)
+	slices.Sort(c.ExtraMuscleGroups)
+
+	c.Notes = c.coachingNotes()
+	return c
+}
+
+// coachingNotes turns the comparison's findings into user-facing remarks.
+func (c PlanComparison) coachingNotes() []string {
+	var notes []string
+	planned := float64(c.PlannedSets)
+	switch {
+	case float64(c.CompletedSets) < planned*comparisonLowSetRatio:
+		notes = append(notes, fmt.Sprintf(
+			"You completed %d of the %d sets planned; the missing volume slows progress.",
+			c.CompletedSets, c.PlannedSets))
+	case float64(c.CompletedSets) > planned*comparisonHighSetRatio:
+		notes = append(notes, fmt.Sprintf(
+			"You completed %d sets against %d planned; keep an eye on recovery.",
+			c.CompletedSets, c.PlannedSets))
+	}
+	if len(c.MissedMuscleGroups) > 0 {
+		notes = append(notes, "Planned but not trained: "+strings.Join(c.MissedMuscleGroups, ", ")+".")
+	}
+	if len(c.ExtraMuscleGroups) > 0 {
+		notes = append(notes, "Trained beyond the plan: "+strings.Join(c.ExtraMuscleGroups, ", ")+".")
+	}
+	percent := int(math.Round(c.Attainment * comparisonPercentMultiple))
+	switch {
+	case c.Attainment == 0:
+		// Nothing comparable was logged.
+	case c.Attainment < comparisonLowAttainment:
+		notes = append(notes, fmt.Sprintf(
+			"Your sets reached %d%% of their targets; a lighter load would keep you in range.", percent))
+	case c.Attainment > comparisonHighAttainment:
+		notes = append(notes, fmt.Sprintf(
+			"Your sets reached %d%% of their targets; there is room to add load.", percent))
+	}
+	if len(notes) == 0 {
+		notes = append(notes, "You trained as planned.")
+	}
+	return notes
+}
@@ -0,0 +1,85 @@
+package domain_test
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_ComparePlanToActual(t *testing.T) {
+	t.Parallel()
+
+	bench := domain.Exercise{ //nolint:exhaustruct // Only muscle groups matter.
+		ID: 1, Name: "Bench Press", PrimaryMuscleGroups: []string{domain.MuscleGroupChest},
+	}
+	squat := domain.Exercise{ //nolint:exhaustruct // Only muscle groups matter.
+		ID: 2, Name: "Squat", PrimaryMuscleGroups: []string{domain.MuscleGroupQuads},
+	}
+	curl := domain.Exercise{ //nolint:exhaustruct // Only muscle groups matter.
+		ID: 3, Name: "Curl", PrimaryMuscleGroups: []string{domain.MuscleGroupBiceps},
+	}
+	sets := func(n, target int, completed *int) []domain.Set {
+		out := make([]domain.Set, n)
+		for i := range out {
+			out[i] = domain.Set{TargetValue: target, CompletedValue: completed} //nolint:exhaustruct // Reps only.
+		}
+		return out
+	}
+	eight, five := 8, 5
+	prescribed := domain.Session{ //nolint:exhaustruct // Slots only.
+		Slots: []domain.ExerciseSlot{
+			{Exercise: bench, Sets: sets(3, 8, nil)}, //nolint:exhaustruct // Exercise and sets only.
+			{Exercise: squat, Sets: sets(3, 8, nil)}, //nolint:exhaustruct // Exercise and sets only.
+		},
+	}
+
+	t.Run("as planned", func(t *testing.T) {
+		t.Parallel()
+		actual := domain.Session{ //nolint:exhaustruct // Slots only.
+			Slots: []domain.ExerciseSlot{
+				{Exercise: bench, Sets: sets(3, 8, &eight)}, //nolint:exhaustruct // Exercise and sets only.
+				{Exercise: squat, Sets: sets(3, 8, &eight)}, //nolint:exhaustruct // Exercise and sets only.
+			},
+		}
+		c := domain.ComparePlanToActual(prescribed, actual)
+		if c.CompletedSets != 6 || c.PlannedSets != 6 || c.Attainment != 1 {
+			t.Errorf("sets = %d/%d, attainment = %v; want 6/6, 1", c.CompletedSets, c.PlannedSets, c.Attainment)
+		}
+		if len(c.Notes) != 1 || c.Notes[0] != "You trained as planned." {
+			t.Errorf("Notes = %q, want the single as-planned note", c.Notes)
+		}
+	})
+
+	t.Run("divergent session", func(t *testing.T) {
+		t.Parallel()
+		// Skipped the squats, cut the bench short at 5 of 8 reps and added curls.
+		actual := domain.Session{ //nolint:exhaustruct // Slots only.
+			Slots: []domain.ExerciseSlot{
+				{Exercise: bench, Sets: append(sets(1, 8, &five), sets(2, 8, nil)...)}, //nolint:exhaustruct // Exercise and sets only.
+				{Exercise: squat, Sets: sets(3, 8, nil)},                               //nolint:exhaustruct // Exercise and sets only.
+				{Exercise: curl, Sets: sets(1, 8, &five)},                              //nolint:exhaustruct // Exercise and sets only.
+			},
+		}
+		c := domain.ComparePlanToActual(prescribed, actual)
+		if c.CompletedSets != 2 || c.PlannedSets != 6 {
+			t.Errorf("sets = %d/%d, want 2/6", c.CompletedSets, c.PlannedSets)
+		}
+		if want := []string{domain.MuscleGroupQuads}; !slices.Equal(c.MissedMuscleGroups, want) {
+			t.Errorf("MissedMuscleGroups = %v, want %v", c.MissedMuscleGroups, want)
+		}
+		if want := []string{domain.MuscleGroupBiceps}; !slices.Equal(c.ExtraMuscleGroups, want) {
+			t.Errorf("ExtraMuscleGroups = %v, want %v", c.ExtraMuscleGroups, want)
+		}
+		if c.Attainment != 10.0/16.0 {
+			t.Errorf("Attainment = %v, want %v", c.Attainment, 10.0/16.0)
+		}
+		notes := strings.Join(c.Notes, "\n")
+		for _, want := range []string{"2 of the 6 sets", domain.MuscleGroupQuads, domain.MuscleGroupBiceps, "63%"} {
+			if !strings.Contains(notes, want) {
+				t.Errorf("Notes = %q, want a mention of %q", c.Notes, want)
+			}
+		}
+	})
+}
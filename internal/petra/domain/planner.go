@@ -1,10 +1,13 @@
 package domain
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"maps"
 	"math"
+	"slices"
+	"strings"
 	"time"
 )
 
@@ -18,28 +21,67 @@ const (
 	exercisesMediumHypertrophy = 4
 	exercisesShort             = 2
 
+	// MaxExercisesPerSessionLimit is the highest cap Preferences accepts: the
+	// largest count the planner ever derives, so a higher cap would be a no-op.
+	MaxExercisesPerSessionLimit = exercisesLongHypertrophy
+
 	numSessionGoals = 2
 
 	hoursPerDay = 24
 )
 
 // errNoExercisesForCategory is returned by PlanDay (and wrapped by Plan) when
-// the exercise pool contains nothing compatible with the derived category.
+// the exercise pool is empty. A pool that merely lacks the derived category
+// falls back to full body instead (see CategoryFallback).
 var errNoExercisesForCategory = errors.New("no exercises available for day category")
 
 // Planner holds the static inputs needed to plan a full week of workouts.
+// Planning only reads them: Plan and PlanDay work on copies of anything they
+// update as they go, such as the muscle groups each planned day trains, so
+// one Planner can serve concurrent calls. Its fields, including the
+// LastPerformed and MuscleGroupsLastTrained history maps, must not be changed
+// while a call may be running.
 type Planner struct {
 	Prefs     Preferences
 	Exercises []Exercise
 	Targets   []MuscleGroupTarget
+	// LastPerformed maps exercise ID to the latest date the user completed a
+	// set of it before the days being planned (see LastPerformedDates). It
+	// enforces Exercise.MinDaysBetween across week boundaries; nil means no
+	// history is known and no exercise is held back.
+	LastPerformed map[int]time.Time
+	// MuscleGroupsLastTrained maps each primary muscle group to the latest
+	// date the user trained it before the days being planned (see
+	// MuscleGroupsLastTrainedDates). The planner reads the previous session's
+	// muscle groups from it, so one session does not repeat the last one's
+	// focus; nil means no history is known.
+	MuscleGroupsLastTrained map[string]time.Time
+	// MuscleGroupRecencyPenalty is subtracted from a candidate's score for
+	// each of its primary muscle groups trained in the previous session.
+	// NewPlanner sets DefaultMuscleGroupRecencyPenalty; zero turns the bias
+	// off.
+	MuscleGroupRecencyPenalty float64
+	// ProgressionStreak is the ProgressionStreakWeeks of the user's history
+	// before the week being planned. With AutoDeloadAfterWeeks it decides an
+	// automatic deload (see AutoDeloadDue).
+	ProgressionStreak int
+	// AutoDeloadAfterWeeks is the streak at which an automatic deload is due.
+	// NewPlanner sets the preferences' EffectiveDeloadInterval; zero never
+	// deloads automatically.
+	AutoDeloadAfterWeeks int
 }
 
 // NewPlanner creates a Planner over the supplied inputs.
 func NewPlanner(prefs Preferences, exercises []Exercise, targets []MuscleGroupTarget) *Planner {
 	return &Planner{
-		Prefs:     prefs,
-		Exercises: exercises,
-		Targets:   targets,
+		Prefs:                     prefs,
+		Exercises:                 exercises,
+		Targets:                   targets,
+		LastPerformed:             nil,
+		MuscleGroupsLastTrained:   nil,
+		MuscleGroupRecencyPenalty: DefaultMuscleGroupRecencyPenalty,
+		ProgressionStreak:         0,
+		AutoDeloadAfterWeeks:      prefs.EffectiveDeloadInterval(),
 	}
 }
 
@@ -47,8 +89,10 @@ func NewPlanner(prefs Preferences, exercises []Exercise, targets []MuscleGroupTa
 // plan always has 7 Session slots indexed by day-offset from startingDate
 // (slot i corresponds to startingDate.AddDate(0, 0, i)). Scheduled workout days
 // are populated with full content; rest days carry an empty Session{Date: ...}
-// with no Slots. Returns an error if startingDate is not a Monday, if no
-// workout days are scheduled, or if a scheduled day has no compatible exercises.
+// with no Slots. A scheduled day whose derived category has no compatible
+// exercise is planned as full body (see CategoryFallback). Returns an error
+// if startingDate is not a Monday, if no workout days are scheduled, or if the
+// exercise pool is empty.
 func (wp *Planner) Plan(startingDate time.Time) (WeekPlan, error) {
 	if startingDate.Weekday() != time.Monday {
 		return WeekPlan{}, fmt.Errorf("startingDate must be a Monday, got %s", startingDate.Weekday())
@@ -78,28 +122,35 @@ func (wp *Planner) Plan(startingDate time.Time) (WeekPlan, error) {
 		return WeekPlan{}, errors.New("no workout days scheduled in preferences")
 	}
 
-	for _, day := range workoutDays {
-		cat := wp.determineCategory(day)
-		if !wp.hasExercisesForCategory(cat) {
-			return WeekPlan{}, fmt.Errorf("%w: %s day (%s)", errNoExercisesForCategory, cat, day.Weekday())
-		}
-	}
-
 	firstPT := wp.firstSessionGoal(startingDate)
-	isDeload := wp.Prefs.IsDeloadWeek(startingDate)
-	wv := weekVolumeFor(startingDate, wp.Prefs)
+	isDeload := wp.isDeloadWeek(startingDate)
 
 	weekUsedExercises := map[int]bool{}
 	volume := map[string]float64{}
+	// Each planned day becomes the previous session for the next one.
+	lastTrained := maps.Clone(wp.MuscleGroupsLastTrained)
+	if lastTrained == nil {
+		lastTrained = map[string]time.Time{}
+	}
 	for i, day := range workoutDays {
 		pt := nextSessionGoal(firstPT, i)
 		if isDeload {
 			pt = SessionGoalHypertrophy
 		}
+		category := wp.dayCategory(day)
+		if !wp.hasExercisesForCategory(category) {
+			return WeekPlan{}, fmt.Errorf(
+				"%w: %s day (%s)", errNoExercisesForCategory, category, day.Weekday(),
+			)
+		}
 		n := exercisesPerSession(wp.Prefs, day.Weekday(), pt, isDeload)
 		slots := wp.selectExercisesForDayWithGoal(
-			wp.determineCategory(day), n, pt, isDeload, wv, weekUsedExercises, volume,
+			day, category, n, pt, isDeload, weekVolumeFor(day, wp.Prefs),
+			previousSessionMuscleGroups(lastTrained, day), weekUsedExercises, volume,
 		)
+		for _, slot := range slots {
+			markTrained(lastTrained, slot.Exercise, day)
+		}
 		dayOffset := int(day.Sub(startingDate).Hours() / hoursPerDay)
 		result.Sessions[dayOffset] = Session{ //nolint:exhaustruct // DifficultyRating/StartedAt/CompletedAt start zero.
 			Date:     day,
@@ -125,14 +176,31 @@ func (wp *Planner) Plan(startingDate time.Time) (WeekPlan, error) {
 // shared map can be threaded through multiple PlanDay calls in the
 // same week. Pass a fresh map if you don't want this side effect.
 //
-// Returns errNoExercisesForCategory (wrapped) if the derived category
-// has no compatible exercises.
+// A derived category with no compatible exercises falls back to full body
+// (see CategoryFallback). Returns errNoExercisesForCategory (wrapped) only
+// if the exercise pool is empty.
 func (wp *Planner) PlanDay(
 	date time.Time,
 	weekUsedExerciseIDs map[int]bool,
 	weekLoad map[string]float64,
 ) (Session, error) {
-	category := wp.determineCategory(date)
+	return wp.PlanDayAs(date, wp.dayCategory(date), weekUsedExerciseIDs, weekLoad)
+}
+
+// PlanDayAs is PlanDay with the category picked by the caller rather than
+// derived from the schedule, for an ad-hoc workout the user wants to focus on
+// one half of the body. Returns errNoExercisesForCategory (wrapped) when the
+// pool has no exercise compatible with category; there is no fallback, since
+// the user asked for that category.
+func (wp *Planner) PlanDayAs(
+	date time.Time,
+	category Category,
+	weekUsedExerciseIDs map[int]bool,
+	weekLoad map[string]float64,
+) (Session, error) {
+	if !category.IsValid() {
+		return Session{}, fmt.Errorf("invalid category %q", category)
+	}
 	if !wp.hasExercisesForCategory(category) {
 		return Session{}, fmt.Errorf(
 			"%w: %s day (%s)", errNoExercisesForCategory, category, date.Weekday(),
@@ -162,11 +230,11 @@ func (wp *Planner) PlanDay(
 	firstPT := wp.firstSessionGoal(monday)
 	pt := nextSessionGoal(firstPT, idx)
 
-	isDeload := wp.Prefs.IsDeloadWeek(monday)
+	isDeload := wp.isDeloadWeek(monday)
 	if isDeload {
 		pt = SessionGoalHypertrophy
 	}
-	wv := weekVolumeFor(monday, wp.Prefs)
+	wv := weekVolumeFor(date, wp.Prefs)
 
 	n := exercisesPerSession(wp.Prefs, date.Weekday(), pt, isDeload)
 	if n == 0 {
@@ -182,7 +250,8 @@ func (wp *Planner) PlanDay(
 	}
 	volume := make(map[string]float64, len(weekLoad))
 	maps.Copy(volume, weekLoad)
-	slots := wp.selectExercisesForDayWithGoal(category, n, pt, isDeload, wv, used, volume)
+	recentMGs := previousSessionMuscleGroups(wp.MuscleGroupsLastTrained, date)
+	slots := wp.selectExercisesForDayWithGoal(date, category, n, pt, isDeload, wv, recentMGs, used, volume)
 
 	return Session{ //nolint:exhaustruct // DifficultyRating/StartedAt/CompletedAt start zero.
 		Date:     date,
@@ -193,12 +262,22 @@ func (wp *Planner) PlanDay(
 }
 
 // exercisesPerSession returns how many exercises to include based on session
-// duration and goal. Hypertrophy non-deload sessions of >= 60 min
-// get one extra exercise to use the working-set time budget more fully;
-// strength and deload sessions keep their base counts.
+// duration and goal, capped at prefs.MaxExercisesPerSession when set.
+// Hypertrophy non-deload sessions of >= 60 min get one extra exercise to use
+// the working-set time budget more fully; strength and deload sessions keep
+// their base counts.
 func exercisesPerSession(prefs Preferences, weekday time.Weekday, pt SessionGoal, isDeload bool) int {
-	hyperBonus := pt == SessionGoalHypertrophy && !isDeload
-	switch minutes := prefs.MinutesForDay(weekday); {
+	n := baseExercisesPerSession(prefs.MinutesForDay(weekday), pt == SessionGoalHypertrophy && !isDeload)
+	if prefs.MaxExercisesPerSession > 0 {
+		n = min(n, prefs.MaxExercisesPerSession)
+	}
+	return n
+}
+
+// baseExercisesPerSession is the uncapped exercise count for a session of
+// minutes; hyperBonus adds the hypertrophy extra on 60+ minute days.
+func baseExercisesPerSession(minutes int, hyperBonus bool) int {
+	switch {
 	case minutes >= minutesLong:
 		if hyperBonus {
 			return exercisesLongHypertrophy
@@ -216,23 +295,72 @@ func exercisesPerSession(prefs Preferences, weekday time.Weekday, pt SessionGoal
 	}
 }
 
+// CategoryDecision is the workout category the planner gives a date, with a
+// sentence explaining why for users who wonder about their plan.
+type CategoryDecision struct {
+	Category Category
+	Reason   string
+}
+
+// DecideCategory returns the category date is planned with and why; see
+// determineCategory for the adjacency rule and CategoryFallback for the
+// full body fallback.
+func (wp *Planner) DecideCategory(date time.Time) CategoryDecision {
+	decision := wp.determineCategory(date)
+	if !wp.hasExercisesForCategory(decision.Category) {
+		return CategoryDecision{
+			Category: CategoryFullBody,
+			Reason: fmt.Sprintf("Full body because the exercise catalogue has no %s exercises yet.",
+				strings.ToLower(decision.Category.Label())),
+		}
+	}
+	return decision
+}
+
 // determineCategory returns the workout category for a given date using the adjacency rule.
 // Uses preference-based weekday checks so week boundaries wrap naturally through date arithmetic:
 // Sunday's "tomorrow" is Monday, Monday's "yesterday" is Sunday.
 // Lower is chosen when tomorrow is a workout day (whether today is scheduled or ad-hoc), so that
 // the following session can use Upper-body exercises while the legs recover. Upper is chosen when
 // yesterday was a workout day. Otherwise FullBody.
-func (wp *Planner) determineCategory(date time.Time) Category {
+func (wp *Planner) determineCategory(date time.Time) CategoryDecision {
 	tomorrow := date.AddDate(0, 0, 1).Weekday()
 	yesterday := date.AddDate(0, 0, -1).Weekday()
 
 	if wp.Prefs.IsWorkoutDay(tomorrow) {
-		return CategoryLower
+		return CategoryDecision{
+			Category: CategoryLower,
+			Reason: fmt.Sprintf(
+				"Lower body because the next day, %s, is a workout day that can train the upper body.", tomorrow),
+		}
 	}
 	if wp.Prefs.IsWorkoutDay(yesterday) {
-		return CategoryUpper
+		return CategoryDecision{
+			Category: CategoryUpper,
+			Reason: fmt.Sprintf(
+				"Upper body because the day before, %s, is a workout day, so the legs get to recover.", yesterday),
+		}
 	}
-	return CategoryFullBody
+	return CategoryDecision{
+		Category: CategoryFullBody,
+		Reason:   "Full body because neither the day before nor the day after is a workout day.",
+	}
+}
+
+// dayCategory returns the category date is planned with: the adjacency-derived
+// category, or CategoryFullBody when the pool has nothing compatible with it.
+func (wp *Planner) dayCategory(date time.Time) Category {
+	return wp.DecideCategory(date).Category
+}
+
+// CategoryFallback reports whether date's adjacency-derived category has no
+// compatible exercise in the pool, in which case Plan and PlanDay plan the
+// day as full body rather than failing. skipped is the category that could
+// not be served. The domain has no logger; callers use this to surface the
+// fallback.
+func (wp *Planner) CategoryFallback(date time.Time) (skipped Category, fellBack bool) {
+	cat := wp.determineCategory(date).Category
+	return cat, !wp.hasExercisesForCategory(cat)
 }
 
 // firstSessionGoal derives the session goal for the first session of the
@@ -274,17 +402,23 @@ func primaryMuscleGroupsOverlap(ex Exercise, selectedPrimaryMuscles map[string]b
 // and marking each picked exercise's ID in weekUsedExercises so later
 // days in the same week skip it. The chosen exercise on every slot is
 // the one that maximises scoreCandidate against the current volume and
-// the planner's Targets, with the lowest exercise ID winning ties.
+// the planner's Targets, less the recency penalty for primary muscle groups
+// in recentMGs (the previous session's), with the lowest exercise ID winning
+// ties.
 // Within a session, exercises whose primary MGs overlap with already
 // selected primaries are skipped (no two chest-primary picks in one
 // session). When no eligible candidate remains, selection stops early
 // (graceful degradation: the session may have fewer than n slots).
+// The picks are then ordered compound-first (see orderCompoundFirst) unless
+// the user prefers to keep them in selection order.
 func (wp *Planner) selectExercisesForDayWithGoal(
+	date time.Time,
 	category Category,
 	n int,
 	pt SessionGoal,
 	isDeload bool,
 	wv weekVolume,
+	recentMGs map[string]bool,
 	weekUsedExercises map[int]bool,
 	volume map[string]float64,
 ) []ExerciseSlot {
@@ -298,10 +432,12 @@ func (wp *Planner) selectExercisesForDayWithGoal(
 
 	for len(selected) < n {
 		bestIdx := wp.pickBestExerciseIdx(
+			date,
 			category,
 			pt,
 			isDeload,
 			wv,
+			recentMGs,
 			selectedPrimaryMGs,
 			weekUsedExercises,
 			volume,
@@ -320,18 +456,45 @@ func (wp *Planner) selectExercisesForDayWithGoal(
 		applyVolume(volume, ex, float64(len(slot.Sets)))
 	}
 
+	if !wp.Prefs.PreserveExerciseOrder {
+		orderCompoundFirst(selected)
+	}
 	return selected
 }
 
+// orderCompoundFirst sorts slots so compound lifts (see Exercise.IsCompound),
+// which need the most energy and technique, are done fresh and isolation work
+// follows; within each group exercises with more primary muscle groups come
+// first. Slots that tie keep their relative order.
+func orderCompoundFirst(slots []ExerciseSlot) {
+	rank := func(ex Exercise) int {
+		if ex.IsCompound() {
+			return 1
+		}
+		return 0
+	}
+	slices.SortStableFunc(slots, func(a, b ExerciseSlot) int {
+		return cmp.Or(
+			cmp.Compare(rank(b.Exercise), rank(a.Exercise)),
+			cmp.Compare(len(b.Exercise.PrimaryMuscleGroups), len(a.Exercise.PrimaryMuscleGroups)),
+		)
+	})
+}
+
 // pickBestExerciseIdx returns the index into wp.Exercises of the exercise that
-// maximises scoreCandidate among candidates that are category-compatible,
-// not already used this week, and don't share a primary MG with selectedPrimaryMGs.
+// maximises scoreCandidate, less its recencyPenalty against recentMGs and,
+// for beginners, its complexityPenalty, among candidates that are
+// category-compatible, not already used this week, not held back by their
+// MinDaysBetween on date, and don't share a primary MG with
+// selectedPrimaryMGs.
 // Ties are broken by lowest exercise ID. Returns -1 if no candidate qualifies.
 func (wp *Planner) pickBestExerciseIdx(
+	date time.Time,
 	category Category,
 	pt SessionGoal,
 	isDeload bool,
 	wv weekVolume,
+	recentMGs map[string]bool,
 	selectedPrimaryMGs map[string]bool,
 	weekUsedExercises map[int]bool,
 	volume map[string]float64,
@@ -343,14 +506,17 @@ func (wp *Planner) pickBestExerciseIdx(
 		ex := wp.Exercises[i]
 		if !isCategoryCompatible(ex.Category, category) ||
 			weekUsedExercises[ex.ID] ||
+			wp.performedTooRecently(ex, date) ||
 			primaryMuscleGroupsOverlap(ex, selectedPrimaryMGs) {
 			continue
 		}
-		score := scoreCandidate(ex, pt, isDeload, wv, volume, targets)
+		score := scoreCandidate(ex, pt, isDeload, wv, volume, targets) -
+			recencyPenalty(ex, recentMGs, wp.MuscleGroupRecencyPenalty) -
+			complexityPenalty(ex, wp.Prefs.Beginner)
 		// Exact float equality is safe here: scores are derived from
 		// integer targets, integer set counts, and fixed half-integer
-		// weights (PrimarySetFraction, SecondarySetFraction), so ties round-trip
-		// cleanly through IEEE 754.
+		// weights (PrimarySetFraction, SecondarySetFraction, the recency
+		// and complexity penalties), so ties round-trip cleanly through IEEE 754.
 		if bestIdx < 0 || score > bestScore ||
 			(score == bestScore && ex.ID < wp.Exercises[bestIdx].ID) {
 			bestIdx = i
@@ -361,21 +527,18 @@ func (wp *Planner) pickBestExerciseIdx(
 }
 
 // applyVolume accumulates the per-set MG contribution from ex into volume:
-// PrimarySetFraction per primary MG, SecondarySetFraction per secondary, scaled
-// by nSets. Mutates volume in place.
+// each muscle group's muscleGroupShares fraction, scaled by nSets. Mutates
+// volume in place.
 func applyVolume(volume map[string]float64, ex Exercise, nSets float64) {
-	for _, mg := range ex.PrimaryMuscleGroups {
-		volume[mg] += nSets * PrimarySetFraction
-	}
-	for _, mg := range ex.SecondaryMuscleGroups {
-		volume[mg] += nSets * SecondarySetFraction
+	for mg, fraction := range muscleGroupShares(ex) {
+		volume[mg] += nSets * fraction
 	}
 }
 
 // buildPlannedExerciseSlot creates an ExerciseSlot for one exercise using
 // BuildPlannedSets as the single source of truth for set prescription.
 func buildPlannedExerciseSlot(ex Exercise, pt SessionGoal, isDeload bool, weekSets int) ExerciseSlot {
-	return ExerciseSlot{ //nolint:exhaustruct // WarmupCompletedAt nil.
+	return ExerciseSlot{ //nolint:exhaustruct // WarmupCompletedAt and RestOverrideSeconds nil.
 		Exercise: ex,
 		Sets:     BuildPlannedSets(ex, pt, isDeload, weekSets),
 	}
@@ -405,10 +568,11 @@ func nextSessionGoal(first SessionGoal, idx int) SessionGoal {
 }
 
 // weekVolume captures the mesocycle-week-derived inputs to one planned session:
-// sets is the base per-exercise working-set count for the week (pre-deload),
+// sets is the base per-exercise working-set count for the day (pre-deload),
 // progress is the ramp position in [0,1] used to lerp each muscle's scoring goal
-// from MinSets toward MaxSets. Both are constant across the days of a calendar
-// week, so the planner resolves them once per Plan/PlanDay call.
+// from MinSets toward MaxSets. progress is constant across the days of a
+// calendar week; sets is too, except that LighterWeekends trims it on Saturday
+// and Sunday, so the planner resolves it per planned day.
 type weekVolume struct {
 	sets     int
 	progress float64
@@ -428,12 +592,12 @@ func goalForWeek(t MuscleGroupTarget, progress float64) float64 {
 	return math.Round(raw*goalQuantisationFactor) / goalQuantisationFactor
 }
 
-// weekVolumeFor resolves the week's volume context from the session date and the
-// user's mesocycle preferences. A zero anchor / disabled deload yields progress 0
-// and the base set count — the Phase B behaviour.
+// weekVolumeFor resolves the volume context for a session on date from the
+// user's mesocycle and weekend preferences. A zero anchor / disabled deload
+// yields progress 0 and the base set count — the Phase B behaviour.
 func weekVolumeFor(date time.Time, prefs Preferences) weekVolume {
 	return weekVolume{
-		sets:     prefs.SetCountFor(date),
+		sets:     prefs.SetCountOn(date),
 		progress: prefs.MesocycleRampProgress(date),
 	}
 }
@@ -494,20 +658,13 @@ func scoreCandidate(
 ) float64 {
 	_, nSets := deriveSchemeForExercise(ex, pt, isDeload, wv.sets)
 	n := float64(nSets)
-	contrib := make(map[string]float64, len(ex.PrimaryMuscleGroups)+len(ex.SecondaryMuscleGroups))
-	for _, mg := range ex.PrimaryMuscleGroups {
-		contrib[mg] += n * PrimarySetFraction
-	}
-	for _, mg := range ex.SecondaryMuscleGroups {
-		contrib[mg] += n * SecondarySetFraction
-	}
 	var score float64
-	for mg, added := range contrib {
+	for mg, fraction := range muscleGroupShares(ex) {
 		t, ok := targets[mg]
 		if !ok {
 			continue // tag-only group: no target row, contributes nothing.
 		}
-		score += segmentReward(volume[mg], added, goalForWeek(t, wv.progress), float64(t.MaxSets))
+		score += segmentReward(volume[mg], n*fraction, goalForWeek(t, wv.progress), float64(t.MaxSets))
 	}
 	return score
 }
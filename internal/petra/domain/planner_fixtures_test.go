@@ -431,3 +431,42 @@ func slotIDs(s domain.Session) []int {
 	}
 	return ids
 }
+
+// recencyExercises returns two full-body exercises each for Chest, Quads and
+// Upper Back and one each for Hamstrings and Biceps; with recencyTargets the
+// three big muscles score highest, so only the recency bias keeps a session
+// from repeating them the day after they were trained.
+func recencyExercises() []domain.Exercise {
+	exercise := func(id int, mg string) domain.Exercise {
+		return domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+			ID: id, Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+			PrimaryMuscleGroups: []string{mg}, RepMin: new(5), RepMax: new(10)}
+	}
+	return []domain.Exercise{
+		exercise(1, "Chest"), exercise(2, "Chest"),
+		exercise(3, "Quads"), exercise(4, "Quads"),
+		exercise(5, "Upper Back"), exercise(6, "Upper Back"),
+		exercise(7, "Hamstrings"), exercise(8, "Biceps"),
+	}
+}
+
+func recencyTargets() []domain.MuscleGroupTarget {
+	return []domain.MuscleGroupTarget{
+		{MuscleGroupName: "Chest", MinSets: 12, MaxSets: 20},
+		{MuscleGroupName: "Quads", MinSets: 12, MaxSets: 20},
+		{MuscleGroupName: "Upper Back", MinSets: 12, MaxSets: 20},
+		{MuscleGroupName: "Hamstrings", MinSets: 6, MaxSets: 12},
+		{MuscleGroupName: "Biceps", MinSets: 6, MaxSets: 12},
+	}
+}
+
+// primaryMuscleGroups returns the primary muscle groups s trains.
+func primaryMuscleGroups(s domain.Session) map[string]bool {
+	mgs := map[string]bool{}
+	for _, slot := range s.Slots {
+		for _, mg := range slot.Exercise.PrimaryMuscleGroups {
+			mgs[mg] = true
+		}
+	}
+	return mgs
+}
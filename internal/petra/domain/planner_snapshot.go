@@ -0,0 +1,85 @@
+package domain
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// PlannerSnapshot captures everything a Planner reads to plan one week, so
+// a maintainer can reproduce a user's generated plan offline: Replay on the
+// decoded snapshot returns the same WeekPlan the user got. Deload weight
+// seeding and in-workout progression read set history at run time and are
+// not part of it.
+type PlannerSnapshot struct {
+	Monday                  time.Time            `json:"monday"`
+	Prefs                   Preferences          `json:"preferences"`
+	Exercises               []Exercise           `json:"exercises"`
+	Targets                 []MuscleGroupTarget  `json:"targets"`
+	LastPerformed           map[int]time.Time    `json:"last_performed,omitempty"`
+	MuscleGroupsLastTrained map[string]time.Time `json:"muscle_groups_last_trained,omitempty"`
+	// MuscleGroupRecencyPenalty is recorded because a deployment can turn the
+	// bias off, and replaying with the default would then plan differently.
+	MuscleGroupRecencyPenalty float64 `json:"muscle_group_recency_penalty"`
+	ProgressionStreak         int     `json:"progression_streak,omitempty"`
+	AutoDeloadAfterWeeks      int     `json:"auto_deload_after_weeks"`
+}
+
+// Snapshot captures wp's inputs for planning the week starting monday.
+func (wp *Planner) Snapshot(monday time.Time) PlannerSnapshot {
+	return PlannerSnapshot{
+		Monday:                    monday,
+		Prefs:                     wp.Prefs,
+		Exercises:                 wp.Exercises,
+		Targets:                   wp.Targets,
+		LastPerformed:             wp.LastPerformed,
+		MuscleGroupsLastTrained:   wp.MuscleGroupsLastTrained,
+		MuscleGroupRecencyPenalty: wp.MuscleGroupRecencyPenalty,
+		ProgressionStreak:         wp.ProgressionStreak,
+		AutoDeloadAfterWeeks:      wp.AutoDeloadAfterWeeks,
+	}
+}
+
+// DecodePlannerSnapshot parses a snapshot encoded with encoding/json. A
+// snapshot taken before MuscleGroupRecencyPenalty or AutoDeloadAfterWeeks
+// was recorded gets the value NewPlanner would have set, not zero, so it
+// replays with the planner it was taken from.
+func DecodePlannerSnapshot(data []byte) (PlannerSnapshot, error) {
+	// The outer fields shadow the embedded ones, telling a missing field
+	// apart from a recorded zero.
+	var raw struct {
+		PlannerSnapshot
+
+		MuscleGroupRecencyPenalty *float64 `json:"muscle_group_recency_penalty"`
+		AutoDeloadAfterWeeks      *int     `json:"auto_deload_after_weeks"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return PlannerSnapshot{}, fmt.Errorf("decode planner snapshot: %w", err)
+	}
+	ps := raw.PlannerSnapshot
+	ps.MuscleGroupRecencyPenalty = DefaultMuscleGroupRecencyPenalty
+	if raw.MuscleGroupRecencyPenalty != nil {
+		ps.MuscleGroupRecencyPenalty = *raw.MuscleGroupRecencyPenalty
+	}
+	ps.AutoDeloadAfterWeeks = ps.Prefs.EffectiveDeloadInterval()
+	if raw.AutoDeloadAfterWeeks != nil {
+		ps.AutoDeloadAfterWeeks = *raw.AutoDeloadAfterWeeks
+	}
+	return ps, nil
+}
+
+// Planner rebuilds the Planner the snapshot was taken from.
+func (ps PlannerSnapshot) Planner() *Planner {
+	wp := NewPlanner(ps.Prefs, ps.Exercises, ps.Targets)
+	wp.LastPerformed = ps.LastPerformed
+	wp.MuscleGroupsLastTrained = ps.MuscleGroupsLastTrained
+	wp.MuscleGroupRecencyPenalty = ps.MuscleGroupRecencyPenalty
+	wp.ProgressionStreak = ps.ProgressionStreak
+	wp.AutoDeloadAfterWeeks = ps.AutoDeloadAfterWeeks
+	return wp
+}
+
+// Replay plans the snapshot's week again.
+func (ps PlannerSnapshot) Replay() (WeekPlan, error) {
+	return ps.Planner().Plan(ps.Monday)
+}
@@ -0,0 +1,77 @@
+package domain_test
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func TestPlannerSnapshot_JSONRoundTripReplaysSamePlan(t *testing.T) {
+	t.Parallel()
+
+	monday := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	exercises := seedExercises()
+	exercises[0].MinDaysBetween = 3
+	planner := domain.NewPlanner(prefs(time.Monday, time.Wednesday, time.Friday), exercises, seedTargets())
+	planner.LastPerformed = map[int]time.Time{exercises[0].ID: monday.AddDate(0, 0, -1)}
+	planner.MuscleGroupsLastTrained = map[string]time.Time{"Chest": monday.AddDate(0, 0, -1)}
+
+	want, err := planner.Plan(monday)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+
+	data, err := json.Marshal(planner.Snapshot(monday))
+	if err != nil {
+		t.Fatalf("marshal snapshot: %v", err)
+	}
+	snapshot, err := domain.DecodePlannerSnapshot(data)
+	if err != nil {
+		t.Fatalf("DecodePlannerSnapshot: %v", err)
+	}
+	got, err := snapshot.Replay()
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("replayed plan differs from the original:\n got %+v\nwant %+v", got, want)
+	}
+}
+
+func TestDecodePlannerSnapshot_DefaultsFieldsMissingFromOlderSnapshots(t *testing.T) {
+	t.Parallel()
+
+	old, err := domain.DecodePlannerSnapshot([]byte(`{"monday": "2026-03-02T00:00:00Z"}`))
+	if err != nil {
+		t.Fatalf("DecodePlannerSnapshot(old): %v", err)
+	}
+	if old.MuscleGroupRecencyPenalty != domain.DefaultMuscleGroupRecencyPenalty {
+		t.Errorf("missing MuscleGroupRecencyPenalty = %v, want default %v",
+			old.MuscleGroupRecencyPenalty, domain.DefaultMuscleGroupRecencyPenalty)
+	}
+	if old.AutoDeloadAfterWeeks != domain.DefaultDeloadInterval {
+		t.Errorf("missing AutoDeloadAfterWeeks = %d, want default %d",
+			old.AutoDeloadAfterWeeks, domain.DefaultDeloadInterval)
+	}
+
+	off, err := domain.DecodePlannerSnapshot([]byte(
+		`{"monday": "2026-03-02T00:00:00Z", "muscle_group_recency_penalty": 0, "auto_deload_after_weeks": 0}`))
+	if err != nil {
+		t.Fatalf("DecodePlannerSnapshot(off): %v", err)
+	}
+	if off.MuscleGroupRecencyPenalty != 0 || off.AutoDeloadAfterWeeks != 0 {
+		t.Errorf("recorded zeros decoded as penalty %v, auto deload after %d; want 0, 0",
+			off.MuscleGroupRecencyPenalty, off.AutoDeloadAfterWeeks)
+	}
+}
+
+func TestDecodePlannerSnapshot_RejectsMalformedJSON(t *testing.T) {
+	t.Parallel()
+
+	if _, err := domain.DecodePlannerSnapshot([]byte("{")); err == nil {
+		t.Error("want an error for malformed JSON")
+	}
+}
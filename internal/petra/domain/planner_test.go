@@ -1,7 +1,12 @@
 package domain_test
 
 import (
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -43,18 +48,30 @@ func TestPlanner_Plan_Validation(t *testing.T) {
 		}
 	})
 
-	t.Run("errors when a scheduled day has no compatible exercises", func(t *testing.T) {
+	t.Run("errors when the exercise pool is empty", func(t *testing.T) {
+		t.Parallel()
+		wp := domain.NewPlanner(prefs(time.Monday, time.Wednesday), nil, targets)
+		if _, err := wp.Plan(monday); err == nil {
+			t.Error("want error when the exercise pool is empty, got nil")
+		}
+	})
+
+	t.Run("falls back to full body when a scheduled day has no compatible exercises", func(t *testing.T) {
 		t.Parallel()
 		// Mon+Tue makes Monday a Lower day (tomorrow scheduled); a pool with no
-		// Lower exercises must fail.
+		// Lower exercises plans Monday as full body instead of failing.
 		upperOnly := []domain.Exercise{
 			{ //nolint:exhaustruct // Test exercise omits display fields.
 				ID: 1, Category: domain.CategoryUpper, ExerciseType: domain.ExerciseTypeWeighted,
 				PrimaryMuscleGroups: []string{"Chest"}, RepMin: new(5), RepMax: new(10)},
 		}
 		wp := domain.NewPlanner(prefs(time.Monday, time.Tuesday), upperOnly, targets)
-		if _, err := wp.Plan(monday); err == nil {
-			t.Error("want error when a scheduled day has no compatible exercises, got nil")
+		plan, err := wp.Plan(monday)
+		if err != nil {
+			t.Fatalf("Plan: %v", err)
+		}
+		if len(plan.Sessions[0].Slots) == 0 {
+			t.Error("want Monday planned from the full-body pool, got no slots")
 		}
 	})
 }
@@ -96,26 +113,32 @@ func TestPlanner_Plan_ExerciseCountForDuration(t *testing.T) {
 	// A single isolated workout day so it is always CategoryFullBody and draws
 	// freely from the pool; its goal is controlled by anchoring on a
 	// strength-first or hypertrophy-first week. The count is the observable
-	// contract: duration × session goal → exercises per session.
+	// contract: duration × session goal → exercises per session, capped by
+	// MaxExercisesPerSession when set.
 	tests := []struct {
-		name    string
-		minutes int
-		goal    domain.SessionGoal
-		want    int
+		name         string
+		minutes      int
+		goal         domain.SessionGoal
+		maxExercises int
+		want         int
 	}{
-		{"60 min strength", 60, domain.SessionGoalStrength, 3},
-		{"60 min hypertrophy", 60, domain.SessionGoalHypertrophy, 4},
-		{"90 min strength", 90, domain.SessionGoalStrength, 4},
-		{"90 min hypertrophy", 90, domain.SessionGoalHypertrophy, 5},
-		{"45 min strength", 45, domain.SessionGoalStrength, 2},
-		{"45 min hypertrophy", 45, domain.SessionGoalHypertrophy, 2},
+		{"60 min strength", 60, domain.SessionGoalStrength, 0, 3},
+		{"60 min hypertrophy", 60, domain.SessionGoalHypertrophy, 0, 4},
+		{"90 min strength", 90, domain.SessionGoalStrength, 0, 4},
+		{"90 min hypertrophy", 90, domain.SessionGoalHypertrophy, 0, 5},
+		{"45 min strength", 45, domain.SessionGoalStrength, 0, 2},
+		{"45 min hypertrophy", 45, domain.SessionGoalHypertrophy, 0, 2},
+		{"90 min hypertrophy capped at 3", 90, domain.SessionGoalHypertrophy, 3, 3},
+		{"90 min strength capped at 3", 90, domain.SessionGoalStrength, 3, 3},
+		{"45 min under a cap of 3", 45, domain.SessionGoalStrength, 3, 2},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			monday := mondayWithFirstGoal(t, tt.goal)
-			p := domain.Preferences{} //nolint:exhaustruct // Only Wednesday duration matters.
+			p := domain.Preferences{} //nolint:exhaustruct // Only Wednesday duration and the cap matter.
 			p.Minutes[time.Wednesday] = tt.minutes
+			p.MaxExercisesPerSession = tt.maxExercises
 			wp := domain.NewPlanner(p, seedExercises(), seedTargets())
 
 			plan, err := wp.Plan(monday)
@@ -532,6 +555,313 @@ func TestPlanner_Plan_SetCountRampsAcrossBlockAndDropsOnDeload(t *testing.T) {
 	}
 }
 
+func TestPlanner_PlanDay_LighterWeekendsTrimsWeekendSets(t *testing.T) {
+	t.Parallel()
+
+	monday := monday2026Date()
+	saturday := date(monday, 5)
+	bench := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+		ID: 1, Name: "Bench", Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+		PrimaryMuscleGroups: []string{"Chest"}, RepMin: new(8), RepMax: new(12)}
+	targets := []domain.MuscleGroupTarget{{MuscleGroupName: "Chest", MinSets: 10, MaxSets: 20}}
+
+	// planBench plans day on its own and returns the bench slot, so Monday
+	// and Saturday prescribe the same exercise.
+	planBench := func(p domain.Preferences, day time.Time) domain.ExerciseSlot {
+		t.Helper()
+		sess, err := domain.NewPlanner(p, []domain.Exercise{bench}, targets).PlanDay(day, nil, nil)
+		if err != nil {
+			t.Fatalf("PlanDay(%s): %v", day.Weekday(), err)
+		}
+		if len(sess.Slots) != 1 || sess.Slots[0].Exercise.ID != bench.ID {
+			t.Fatalf("PlanDay(%s) slots = %v, want only the bench", day.Weekday(), slotIDs(sess))
+		}
+		return sess.Slots[0]
+	}
+
+	p := prefs(time.Monday, time.Saturday)
+	p.LighterWeekends = true
+	weekday, weekend := planBench(p, monday), planBench(p, saturday)
+	if len(weekday.Sets) != 3 {
+		t.Errorf("Monday sets = %d, want the base 3", len(weekday.Sets))
+	}
+	if len(weekend.Sets) >= len(weekday.Sets) {
+		t.Errorf("Saturday sets = %d, want fewer than Monday's %d", len(weekend.Sets), len(weekday.Sets))
+	}
+
+	p.LighterWeekends = false
+	if got := len(planBench(p, saturday).Sets); got != len(weekday.Sets) {
+		t.Errorf("Saturday sets with the preference off = %d, want Monday's %d", got, len(weekday.Sets))
+	}
+}
+
+func TestPlanner_PlanDay_OrdersCompoundBeforeIsolation(t *testing.T) {
+	t.Parallel()
+
+	monday := monday2026Date()
+	squat := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+		ID: 1, Name: "Squat", Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+		PrimaryMuscleGroups: []string{"Quads", "Glutes"}, RepMin: new(5), RepMax: new(8)}
+	curl := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+		ID: 2, Name: "Curl", Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+		PrimaryMuscleGroups: []string{"Biceps"}, RepMin: new(8), RepMax: new(12)}
+	targets := []domain.MuscleGroupTarget{
+		{MuscleGroupName: "Quads", MinSets: 10, MaxSets: 20},
+		{MuscleGroupName: "Glutes", MinSets: 10, MaxSets: 20},
+		{MuscleGroupName: "Biceps", MinSets: 10, MaxSets: 20},
+	}
+	// Legs are already past their ceiling this week, so the curl scores
+	// higher and is picked first.
+	weekLoad := map[string]float64{"Quads": 20, "Glutes": 20}
+
+	p := prefs(time.Monday)
+	plan := func() []int {
+		t.Helper()
+		sess, err := domain.NewPlanner(p, []domain.Exercise{squat, curl}, targets).PlanDay(monday, nil, weekLoad)
+		if err != nil {
+			t.Fatalf("PlanDay: %v", err)
+		}
+		return slotIDs(sess)
+	}
+
+	if got, want := plan(), []int{squat.ID, curl.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("default order = %v, want the compound squat first %v", got, want)
+	}
+	p.PreserveExerciseOrder = true
+	if got, want := plan(), []int{curl.ID, squat.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("preserved order = %v, want selection order %v", got, want)
+	}
+}
+
+func TestPlanner_PlanDay_OrdersCompoundMissingMuscleGroupsByCategory(t *testing.T) {
+	t.Parallel()
+
+	// A squat whose muscle groups were lost scores nothing, so it is picked
+	// after the curl; its full body category must still put it first.
+	monday := monday2026Date()
+	squat := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields and muscle groups.
+		ID: 1, Name: "Squat", Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+		RepMin: new(5), RepMax: new(8)}
+	curl := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+		ID: 2, Name: "Curl", Category: domain.CategoryUpper, ExerciseType: domain.ExerciseTypeWeighted,
+		PrimaryMuscleGroups: []string{"Biceps"}, RepMin: new(8), RepMax: new(12)}
+	targets := []domain.MuscleGroupTarget{{MuscleGroupName: "Biceps", MinSets: 10, MaxSets: 20}}
+
+	sess, err := domain.NewPlanner(prefs(time.Monday), []domain.Exercise{squat, curl}, targets).PlanDay(monday, nil, nil)
+	if err != nil {
+		t.Fatalf("PlanDay: %v", err)
+	}
+	if got, want := slotIDs(sess), []int{squat.ID, curl.ID}; !reflect.DeepEqual(got, want) {
+		t.Errorf("order = %v, want the compound squat first %v", got, want)
+	}
+}
+
+func TestPlanner_PlanDay_HoldsBackExerciseWithinMinDaysBetween(t *testing.T) {
+	t.Parallel()
+
+	// Sunday's deadlift belongs to last week, so only its spacing, not the
+	// one-use-per-week rule, can keep it out of Monday's session.
+	monday := monday2026Date()
+	deadlift := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+		ID: 1, Name: "Deadlift", Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+		PrimaryMuscleGroups: []string{"Hamstrings", "Glutes"}, RepMin: new(3), RepMax: new(5), MinDaysBetween: 3}
+	legPress := domain.Exercise{ //nolint:exhaustruct // Test exercise omits display fields.
+		ID: 2, Name: "Leg Press", Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+		PrimaryMuscleGroups: []string{"Quads"}, RepMin: new(8), RepMax: new(12)}
+
+	tests := []struct {
+		name          string
+		lastPerformed map[int]time.Time
+		wantDeadlift  bool
+	}{
+		{"no history", nil, true},
+		{"done yesterday", map[int]time.Time{deadlift.ID: monday.AddDate(0, 0, -1)}, false},
+		{"done two days ago", map[int]time.Time{deadlift.ID: monday.AddDate(0, 0, -2)}, false},
+		{"done three days ago", map[int]time.Time{deadlift.ID: monday.AddDate(0, 0, -3)}, true},
+		{"only the spaced exercise is held back", map[int]time.Time{legPress.ID: monday.AddDate(0, 0, -1)}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wp := domain.NewPlanner(prefs(time.Monday), []domain.Exercise{deadlift, legPress}, nil)
+			wp.LastPerformed = tt.lastPerformed
+			sess, err := wp.PlanDay(monday, nil, nil)
+			if err != nil {
+				t.Fatalf("PlanDay: %v", err)
+			}
+			ids := slotIDs(sess)
+			if got := slices.Contains(ids, deadlift.ID); got != tt.wantDeadlift {
+				t.Errorf("deadlift planned = %t, want %t (slots %v)", got, tt.wantDeadlift, ids)
+			}
+			if !slices.Contains(ids, legPress.ID) {
+				t.Errorf("leg press missing from slots %v; it has no spacing", ids)
+			}
+		})
+	}
+}
+
+func TestPlanner_Plan_ConsecutiveDaysRepeatFewerMuscleGroups(t *testing.T) {
+	t.Parallel()
+
+	repeated := func(penalty float64) []string {
+		t.Helper()
+		wp := domain.NewPlanner(prefs(time.Monday, time.Tuesday), recencyExercises(), recencyTargets())
+		wp.MuscleGroupRecencyPenalty = penalty
+		plan, err := wp.Plan(monday2026Date())
+		if err != nil {
+			t.Fatalf("Plan: %v", err)
+		}
+		monday := primaryMuscleGroups(plan.Sessions[0])
+		var mgs []string
+		for mg := range primaryMuscleGroups(plan.Sessions[1]) {
+			if monday[mg] {
+				mgs = append(mgs, mg)
+			}
+		}
+		slices.Sort(mgs)
+		return mgs
+	}
+
+	unbiased := repeated(0)
+	biased := repeated(domain.DefaultMuscleGroupRecencyPenalty)
+	if len(biased) >= len(unbiased) {
+		t.Errorf("Tuesday repeats Monday's %v with the recency bias, %v without; want fewer with it", biased, unbiased)
+	}
+}
+
+func TestPlanner_PlanDay_DeprioritisesPreviousSessionMuscleGroups(t *testing.T) {
+	t.Parallel()
+
+	tue := date(monday2026Date(), 1)
+	tests := []struct {
+		name        string
+		lastTrained map[string]time.Time
+		wantFirst   []string
+	}{
+		{"no history", nil, []string{"Chest", "Quads", "Upper Back"}},
+		{
+			name: "trained yesterday",
+			lastTrained: map[string]time.Time{
+				"Chest": tue.AddDate(0, 0, -1), "Quads": tue.AddDate(0, 0, -1), "Upper Back": tue.AddDate(0, 0, -1),
+			},
+			wantFirst: []string{"Biceps", "Hamstrings"},
+		},
+		{
+			// Only the most recent session counts: Quads and Upper Back were
+			// trained before it and are fair game again.
+			name: "trained before the previous session",
+			lastTrained: map[string]time.Time{
+				"Chest": tue.AddDate(0, 0, -1), "Quads": tue.AddDate(0, 0, -3), "Upper Back": tue.AddDate(0, 0, -3),
+			},
+			wantFirst: []string{"Quads", "Upper Back"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wp := domain.NewPlanner(prefs(time.Tuesday), recencyExercises(), recencyTargets())
+			wp.MuscleGroupsLastTrained = tt.lastTrained
+			sess, err := wp.PlanDay(tue, nil, nil)
+			if err != nil {
+				t.Fatalf("PlanDay: %v", err)
+			}
+			mgs := primaryMuscleGroups(sess)
+			for _, mg := range tt.wantFirst {
+				if !mgs[mg] {
+					t.Errorf("session %v misses %s", slotIDs(sess), mg)
+				}
+			}
+		})
+	}
+}
+
+func TestPlanner_Plan_BeginnerSkewsTowardSimpleExercises(t *testing.T) {
+	t.Parallel()
+
+	// meanComplexity plans a week over the seed pool, rated 1–5 by ID, and
+	// returns the mean complexity of the planned exercises.
+	meanComplexity := func(beginner bool) float64 {
+		t.Helper()
+		exercises := seedExercises()
+		for i := range exercises {
+			exercises[i].Complexity = domain.MinComplexity + exercises[i].ID%domain.MaxComplexity
+		}
+		p := prefs(time.Monday, time.Wednesday, time.Friday)
+		p.Beginner = beginner
+		plan, err := domain.NewPlanner(p, exercises, seedTargets()).Plan(monday2026Date())
+		if err != nil {
+			t.Fatalf("Plan: %v", err)
+		}
+		var total, n int
+		for _, s := range planSessions(plan) {
+			for _, slot := range s.Slots {
+				total += slot.Exercise.Complexity
+				n++
+			}
+		}
+		if n == 0 {
+			t.Fatal("plan has no exercises")
+		}
+		return float64(total) / float64(n)
+	}
+
+	beginner, experienced := meanComplexity(true), meanComplexity(false)
+	if beginner >= experienced {
+		t.Errorf("mean complexity = %.2f for a beginner, %.2f otherwise; want the beginner's lower",
+			beginner, experienced)
+	}
+}
+
+// TestPlanner_ConcurrentPlanningMatchesSequential shares one Planner, history
+// maps included, across goroutines; run it with -race to catch a planning
+// path that writes to the Planner.
+func TestPlanner_ConcurrentPlanningMatchesSequential(t *testing.T) {
+	t.Parallel()
+
+	monday := monday2026Date()
+	exercises := seedExercises()
+	exercises[0].MinDaysBetween = 3
+	wp := domain.NewPlanner(prefs(time.Monday, time.Wednesday, time.Friday), exercises, seedTargets())
+	wp.LastPerformed = map[int]time.Time{exercises[0].ID: monday.AddDate(0, 0, -1)}
+	wp.MuscleGroupsLastTrained = map[string]time.Time{"Chest": monday.AddDate(0, 0, -1)}
+
+	wantWeek, err := wp.Plan(monday)
+	if err != nil {
+		t.Fatalf("Plan: %v", err)
+	}
+	wantDay, err := wp.PlanDay(date(monday, 1), nil, nil)
+	if err != nil {
+		t.Fatalf("PlanDay: %v", err)
+	}
+
+	const goroutines = 16
+	var wg sync.WaitGroup
+	errs := make(chan error, 2*goroutines)
+	for range goroutines {
+		wg.Go(func() {
+			week, planErr := wp.Plan(monday)
+			if planErr != nil {
+				errs <- planErr
+			} else if !reflect.DeepEqual(week, wantWeek) {
+				errs <- errors.New("concurrent Plan differs from the sequential plan")
+			}
+		})
+		wg.Go(func() {
+			day, planErr := wp.PlanDay(date(monday, 1), nil, nil)
+			if planErr != nil {
+				errs <- planErr
+			} else if !reflect.DeepEqual(day, wantDay) {
+				errs <- errors.New("concurrent PlanDay differs from the sequential plan")
+			}
+		})
+	}
+	wg.Wait()
+	close(errs)
+	for err = range errs {
+		t.Error(err)
+	}
+}
+
 // --- Scoring-driven selection (via PlanDay's public weekLoad / used seam) ---
 
 func TestPlanner_PlanDay_PrefersUnderTargetMuscle(t *testing.T) {
@@ -771,10 +1101,11 @@ func TestPlanner_PlanDay_SessionGoalMatchesWeeklyPlanner(t *testing.T) {
 	}
 }
 
-func TestPlanner_PlanDay_EmptyCategoryPoolReturnsError(t *testing.T) {
+func TestPlanner_PlanDay_EmptyCategoryPoolFallsBackToFullBody(t *testing.T) {
 	t.Parallel()
 
-	// Mon+Tue makes Monday a Lower day; a pool with no Lower exercises must error.
+	// Mon+Tue makes Monday a Lower day; a pool with no Lower exercises must
+	// still produce a workout, drawn from the full-body-compatible pool.
 	all := planDayExercises()
 	noLower := make([]domain.Exercise, 0, len(all))
 	for _, ex := range all {
@@ -784,10 +1115,132 @@ func TestPlanner_PlanDay_EmptyCategoryPoolReturnsError(t *testing.T) {
 	}
 	wp := domain.NewPlanner(prefs(time.Monday, time.Tuesday), noLower, nil)
 
+	skipped, fellBack := wp.CategoryFallback(monday2026Date())
+	if !fellBack || skipped != domain.CategoryLower {
+		t.Fatalf("CategoryFallback = (%s, %t), want (%s, true)", skipped, fellBack, domain.CategoryLower)
+	}
+	got, err := wp.PlanDay(monday2026Date(), nil, nil)
+	if err != nil {
+		t.Fatalf("PlanDay: %v", err)
+	}
+	if len(got.Slots) == 0 {
+		t.Fatal("want a full-body workout, got no slots")
+	}
+	for _, slot := range got.Slots {
+		if slot.Exercise.Category == domain.CategoryLower {
+			t.Errorf("slot %d is a Lower exercise; the pool has none", slot.Exercise.ID)
+		}
+	}
+
+	// Tuesday is an Upper day the pool can serve, so no fallback applies.
+	if _, fellBack = wp.CategoryFallback(date(monday2026Date(), 1)); fellBack {
+		t.Error("CategoryFallback(Tuesday) = true, want false for a servable Upper day")
+	}
+}
+
+func TestPlanner_DecideCategory_ExplainsTheChoice(t *testing.T) {
+	t.Parallel()
+
+	all := planDayExercises()
+	noLower := make([]domain.Exercise, 0, len(all))
+	for _, ex := range all {
+		if ex.Category != domain.CategoryLower {
+			noLower = append(noLower, ex)
+		}
+	}
+	monday := monday2026Date()
+	tests := []struct {
+		name       string
+		exercises  []domain.Exercise
+		date       time.Time
+		want       domain.Category
+		wantReason string
+	}{
+		{
+			"next day trains",
+			all,
+			monday,
+			domain.CategoryLower,
+			"Lower body because the next day, Tuesday, is a workout day that can train the upper body.",
+		},
+		{
+			"day before trained",
+			all,
+			date(monday, 1),
+			domain.CategoryUpper,
+			"Upper body because the day before, Monday, is a workout day, so the legs get to recover.",
+		},
+		{
+			"no neighbours",
+			all,
+			date(monday, 3),
+			domain.CategoryFullBody,
+			"Full body because neither the day before nor the day after is a workout day.",
+		},
+		{
+			"catalogue gap",
+			noLower,
+			monday,
+			domain.CategoryFullBody,
+			"Full body because the exercise catalogue has no lower body exercises yet.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wp := domain.NewPlanner(prefs(time.Monday, time.Tuesday), tt.exercises, nil)
+			got := wp.DecideCategory(tt.date)
+			if got.Category != tt.want || got.Reason != tt.wantReason {
+				t.Errorf("DecideCategory(%s) = %+v, want %s: %q", tt.date.Weekday(), got, tt.want, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestPlanner_PlanDayAs_UsesChosenCategory(t *testing.T) {
+	t.Parallel()
+
+	// Monday alone would be planned full body; the caller asks for upper.
+	wp := domain.NewPlanner(prefs(time.Monday), planDayExercises(), nil)
+	sess, err := wp.PlanDayAs(monday2026Date(), domain.CategoryUpper, nil, nil)
+	if err != nil {
+		t.Fatalf("PlanDayAs: %v", err)
+	}
+	if len(sess.Slots) == 0 {
+		t.Fatal("want an upper body workout, got no slots")
+	}
+	for _, slot := range sess.Slots {
+		if slot.Exercise.Category != domain.CategoryUpper {
+			t.Errorf("slot %d is %s, want upper", slot.Exercise.ID, slot.Exercise.Category)
+		}
+	}
+	if _, err = wp.PlanDayAs(monday2026Date(), domain.Category("arms"), nil, nil); err == nil {
+		t.Error("PlanDayAs must error on an unknown category")
+	}
+}
+
+func TestPlanner_PlanDay_EmptyPoolReturnsError(t *testing.T) {
+	t.Parallel()
+
 	// The sentinel (errNoExercisesForCategory) is unexported and purely internal,
-	// so the public contract is simply: an empty category pool yields an error.
+	// so the public contract is simply: an empty pool yields an error.
+	wp := domain.NewPlanner(prefs(time.Monday, time.Tuesday), nil, nil)
 	if _, err := wp.PlanDay(monday2026Date(), nil, nil); err == nil {
-		t.Fatal("PlanDay must error when the category pool is empty")
+		t.Fatal("PlanDay must error when the exercise pool is empty")
+	}
+}
+
+func TestPlanner_Plan_EmptyPoolErrorNamesFailingDay(t *testing.T) {
+	t.Parallel()
+
+	wp := domain.NewPlanner(prefs(time.Wednesday, time.Friday), nil, nil)
+	_, err := wp.Plan(monday2026Date())
+	if err == nil {
+		t.Fatal("Plan must error when the exercise pool is empty")
+	}
+	want := fmt.Sprintf("%s day (Wednesday)", domain.CategoryFullBody)
+	if !strings.Contains(err.Error(), want) {
+		t.Errorf("Plan error = %q, want it to name the failing %q", err, want)
 	}
 }
 
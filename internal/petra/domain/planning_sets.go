@@ -59,7 +59,7 @@ func BuildPlannedSets(exercise Exercise, goal SessionGoal, isDeload bool, weekSe
 	targetValue, n := deriveSchemeForExercise(exercise, goal, isDeload, weekSets)
 	sets := make([]Set, n)
 	for i := range sets {
-		sets[i] = Set{ //nolint:exhaustruct // WeightKg, CompletedValue, CompletedAt, Signal start nil.
+		sets[i] = Set{ //nolint:exhaustruct // WeightKg, CompletedValue, CompletedAt, Signal, Side start nil.
 			TargetValue: targetValue,
 		}
 	}
@@ -76,7 +76,8 @@ func BuildPlannedSets(exercise Exercise, goal SessionGoal, isDeload bool, weekSe
 // HasWeight exercises always get an allocated WeightKg pointer so the per-set
 // form has a non-nil binding target. When historicalSets contains a non-nil
 // WeightKg, the most recent one seeds every new set so the user's progression
-// isn't lost just because the prescription changed; otherwise the seed is 0.
+// isn't lost just because the prescription changed; otherwise the seed is the
+// exercise's StartWeightKg (DefaultStartWeightKg, or 0 when unset).
 // Bodyweight and time-based exercises stay nil.
 //
 // isDeload drops one set (floored at 2) and targets repMax (see BuildPlannedSets).
@@ -91,7 +92,7 @@ func BuildSetsForAdd(
 	if !exercise.HasWeight() {
 		return sets
 	}
-	var seedWeight float64
+	seedWeight := exercise.StartWeightKg()
 	for _, v := range slices.Backward(historicalSets) {
 		if v.WeightKg != nil {
 			seedWeight = *v.WeightKg
@@ -161,9 +161,9 @@ func Test_BuildSetsForAdd(t *testing.T) {
 	t.Run("weighted seeds from most recent non-nil historical weight", func(t *testing.T) {
 		t.Parallel()
 		history := []domain.Set{
-			{WeightKg: weightPtr(60), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
-			{WeightKg: weightPtr(62.5), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
-			{WeightKg: nil, TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil}, // never recorded
+			{WeightKg: weightPtr(60), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
+			{WeightKg: weightPtr(62.5), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
+			{WeightKg: nil, TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false}, // never recorded
 		}
 		sets := domain.BuildSetsForAdd(weighted, domain.SessionGoalHypertrophy, false, 4, history)
 		for i, s := range sets {
@@ -176,8 +176,8 @@ func Test_BuildSetsForAdd(t *testing.T) {
 	t.Run("weighted with history of all-nil weights allocates zero", func(t *testing.T) {
 		t.Parallel()
 		history := []domain.Set{
-			{WeightKg: nil, TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
-			{WeightKg: nil, TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
+			{WeightKg: nil, TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
+			{WeightKg: nil, TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
 		}
 		sets := domain.BuildSetsForAdd(weighted, domain.SessionGoalStrength, false, 4, history)
 		for i, s := range sets {
@@ -187,10 +187,37 @@ func Test_BuildSetsForAdd(t *testing.T) {
 		}
 	})
 
+	t.Run("weighted with no history seeds from DefaultStartWeightKg", func(t *testing.T) {
+		t.Parallel()
+		seeded := weighted
+		seeded.DefaultStartWeightKg = weightPtr(20)
+		sets := domain.BuildSetsForAdd(seeded, domain.SessionGoalStrength, false, 4, nil)
+		for i, s := range sets {
+			if s.WeightKg == nil || *s.WeightKg != 20 {
+				t.Errorf("set[%d].WeightKg = %v, want 20", i, s.WeightKg)
+			}
+		}
+	})
+
+	t.Run("historical weight wins over DefaultStartWeightKg", func(t *testing.T) {
+		t.Parallel()
+		seeded := weighted
+		seeded.DefaultStartWeightKg = weightPtr(20)
+		history := []domain.Set{
+			{WeightKg: weightPtr(35), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
+		}
+		sets := domain.BuildSetsForAdd(seeded, domain.SessionGoalStrength, false, 4, history)
+		for i, s := range sets {
+			if s.WeightKg == nil || *s.WeightKg != 35 {
+				t.Errorf("set[%d].WeightKg = %v, want 35", i, s.WeightKg)
+			}
+		}
+	})
+
 	t.Run("assisted preserves negative seed weight", func(t *testing.T) {
 		t.Parallel()
 		history := []domain.Set{
-			{WeightKg: weightPtr(-20), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
+			{WeightKg: weightPtr(-20), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
 		}
 		sets := domain.BuildSetsForAdd(assisted, domain.SessionGoalStrength, false, 4, history)
 		for i, s := range sets {
@@ -203,7 +230,7 @@ func Test_BuildSetsForAdd(t *testing.T) {
 	t.Run("bodyweight leaves WeightKg nil regardless of history", func(t *testing.T) {
 		t.Parallel()
 		history := []domain.Set{
-			{WeightKg: weightPtr(100), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
+			{WeightKg: weightPtr(100), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
 		}
 		sets := domain.BuildSetsForAdd(bodyweight, domain.SessionGoalStrength, false, 4, history)
 		for i, s := range sets {
@@ -216,7 +243,7 @@ func Test_BuildSetsForAdd(t *testing.T) {
 	t.Run("time-based leaves WeightKg nil regardless of history", func(t *testing.T) {
 		t.Parallel()
 		history := []domain.Set{
-			{WeightKg: weightPtr(100), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
+			{WeightKg: weightPtr(100), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
 		}
 		sets := domain.BuildSetsForAdd(timeBased, domain.SessionGoalStrength, false, 4, history)
 		for i, s := range sets {
@@ -229,7 +256,7 @@ func Test_BuildSetsForAdd(t *testing.T) {
 	t.Run("each set gets independent weight pointer", func(t *testing.T) {
 		t.Parallel()
 		history := []domain.Set{
-			{WeightKg: weightPtr(80), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil},
+			{WeightKg: weightPtr(80), TargetValue: 0, CompletedValue: nil, CompletedAt: nil, Signal: nil, Side: nil, DurationSeconds: nil, TechnicalFailure: false, Warmup: false},
 		}
 		sets := domain.BuildSetsForAdd(weighted, domain.SessionGoalStrength, false, 4, history)
 		if len(sets) < 2 {
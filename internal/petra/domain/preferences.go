@@ -1,6 +1,10 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"math"
+	"time"
+)
 
 // Preferences stores how long a user wants to work out each day of the week.
 // Minutes is indexed by time.Weekday (Sunday=0 … Saturday=6); a value of 0
@@ -12,6 +16,61 @@ type Preferences struct {
 	DeloadEnabled            bool
 	MesocycleLength          int
 	MesocycleAnchor          time.Time
+	// DeloadInterval, with DeloadEnabled, is how many weeks in a row a lift
+	// may gain weight before the planner deloads early, ahead of the end of
+	// the MesocycleLength block (see Planner.AutoDeloadDue). Read it through
+	// EffectiveDeloadInterval; zero means automatic, DefaultDeloadInterval.
+	DeloadInterval int
+	// MaxExercisesPerSession caps how many exercises the planner puts in a
+	// session, whatever its length and goal. Zero means no cap.
+	MaxExercisesPerSession int
+	// LighterWeekends prescribes one working set fewer per exercise on
+	// Saturday and Sunday sessions; see SetCountOn.
+	LighterWeekends bool
+	// PreserveExerciseOrder keeps a planned session's exercises in the order
+	// the planner picked them instead of putting compound lifts first.
+	PreserveExerciseOrder bool
+	// RoundWeightsDown snaps scaled loads — a weight carried across rep
+	// schemes or cut after a too-heavy set — to the lighter realisable load
+	// instead of the nearest, for users who prefer to err conservative.
+	RoundWeightsDown bool
+	// ProgressionAggressiveness multiplies every weight increment the
+	// progression adds after a too-light set: above 1 for users on a bulk who
+	// want faster progression, below 1 for users cutting who mostly want to
+	// maintain. Read it through EffectiveProgressionAggressiveness; zero means
+	// the default.
+	ProgressionAggressiveness float64
+	// Beginner marks a user new to strength training: the planner prefers
+	// exercises with a low Exercise.Complexity for them (see
+	// complexityPenalty).
+	Beginner bool
+	// PreferredWorkoutTime is when the user usually starts a workout, used to
+	// time reminders on workout days. Nil means no reminders.
+	PreferredWorkoutTime *TimeOfDay
+}
+
+const (
+	// DefaultProgressionAggressiveness leaves weight increments unchanged.
+	DefaultProgressionAggressiveness = 1.0
+	// MinProgressionAggressiveness and MaxProgressionAggressiveness bound the
+	// multiplier so an increment is never less than half or more than double
+	// the standard plate step.
+	MinProgressionAggressiveness = 0.5
+	MaxProgressionAggressiveness = 2.0
+)
+
+// EffectiveProgressionAggressiveness returns ProgressionAggressiveness clamped
+// to [MinProgressionAggressiveness, MaxProgressionAggressiveness], with zero
+// or NaN meaning DefaultProgressionAggressiveness.
+func (p Preferences) EffectiveProgressionAggressiveness() float64 {
+	return clampAggressiveness(p.ProgressionAggressiveness)
+}
+
+func clampAggressiveness(m float64) float64 {
+	if m == 0 || math.IsNaN(m) {
+		return DefaultProgressionAggressiveness
+	}
+	return math.Min(math.Max(m, MinProgressionAggressiveness), MaxProgressionAggressiveness)
 }
 
 // IsEmpty reports whether no workout days are scheduled.
@@ -34,3 +93,16 @@ func (p Preferences) MinutesForDay(weekday time.Weekday) int {
 func (p Preferences) IsWorkoutDay(weekday time.Weekday) bool {
 	return p.MinutesForDay(weekday) > 0
 }
+
+// ValidateMaxExercisesPerSession reports whether n is an acceptable
+// Preferences.MaxExercisesPerSession: zero (no cap) or 1 through
+// MaxExercisesPerSessionLimit. The error is a ValidationError safe to show the
+// user.
+func ValidateMaxExercisesPerSession(n int) error {
+	if n < 0 || n > MaxExercisesPerSessionLimit {
+		return ValidationError{Message: fmt.Sprintf(
+			"Exercises per workout must be between 1 and %d, or no limit.", MaxExercisesPerSessionLimit,
+		)}
+	}
+	return nil
+}
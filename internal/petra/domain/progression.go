@@ -14,6 +14,15 @@ type Config struct {
 	RepMax         int
 	StartingWeight float64 // kg; caller-derived from history, may be user-overridden
 	IsDeload       bool
+	RoundDown      bool // Snap scaled-down loads to the lighter realisable load; see Preferences.RoundWeightsDown.
+	// Aggressiveness scales the increment added after a too-light set; see
+	// Preferences.ProgressionAggressiveness. It is clamped to the same band,
+	// and zero means 1.
+	Aggressiveness float64
+	// MaxConsecutiveDecreases is how many too-heavy sets in a row may each
+	// lower the weight; the next one holds it instead. Zero means
+	// DefaultMaxConsecutiveDecreases.
+	MaxConsecutiveDecreases int
 }
 
 // SetTarget is what the progression recommends for the upcoming set: the load
@@ -24,6 +33,11 @@ type Config struct {
 type SetTarget struct {
 	WeightKg    float64
 	TargetValue int
+	// Stalled marks a weight held after a run of too-heavy sets rather than
+	// lowered again; see Config.MaxConsecutiveDecreases. The set page then
+	// suggests checking form or recovery, since more load cuts are not
+	// fixing whatever makes the sets feel heavy.
+	Stalled bool
 }
 
 // AbsWeightKg returns the unsigned magnitude of WeightKg. Assisted exercises
@@ -38,6 +52,9 @@ type SetResult struct {
 	ActualValue int
 	Signal      Signal
 	WeightKg    float64 // weight actually used; may differ from recommendation if user overrode
+	// TechnicalFailure marks reps reached with form breaking down. The next
+	// set holds the weight rather than progressing, whatever the signal says.
+	TechnicalFailure bool
 }
 
 const (
@@ -49,6 +66,10 @@ const (
 	weightIncrementKgLow  = 1.0
 	weightIncrementKgHigh = 2.5
 	weightDecrementFactor = 0.10
+
+	// DefaultMaxConsecutiveDecreases lets two too-heavy sets in a row lower
+	// the weight before the progression holds it.
+	DefaultMaxConsecutiveDecreases = 2
 )
 
 // Progression manages set-to-set weight progression for one exercise execution.
@@ -77,17 +98,25 @@ func NewProgressionFromHistory(config Config, completed []SetResult) *Progressio
 // — no autoregulation, just the override. This lets a one-time correction
 // (e.g. dropping a seeded 61 kg to 60 kg because that's what the rack offers)
 // propagate to the remaining sets without forcing the user to re-enter it.
+//
+// A run of too-heavy sets lowers the weight at most
+// Config.MaxConsecutiveDecreases times. Past that the weight is held and the
+// target marked Stalled: a user who rates every set too heavy would otherwise
+// be walked down towards an empty bar.
 func (p *Progression) CurrentSet() SetTarget {
 	reps := DeriveScheme(p.config.RepMin, p.config.RepMax, p.config.Type, p.config.IsDeload).TargetReps
 	if len(p.completed) == 0 {
-		return SetTarget{WeightKg: p.config.StartingWeight, TargetValue: reps}
+		return SetTarget{WeightKg: p.config.StartingWeight, TargetValue: reps, Stalled: false}
 	}
 	last := p.completed[len(p.completed)-1]
 	if p.config.IsDeload {
-		return SetTarget{WeightKg: last.WeightKg, TargetValue: reps}
+		return SetTarget{WeightKg: last.WeightKg, TargetValue: reps, Stalled: false}
 	}
-	weight := adjustedWeight(last)
-	return SetTarget{WeightKg: weight, TargetValue: reps}
+	if p.consecutiveTooHeavy() > p.maxConsecutiveDecreases() {
+		return SetTarget{WeightKg: last.WeightKg, TargetValue: reps, Stalled: true}
+	}
+	weight := adjustedWeight(last, p.config.RoundDown, p.config.Aggressiveness)
+	return SetTarget{WeightKg: weight, TargetValue: reps, Stalled: false}
 }
 
 // RecordCompletion records what actually happened and advances internal state.
@@ -100,14 +129,36 @@ func (p *Progression) SetsCompleted() int {
 	return len(p.completed)
 }
 
-func adjustedWeight(last SetResult) float64 {
+// consecutiveTooHeavy counts the too-heavy sets at the end of the history.
+func (p *Progression) consecutiveTooHeavy() int {
+	n := 0
+	for i := len(p.completed) - 1; i >= 0 && p.completed[i].Signal == SignalTooHeavy; i-- {
+		n++
+	}
+	return n
+}
+
+func (p *Progression) maxConsecutiveDecreases() int {
+	if p.config.MaxConsecutiveDecreases <= 0 {
+		return DefaultMaxConsecutiveDecreases
+	}
+	return p.config.MaxConsecutiveDecreases
+}
+
+func adjustedWeight(last SetResult, roundDown bool, aggressiveness float64) float64 {
 	switch last.Signal {
 	case SignalTooLight:
-		return snapWeight(last.WeightKg + incrementFor(last.WeightKg))
+		if last.TechnicalFailure {
+			// The reps came easily but the form did not: adding load would
+			// build on the breakdown, so hold until a clean set.
+			return last.WeightKg
+		}
+		step := incrementFor(last.WeightKg) * clampAggressiveness(aggressiveness)
+		return snapWeightUp(last.WeightKg + step)
 	case SignalTooHeavy:
 		increment := incrementFor(last.WeightKg)
 		decrement := math.Max(increment, math.Abs(last.WeightKg)*weightDecrementFactor)
-		return snapWeight(last.WeightKg - decrement)
+		return snapWeight(last.WeightKg-decrement, roundDown)
 	case SignalOnTarget:
 		return last.WeightKg
 	default:
@@ -127,15 +178,40 @@ func incrementFor(weight float64) float64 {
 	return weightIncrementKgHigh
 }
 
-// snapWeight rounds a kilo value to the nearest realisable load: 1kg in the
+// snapWeight rounds a kilo value to a realisable load: 1kg steps in the
 // dumbbell range (|w| < 10kg), 0.5kg above. User overrides may sit off-grid,
-// so each per-set adjustment is snapped before being recommended.
-func snapWeight(kg float64) float64 {
+// so each per-set adjustment is snapped before being recommended. It rounds
+// to the nearest step, or with roundDown to the step at or below, which for
+// assisted loads (negative) means more assistance: either way the easier of
+// the two neighbours.
+func snapWeight(kg float64, roundDown bool) float64 {
+	step := 0.5
+	if math.Abs(kg) < dumbbellThresholdKg {
+		step = 1
+	}
+	if roundDown {
+		// The epsilon keeps a load already on the grid, give or take float
+		// error, from dropping a whole step.
+		const epsilon = 1e-9
+		return math.Floor(kg/step+epsilon) * step
+	}
+	return math.Round(kg/step) * step
+}
+
+// snapWeightUp snaps a progressed load to the grid step at or above it, on
+// the grid snapWeight uses. Rounding to the nearest step instead could undo a
+// scaled-down increment: half of the 1kg dumbbell step from 6kg is 6.5kg,
+// which rounds back to 6kg, and an assisted -6kg would stall the same way. A
+// too-light set therefore always gains at least one realisable step.
+func snapWeightUp(kg float64) float64 {
+	step := 0.5
 	if math.Abs(kg) < dumbbellThresholdKg {
-		return math.Round(kg)
+		step = 1
 	}
-	const halfKg = 0.5
-	return math.Round(kg/halfKg) * halfKg
+	// The epsilon keeps a load already on the grid, give or take float error,
+	// from gaining a whole step.
+	const epsilon = 1e-9
+	return math.Ceil(kg/step-epsilon) * step
 }
 
 // DeloadSeedWeight applies a deload reduction to a working weight, returning
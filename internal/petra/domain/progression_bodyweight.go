@@ -0,0 +1,89 @@
+package domain
+
+const (
+	// AddedLoadStartKg is the first external load suggested once a bodyweight
+	// exercise is outgrown: the smallest plate most gyms stock, light enough
+	// that the rep target stays reachable on the first loaded session.
+	AddedLoadStartKg = 2.5
+
+	// outgrownSessions is how many consecutive sessions must top out the rep
+	// range before added load is suggested. One strong day can be a fluke;
+	// two in a row means more reps have stopped being a useful stimulus.
+	outgrownSessions = 2
+)
+
+// ReadyForAddedLoad reports whether a bodyweight exercise has been outgrown:
+// in each of the outgrownSessions most recent sessions in history (most
+// recent first), every completed set reached at least repMax reps. Sessions
+// with no completed set are skipped, so an abandoned session neither counts
+// towards nor breaks the streak. Bodyweight exercises have no load to
+// progress, so without this the only way forward is ever more reps.
+func ReadyForAddedLoad(repMax int, history []ExerciseSetHistory) bool {
+	streak := 0
+	for _, h := range history {
+		completed, toppedOut := 0, true
+		for _, set := range h.Sets {
+			if set.CompletedValue == nil {
+				continue
+			}
+			completed++
+			if *set.CompletedValue < repMax {
+				toppedOut = false
+			}
+		}
+		if completed == 0 {
+			continue
+		}
+		if !toppedOut {
+			return false
+		}
+		streak++
+		if streak == outgrownSessions {
+			return true
+		}
+	}
+	return false
+}
+
+// NextAddedLoad returns the external load to prescribe for the next session
+// of a bodyweight exercise, given its history most recent first, and false
+// while the exercise is still done at bodyweight alone. Once the latest
+// session with completed sets carried added load, the exercise stays loaded:
+// the load is kept, and raised by AddedLoadStartKg after a session whose
+// every completed set reached repMax. Otherwise AddedLoadStartKg is
+// prescribed once ReadyForAddedLoad.
+func NextAddedLoad(repMax int, history []ExerciseSetHistory) (float64, bool) {
+	for _, h := range history {
+		var (
+			completed int
+			load      float64
+			toppedOut = true
+		)
+		for _, set := range h.Sets {
+			if set.CompletedValue == nil {
+				continue
+			}
+			completed++
+			if *set.CompletedValue < repMax {
+				toppedOut = false
+			}
+			if set.WeightKg != nil {
+				load = max(load, *set.WeightKg)
+			}
+		}
+		if completed == 0 {
+			continue
+		}
+		if load <= 0 {
+			break
+		}
+		if toppedOut {
+			load += AddedLoadStartKg
+		}
+		return load, true
+	}
+	if ReadyForAddedLoad(repMax, history) {
+		return AddedLoadStartKg, true
+	}
+	return 0, false
+}
@@ -0,0 +1,99 @@
+package domain_test
+
+import (
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_ReadyForAddedLoad(t *testing.T) {
+	t.Parallel()
+
+	session := func(reps ...int) domain.ExerciseSetHistory {
+		sets := make([]domain.Set, len(reps))
+		for i, r := range reps {
+			if r >= 0 {
+				sets[i].CompletedValue = new(r)
+			}
+		}
+		return domain.ExerciseSetHistory{Sets: sets} //nolint:exhaustruct // Date is not read.
+	}
+
+	const repMax = 10
+	cases := []struct {
+		name    string
+		history []domain.ExerciseSetHistory
+		want    bool
+	}{
+		{"no history", nil, false},
+		{"one topped-out session", []domain.ExerciseSetHistory{session(10, 10, 10)}, false},
+		{"two topped-out sessions", []domain.ExerciseSetHistory{session(12, 11, 10), session(10, 10, 10)}, true},
+		{"latest session below repMax", []domain.ExerciseSetHistory{
+			session(10, 9, 10), session(10, 10, 10), session(10, 10, 10),
+		}, false},
+		{"older miss does not matter once the streak is complete", []domain.ExerciseSetHistory{
+			session(10, 10), session(10, 10), session(6, 6),
+		}, true},
+		{"session without completed sets is skipped", []domain.ExerciseSetHistory{
+			session(10, 10), session(-1, -1), session(10, 10),
+		}, true},
+		{"uncompleted sets in a session are ignored", []domain.ExerciseSetHistory{
+			session(10, 10, -1), session(10, 10, 10),
+		}, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			if got := domain.ReadyForAddedLoad(repMax, tc.history); got != tc.want {
+				t.Errorf("ReadyForAddedLoad = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func Test_NextAddedLoad(t *testing.T) {
+	t.Parallel()
+
+	// session completes one set per reps value, with kg of added load on
+	// each (0 for none).
+	session := func(kg float64, reps ...int) domain.ExerciseSetHistory {
+		sets := make([]domain.Set, len(reps))
+		for i, r := range reps {
+			sets[i].CompletedValue = new(r)
+			if kg > 0 {
+				sets[i].WeightKg = new(kg)
+			}
+		}
+		return domain.ExerciseSetHistory{Sets: sets} //nolint:exhaustruct // Date is not read.
+	}
+
+	const repMax = 10
+	cases := []struct {
+		name    string
+		history []domain.ExerciseSetHistory
+		wantKg  float64
+		wantOK  bool
+	}{
+		{"no history", nil, 0, false},
+		{"still progressing reps", []domain.ExerciseSetHistory{session(0, 9, 8), session(0, 8, 8)}, 0, false},
+		{"outgrown starts added load", []domain.ExerciseSetHistory{session(0, 10, 10), session(0, 11, 10)},
+			domain.AddedLoadStartKg, true},
+		{"loaded session below repMax keeps the load", []domain.ExerciseSetHistory{
+			session(5, 8, 7), session(0, 10, 10), session(0, 10, 10),
+		}, 5, true},
+		{"loaded session topping out adds a step", []domain.ExerciseSetHistory{session(5, 10, 10)},
+			5 + domain.AddedLoadStartKg, true},
+		{"back to bodyweight below repMax stops the load", []domain.ExerciseSetHistory{
+			session(0, 7, 6), session(5, 10, 10),
+		}, 0, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			kg, ok := domain.NextAddedLoad(repMax, tc.history)
+			if kg != tc.wantKg || ok != tc.wantOK {
+				t.Errorf("NextAddedLoad = (%v, %v), want (%v, %v)", kg, ok, tc.wantKg, tc.wantOK)
+			}
+		})
+	}
+}
@@ -120,7 +120,7 @@ func TestConvertWeight(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
-			got := domain.ConvertWeight(tt.weight, tt.fromReps, tt.toReps)
+			got := domain.ConvertWeight(tt.weight, tt.fromReps, tt.toReps, false)
 			if got != tt.want {
 				t.Errorf("ConvertWeight(%v, %d, %d) = %v; want %v",
 					tt.weight, tt.fromReps, tt.toReps, got, tt.want)
@@ -129,6 +129,24 @@ func TestConvertWeight(t *testing.T) {
 	}
 }
 
+func TestConvertWeight_RoundDownIsLighterThanNearest(t *testing.T) {
+	t.Parallel()
+
+	// 100 kg x3 → x8: 110 / (1 + 8/30) ≈ 86.84 kg.
+	nearest := domain.ConvertWeight(100, 3, 8, false)
+	down := domain.ConvertWeight(100, 3, 8, true)
+	if nearest != 87 || down != 86.5 {
+		t.Errorf("ConvertWeight(100, 3, 8) = %v nearest, %v down; want 87, 86.5", nearest, down)
+	}
+	if down >= nearest {
+		t.Errorf("round-down %v is not lighter than nearest %v", down, nearest)
+	}
+	// A load already on the grid stays put: 100 kg x5 → x10 is exactly 87.5.
+	if got := domain.ConvertWeight(100, 5, 10, true); got != 87.5 {
+		t.Errorf("ConvertWeight(100, 5, 10, down) = %v, want 87.5", got)
+	}
+}
+
 // FuzzConvertWeight asserts the structural invariants ConvertWeight must hold
 // across its realistic input domain (finite loads up to 1e6 kg, rep counts
 // within ±100 so we exercise both the non-positive guard and the 1..50 working
@@ -167,7 +185,7 @@ func FuzzConvertWeight(f *testing.F) {
 			t.Skip()
 		}
 
-		got := domain.ConvertWeight(weight, fromReps, toReps)
+		got := domain.ConvertWeight(weight, fromReps, toReps, false)
 
 		// Finite, bounded input must never produce NaN or ±Inf.
 		if math.IsNaN(got) || math.IsInf(got, 0) {
@@ -12,6 +12,14 @@ const (
 	restMid  = 150 // seconds
 	restHigh = 90  // seconds
 
+	// Reps-in-reserve guidance: how many more reps the lifter should feel
+	// they could have done at the end of a set. Strength work stays further
+	// from failure to keep bar speed and technique; hypertrophy work needs
+	// proximity to failure; deload keeps well clear of it.
+	rirStrength    = 3
+	rirHypertrophy = 2
+	rirDeload      = 4
+
 	// deloadSetFloor is the minimum set count a deload prescription will return.
 	// Preserves at least two working sets per exercise so deload still functions
 	// as training rather than a single confirmation set.
@@ -24,8 +32,9 @@ const (
 // mesocycle week drives set count (see Preferences.SetCountFor / BuildPlannedSets), not the
 // goal-derived rep band.
 type Scheme struct {
-	TargetReps  int
-	RestSeconds int
+	TargetReps    int
+	RestSeconds   int
+	RepsInReserve int // Display-only effort guidance; never stored or used for progression.
 }
 
 // DeriveScheme returns the rep target and inter-set rest for one exercise given
@@ -45,18 +54,24 @@ type Scheme struct {
 //	reps ≤ 5  → 180s rest  (heavy work, full ATP-PCr recovery)
 //	reps 6-10 → 150s rest  (moderate; longer rest improves hypertrophy in trained lifters per Schoenfeld 2016)
 //	reps ≥ 11 → 90s rest   (lighter; rest shortens)
+//
+// Reps in reserve (RIR) is derived from the goal:
+//
+//	Strength    → 3 RIR
+//	Hypertrophy → 2 RIR
+//	Deload      → 4 RIR
 func DeriveScheme(repMin, repMax int, p SessionGoal, isDeload bool) Scheme {
 	if isDeload {
 		// Deload forces hypertrophy targets (repMax) regardless of incoming p.
 		p = SessionGoalHypertrophy
 	}
 
-	var reps int
+	var reps, rir int
 	switch p {
 	case SessionGoalStrength:
-		reps = repMin
+		reps, rir = repMin, rirStrength
 	case SessionGoalHypertrophy:
-		reps = repMax
+		reps, rir = repMax, rirHypertrophy
 	default:
 		panic(fmt.Sprintf("domain: unknown SessionGoal %q", p))
 	}
@@ -71,7 +86,11 @@ func DeriveScheme(repMin, repMax int, p SessionGoal, isDeload bool) Scheme {
 		rest = restHigh
 	}
 
-	return Scheme{TargetReps: reps, RestSeconds: rest}
+	if isDeload {
+		rir = rirDeload
+	}
+
+	return Scheme{TargetReps: reps, RestSeconds: rest, RepsInReserve: rir}
 }
 
 // deloadSets reduces the week's base set count by one, floored at deloadSetFloor,
@@ -97,3 +116,31 @@ func RestSecondsFor(ex Exercise, pt SessionGoal, isDeload bool) int {
 	}
 	return DeriveScheme(*ex.RepMin, *ex.RepMax, pt, isDeload).RestSeconds
 }
+
+// Bounds for a user's per-exercise rest override, in seconds.
+const (
+	MinRestOverrideSeconds = 10
+	MaxRestOverrideSeconds = 600
+)
+
+// ValidateRestOverride reports whether seconds is an acceptable per-exercise
+// rest override, returning a ValidationError safe to show the user if not.
+func ValidateRestOverride(seconds int) error {
+	if seconds < MinRestOverrideSeconds || seconds > MaxRestOverrideSeconds {
+		return ValidationError{Message: fmt.Sprintf(
+			"Rest must be between %d and %d seconds.", MinRestOverrideSeconds, MaxRestOverrideSeconds,
+		)}
+	}
+	return nil
+}
+
+// RepsInReserveFor returns the reps-in-reserve guidance for the given exercise
+// under the session's goal, or nil when the exercise is not rep-based (timed
+// holds, or a missing rep range). Display-only: completed sets never record it.
+func RepsInReserveFor(ex Exercise, pt SessionGoal, isDeload bool) *int {
+	if ex.IsTimed() || ex.RepMin == nil || ex.RepMax == nil {
+		return nil
+	}
+	rir := DeriveScheme(*ex.RepMin, *ex.RepMax, pt, isDeload).RepsInReserve
+	return &rir
+}
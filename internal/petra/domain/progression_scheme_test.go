@@ -219,3 +219,47 @@ func Test_deloadSets(t *testing.T) {
 		})
 	}
 }
+
+func TestRepsInReserveFor(t *testing.T) {
+	t.Parallel()
+
+	weighted := domain.Exercise{ //nolint:exhaustruct // Only fields read by RepsInReserveFor are set.
+		ExerciseType: domain.ExerciseTypeWeighted,
+		RepMin:       new(5),
+		RepMax:       new(10),
+	}
+	timed := domain.Exercise{ //nolint:exhaustruct // Only fields read by RepsInReserveFor are set.
+		ExerciseType:           domain.ExerciseTypeTime,
+		DefaultStartingSeconds: new(30),
+	}
+
+	tests := []struct {
+		name     string
+		ex       domain.Exercise
+		goal     domain.SessionGoal
+		isDeload bool
+		want     *int
+	}{
+		{"strength keeps 3 in reserve", weighted, domain.SessionGoalStrength, false, new(3)},
+		{"hypertrophy keeps 2 in reserve", weighted, domain.SessionGoalHypertrophy, false, new(2)},
+		{"deload keeps 4 in reserve regardless of goal", weighted, domain.SessionGoalStrength, true, new(4)},
+		{"timed hold has no RIR", timed, domain.SessionGoalStrength, false, nil},
+		{"missing rep range has no RIR", domain.Exercise{ //nolint:exhaustruct // Rep range deliberately unset.
+			ExerciseType: domain.ExerciseTypeWeighted,
+		}, domain.SessionGoalHypertrophy, false, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := domain.RepsInReserveFor(tt.ex, tt.goal, tt.isDeload)
+			switch {
+			case tt.want == nil && got != nil:
+				t.Errorf("RepsInReserveFor() = %d, want nil", *got)
+			case tt.want != nil && got == nil:
+				t.Errorf("RepsInReserveFor() = nil, want %d", *tt.want)
+			case tt.want != nil && *got != *tt.want:
+				t.Errorf("RepsInReserveFor() = %d, want %d", *got, *tt.want)
+			}
+		})
+	}
+}
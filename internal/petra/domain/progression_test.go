@@ -51,11 +51,14 @@ func TestCurrentSet_FirstSet(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			p := domain.NewProgression(domain.Config{
-				Type:           tt.goal,
-				RepMin:         tt.repMin,
-				RepMax:         tt.repMax,
-				StartingWeight: tt.startingWeight,
-				IsDeload:       false,
+				Type:                    tt.goal,
+				RepMin:                  tt.repMin,
+				RepMax:                  tt.repMax,
+				StartingWeight:          tt.startingWeight,
+				IsDeload:                false,
+				RoundDown:               false,
+				Aggressiveness:          1,
+				MaxConsecutiveDecreases: 0,
 			})
 			got := p.CurrentSet()
 			if got.TargetValue != tt.wantReps {
@@ -99,11 +102,14 @@ func TestCurrentSet_SignalAdjustment(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 			p := domain.NewProgression(domain.Config{
-				Type:           domain.SessionGoalHypertrophy,
-				RepMin:         5,
-				RepMax:         8,
-				StartingWeight: startWeight,
-				IsDeload:       false,
+				Type:                    domain.SessionGoalHypertrophy,
+				RepMin:                  5,
+				RepMax:                  8,
+				StartingWeight:          startWeight,
+				IsDeload:                false,
+				RoundDown:               false,
+				Aggressiveness:          1,
+				MaxConsecutiveDecreases: 0,
 			})
 			p.RecordCompletion(domain.SetResult{
 				ActualValue: 8,
@@ -123,11 +129,14 @@ func TestCurrentSet_TooHeavyRounding(t *testing.T) {
 
 	// 23kg: |w|*0.10 = 2.3, below the 2.5kg minimum step → 23 - 2.5 = 20.5
 	p := domain.NewProgression(domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         5,
-		RepMax:         8,
-		StartingWeight: 23.0,
-		IsDeload:       false,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  5,
+		RepMax:                  8,
+		StartingWeight:          23.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	})
 	p.RecordCompletion(domain.SetResult{
 		ActualValue: 5,
@@ -140,17 +149,122 @@ func TestCurrentSet_TooHeavyRounding(t *testing.T) {
 	}
 }
 
+func TestCurrentSet_TooLightScalesWithAggressiveness(t *testing.T) {
+	t.Parallel()
+
+	// The 2.5kg plate step from 100kg, scaled and snapped to the 0.5kg grid:
+	// half of it is 1.25, which rounds to 1.5. Out-of-band multipliers clamp.
+	for _, tt := range []struct {
+		aggressiveness float64
+		want           float64
+	}{{0.5, 101.5}, {1, 102.5}, {2, 105}, {0, 102.5}, {0.1, 101.5}, {4, 105}} {
+		p := domain.NewProgression(domain.Config{
+			Type:                    domain.SessionGoalHypertrophy,
+			RepMin:                  5,
+			RepMax:                  8,
+			StartingWeight:          100,
+			IsDeload:                false,
+			RoundDown:               false,
+			Aggressiveness:          tt.aggressiveness,
+			MaxConsecutiveDecreases: 0,
+		})
+		p.RecordCompletion(domain.SetResult{
+			ActualValue: 8,
+			Signal:      domain.SignalTooLight,
+			WeightKg:    100,
+		})
+		if got := p.CurrentSet().WeightKg; got != tt.want {
+			t.Errorf("Aggressiveness %v: WeightKg = %v, want %v", tt.aggressiveness, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentSet_TooLightScaledStepStillProgressesOnDumbbellGrid(t *testing.T) {
+	t.Parallel()
+
+	// Below 10kg the grid is 1kg, so a scaled-down step must still move one
+	// whole kilo rather than round back to the weight just lifted.
+	for _, tt := range []struct {
+		name           string
+		weight         float64
+		aggressiveness float64
+		want           float64
+	}{
+		{name: "dumbbell at half steps", weight: 6, aggressiveness: 0.5, want: 7},
+		{name: "dumbbell at gentle steps", weight: 6, aggressiveness: 0.75, want: 7},
+		{name: "dumbbell at double steps", weight: 6, aggressiveness: 2, want: 8},
+		{name: "assisted at half steps", weight: -6, aggressiveness: 0.5, want: -5},
+		{name: "assisted at gentle steps", weight: -6, aggressiveness: 0.75, want: -5},
+		{name: "assisted at double steps", weight: -6, aggressiveness: 2, want: -4},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := domain.NewProgression(domain.Config{
+				Type:                    domain.SessionGoalHypertrophy,
+				RepMin:                  8,
+				RepMax:                  12,
+				StartingWeight:          tt.weight,
+				IsDeload:                false,
+				RoundDown:               false,
+				Aggressiveness:          tt.aggressiveness,
+				MaxConsecutiveDecreases: 0,
+			})
+			p.RecordCompletion(domain.SetResult{
+				ActualValue:      12,
+				Signal:           domain.SignalTooLight,
+				WeightKg:         tt.weight,
+				TechnicalFailure: false,
+			})
+			if got := p.CurrentSet().WeightKg; got != tt.want {
+				t.Errorf("WeightKg after a too-light %v kg set = %v, want %v", tt.weight, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCurrentSet_TooHeavyRoundDown(t *testing.T) {
+	t.Parallel()
+
+	// 67kg: 67 - 6.7 = 60.3, which rounds to 60.5 but down to 60.
+	for _, tt := range []struct {
+		roundDown bool
+		want      float64
+	}{{false, 60.5}, {true, 60}} {
+		p := domain.NewProgression(domain.Config{
+			Type:                    domain.SessionGoalHypertrophy,
+			RepMin:                  5,
+			RepMax:                  8,
+			StartingWeight:          67,
+			IsDeload:                false,
+			RoundDown:               tt.roundDown,
+			Aggressiveness:          1,
+			MaxConsecutiveDecreases: 0,
+		})
+		p.RecordCompletion(domain.SetResult{
+			ActualValue: 5,
+			Signal:      domain.SignalTooHeavy,
+			WeightKg:    67,
+		})
+		if got := p.CurrentSet().WeightKg; got != tt.want {
+			t.Errorf("RoundDown %t: WeightKg = %v, want %v", tt.roundDown, got, tt.want)
+		}
+	}
+}
+
 func TestCurrentSet_OverridePropagates(t *testing.T) {
 	t.Parallel()
 
 	// Recommended set 1 = 100kg. User overrides to 95kg and signals OnTarget.
 	// Set 2 recommendation must be 95kg (from actual), not 100kg.
 	p := domain.NewProgression(domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         5,
-		RepMax:         8,
-		StartingWeight: 100.0,
-		IsDeload:       false,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  5,
+		RepMax:                  8,
+		StartingWeight:          100.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	})
 	p.RecordCompletion(domain.SetResult{
 		ActualValue: 8,
@@ -169,11 +283,14 @@ func TestCurrentSet_OverrideThenTooLight(t *testing.T) {
 	// User overrides set 2 to 90kg and signals TooLight.
 	// Set 3 must be 90 + 2.5 = 92.5kg.
 	p := domain.NewProgression(domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         5,
-		RepMax:         8,
-		StartingWeight: 100.0,
-		IsDeload:       false,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  5,
+		RepMax:                  8,
+		StartingWeight:          100.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	})
 	p.RecordCompletion(domain.SetResult{
 		ActualValue: 8,
@@ -191,15 +308,58 @@ func TestCurrentSet_OverrideThenTooLight(t *testing.T) {
 	}
 }
 
+func TestCurrentSet_TechnicalFailureHoldsWeight(t *testing.T) {
+	t.Parallel()
+
+	// Reps landed in range and the set felt light, but form broke down: the
+	// next set must repeat the weight instead of adding 2.5kg.
+	cfg := domain.Config{
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  5,
+		RepMax:                  8,
+		StartingWeight:          100.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
+	}
+	tests := []struct {
+		name             string
+		technicalFailure bool
+		want             float64
+	}{
+		{name: "clean set progresses", technicalFailure: false, want: 102.5},
+		{name: "technical failure holds", technicalFailure: true, want: 100.0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := domain.NewProgression(cfg)
+			p.RecordCompletion(domain.SetResult{
+				ActualValue:      8,
+				Signal:           domain.SignalTooLight,
+				WeightKg:         100.0,
+				TechnicalFailure: tt.technicalFailure,
+			})
+			if got := p.CurrentSet().WeightKg; got != tt.want {
+				t.Errorf("WeightKg = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestNewFromHistory_MatchesReplay(t *testing.T) {
 	t.Parallel()
 
 	config := domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         5,
-		RepMax:         8,
-		StartingWeight: 80.0,
-		IsDeload:       false,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  5,
+		RepMax:                  8,
+		StartingWeight:          80.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	}
 	results := []domain.SetResult{
 		{ActualValue: 8, Signal: domain.SignalTooLight, WeightKg: 80.0},
@@ -230,11 +390,14 @@ func TestNewFromHistory_EmptySliceEqualsNew(t *testing.T) {
 	t.Parallel()
 
 	config := domain.Config{
-		Type:           domain.SessionGoalStrength,
-		RepMin:         5,
-		RepMax:         10,
-		StartingWeight: 60.0,
-		IsDeload:       false,
+		Type:                    domain.SessionGoalStrength,
+		RepMin:                  5,
+		RepMax:                  10,
+		StartingWeight:          60.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	}
 	fresh := domain.NewProgression(config)
 	fromEmpty := domain.NewProgressionFromHistory(config, nil)
@@ -248,11 +411,14 @@ func TestSetsCompleted(t *testing.T) {
 	t.Parallel()
 
 	p := domain.NewProgression(domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         5,
-		RepMax:         8,
-		StartingWeight: 60.0,
-		IsDeload:       false,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  5,
+		RepMax:                  8,
+		StartingWeight:          60.0,
+		IsDeload:                false,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	})
 
 	if p.SetsCompleted() != 0 {
@@ -378,11 +544,14 @@ func TestAdjustedWeight_AssistedAndZeroBoundary(t *testing.T) {
 			t.Parallel()
 			p := domain.NewProgressionFromHistory(
 				domain.Config{
-					Type:           domain.SessionGoalStrength,
-					RepMin:         5,
-					RepMax:         10,
-					StartingWeight: 0,
-					IsDeload:       false,
+					Type:                    domain.SessionGoalStrength,
+					RepMin:                  5,
+					RepMax:                  10,
+					StartingWeight:          0,
+					IsDeload:                false,
+					RoundDown:               false,
+					Aggressiveness:          1,
+					MaxConsecutiveDecreases: 0,
 				},
 				[]domain.SetResult{
 					{ActualValue: 5, Signal: tt.signal, WeightKg: tt.lastWeight},
@@ -420,11 +589,14 @@ func TestProgression_DeloadFirstSetUsesStartingWeight(t *testing.T) {
 	t.Parallel()
 
 	cfg := domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         8,
-		RepMax:         12,
-		StartingWeight: 67.5,
-		IsDeload:       true,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  8,
+		RepMax:                  12,
+		StartingWeight:          67.5,
+		IsDeload:                true,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	}
 	p := domain.NewProgression(cfg)
 
@@ -446,11 +618,14 @@ func TestProgression_DeloadCarriesUserOverrideForward(t *testing.T) {
 	t.Parallel()
 
 	cfg := domain.Config{
-		Type:           domain.SessionGoalHypertrophy,
-		RepMin:         8,
-		RepMax:         12,
-		StartingWeight: 61.0,
-		IsDeload:       true,
+		Type:                    domain.SessionGoalHypertrophy,
+		RepMin:                  8,
+		RepMax:                  12,
+		StartingWeight:          61.0,
+		IsDeload:                true,
+		RoundDown:               false,
+		Aggressiveness:          1,
+		MaxConsecutiveDecreases: 0,
 	}
 	p := domain.NewProgression(cfg)
 
@@ -523,7 +698,8 @@ func TestDeloadSeedWeight(t *testing.T) {
 func TestAdjustedWeight_UnknownSignalDoesNotPanic(t *testing.T) {
 	t.Parallel()
 	p := domain.NewProgressionFromHistory(
-		domain.Config{Type: domain.SessionGoalStrength, RepMin: 5, RepMax: 8, StartingWeight: 50, IsDeload: false},
+		domain.Config{Type: domain.SessionGoalStrength, RepMin: 5, RepMax: 8, StartingWeight: 50, IsDeload: false,
+			RoundDown: false, Aggressiveness: 1, MaxConsecutiveDecreases: 0},
 		[]domain.SetResult{{ActualValue: 5, Signal: domain.Signal("bogus"), WeightKg: 60}},
 	)
 	got := p.CurrentSet()
@@ -546,11 +722,14 @@ func TestExhaustiveSignalCoverage(t *testing.T) {
 	for _, s := range valid {
 		p := domain.NewProgressionFromHistory(
 			domain.Config{
-				Type:           domain.SessionGoalHypertrophy,
-				RepMin:         5,
-				RepMax:         8,
-				StartingWeight: 50,
-				IsDeload:       false,
+				Type:                    domain.SessionGoalHypertrophy,
+				RepMin:                  5,
+				RepMax:                  8,
+				StartingWeight:          50,
+				IsDeload:                false,
+				RoundDown:               false,
+				Aggressiveness:          1,
+				MaxConsecutiveDecreases: 0,
 			},
 			[]domain.SetResult{
 				{ActualValue: 8, Signal: s, WeightKg: 50},
@@ -563,3 +742,70 @@ func TestExhaustiveSignalCoverage(t *testing.T) {
 		}
 	}
 }
+
+// TestCurrentSet_RepeatedTooHeavyHoldsWeight walks a progression through set
+// after set rated too heavy: the weight drops MaxConsecutiveDecreases times,
+// then holds above zero with the target marked Stalled.
+func TestCurrentSet_RepeatedTooHeavyHoldsWeight(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name         string
+		maxDecreases int
+		want         []float64 // Recommended weight before each set.
+	}{
+		{name: "default", maxDecreases: 0, want: []float64{20, 17.5, 15, 15, 15, 15, 15, 15}},
+		{name: "configured", maxDecreases: 4, want: []float64{20, 17.5, 15, 12.5, 10, 10, 10, 10}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			p := domain.NewProgression(domain.Config{
+				Type:                    domain.SessionGoalHypertrophy,
+				RepMin:                  8,
+				RepMax:                  12,
+				StartingWeight:          20,
+				IsDeload:                false,
+				RoundDown:               false,
+				Aggressiveness:          1,
+				MaxConsecutiveDecreases: tt.maxDecreases,
+			})
+			decreases := tt.maxDecreases
+			if decreases == 0 {
+				decreases = domain.DefaultMaxConsecutiveDecreases
+			}
+			for i, want := range tt.want {
+				got := p.CurrentSet()
+				if got.WeightKg != want {
+					t.Fatalf("set %d: weight = %v, want %v", i+1, got.WeightKg, want)
+				}
+				if wantStalled := i > decreases; got.Stalled != wantStalled {
+					t.Errorf("set %d: Stalled = %t, want %t", i+1, got.Stalled, wantStalled)
+				}
+				p.RecordCompletion(domain.SetResult{
+					ActualValue: 4, Signal: domain.SignalTooHeavy, WeightKg: got.WeightKg, TechnicalFailure: false,
+				})
+			}
+		})
+	}
+}
+
+// TestCurrentSet_OnTargetResetsTooHeavyRun checks that only an unbroken run of
+// too-heavy sets holds the weight.
+func TestCurrentSet_OnTargetResetsTooHeavyRun(t *testing.T) {
+	t.Parallel()
+
+	p := domain.NewProgressionFromHistory(
+		domain.Config{Type: domain.SessionGoalHypertrophy, RepMin: 8, RepMax: 12, StartingWeight: 20, IsDeload: false,
+			RoundDown: false, Aggressiveness: 1, MaxConsecutiveDecreases: 0},
+		[]domain.SetResult{
+			{ActualValue: 5, Signal: domain.SignalTooHeavy, WeightKg: 20, TechnicalFailure: false},
+			{ActualValue: 5, Signal: domain.SignalTooHeavy, WeightKg: 17.5, TechnicalFailure: false},
+			{ActualValue: 10, Signal: domain.SignalOnTarget, WeightKg: 15, TechnicalFailure: false},
+			{ActualValue: 5, Signal: domain.SignalTooHeavy, WeightKg: 15, TechnicalFailure: false},
+		},
+	)
+	if got := p.CurrentSet(); got.WeightKg != 12.5 || got.Stalled {
+		t.Errorf("CurrentSet() = %+v, want 12.5kg and not stalled", got)
+	}
+}
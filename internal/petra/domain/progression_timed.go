@@ -43,10 +43,10 @@ func NewTimedProgressionFromHistory(c TimedConfig, completed []SetResult) *Timed
 // carries the seconds goal; WeightKg stays zero (timed holds have no load).
 func (p *TimedProgression) CurrentSet() SetTarget {
 	if len(p.completed) == 0 {
-		return SetTarget{WeightKg: 0, TargetValue: p.config.StartingSeconds}
+		return SetTarget{WeightKg: 0, TargetValue: p.config.StartingSeconds, Stalled: false}
 	}
 	last := p.completed[len(p.completed)-1]
-	return SetTarget{WeightKg: 0, TargetValue: adjustedSeconds(last)}
+	return SetTarget{WeightKg: 0, TargetValue: adjustedSeconds(last), Stalled: false}
 }
 
 // RecordCompletion records what actually happened and advances internal state.
@@ -0,0 +1,47 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeOfDay is a wall-clock time as minutes after midnight. Like workout
+// dates, it is read in UTC.
+type TimeOfDay int
+
+// minutesPerDay bounds TimeOfDay to [0, minutesPerDay).
+const minutesPerDay = 24 * 60
+
+// ParseTimeOfDay parses a 24-hour "HH:MM" time, as sent by an
+// <input type="time">, and returns a ValidationError for anything else.
+func ParseTimeOfDay(s string) (TimeOfDay, error) {
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, ValidationError{Message: fmt.Sprintf("Invalid time %q, use HH:MM.", s)}
+	}
+	return TimeOfDay(t.Hour()*60 + t.Minute()), nil //nolint:mnd // Minutes per hour.
+}
+
+// String formats t as "HH:MM".
+func (t TimeOfDay) String() string {
+	return fmt.Sprintf("%02d:%02d", int(t)/60, int(t)%60) //nolint:mnd // Minutes per hour.
+}
+
+// On returns t on date's day.
+func (t TimeOfDay) On(date time.Time) time.Time {
+	y, m, d := date.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Add(time.Duration(int(t)%minutesPerDay) * time.Minute)
+}
+
+// Reminder is a nudge due to a user to do the workout planned on Date, from
+// At, their preferred workout time that day, onwards.
+type Reminder struct {
+	UserID int
+	Date   time.Time
+	At     time.Time
+}
+
+// DueBy reports whether the reminder's time has come by now.
+func (r Reminder) DueBy(now time.Time) bool {
+	return !now.Before(r.At)
+}
@@ -0,0 +1,39 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_ParseTimeOfDay(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"00:00", "07:30", "23:59"} {
+		got, err := domain.ParseTimeOfDay(in)
+		if err != nil {
+			t.Errorf("ParseTimeOfDay(%q): %v", in, err)
+			continue
+		}
+		if got.String() != in {
+			t.Errorf("ParseTimeOfDay(%q).String() = %q", in, got.String())
+		}
+	}
+	for _, in := range []string{"", "24:00", "7:5", "07:30:00", "noon"} {
+		var ve domain.ValidationError
+		if _, err := domain.ParseTimeOfDay(in); !errors.As(err, &ve) {
+			t.Errorf("ParseTimeOfDay(%q) error = %v, want a ValidationError", in, err)
+		}
+	}
+
+	at := domain.TimeOfDay(7*60 + 30).On(time.Date(2026, 5, 11, 15, 0, 0, 0, time.UTC))
+	reminder := domain.Reminder{UserID: 1, Date: at.Truncate(24 * time.Hour), At: at}
+	if want := time.Date(2026, 5, 11, 7, 30, 0, 0, time.UTC); !at.Equal(want) {
+		t.Errorf("On = %s, want %s", at, want)
+	}
+	if reminder.DueBy(at.Add(-time.Minute)) || !reminder.DueBy(at) {
+		t.Error("DueBy: want due from At onwards only")
+	}
+}
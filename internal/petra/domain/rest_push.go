@@ -71,7 +71,7 @@ func PlanRestPush(
 		return RestPushDecision{Action: RestPushActionCancel} //nolint:exhaustruct // FireAt/Payload unused for Cancel.
 	}
 
-	restSeconds := RestSecondsFor(slot.Exercise, goal, isDeload)
+	restSeconds := slot.RestSeconds(goal, isDeload)
 	if restSeconds <= 0 {
 		return RestPushDecision{Action: RestPushActionNoOp} //nolint:exhaustruct // FireAt/Payload unused for NoOp.
 	}
@@ -14,6 +14,11 @@ const (
 	SessionGoalHypertrophy SessionGoal = "hypertrophy"
 )
 
+// SessionGoals returns every defined SessionGoal in display order.
+func SessionGoals() []SessionGoal {
+	return []SessionGoal{SessionGoalStrength, SessionGoalHypertrophy}
+}
+
 // SessionStatus is the lifecycle state of a workout session, for display.
 type SessionStatus string
 
@@ -31,6 +36,9 @@ type ExerciseSlot struct {
 	Exercise          Exercise
 	Sets              []Set
 	WarmupCompletedAt *time.Time // Nullable timestamp when warmup for this exercise was completed
+	// RestOverrideSeconds is the user's own inter-set rest for this exercise,
+	// read alongside the slot. Nil means the goal-derived rest applies.
+	RestOverrideSeconds *int
 }
 
 // ExerciseSlotState is the completion state of an exercise slot, for display.
@@ -98,7 +106,7 @@ func (es ExerciseSlot) RestEndAt(goal SessionGoal, isDeload bool) (time.Time, bo
 	if !incomplete {
 		return time.Time{}, false
 	}
-	restSeconds := RestSecondsFor(es.Exercise, goal, isDeload)
+	restSeconds := es.RestSeconds(goal, isDeload)
 	if restSeconds <= 0 {
 		return time.Time{}, false
 	}
@@ -109,6 +117,15 @@ func (es ExerciseSlot) RestEndAt(goal SessionGoal, isDeload bool) (time.Time, bo
 	return clockStart.Add(time.Duration(restSeconds) * time.Second), true
 }
 
+// RestSeconds returns the inter-set rest for this slot: the user's override
+// when one is set, otherwise RestSecondsFor the session's goal.
+func (es ExerciseSlot) RestSeconds(goal SessionGoal, isDeload bool) int {
+	if es.RestOverrideSeconds != nil {
+		return *es.RestOverrideSeconds
+	}
+	return RestSecondsFor(es.Exercise, goal, isDeload)
+}
+
 // setAt returns a pointer to the set at setIndex, or ErrSetIndexOutOfBounds
 // when setIndex is out of range. The value receiver still yields a usable
 // pointer: es.Sets shares its backing array with the caller's slot, so
@@ -158,14 +175,42 @@ func (s *Session) Start(now time.Time) error {
 
 // Complete marks the session as finished at now. Returns ErrNotStarted if
 // the session has not been started yet — completion implies a prior start.
+// When now is before StartedAt, because the clock stepped back since the
+// start, CompletedAt is clamped to StartedAt so the recorded duration is zero
+// rather than negative; check ClockSkewed first to report it.
 func (s *Session) Complete(now time.Time) error {
 	if s.StartedAt.IsZero() {
 		return ErrNotStarted
 	}
+	if now.Before(s.StartedAt) {
+		now = s.StartedAt
+	}
 	s.CompletedAt = now
 	return nil
 }
 
+// ClockSkewed reports whether completing the session at now would put
+// CompletedAt before StartedAt.
+func (s *Session) ClockSkewed(now time.Time) bool {
+	return !s.StartedAt.IsZero() && now.Before(s.StartedAt)
+}
+
+// Duration returns how long the session ran, from StartedAt to CompletedAt,
+// or zero when it has not been both started and completed. Sessions stored
+// with CompletedAt before StartedAt, from a skewed clock, have their
+// timestamps taken as swapped so the duration is never negative; skewed
+// reports that so callers can log it.
+func (s *Session) Duration() (d time.Duration, skewed bool) {
+	if s.StartedAt.IsZero() || s.CompletedAt.IsZero() {
+		return 0, false
+	}
+	d = s.CompletedAt.Sub(s.StartedAt)
+	if d < 0 {
+		return -d, true
+	}
+	return d, false
+}
+
 // SetDifficulty records the post-session difficulty rating (1-5). Returns
 // ErrInvalidDifficultyRating when rating is outside that range.
 func (s *Session) SetDifficulty(rating int) error {
@@ -265,6 +310,28 @@ func (s *Session) RecordSet(
 	return nil
 }
 
+// SetFlags overwrites a set's technical-failure, warmup and side markers
+// with flags. Returns ErrSlotNotFound or ErrSetIndexOutOfBounds when the lookup
+// fails.
+func (s *Session) SetFlags(pos, setIndex int, flags SetFlags) error {
+	slot, err := s.slotAt(pos)
+	if err != nil {
+		return err
+	}
+	set, err := slot.setAt(setIndex)
+	if err != nil {
+		return err
+	}
+	set.TechnicalFailure = flags.TechnicalFailure
+	set.Warmup = flags.Warmup
+	set.Side = nil
+	if flags.Side != nil {
+		sd := *flags.Side
+		set.Side = &sd
+	}
+	return nil
+}
+
 // UpdateCompletedValue records the actual reps (or seconds for time-based)
 // achieved on a set, and stamps the completion time. Returns
 // ErrSlotNotFound or ErrSetIndexOutOfBounds when the lookup fails.
@@ -286,16 +353,20 @@ func (s *Session) UpdateCompletedValue(pos, setIndex, value int, now time.Time)
 
 // AddExercise appends a new exercise slot to the session. The slot's position
 // is len(s.Slots) at the time of the append, persisted by the
-// repository as the row's position column. Returns
-// ErrExerciseAlreadyInSession when an existing slot already references the
-// same Exercise.ID.
+// repository as the row's position column. In a circuit the sets collapse to
+// the circuit's single per-round set, so every slot keeps the same window.
+// Returns ErrExerciseAlreadyInSession when an existing slot already
+// references the same Exercise.ID.
 func (s *Session) AddExercise(ex Exercise, sets []Set) error {
 	for _, existing := range s.Slots {
 		if existing.Exercise.ID == ex.ID {
 			return ErrExerciseAlreadyInSession
 		}
 	}
-	s.Slots = append(s.Slots, ExerciseSlot{ //nolint:exhaustruct // WarmupCompletedAt nil.
+	if s.IsCircuit() {
+		sets = circuitSets(sets, s.CircuitSeconds())
+	}
+	s.Slots = append(s.Slots, ExerciseSlot{ //nolint:exhaustruct // WarmupCompletedAt and RestOverrideSeconds nil.
 		Exercise: ex,
 		Sets:     sets,
 	})
@@ -307,16 +378,22 @@ func (s *Session) AddExercise(ex Exercise, sets []Set) error {
 // continue to resolve). The new sets slice replaces the slot's existing
 // sets entirely; any prior recorded data is dropped. The warmup-completion
 // flag is reset to nil because the warmup performed for the old exercise
-// does not apply to the new one. Returns ErrSlotNotFound when pos is out of
-// range.
+// does not apply to the new one, and so is the rest override, which belongs
+// to the old exercise (the next read hydrates the new one's). In a circuit
+// the sets collapse as in AddExercise. Returns ErrSlotNotFound when pos is
+// out of range.
 func (s *Session) SwapExerciseInSlot(pos int, newExercise Exercise, sets []Set) error {
 	slot, err := s.slotAt(pos)
 	if err != nil {
 		return err
 	}
+	if s.IsCircuit() {
+		sets = circuitSets(sets, s.CircuitSeconds())
+	}
 	slot.Exercise = newExercise
 	slot.Sets = sets
 	slot.WarmupCompletedAt = nil
+	slot.RestOverrideSeconds = nil
 	return nil
 }
 
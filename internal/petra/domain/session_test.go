@@ -61,6 +61,64 @@ func Test_Session_Complete_AfterStart(t *testing.T) {
 	}
 }
 
+func Test_Session_Complete_ClockSteppedBack_ClampsToStart(t *testing.T) {
+	t.Parallel()
+
+	startAt := time.Date(2026, 5, 10, 9, 0, 0, 0, time.UTC)
+	now := startAt.Add(-5 * time.Minute)
+	sess := domain.Session{ //nolint:exhaustruct // Test sessions omit irrelevant fields.
+		Date:      time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC),
+		StartedAt: startAt,
+	}
+
+	if !sess.ClockSkewed(now) {
+		t.Error("ClockSkewed = false, want true for a completion before the start")
+	}
+	if err := sess.Complete(now); err != nil {
+		t.Fatalf("Complete: %v", err)
+	}
+	if !sess.CompletedAt.Equal(startAt) {
+		t.Errorf("CompletedAt = %v, want clamped to StartedAt %v", sess.CompletedAt, startAt)
+	}
+	if d, _ := sess.Duration(); d != 0 {
+		t.Errorf("Duration = %v, want 0", d)
+	}
+}
+
+func Test_Session_Duration(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2026, 5, 10, 8, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name        string
+		startedAt   time.Time
+		completedAt time.Time
+		want        time.Duration
+		wantSkewed  bool
+	}{
+		{"completed after start", start, start.Add(45 * time.Minute), 45 * time.Minute, false},
+		{"inverted by clock skew", start.Add(45 * time.Minute), start, 45 * time.Minute, true},
+		{"not completed", start, time.Time{}, 0, false},
+		{"not started", time.Time{}, start, 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			sess := domain.Session{ //nolint:exhaustruct // Test sessions omit irrelevant fields.
+				StartedAt:   tt.startedAt,
+				CompletedAt: tt.completedAt,
+			}
+			got, skewed := sess.Duration()
+			if got < 0 {
+				t.Fatalf("Duration = %v, want non-negative", got)
+			}
+			if got != tt.want || skewed != tt.wantSkewed {
+				t.Errorf("Duration = (%v, %v), want (%v, %v)", got, skewed, tt.want, tt.wantSkewed)
+			}
+		})
+	}
+}
+
 func Test_Session_Complete_NotStarted_ReturnsErrNotStarted(t *testing.T) {
 	t.Parallel()
 
@@ -605,11 +663,15 @@ func TestSessionHasIncompleteSets(t *testing.T) {
 	completedAt := time.Date(2026, 5, 11, 10, 0, 0, 0, time.UTC)
 	completedVal := 5
 	completedSet := domain.Set{
-		WeightKg:       nil,
-		TargetValue:    5,
-		CompletedValue: &completedVal,
-		CompletedAt:    &completedAt,
-		Signal:         nil,
+		WeightKg:         nil,
+		TargetValue:      5,
+		CompletedValue:   &completedVal,
+		CompletedAt:      &completedAt,
+		Signal:           nil,
+		Side:             nil,
+		DurationSeconds:  nil,
+		TechnicalFailure: false,
+		Warmup:           false,
 	}
 	incompleteSet := domain.Set{TargetValue: 5} //nolint:exhaustruct // Other fields nil — represents an unfinished set.
 
@@ -1061,6 +1123,7 @@ func Test_ExerciseSlot_RestEndAt(t *testing.T) {
 	repMin5, repMax5 := 5, 5     // strength → 180s rest.
 	repMin12, repMax15 := 12, 15 // hypertrophy → 90s rest.
 	startSecs := 30              // for the timed-exercise case.
+	restOverride := 45           // user's own rest, replacing the derived band.
 
 	squat := domain.Exercise{ //nolint:exhaustruct // Only rest-relevant fields set.
 		Name: "Squat", ExerciseType: domain.ExerciseTypeWeighted,
@@ -1154,6 +1217,17 @@ func Test_ExerciseSlot_RestEndAt(t *testing.T) {
 			wantOK:    true,
 			wantEndAt: setDoneAt.Add(90 * time.Second),
 		},
+		{ //nolint:exhaustruct // isDeload defaults to false; only deload-true case overrides.
+			name: "rest override replaces the derived rest",
+			slot: domain.ExerciseSlot{
+				Exercise: squat, WarmupCompletedAt: &warmupAt,
+				Sets:                []domain.Set{completedSet, incompleteSet},
+				RestOverrideSeconds: &restOverride,
+			},
+			pt:        domain.SessionGoalStrength,
+			wantOK:    true,
+			wantEndAt: setDoneAt.Add(45 * time.Second),
+		},
 		{
 			name: "deload forces hypertrophy mapping for the rest band",
 			slot: domain.ExerciseSlot{
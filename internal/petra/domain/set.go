@@ -41,11 +41,54 @@ func (s Signal) Glyph() string {
 	}
 }
 
+// Side is which limb a unilateral set worked. Bilateral work leaves a Set's
+// Side nil; SideBoth marks a unilateral exercise done on both sides in one
+// logged set, which carries no per-side information.
+type Side string
+
+const (
+	SideLeft  Side = "left"
+	SideRight Side = "right"
+	SideBoth  Side = "both"
+)
+
+// Valid reports whether s is one of the known sides.
+func (s Side) Valid() bool {
+	switch s {
+	case SideLeft, SideRight, SideBoth:
+		return true
+	default:
+		return false
+	}
+}
+
 // Set represents a single set of an exercise with target and actual performance.
 type Set struct {
-	WeightKg       *float64   // Nullable for bodyweight and time_based exercises.
-	TargetValue    int        // Reps or seconds; unit derived from the parent exercise type.
-	CompletedValue *int       // Same unit as TargetValue; nil until the set is completed.
-	CompletedAt    *time.Time // Nullable timestamp when set was completed.
-	Signal         *Signal    // Nullable; nil until the set is completed.
+	WeightKg         *float64   // Nullable for bodyweight and time_based exercises.
+	TargetValue      int        // Reps or seconds; unit derived from the parent exercise type.
+	CompletedValue   *int       // Same unit as TargetValue; nil until the set is completed.
+	CompletedAt      *time.Time // Nullable timestamp when set was completed.
+	Signal           *Signal    // Nullable; nil until the set is completed.
+	Side             *Side      // Nullable; set only for unilateral work logged per side.
+	DurationSeconds  *int       // Nullable; set only on circuit sets, whose CompletedValue counts rounds.
+	TechnicalFailure bool       // Reps reached but form broke down; progression holds the weight.
+	Warmup           bool       // A warmup or back-off set; analyses count working sets only.
+}
+
+// SetFlags are the markers the set form records with a completion, beside
+// the signal, weight and value.
+type SetFlags struct {
+	// TechnicalFailure sets Set.TechnicalFailure.
+	TechnicalFailure bool
+	// Warmup sets Set.Warmup.
+	Warmup bool
+	// Side sets Set.Side; nil when the set was not logged per side.
+	Side *Side
+}
+
+// IsWorking reports whether s is a working set rather than a warmup or
+// back-off set. Record and 1RM analyses skip sets that are not, so light
+// ramp-up or back-off work cannot drag or skew them.
+func (s Set) IsWorking() bool {
+	return !s.Warmup
 }
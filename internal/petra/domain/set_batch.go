@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// MaxSetBatchSize caps how many completions one RecordSets call accepts.
+const MaxSetBatchSize = 100
+
+// SetCompletion is one set completion in a batch, such as sets a client
+// logged offline and syncs later. The set is addressed by exercise rather than
+// slot position so that a client can build it without knowing the slot order.
+type SetCompletion struct {
+	ExerciseID int
+	// SetNumber is 1-based, as the user sees it.
+	SetNumber int
+	// Reps is the completed value: reps, or seconds for time-based exercises.
+	Reps int
+	// WeightKg is required for weighted exercises and must be nil otherwise.
+	WeightKg *float64
+}
+
+// RecordSets records every completion in items, or none of them. The returned
+// slice has one entry per item: nil when the item is valid, otherwise a
+// ValidationError saying what is wrong with it. When any entry is non-nil the
+// session is left untouched. A completion addresses the first slot holding
+// its exercise.
+func (s *Session) RecordSets(items []SetCompletion, now time.Time) []error {
+	errs := make([]error, len(items))
+	positions := make([]int, len(items))
+	failed := false
+	for i, item := range items {
+		positions[i], errs[i] = s.validateSetCompletion(item)
+		if errs[i] != nil {
+			failed = true
+		}
+	}
+	if failed {
+		return errs
+	}
+	for i, item := range items {
+		// Validation above covers every failure RecordSet can return.
+		_ = s.RecordSet(positions[i], item.SetNumber-1, nil, item.WeightKg, item.Reps, now)
+	}
+	return errs
+}
+
+// validateSetCompletion returns the slot position item addresses, or a
+// ValidationError when it cannot be recorded.
+func (s *Session) validateSetCompletion(item SetCompletion) (int, error) {
+	pos := -1
+	for i, slot := range s.Slots {
+		if slot.Exercise.ID == item.ExerciseID {
+			pos = i
+			break
+		}
+	}
+	if pos < 0 {
+		return -1, ValidationError{Message: fmt.Sprintf("Exercise %d is not in this workout.", item.ExerciseID)}
+	}
+	slot := s.Slots[pos]
+	if item.SetNumber < 1 || item.SetNumber > len(slot.Sets) {
+		return -1, ValidationError{Message: fmt.Sprintf("%s has sets 1 to %d, not %d.",
+			slot.Exercise.Name, len(slot.Sets), item.SetNumber)}
+	}
+	if item.Reps < 0 {
+		return -1, ValidationError{Message: "Reps cannot be negative."}
+	}
+	weighted := slot.Exercise.LoadModel() == LoadWeighted
+	switch {
+	case weighted && item.WeightKg == nil:
+		return -1, ValidationError{Message: fmt.Sprintf("%s needs a weight.", slot.Exercise.Name)}
+	case !weighted && item.WeightKg != nil:
+		return -1, ValidationError{Message: fmt.Sprintf("%s does not take a weight.", slot.Exercise.Name)}
+	case weighted && (math.IsNaN(*item.WeightKg) || math.IsInf(*item.WeightKg, 0)):
+		return -1, ValidationError{Message: "Weight must be a finite number."}
+	}
+	return pos, nil
+}
@@ -0,0 +1,87 @@
+package domain_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func batchTestSession() domain.Session {
+	return domain.Session{ //nolint:exhaustruct // Test sessions omit irrelevant fields.
+		Slots: []domain.ExerciseSlot{
+			{ //nolint:exhaustruct // Test slots omit irrelevant fields.
+				Exercise: domain.Exercise{ //nolint:exhaustruct // Test exercises omit display fields.
+					ID: 1, Name: "Squat", ExerciseType: domain.ExerciseTypeWeighted,
+				},
+				Sets: make([]domain.Set, 2),
+			},
+			{ //nolint:exhaustruct // Test slots omit irrelevant fields.
+				Exercise: domain.Exercise{ //nolint:exhaustruct // Test exercises omit display fields.
+					ID: 2, Name: "Push-up", ExerciseType: domain.ExerciseTypeBodyweight,
+				},
+				Sets: make([]domain.Set, 1),
+			},
+		},
+	}
+}
+
+func Test_Session_RecordSets_AllValid(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 18, 0, 0, 0, time.UTC)
+	weight := 80.0
+	sess := batchTestSession()
+	errs := sess.RecordSets([]domain.SetCompletion{
+		{ExerciseID: 1, SetNumber: 2, Reps: 5, WeightKg: &weight},
+		{ExerciseID: 2, SetNumber: 1, Reps: 15, WeightKg: nil},
+	}, now)
+	for i, err := range errs {
+		if err != nil {
+			t.Errorf("item %d: %v", i, err)
+		}
+	}
+	got := sess.Slots[0].Sets[1]
+	if got.CompletedAt == nil || *got.CompletedValue != 5 || *got.WeightKg != 80 {
+		t.Errorf("squat set 2 = %+v, want 5 reps at 80 kg completed", got)
+	}
+	if sess.Slots[0].Sets[0].CompletedAt != nil {
+		t.Error("squat set 1 completed, want untouched")
+	}
+	if sess.Slots[1].Sets[0].CompletedAt == nil {
+		t.Error("push-up set 1 not completed")
+	}
+}
+
+func Test_Session_RecordSets_InvalidItemRecordsNothing(t *testing.T) {
+	t.Parallel()
+
+	weight := 80.0
+	sess := batchTestSession()
+	errs := sess.RecordSets([]domain.SetCompletion{
+		{ExerciseID: 1, SetNumber: 1, Reps: 5, WeightKg: &weight},
+		{ExerciseID: 1, SetNumber: 0, Reps: 5, WeightKg: &weight},
+		{ExerciseID: 1, SetNumber: 2, Reps: 5, WeightKg: nil},
+		{ExerciseID: 2, SetNumber: 1, Reps: -1, WeightKg: nil},
+		{ExerciseID: 2, SetNumber: 1, Reps: 10, WeightKg: &weight},
+		{ExerciseID: 3, SetNumber: 1, Reps: 5, WeightKg: nil},
+	}, time.Now())
+
+	if errs[0] != nil {
+		t.Errorf("valid item 0: %v", errs[0])
+	}
+	for i, err := range errs[1:] {
+		var ve domain.ValidationError
+		if !errors.As(err, &ve) {
+			t.Errorf("item %d error = %v, want a ValidationError", i+1, err)
+		}
+	}
+	for _, slot := range sess.Slots {
+		for _, set := range slot.Sets {
+			if set.CompletedAt != nil {
+				t.Fatalf("%s set completed despite invalid items in the batch", slot.Exercise.Name)
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package domain
+
+import "math"
+
+// sideImbalanceThreshold is the relative gap between the left and right side,
+// measured against the stronger side, at which a unilateral exercise is
+// flagged. Below ~10% the difference is within day-to-day noise and the
+// rounding of dumbbell jumps; above it one side is doing measurably less.
+const sideImbalanceThreshold = 0.10
+
+// SideImbalance compares the left and right sides of a unilateral exercise
+// across a window of history. Volume is reps × kg for loaded sets and plain
+// reps for unloaded (bodyweight) ones; TopWeightKg is the heaviest completed
+// set per side, zero when neither side carried load.
+type SideImbalance struct {
+	LeftVolume       float64
+	RightVolume      float64
+	LeftTopWeightKg  float64
+	RightTopWeightKg float64
+	VolumeGap        float64 // Relative to the higher-volume side, 0..1.
+	WeightGap        float64 // Relative to the heavier side, 0..1.
+	WeakerSide       Side
+	VolumeImbalanced bool
+	WeightImbalanced bool
+}
+
+// Imbalanced reports whether either the volume or the top-weight gap crossed
+// the threshold.
+func (si SideImbalance) Imbalanced() bool {
+	return si.VolumeImbalanced || si.WeightImbalanced
+}
+
+// DetectSideImbalance totals the completed left and right sets in history and
+// reports the gap between them. Sets with no side, SideBoth, or no completed
+// value are ignored, as is assisted load (a negative weight is help, not
+// work). The second return is false when either side has no completed set —
+// there is nothing to compare — in which case the SideImbalance is zero.
+func DetectSideImbalance(history []ExerciseSetHistory) (SideImbalance, bool) {
+	var (
+		si                  SideImbalance
+		leftSets, rightSets int
+	)
+	for _, h := range history {
+		for _, set := range h.Sets {
+			if set.Side == nil || set.CompletedValue == nil {
+				continue
+			}
+			reps := float64(*set.CompletedValue)
+			volume, weight := reps, 0.0
+			if set.WeightKg != nil && *set.WeightKg > 0 {
+				weight = *set.WeightKg
+				volume = reps * weight
+			}
+			switch *set.Side {
+			case SideLeft:
+				leftSets++
+				si.LeftVolume += volume
+				si.LeftTopWeightKg = math.Max(si.LeftTopWeightKg, weight)
+			case SideRight:
+				rightSets++
+				si.RightVolume += volume
+				si.RightTopWeightKg = math.Max(si.RightTopWeightKg, weight)
+			case SideBoth:
+			}
+		}
+	}
+	if leftSets == 0 || rightSets == 0 {
+		return SideImbalance{}, false
+	}
+
+	si.VolumeGap = relativeGap(si.LeftVolume, si.RightVolume)
+	si.WeightGap = relativeGap(si.LeftTopWeightKg, si.RightTopWeightKg)
+	si.VolumeImbalanced = si.VolumeGap >= sideImbalanceThreshold
+	si.WeightImbalanced = si.WeightGap >= sideImbalanceThreshold
+	switch {
+	case si.LeftVolume < si.RightVolume, si.LeftVolume == si.RightVolume && si.LeftTopWeightKg < si.RightTopWeightKg:
+		si.WeakerSide = SideLeft
+	case si.RightVolume < si.LeftVolume, si.RightTopWeightKg < si.LeftTopWeightKg:
+		si.WeakerSide = SideRight
+	}
+	return si, true
+}
+
+// relativeGap returns |a-b| divided by the larger of the two, or 0 when both
+// are zero.
+func relativeGap(a, b float64) float64 {
+	hi := math.Max(a, b)
+	if hi == 0 {
+		return 0
+	}
+	return math.Abs(a-b) / hi
+}
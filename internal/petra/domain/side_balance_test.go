@@ -0,0 +1,98 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// sidedSet builds a completed set on side with reps at weightKg (nil for
+// unloaded work).
+func sidedSet(side domain.Side, reps int, weightKg *float64) domain.Set {
+	return domain.Set{ //nolint:exhaustruct // CompletedAt and Signal are irrelevant to side balance.
+		WeightKg:       weightKg,
+		TargetValue:    reps,
+		CompletedValue: &reps,
+		Side:           &side,
+	}
+}
+
+func TestDetectSideImbalance(t *testing.T) {
+	t.Parallel()
+
+	day := time.Date(2026, 5, 4, 0, 0, 0, 0, time.UTC)
+	kg := func(w float64) *float64 { return &w }
+
+	t.Run("lopsided per-side data flags an imbalance on the weaker side", func(t *testing.T) {
+		t.Parallel()
+		// Single-arm rows: the left arm keeps up on weight but runs out of reps.
+		history := []domain.ExerciseSetHistory{
+			{Date: day, Sets: []domain.Set{
+				sidedSet(domain.SideLeft, 6, kg(20)), sidedSet(domain.SideRight, 10, kg(20)),
+				sidedSet(domain.SideLeft, 5, kg(20)), sidedSet(domain.SideRight, 10, kg(20)),
+			}},
+		}
+		got, ok := domain.DetectSideImbalance(history)
+		if !ok {
+			t.Fatal("DetectSideImbalance ok = false, want true with both sides logged")
+		}
+		if !got.VolumeImbalanced || !got.Imbalanced() {
+			t.Errorf("VolumeImbalanced = %t, want true (gap %.2f)", got.VolumeImbalanced, got.VolumeGap)
+		}
+		if got.WeightImbalanced {
+			t.Errorf("WeightImbalanced = true, want false for equal top weights")
+		}
+		if got.WeakerSide != domain.SideLeft {
+			t.Errorf("WeakerSide = %q, want %q", got.WeakerSide, domain.SideLeft)
+		}
+	})
+
+	t.Run("top-weight gap alone is flagged", func(t *testing.T) {
+		t.Parallel()
+		history := []domain.ExerciseSetHistory{
+			{Date: day, Sets: []domain.Set{
+				sidedSet(domain.SideLeft, 8, kg(24)), sidedSet(domain.SideRight, 8, kg(20)),
+			}},
+			{Date: day.AddDate(0, 0, -3), Sets: []domain.Set{
+				sidedSet(domain.SideLeft, 8, kg(20)), sidedSet(domain.SideRight, 10, kg(20)),
+			}},
+		}
+		got, ok := domain.DetectSideImbalance(history)
+		if !ok || !got.WeightImbalanced {
+			t.Fatalf("got (%+v, %t), want a weight imbalance", got, ok)
+		}
+	})
+
+	t.Run("balanced sides are not flagged", func(t *testing.T) {
+		t.Parallel()
+		history := []domain.ExerciseSetHistory{
+			{Date: day, Sets: []domain.Set{
+				sidedSet(domain.SideLeft, 10, nil), sidedSet(domain.SideRight, 10, nil),
+				sidedSet(domain.SideLeft, 9, nil), sidedSet(domain.SideRight, 10, nil),
+			}},
+		}
+		got, ok := domain.DetectSideImbalance(history)
+		if !ok {
+			t.Fatal("DetectSideImbalance ok = false, want true")
+		}
+		if got.Imbalanced() {
+			t.Errorf("Imbalanced = true for a 5%% gap, want false (%+v)", got)
+		}
+	})
+
+	t.Run("one side only, both, or unsided sets have nothing to compare", func(t *testing.T) {
+		t.Parallel()
+		unsided := domain.Set{ //nolint:exhaustruct // A bilateral set carries no side.
+			TargetValue: 10, CompletedValue: new(10),
+		}
+		history := []domain.ExerciseSetHistory{
+			{Date: day, Sets: []domain.Set{
+				sidedSet(domain.SideLeft, 10, nil), sidedSet(domain.SideBoth, 10, nil), unsided,
+			}},
+		}
+		if got, ok := domain.DetectSideImbalance(history); ok {
+			t.Errorf("DetectSideImbalance = (%+v, true), want false without a right-side set", got)
+		}
+	})
+}
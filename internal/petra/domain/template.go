@@ -0,0 +1,88 @@
+package domain
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// MaxTemplateNameLength caps a WorkoutTemplate name, matching the schema CHECK.
+const MaxTemplateNameLength = 64
+
+// WorkoutTemplate is a named routine, such as "5/3/1 Day A", saved from one of
+// the user's sessions so it can be done again on another day. It keeps the
+// session's structure (goal, exercises in order and working set counts) but
+// no weights or reps; those are prescribed afresh each time it is used.
+type WorkoutTemplate struct {
+	ID        int
+	Name      string
+	Goal      SessionGoal
+	Exercises []TemplateExercise
+}
+
+// TemplateExercise is one exercise of a WorkoutTemplate.
+type TemplateExercise struct {
+	Exercise Exercise
+	// Sets is the number of working sets.
+	Sets int
+}
+
+// NewWorkoutTemplate captures the structure of sess under name. Warmup sets
+// are not part of the structure, so a slot holding only warmups is left out.
+// Returns a ValidationError when the trimmed name is empty or longer than
+// MaxTemplateNameLength, or when sess has no working sets to save.
+func NewWorkoutTemplate(name string, sess Session) (WorkoutTemplate, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return WorkoutTemplate{}, ValidationError{Message: "Give the template a name."}
+	}
+	if utf8.RuneCountInString(name) > MaxTemplateNameLength {
+		return WorkoutTemplate{}, ValidationError{
+			Message: fmt.Sprintf("Template names can be at most %d characters.", MaxTemplateNameLength),
+		}
+	}
+	t := WorkoutTemplate{ID: 0, Name: name, Goal: sess.Goal, Exercises: nil}
+	for _, slot := range sess.Slots {
+		working := 0
+		for _, set := range slot.Sets {
+			if set.IsWorking() {
+				working++
+			}
+		}
+		if working > 0 {
+			t.Exercises = append(t.Exercises, TemplateExercise{Exercise: slot.Exercise, Sets: working})
+		}
+	}
+	if len(t.Exercises) == 0 {
+		return WorkoutTemplate{}, ValidationError{Message: "This workout has no exercises to save as a template."}
+	}
+	return t, nil
+}
+
+// Session lays the template out as an unstarted session on date. Each
+// exercise gets its set count with the goal's rep target from
+// BuildPlannedSets; time-based exercises keep their fixed count. Weighted
+// exercises start at weights[exercise ID], the caller's progression-derived
+// starting weight, and at 0 when it is missing.
+func (t WorkoutTemplate) Session(date time.Time, weights map[int]float64) Session {
+	sess := Session{ //nolint:exhaustruct // Unstarted: no timestamps, rating or deload.
+		Date:  StartOfDay(date),
+		Goal:  t.Goal,
+		Slots: make([]ExerciseSlot, 0, len(t.Exercises)),
+	}
+	for _, te := range t.Exercises {
+		sets := BuildPlannedSets(te.Exercise, t.Goal, false, te.Sets)
+		if te.Exercise.HasWeight() {
+			for i := range sets {
+				w := weights[te.Exercise.ID]
+				sets[i].WeightKg = &w
+			}
+		}
+		sess.Slots = append(sess.Slots, ExerciseSlot{ //nolint:exhaustruct // Warmup not done; rest override is read back.
+			Exercise: te.Exercise,
+			Sets:     sets,
+		})
+	}
+	return sess
+}
@@ -0,0 +1,71 @@
+package domain_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_NewWorkoutTemplate(t *testing.T) {
+	t.Parallel()
+
+	repMin, repMax, seconds := 3, 8, 45
+	squat := domain.Exercise{ //nolint:exhaustruct // Load model and rep range only.
+		ID: 1, Name: "Squat", ExerciseType: domain.ExerciseTypeWeighted, RepMin: &repMin, RepMax: &repMax,
+	}
+	plank := domain.Exercise{ //nolint:exhaustruct // Load model and seconds only.
+		ID: 2, Name: "Plank", ExerciseType: domain.ExerciseTypeTime, DefaultStartingSeconds: &seconds,
+	}
+	row := domain.Exercise{ID: 3, Name: "Barbell Row"} //nolint:exhaustruct // Identity only.
+	working := domain.Set{TargetValue: 5}              //nolint:exhaustruct // Prescription only.
+	warmup := domain.Set{TargetValue: 5, Warmup: true} //nolint:exhaustruct // Prescription only.
+	sess := domain.Session{                            //nolint:exhaustruct // Goal and slots only.
+		Goal: domain.SessionGoalStrength,
+		Slots: []domain.ExerciseSlot{
+			{Exercise: squat, Sets: []domain.Set{warmup, working, working, working}}, //nolint:exhaustruct // Sets only.
+			{Exercise: row, Sets: []domain.Set{warmup}},                              //nolint:exhaustruct // Sets only.
+			{Exercise: plank, Sets: []domain.Set{working, working}},                  //nolint:exhaustruct // Sets only.
+		},
+	}
+
+	tmpl, err := domain.NewWorkoutTemplate("  5/3/1 Day A ", sess)
+	if err != nil {
+		t.Fatalf("NewWorkoutTemplate: %v", err)
+	}
+	if tmpl.Name != "5/3/1 Day A" || tmpl.Goal != domain.SessionGoalStrength || len(tmpl.Exercises) != 2 {
+		t.Fatalf("template = %+v, want trimmed name, strength, and Squat and Plank", tmpl)
+	}
+	if got := tmpl.Exercises[0]; got.Exercise.ID != squat.ID || got.Sets != 3 {
+		t.Errorf("first exercise = %s x%d, want Squat x3 without the warmup", got.Exercise.Name, got.Sets)
+	}
+
+	date := time.Date(2026, 5, 27, 0, 0, 0, 0, time.UTC)
+	planned := tmpl.Session(date, map[int]float64{squat.ID: 102.5})
+	if !planned.Date.Equal(date) || planned.Goal != domain.SessionGoalStrength || len(planned.Slots) != 2 {
+		t.Fatalf("Session = %+v, want an unstarted strength session with two slots", planned)
+	}
+	squatSets := planned.Slots[0].Sets
+	if len(squatSets) != 3 || squatSets[0].WeightKg == nil || *squatSets[0].WeightKg != 102.5 {
+		t.Errorf("squat sets = %+v, want three at 102.5 kg", squatSets)
+	}
+	if plankSets := planned.Slots[1].Sets; plankSets[0].WeightKg != nil || plankSets[0].TargetValue != seconds {
+		t.Errorf("plank sets = %+v, want unweighted %d s holds", plankSets, seconds)
+	}
+
+	for name, tt := range map[string]struct {
+		name string
+		sess domain.Session
+	}{
+		"blank name":     {"   ", sess},
+		"long name":      {strings.Repeat("a", domain.MaxTemplateNameLength+1), sess},
+		"no working set": {"Warmups", domain.Session{Slots: sess.Slots[1:2]}}, //nolint:exhaustruct // Slots only.
+	} {
+		var ve domain.ValidationError
+		if _, err = domain.NewWorkoutTemplate(tt.name, tt.sess); !errors.As(err, &ve) {
+			t.Errorf("%s: NewWorkoutTemplate error = %v, want a ValidationError", name, err)
+		}
+	}
+}
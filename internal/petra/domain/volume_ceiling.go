@@ -0,0 +1,73 @@
+package domain
+
+import (
+	"maps"
+	"math"
+)
+
+// VolumeCeilingWarning records one slot CapAtMaxVolume trimmed because its
+// sets would have pushed MuscleGroup past its weekly ceiling (MaxSets, ≈ MRV).
+// PriorVolume is the muscle group's volume before the slot, in the same
+// fractional sets as WeeklyPlannedVolume. KeptSets never drops below one, so
+// when PriorVolume already sits at the ceiling the warning stands even after
+// trimming.
+type VolumeCeilingWarning struct {
+	MuscleGroup string
+	ExerciseID  int
+	PriorVolume float64
+	MaxSets     int
+	PlannedSets int
+	KeptSets    int
+}
+
+// CapAtMaxVolume is the MRV guardrail for a session planned on top of an
+// already-loaded week. PlanDay's scoring only penalises sets past a muscle's
+// ceiling, so when the rest of the week already sits near MaxSets it can
+// still pick a high-set exercise for that muscle. CapAtMaxVolume walks
+// sess.Slots in order, starting from weekLoad (the week's volume before
+// sess; not mutated). It trims each slot's working sets so that no targeted
+// muscle group exceeds MaxSets. Each trimmed slot keeps at least one set and
+// gets one warning, naming the muscle group that allowed the fewest sets.
+// Muscle groups without a target row are never a limit.
+func (wp *Planner) CapAtMaxVolume(sess *Session, weekLoad map[string]float64) []VolumeCeilingWarning {
+	targets := make(map[string]MuscleGroupTarget, len(wp.Targets))
+	for _, t := range wp.Targets {
+		targets[t.MuscleGroupName] = t
+	}
+	volume := make(map[string]float64, len(weekLoad))
+	maps.Copy(volume, weekLoad)
+
+	var warnings []VolumeCeilingWarning
+	for i := range sess.Slots {
+		slot := &sess.Slots[i]
+		planned := len(slot.Sets)
+		if planned == 0 {
+			continue
+		}
+		allowed, limiting := planned, ""
+		for mg, fraction := range muscleGroupShares(slot.Exercise) {
+			t, ok := targets[mg]
+			if !ok {
+				continue
+			}
+			n := int(math.Floor((float64(t.MaxSets) - volume[mg]) / fraction))
+			if n < allowed || (n == allowed && limiting != "" && mg < limiting) {
+				allowed, limiting = n, mg
+			}
+		}
+		if allowed < planned {
+			kept := max(allowed, 1)
+			warnings = append(warnings, VolumeCeilingWarning{
+				MuscleGroup: limiting,
+				ExerciseID:  slot.Exercise.ID,
+				PriorVolume: volume[limiting],
+				MaxSets:     targets[limiting].MaxSets,
+				PlannedSets: planned,
+				KeptSets:    kept,
+			})
+			slot.Sets = slot.Sets[:kept]
+		}
+		applyVolume(volume, slot.Exercise, float64(len(slot.Sets)))
+	}
+	return warnings
+}
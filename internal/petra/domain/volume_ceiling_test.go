@@ -0,0 +1,84 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func TestPlanner_CapAtMaxVolume(t *testing.T) {
+	t.Parallel()
+
+	exercises := []domain.Exercise{
+		{ //nolint:exhaustruct // Test exercise omits display fields.
+			ID: 1, Category: domain.CategoryFullBody, ExerciseType: domain.ExerciseTypeWeighted,
+			PrimaryMuscleGroups: []string{"Chest"}, SecondaryMuscleGroups: []string{"Triceps"},
+			RepMin: new(8), RepMax: new(12)},
+	}
+	targets := []domain.MuscleGroupTarget{
+		{MuscleGroupName: "Chest", MinSets: 10, MaxSets: 20},
+		{MuscleGroupName: "Triceps", MinSets: 8, MaxSets: 16},
+	}
+	wp := domain.NewPlanner(prefs(time.Tuesday), exercises, targets)
+	tue := date(monday2026Date(), 1)
+
+	t.Run("prior volume near the ceiling trims sets and warns", func(t *testing.T) {
+		t.Parallel()
+		weekLoad := map[string]float64{"Chest": 18.5}
+		sess, err := wp.PlanDay(tue, nil, weekLoad)
+		if err != nil {
+			t.Fatalf("PlanDay: %v", err)
+		}
+		planned := len(sess.Slots[0].Sets)
+		if planned < 2 {
+			t.Fatalf("planned %d sets; the test needs at least 2 to observe a trim", planned)
+		}
+
+		warnings := wp.CapAtMaxVolume(&sess, weekLoad)
+		if len(warnings) != 1 {
+			t.Fatalf("warnings = %+v, want exactly one", warnings)
+		}
+		w := warnings[0]
+		if w.MuscleGroup != "Chest" || w.ExerciseID != 1 || w.MaxSets != 20 || w.PriorVolume != 18.5 {
+			t.Errorf("warning = %+v, want Chest on exercise 1 from 18.5 of 20", w)
+		}
+		if w.PlannedSets != planned || w.KeptSets != 1 {
+			t.Errorf("warning sets = %d→%d, want %d→1", w.PlannedSets, w.KeptSets, planned)
+		}
+		if got := len(sess.Slots[0].Sets); got != 1 {
+			t.Errorf("slot keeps %d sets, want 1", got)
+		}
+		if weekLoad["Chest"] != 18.5 {
+			t.Errorf("weekLoad mutated: Chest = %v", weekLoad["Chest"])
+		}
+	})
+
+	t.Run("secondary muscle at the ceiling counts at its fraction", func(t *testing.T) {
+		t.Parallel()
+		weekLoad := map[string]float64{"Triceps": 15}
+		sess, err := wp.PlanDay(tue, nil, weekLoad)
+		if err != nil {
+			t.Fatalf("PlanDay: %v", err)
+		}
+		warnings := wp.CapAtMaxVolume(&sess, weekLoad)
+		if len(warnings) != 1 || warnings[0].MuscleGroup != "Triceps" || warnings[0].KeptSets != 2 {
+			t.Errorf("warnings = %+v, want Triceps trimmed to 2 half-sets", warnings)
+		}
+	})
+
+	t.Run("a fresh week is left alone", func(t *testing.T) {
+		t.Parallel()
+		sess, err := wp.PlanDay(tue, nil, nil)
+		if err != nil {
+			t.Fatalf("PlanDay: %v", err)
+		}
+		planned := len(sess.Slots[0].Sets)
+		if warnings := wp.CapAtMaxVolume(&sess, nil); len(warnings) != 0 {
+			t.Errorf("warnings = %+v, want none", warnings)
+		}
+		if got := len(sess.Slots[0].Sets); got != planned {
+			t.Errorf("slot keeps %d sets, want %d", got, planned)
+		}
+	})
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "math"
+
+// WarmupSet is one ramp-up set performed before a slot's working sets.
+type WarmupSet struct {
+	WeightKg float64
+	Reps     int
+}
+
+// warmupStep is one rung of the warmup ramp: a fraction of the working weight
+// and the reps to do with it. Reps fall as the load climbs so the ramp primes
+// the lift without tiring it.
+type warmupStep struct {
+	fraction float64
+	reps     int
+}
+
+// warmupSteps returns the ramp for a weighted compound lift. Strength
+// sessions, whose working sets sit near the top of the user's range, end on
+// an extra heavy single.
+func warmupSteps(goal SessionGoal) []warmupStep {
+	steps := []warmupStep{
+		{fraction: 0.4, reps: 8},
+		{fraction: 0.6, reps: 5},
+		{fraction: 0.8, reps: 3},
+	}
+	if goal == SessionGoalStrength {
+		steps = append(steps, warmupStep{fraction: 0.9, reps: 1})
+	}
+	return steps
+}
+
+// WarmupRamp returns the warmup sets to perform before the slot at pos, given
+// its first working set's weight. Only the session's first weighted compound
+// lift (see Exercise.IsCompound) gets a ramp; later lifts are warm by then,
+// and bodyweight, assisted and timed exercises have no load to ramp.
+// Deload sessions are recovery work and get none. Weights round down to the
+// 2.5 kg plate step; rungs that round to nothing or repeat the previous weight
+// are dropped, so a light working weight gets a short ramp or none at all.
+func (s *Session) WarmupRamp(pos int, workingWeightKg float64) []WarmupSet {
+	if s.IsDeload || pos != s.firstWeightedCompoundPos() {
+		return nil
+	}
+	var ramp []WarmupSet
+	for _, step := range warmupSteps(s.Goal) {
+		w := math.Floor(workingWeightKg*step.fraction/weightIncrementKgHigh) * weightIncrementKgHigh
+		if w <= 0 || (len(ramp) > 0 && w <= ramp[len(ramp)-1].WeightKg) {
+			continue
+		}
+		ramp = append(ramp, WarmupSet{WeightKg: w, Reps: step.reps})
+	}
+	return ramp
+}
+
+// firstWeightedCompoundPos returns the position of the first slot whose
+// exercise is a weighted compound lift, or -1 when the session has none.
+func (s *Session) firstWeightedCompoundPos() int {
+	for i, slot := range s.Slots {
+		if slot.Exercise.ExerciseType == ExerciseTypeWeighted && slot.Exercise.IsCompound() {
+			return i
+		}
+	}
+	return -1
+}
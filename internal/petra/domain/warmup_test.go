@@ -0,0 +1,63 @@
+package domain_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_Session_WarmupRamp(t *testing.T) {
+	t.Parallel()
+
+	slot := func(exerciseType domain.ExerciseType, primaries ...string) domain.ExerciseSlot {
+		return domain.ExerciseSlot{ //nolint:exhaustruct // Only the exercise matters.
+			Exercise: domain.Exercise{ //nolint:exhaustruct // Type and muscles only.
+				ExerciseType:        exerciseType,
+				PrimaryMuscleGroups: primaries,
+			},
+		}
+	}
+	curl := slot(domain.ExerciseTypeWeighted, "Biceps")
+	squat := slot(domain.ExerciseTypeWeighted, "Quads", "Glutes")
+	pullUp := slot(domain.ExerciseTypeBodyweight, "Lats", "Biceps")
+	bench := slot(domain.ExerciseTypeWeighted, "Chest", "Triceps")
+	session := func(goal domain.SessionGoal, isDeload bool) domain.Session {
+		return domain.Session{ //nolint:exhaustruct // Goal, deload and slots only.
+			Goal:     goal,
+			IsDeload: isDeload,
+			Slots:    []domain.ExerciseSlot{curl, pullUp, squat, bench},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		session domain.Session
+		pos     int
+		weight  float64
+		want    []domain.WarmupSet
+	}{
+		{"strength session ramps the first weighted compound", session(domain.SessionGoalStrength, false), 2, 100,
+			[]domain.WarmupSet{
+				{WeightKg: 40, Reps: 8}, {WeightKg: 60, Reps: 5}, {WeightKg: 80, Reps: 3}, {WeightKg: 90, Reps: 1},
+			}},
+		{"hypertrophy session skips the heavy single", session(domain.SessionGoalHypertrophy, false), 2, 100,
+			[]domain.WarmupSet{{WeightKg: 40, Reps: 8}, {WeightKg: 60, Reps: 5}, {WeightKg: 80, Reps: 3}}},
+		{"weights round down to the plate step", session(domain.SessionGoalHypertrophy, false), 2, 52.5,
+			[]domain.WarmupSet{{WeightKg: 20, Reps: 8}, {WeightKg: 30, Reps: 5}, {WeightKg: 40, Reps: 3}}},
+		{"light weights drop repeated rungs", session(domain.SessionGoalHypertrophy, false), 2, 5,
+			[]domain.WarmupSet{{WeightKg: 2.5, Reps: 5}}},
+		{"deload is recovery and gets no ramp", session(domain.SessionGoalStrength, true), 2, 100, nil},
+		{"later compound lifts get no ramp", session(domain.SessionGoalStrength, false), 3, 100, nil},
+		{"isolation lifts get no ramp", session(domain.SessionGoalStrength, false), 0, 100, nil},
+		{"bodyweight lifts get no ramp", session(domain.SessionGoalStrength, false), 1, 100, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if got := tt.session.WarmupRamp(tt.pos, tt.weight); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WarmupRamp(%d, %v) = %v, want %v", tt.pos, tt.weight, got, tt.want)
+			}
+		})
+	}
+}
@@ -62,6 +62,52 @@ func (wp *WeekPlan) Replace(newPlan WeekPlan) {
 	wp.Sessions = newPlan.Sessions
 }
 
+// Reschedule moves the planned session on from to the day to, both within
+// this week, leaving from as a rest day. The session keeps its slots, goal
+// and deload flag; only its date changes. Returns ErrNotFound when either
+// date is outside the week or from holds no planned session,
+// ErrAlreadyStarted when either day's session has been started, and
+// ErrAlreadyExists when to already holds a planned session.
+func (wp *WeekPlan) Reschedule(from, to time.Time) error {
+	src, dst := wp.SessionOn(from), wp.SessionOn(to)
+	if src == nil || dst == nil || src == dst {
+		return ErrNotFound
+	}
+	if isPlanned := len(src.Slots) > 0 || src.Goal != ""; !isPlanned {
+		return ErrNotFound
+	}
+	if !src.StartedAt.IsZero() || !dst.StartedAt.IsZero() {
+		return ErrAlreadyStarted
+	}
+	if len(dst.Slots) > 0 || dst.Goal != "" {
+		return ErrAlreadyExists
+	}
+	moved := *src
+	moved.Date = dst.Date
+	*dst = moved
+	*src = Session{ //nolint:exhaustruct // A rest day carries only its date.
+		Date: src.Date,
+	}
+	return nil
+}
+
+// PlaceSession puts sess on its date in place of the planned session or rest
+// day there, such as a workout started from a template instead of the one
+// the planner chose. Returns ErrNotFound when sess.Date is outside this week
+// and ErrAlreadyStarted when the day's session has been started.
+func (wp *WeekPlan) PlaceSession(sess Session) error {
+	dst := wp.SessionOn(sess.Date)
+	if dst == nil {
+		return ErrNotFound
+	}
+	if !dst.StartedAt.IsZero() {
+		return ErrAlreadyStarted
+	}
+	sess.Date = dst.Date
+	*dst = sess
+	return nil
+}
+
 // FlipDeloadFromToday sets IsDeload=true on every non-completed scheduled
 // session whose Date is on or after today. Past sessions, completed sessions,
 // and rest-day placeholders (no slots) are left untouched. Idempotent.
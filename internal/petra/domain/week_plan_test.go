@@ -177,3 +177,96 @@ func TestWeekPlan_Dispatchers_DelegateToSession(t *testing.T) {
 		t.Error("Start should set StartedAt on the underlying session")
 	}
 }
+
+func TestWeekPlan_Reschedule(t *testing.T) {
+	t.Parallel()
+
+	day := func(offset int) time.Time { return monday().AddDate(0, 0, offset) }
+	// week has planned sessions on Monday and Wednesday, a started one on
+	// Friday, and rest days with their dates set everywhere else.
+	week := func() domain.WeekPlan {
+		wp := newWeekPlan()
+		for i := range wp.Sessions {
+			wp.Sessions[i].Date = day(i)
+		}
+		wp.Sessions[0] = sessionOn(0, false, false, true)
+		wp.Sessions[2] = sessionOn(2, false, false, false)
+		wp.Sessions[4] = sessionOn(4, true, false, false)
+		return wp
+	}
+
+	t.Run("moves the session onto a rest day", func(t *testing.T) {
+		t.Parallel()
+		wp := week()
+		if err := wp.Reschedule(day(0), day(1)); err != nil {
+			t.Fatalf("Reschedule: %v", err)
+		}
+		moved := wp.Sessions[1]
+		if !moved.Date.Equal(day(1)) || len(moved.Slots) != 1 || !moved.IsDeload ||
+			moved.Goal != domain.SessionGoalStrength {
+			t.Errorf("Tuesday = %+v, want Monday's deload strength session dated Tuesday", moved)
+		}
+		if left := wp.Sessions[0]; !left.Date.Equal(day(0)) || len(left.Slots) != 0 || left.Goal != "" {
+			t.Errorf("Monday = %+v, want a rest day", left)
+		}
+	})
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		want     error
+	}{
+		{"onto a started session", day(2), day(4), domain.ErrAlreadyStarted},
+		{"a started session", day(4), day(5), domain.ErrAlreadyStarted},
+		{"onto a planned session", day(0), day(2), domain.ErrAlreadyExists},
+		{"from a rest day", day(1), day(3), domain.ErrNotFound},
+		{"out of the week", day(0), day(7), domain.ErrNotFound},
+		{"onto itself", day(0), day(0), domain.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			wp := week()
+			before := wp
+			if err := wp.Reschedule(tt.from, tt.to); !errors.Is(err, tt.want) {
+				t.Errorf("Reschedule error = %v, want %v", err, tt.want)
+			}
+			for i := range wp.Sessions {
+				if !wp.Sessions[i].Date.Equal(before.Sessions[i].Date) ||
+					len(wp.Sessions[i].Slots) != len(before.Sessions[i].Slots) {
+					t.Errorf("day %d changed on a rejected reschedule", i)
+				}
+			}
+		})
+	}
+}
+
+func TestWeekPlan_PlaceSession(t *testing.T) {
+	t.Parallel()
+
+	wp := newWeekPlan()
+	for i := range wp.Sessions {
+		wp.Sessions[i].Date = monday().AddDate(0, 0, i)
+	}
+	wp.Sessions[0] = sessionOn(0, false, false, false)
+	wp.Sessions[2] = sessionOn(2, true, false, false)
+
+	placed := sessionOn(0, false, false, false)
+	placed.Goal = domain.SessionGoalHypertrophy
+	placed.Date = monday().Add(15 * time.Hour) // Not midnight: PlaceSession keeps the day's date.
+	if err := wp.PlaceSession(placed); err != nil {
+		t.Fatalf("PlaceSession over a planned session: %v", err)
+	}
+	if got := wp.Sessions[0]; got.Goal != domain.SessionGoalHypertrophy || !got.Date.Equal(monday()) {
+		t.Errorf("Monday = %+v, want the placed hypertrophy session dated Monday", got)
+	}
+	if err := wp.PlaceSession(sessionOn(1, false, false, false)); err != nil {
+		t.Errorf("PlaceSession on a rest day: %v", err)
+	}
+	if err := wp.PlaceSession(sessionOn(2, false, false, false)); !errors.Is(err, domain.ErrAlreadyStarted) {
+		t.Errorf("PlaceSession over a started session = %v, want ErrAlreadyStarted", err)
+	}
+	if err := wp.PlaceSession(sessionOn(7, false, false, false)); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("PlaceSession out of the week = %v, want ErrNotFound", err)
+	}
+}
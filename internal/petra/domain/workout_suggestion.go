@@ -0,0 +1,110 @@
+package domain
+
+import (
+	"fmt"
+	"time"
+)
+
+// SuggestionLookbackDays is how many days before today SuggestWorkout looks
+// at. Training on every one of them earns a rest day.
+const SuggestionLookbackDays = 3
+
+// WorkoutSuggestion is SuggestWorkout's answer to "what should I train
+// today?". Category is empty when Rest is true.
+type WorkoutSuggestion struct {
+	Rest     bool
+	Category Category
+	Reason   string
+}
+
+// TrainedCategory reports which split the session's completed sets trained:
+// upper or lower when every exercise with a completed set belongs to it, and
+// full body when they span both or include a full-body exercise. ok is false
+// when nothing was completed.
+func (s *Session) TrainedCategory() (Category, bool) {
+	var upper, lower bool
+	for _, slot := range s.Slots {
+		if slot.CompletedSetCount() == 0 {
+			continue
+		}
+		switch slot.Exercise.Category {
+		case CategoryUpper:
+			upper = true
+		case CategoryLower:
+			lower = true
+		case CategoryFullBody:
+			upper, lower = true, true
+		}
+	}
+	switch {
+	case upper && lower:
+		return CategoryFullBody, true
+	case upper:
+		return CategoryUpper, true
+	case lower:
+		return CategoryLower, true
+	default:
+		return "", false
+	}
+}
+
+// SuggestWorkout recommends today's split from the sessions trained on the
+// days before today, so the muscles worked yesterday get a day to recover.
+// It suggests rest after SuggestionLookbackDays days in a row or a
+// full-body day yesterday, the opposite half after an upper or lower day,
+// and full body after a day off. Sessions on or after today are ignored;
+// sessions may be in any order.
+func SuggestWorkout(sessions []Session, today time.Time) WorkoutSuggestion {
+	trained := make(map[time.Time]Category)
+	for i := range sessions {
+		if !sessions[i].Date.Before(today) {
+			continue
+		}
+		if c, ok := sessions[i].TrainedCategory(); ok {
+			trained[sessions[i].Date] = c
+		}
+	}
+
+	streak := 0
+	for day := today.AddDate(0, 0, -1); streak < SuggestionLookbackDays; day = day.AddDate(0, 0, -1) {
+		if _, ok := trained[day]; !ok {
+			break
+		}
+		streak++
+	}
+	if streak == SuggestionLookbackDays {
+		return WorkoutSuggestion{
+			Rest:     true,
+			Category: "",
+			Reason: fmt.Sprintf("You have trained %d days in a row; a rest day lets you recover.",
+				SuggestionLookbackDays),
+		}
+	}
+
+	switch trained[today.AddDate(0, 0, -1)] {
+	case CategoryFullBody:
+		return WorkoutSuggestion{
+			Rest:     true,
+			Category: "",
+			Reason:   "Yesterday was a full-body workout; rest so every muscle group can recover.",
+		}
+	case CategoryUpper:
+		return WorkoutSuggestion{
+			Rest:     false,
+			Category: CategoryLower,
+			Reason:   "Yesterday trained the upper body; train the lower body while it recovers.",
+		}
+	case CategoryLower:
+		return WorkoutSuggestion{
+			Rest:     false,
+			Category: CategoryUpper,
+			Reason:   "Yesterday trained the lower body; train the upper body while it recovers.",
+		}
+	default:
+		return WorkoutSuggestion{
+			Rest:     false,
+			Category: CategoryFullBody,
+			Reason:   "You did not train yesterday, so every muscle group is recovered for a full-body workout.",
+		}
+	}
+}
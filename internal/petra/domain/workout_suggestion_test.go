@@ -0,0 +1,69 @@
+package domain_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_SuggestWorkout(t *testing.T) {
+	t.Parallel()
+
+	today := time.Date(2026, 5, 13, 0, 0, 0, 0, time.UTC)
+	reps, at := 8, today.Add(-time.Hour)
+	trained := func(offset int, categories ...domain.Category) domain.Session {
+		s := domain.Session{Date: today.AddDate(0, 0, offset)} //nolint:exhaustruct // Date and slots only.
+		for _, c := range categories {
+			s.Slots = append(s.Slots, domain.ExerciseSlot{ //nolint:exhaustruct // Category and sets only.
+				Exercise: domain.Exercise{Category: c}, //nolint:exhaustruct // Category only.
+				Sets: []domain.Set{ //nolint:exhaustruct // Completion only.
+					{CompletedValue: &reps, CompletedAt: &at},
+				},
+			})
+		}
+		return s
+	}
+	planned := trained(-1, domain.CategoryLower)
+	planned.Slots[0].Sets[0].CompletedValue, planned.Slots[0].Sets[0].CompletedAt = nil, nil
+
+	tests := []struct {
+		name     string
+		sessions []domain.Session
+		wantRest bool
+		want     domain.Category
+	}{
+		{"nothing trained suggests full body", nil, false, domain.CategoryFullBody},
+		{"upper then lower suggests upper",
+			[]domain.Session{trained(-2, domain.CategoryUpper), trained(-1, domain.CategoryLower)},
+			false, domain.CategoryUpper},
+		{"lower then upper suggests lower",
+			[]domain.Session{trained(-1, domain.CategoryUpper), trained(-2, domain.CategoryLower)},
+			false, domain.CategoryLower},
+		{"full body yesterday suggests rest",
+			[]domain.Session{trained(-1, domain.CategoryFullBody)}, true, ""},
+		{"upper and lower exercises in one session count as full body",
+			[]domain.Session{trained(-1, domain.CategoryUpper, domain.CategoryLower)}, true, ""},
+		{"three days in a row suggests rest",
+			[]domain.Session{
+				trained(-3, domain.CategoryLower), trained(-2, domain.CategoryUpper), trained(-1, domain.CategoryLower),
+			}, true, ""},
+		{"a session without completed sets is a day off",
+			[]domain.Session{planned}, false, domain.CategoryFullBody},
+		{"today's session is ignored",
+			[]domain.Session{trained(0, domain.CategoryUpper)}, false, domain.CategoryFullBody},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got := domain.SuggestWorkout(tt.sessions, today)
+			if got.Rest != tt.wantRest || got.Category != tt.want {
+				t.Errorf("SuggestWorkout = rest %t, category %q; want rest %t, category %q",
+					got.Rest, got.Category, tt.wantRest, tt.want)
+			}
+			if got.Reason == "" {
+				t.Error("Reason is empty, want an explanation")
+			}
+		})
+	}
+}
@@ -51,10 +51,17 @@ func (r *sqliteExerciseRepository) ListMuscleGroups(ctx context.Context) (_ []st
 	return muscleGroups, nil
 }
 
-func (r *sqliteExerciseRepository) List(ctx context.Context) (_ []domain.Exercise, err error) {
-	rows, err := r.db.ReadOnly.QueryContext(ctx, `
+func (r *sqliteExerciseRepository) List(ctx context.Context) ([]domain.Exercise, error) {
+	return r.list(ctx, r.db.ReadOnly)
+}
+
+// list reads the exercise catalogue through q, so Snapshot can read it inside
+// its transaction.
+func (r *sqliteExerciseRepository) list(ctx context.Context, q queryer) (_ []domain.Exercise, err error) {
+	rows, err := q.QueryContext(ctx, `
 		SELECT id, name, category, exercise_type, content,
-		       default_starting_seconds, rep_min, rep_max
+		       default_starting_seconds, rep_min, rep_max, default_start_weight_kg, min_days_between,
+		       complexity
 		FROM exercises
 		ORDER BY id`)
 	if err != nil {
@@ -71,9 +78,11 @@ func (r *sqliteExerciseRepository) List(ctx context.Context) (_ []domain.Exercis
 		var exercise domain.Exercise
 		var content string
 		var defaultStartingSeconds, repMin, repMax sql.NullInt64
+		var defaultStartWeightKg sql.NullFloat64
 		if err = rows.Scan(
 			&exercise.ID, &exercise.Name, &exercise.Category, &exercise.ExerciseType,
-			&content, &defaultStartingSeconds, &repMin, &repMax,
+			&content, &defaultStartingSeconds, &repMin, &repMax, &defaultStartWeightKg,
+			&exercise.MinDaysBetween, &exercise.Complexity,
 		); err != nil {
 			return nil, fmt.Errorf("scan exercise: %w", err)
 		}
@@ -92,6 +101,10 @@ func (r *sqliteExerciseRepository) List(ctx context.Context) (_ []domain.Exercis
 			v := int(repMax.Int64)
 			exercise.RepMax = &v
 		}
+		if defaultStartWeightKg.Valid {
+			v := defaultStartWeightKg.Float64
+			exercise.DefaultStartWeightKg = &v
+		}
 		exercises = append(exercises, exercise)
 	}
 	if err = rows.Err(); err != nil {
@@ -102,7 +115,7 @@ func (r *sqliteExerciseRepository) List(ctx context.Context) (_ []domain.Exercis
 	for i := range exercises {
 		ids[i] = exercises[i].ID
 	}
-	byExercise, err := fetchMuscleGroupsByExerciseID(ctx, r.db.ReadOnly, ids)
+	byExercise, err := fetchMuscleGroupsByExerciseID(ctx, q, ids)
 	if err != nil {
 		return nil, fmt.Errorf("fetch muscle groups: %w", err)
 	}
@@ -178,10 +191,12 @@ func (r *sqliteExerciseRepository) get(ctx context.Context, q queryer, id int) (
 	var exercise domain.Exercise
 	var content string
 	var defaultStartingSeconds, repMin, repMax sql.NullInt64
+	var defaultStartWeightKg sql.NullFloat64
 
 	err := q.QueryRowContext(ctx, `
 		SELECT id, name, category, exercise_type, content,
-		       default_starting_seconds, rep_min, rep_max
+		       default_starting_seconds, rep_min, rep_max, default_start_weight_kg, min_days_between,
+		       complexity
 		FROM exercises
 		WHERE id = ?`, id).Scan(
 		&exercise.ID,
@@ -192,6 +207,9 @@ func (r *sqliteExerciseRepository) get(ctx context.Context, q queryer, id int) (
 		&defaultStartingSeconds,
 		&repMin,
 		&repMax,
+		&defaultStartWeightKg,
+		&exercise.MinDaysBetween,
+		&exercise.Complexity,
 	)
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Exercise{}, domain.ErrNotFound
@@ -214,6 +232,10 @@ func (r *sqliteExerciseRepository) get(ctx context.Context, q queryer, id int) (
 		v := int(repMax.Int64)
 		exercise.RepMax = &v
 	}
+	if defaultStartWeightKg.Valid {
+		v := defaultStartWeightKg.Float64
+		exercise.DefaultStartWeightKg = &v
+	}
 
 	byExercise, err := fetchMuscleGroupsByExerciseID(ctx, q, []int{exercise.ID})
 	if err != nil {
@@ -251,17 +273,21 @@ func (r *sqliteExerciseRepository) set(
 	if upsert {
 		result, err = tx.ExecContext(ctx, `
 			INSERT INTO exercises (id, name, category, exercise_type, content,
-			                       default_starting_seconds, rep_min, rep_max)
-			VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+			                       default_starting_seconds, rep_min, rep_max, default_start_weight_kg,
+			                       min_days_between, complexity)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			ex.ID, ex.Name, ex.Category, ex.ExerciseType, content,
-			ex.DefaultStartingSeconds, ex.RepMin, ex.RepMax)
+			ex.DefaultStartingSeconds, ex.RepMin, ex.RepMax, ex.DefaultStartWeightKg, ex.MinDaysBetween,
+			ex.Complexity)
 	} else {
 		result, err = tx.ExecContext(ctx, `
 			INSERT INTO exercises (name, category, exercise_type, content,
-			                       default_starting_seconds, rep_min, rep_max)
-			VALUES (?, ?, ?, ?, ?, ?, ?)`,
+			                       default_starting_seconds, rep_min, rep_max, default_start_weight_kg,
+			                       min_days_between, complexity)
+			VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			ex.Name, ex.Category, ex.ExerciseType, content,
-			ex.DefaultStartingSeconds, ex.RepMin, ex.RepMax)
+			ex.DefaultStartingSeconds, ex.RepMin, ex.RepMax, ex.DefaultStartWeightKg, ex.MinDaysBetween,
+			ex.Complexity)
 	}
 	if err != nil {
 		return ex, fmt.Errorf("insert exercise: %w", err)
@@ -22,6 +22,8 @@ func newTestExercise() domain.Exercise {
 		SecondaryMuscleGroups: []string{"Triceps"},
 		RepMin:                new(5),
 		RepMax:                new(10),
+		MinDaysBetween:        2,
+		Complexity:            3,
 	}
 }
 
@@ -85,6 +87,12 @@ func TestExerciseRepository_CreateThenGetRoundTrip(t *testing.T) {
 		got.Resources[0].URL != "https://example.com/bench" {
 		t.Errorf("Resources round-trip: got %v", got.Resources)
 	}
+	if got.MinDaysBetween != 2 {
+		t.Errorf("MinDaysBetween: want 2, got %d", got.MinDaysBetween)
+	}
+	if got.Complexity != 3 {
+		t.Errorf("Complexity: want 3, got %d", got.Complexity)
+	}
 }
 
 func TestExerciseRepository_UpdatePersistsChanges(t *testing.T) {
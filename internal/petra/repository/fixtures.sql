@@ -87,6 +87,94 @@ SET exercise_type            = 'time_based',
     rep_max                  = NULL
 WHERE name = 'Plank';
 
+-- Starting loads for a user's first-ever session of each weighted exercise:
+-- an empty bar for the barbell lifts and a light, form-first load elsewhere.
+-- Exercises not listed (and every assisted or bodyweight one) keep NULL and
+-- start at 0 kg.
+WITH seed(name, weight_kg) AS (VALUES ('Deadlift', 40.0),
+                                      ('Bench Press', 20.0),
+                                      ('Tricep Pushdown', 15.0),
+                                      ('Dumbbell Biceps Curl', 6.0),
+                                      ('Lateral Raise', 4.0),
+                                      ('Dumbbell Shoulder Press', 8.0),
+                                      ('Dumbbell Bench Press', 10.0),
+                                      ('Cable Fly', 5.0),
+                                      ('Pulldown', 25.0),
+                                      ('Pulldown, Reverse Grip', 25.0),
+                                      ('Seated Cable Row', 25.0),
+                                      ('One-Arm Dumbbell Row', 10.0),
+                                      ('Abdominal Machine Crunch', 20.0),
+                                      ('Leg Press', 50.0),
+                                      ('Leg Extension', 20.0),
+                                      ('Leg Curl', 20.0),
+                                      ('Calf Raise', 20.0),
+                                      ('Incline Dumbbell Bench Press', 8.0),
+                                      ('Romanian Deadlift', 30.0),
+                                      ('Hip Abductor', 25.0),
+                                      ('Hip Adductor', 25.0),
+                                      ('Rotary Torso', 15.0),
+                                      ('Seated Calf Raise', 20.0),
+                                      ('Squat', 20.0),
+                                      ('Pec Fly', 15.0),
+                                      ('Smith Machine Squat', 20.0),
+                                      ('Overhead Press', 20.0),
+                                      ('Barbell Row', 30.0),
+                                      ('Face Pull', 10.0),
+                                      ('Hip Thrust', 30.0),
+                                      ('Hammer Curl', 6.0),
+                                      ('Skull Crusher', 10.0))
+UPDATE exercises
+SET default_start_weight_kg = seed.weight_kg
+FROM seed
+WHERE exercises.name = seed.name;
+
+-- How much technique each movement demands, from 1 (machines and simple
+-- isolation) to 5; the planner steers beginners towards the low end. The
+-- free-weight barbell lifts rate highest.
+WITH rating(name, complexity) AS (VALUES ('Deadlift', 4),
+                                         ('Bench Press', 3),
+                                         ('Tricep Pushdown', 1),
+                                         ('Dumbbell Biceps Curl', 1),
+                                         ('Lateral Raise', 1),
+                                         ('Dumbbell Shoulder Press', 2),
+                                         ('Dumbbell Bench Press', 2),
+                                         ('Cable Fly', 1),
+                                         ('Pulldown', 1),
+                                         ('Pulldown, Reverse Grip', 1),
+                                         ('Seated Cable Row', 1),
+                                         ('One-Arm Dumbbell Row', 2),
+                                         ('Abdominal Machine Crunch', 1),
+                                         ('Leg Press', 1),
+                                         ('Leg Extension', 1),
+                                         ('Leg Curl', 1),
+                                         ('Calf Raise', 1),
+                                         ('Back Extension', 2),
+                                         ('Push-Up', 2),
+                                         ('Ab Wheel Rollout', 3),
+                                         ('Plank', 1),
+                                         ('Incline Dumbbell Bench Press', 2),
+                                         ('Romanian Deadlift', 4),
+                                         ('Assisted Pull-Up', 2),
+                                         ('Hip Abductor', 1),
+                                         ('Hip Adductor', 1),
+                                         ('Rotary Torso', 1),
+                                         ('Seated Calf Raise', 1),
+                                         ('Squat', 4),
+                                         ('Pec Fly', 1),
+                                         ('Smith Machine Squat', 2),
+                                         ('Overhead Press', 3),
+                                         ('Barbell Row', 3),
+                                         ('Face Pull', 2),
+                                         ('Hip Thrust', 3),
+                                         ('Bulgarian Split Squat', 3),
+                                         ('Hammer Curl', 1),
+                                         ('Skull Crusher', 2),
+                                         ('Hanging Leg Raise', 3))
+UPDATE exercises
+SET complexity = rating.complexity
+FROM rating
+WHERE exercises.name = rating.name;
+
 INSERT INTO exercise_muscle_groups (exercise_id, muscle_group_name, is_primary)
 VALUES (1, 'Forearms', 0),
        (1, 'Glutes', 1),
@@ -44,8 +44,7 @@ func setupTestReposWithDB(t *testing.T) (context.Context, *sqlitekit.Database, *
 		[]byte("test-user"), "Test User").Scan(&userID); err != nil {
 		t.Fatalf("insert test user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	return ctx, db, repository.New(db)
 }
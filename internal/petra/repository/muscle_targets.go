@@ -19,8 +19,16 @@ func newSQLiteMuscleGroupTargetRepository(db *sqlitekit.Database) *sqliteMuscleG
 
 // List returns all configured weekly volume range targets, ordered by muscle-group
 // name. The targets table is seeded by migrations and is not user-editable.
-func (r *sqliteMuscleGroupTargetRepository) List(ctx context.Context) (_ []domain.MuscleGroupTarget, err error) {
-	rows, err := r.db.ReadOnly.QueryContext(ctx, `
+func (r *sqliteMuscleGroupTargetRepository) List(ctx context.Context) ([]domain.MuscleGroupTarget, error) {
+	return r.list(ctx, r.db.ReadOnly)
+}
+
+// list reads the weekly targets through q, so Snapshot can read them inside
+// its transaction.
+func (r *sqliteMuscleGroupTargetRepository) list(
+	ctx context.Context, q queryer,
+) (_ []domain.MuscleGroupTarget, err error) {
+	rows, err := q.QueryContext(ctx, `
 		SELECT muscle_group_name, min_sets, max_sets
 		FROM muscle_group_weekly_targets
 		ORDER BY muscle_group_name`)
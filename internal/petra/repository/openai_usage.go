@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+)
+
+// monthFormat keys openai_usage rows by UTC calendar month.
+const monthFormat = "2006-01"
+
+type sqliteOpenAIUsageRepository struct {
+	baseRepository
+}
+
+func newSQLiteOpenAIUsageRepository(db *sqlitekit.Database) *sqliteOpenAIUsageRepository {
+	return &sqliteOpenAIUsageRepository{baseRepository: newBaseRepository(db)}
+}
+
+// Get returns the tokens the authenticated user has spent in the calendar
+// month containing month, or zero when nothing was recorded.
+func (r *sqliteOpenAIUsageRepository) Get(ctx context.Context, month time.Time) (int64, error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	var tokens int64
+	err := r.db.ReadOnly.QueryRowContext(ctx, `
+		SELECT tokens
+		FROM openai_usage
+		WHERE user_id = ? AND month = ?`, userID, month.UTC().Format(monthFormat)).Scan(&tokens)
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("query openai usage: %w", err)
+	}
+	return tokens, nil
+}
+
+// Add adds tokens to the authenticated user's total for the calendar month
+// containing month.
+func (r *sqliteOpenAIUsageRepository) Add(ctx context.Context, month time.Time, tokens int64) error {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	if _, err := r.db.ReadWrite.ExecContext(ctx, `
+		INSERT INTO openai_usage (user_id, month, tokens)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id, month) DO UPDATE SET tokens = tokens + excluded.tokens`,
+		userID, month.UTC().Format(monthFormat), tokens); err != nil {
+		return fmt.Errorf("add openai usage: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository_test
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOpenAIUsageRepository_AddAccumulatesPerMonth(t *testing.T) {
+	t.Parallel()
+
+	ctx, repos := setupTestRepos(t)
+	may := time.Date(2026, 5, 3, 12, 0, 0, 0, time.UTC)
+	lateMay := time.Date(2026, 5, 31, 23, 0, 0, 0, time.UTC)
+	june := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	if got, err := repos.OpenAIUsage.Get(ctx, may); err != nil || got != 0 {
+		t.Fatalf("Get before any usage = (%d, %v), want (0, nil)", got, err)
+	}
+	for _, add := range []struct {
+		month  time.Time
+		tokens int64
+	}{{may, 1200}, {lateMay, 800}, {june, 50}} {
+		if err := repos.OpenAIUsage.Add(ctx, add.month, add.tokens); err != nil {
+			t.Fatalf("Add(%s, %d): %v", add.month.Format(time.DateOnly), add.tokens, err)
+		}
+	}
+
+	if got, err := repos.OpenAIUsage.Get(ctx, may); err != nil || got != 2000 {
+		t.Errorf("May usage = (%d, %v), want (2000, nil)", got, err)
+	}
+	if got, err := repos.OpenAIUsage.Get(ctx, june); err != nil || got != 50 {
+		t.Errorf("June usage = (%d, %v), want (50, nil)", got, err)
+	}
+}
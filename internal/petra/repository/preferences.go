@@ -25,20 +25,33 @@ func newSQLitePreferencesRepository(db *sqlitekit.Database) *sqlitePreferencesRe
 
 // Get returns the authenticated user's weekly schedule preferences. When no
 // row exists yet the weekday minutes default to zero (all rest days),
-// RestNotificationsEnabled defaults to true, and MesocycleLength defaults
-// to 5, matching the SQL column defaults.
+// RestNotificationsEnabled defaults to true, MesocycleLength defaults to 5,
+// MaxExercisesPerSession to 0 (no cap), DeloadInterval to 0 (automatic), and
+// LighterWeekends, PreserveExerciseOrder, RoundWeightsDown and Beginner to false,
+// ProgressionAggressiveness to 1, and PreferredWorkoutTime to nil, matching
+// the SQL column defaults.
 func (r *sqlitePreferencesRepository) Get(ctx context.Context) (domain.Preferences, error) {
+	return r.get(ctx, r.db.ReadOnly)
+}
+
+// get reads the authenticated user's preferences through q, so Snapshot can
+// read them inside its transaction.
+func (r *sqlitePreferencesRepository) get(ctx context.Context, q queryer) (domain.Preferences, error) {
 	userID := contexthelpers.AuthenticatedUserID(ctx)
 
 	var (
-		prefs     domain.Preferences
-		anchorStr sql.NullString
+		prefs       domain.Preferences
+		anchorStr   sql.NullString
+		preferredAt sql.NullString
 	)
-	err := r.db.ReadOnly.QueryRowContext(ctx, `
+	err := q.QueryRowContext(ctx, `
 		SELECT monday_minutes, tuesday_minutes, wednesday_minutes, thursday_minutes,
 		       friday_minutes, saturday_minutes, sunday_minutes,
 		       rest_notifications_enabled,
-		       deload_enabled, mesocycle_length, mesocycle_anchor
+		       deload_enabled, mesocycle_length, mesocycle_anchor,
+		       max_exercises_per_session, lighter_weekends, preserve_exercise_order,
+		       round_weights_down, progression_aggressiveness, preferred_workout_time,
+		       deload_interval, beginner
 		FROM workout_preferences
 		WHERE user_id = ?`, userID).Scan(
 		&prefs.Minutes[time.Monday], &prefs.Minutes[time.Tuesday],
@@ -47,12 +60,16 @@ func (r *sqlitePreferencesRepository) Get(ctx context.Context) (domain.Preferenc
 		&prefs.Minutes[time.Sunday],
 		&prefs.RestNotificationsEnabled,
 		&prefs.DeloadEnabled, &prefs.MesocycleLength, &anchorStr,
+		&prefs.MaxExercisesPerSession, &prefs.LighterWeekends, &prefs.PreserveExerciseOrder,
+		&prefs.RoundWeightsDown, &prefs.ProgressionAggressiveness, &preferredAt,
+		&prefs.DeloadInterval, &prefs.Beginner,
 	)
 
 	if errors.Is(err, sql.ErrNoRows) {
 		return domain.Preferences{ //nolint:exhaustruct // Weekday minutes zero by design.
-			RestNotificationsEnabled: true,
-			MesocycleLength:          defaultMesocycleLengthWeeks,
+			RestNotificationsEnabled:  true,
+			MesocycleLength:           defaultMesocycleLengthWeeks,
+			ProgressionAggressiveness: domain.DefaultProgressionAggressiveness,
 		}, nil
 	}
 	if err != nil {
@@ -65,6 +82,13 @@ func (r *sqlitePreferencesRepository) Get(ctx context.Context) (domain.Preferenc
 		}
 		prefs.MesocycleAnchor = anchor
 	}
+	if preferredAt.Valid {
+		at, parseErr := domain.ParseTimeOfDay(preferredAt.String)
+		if parseErr != nil {
+			return domain.Preferences{}, fmt.Errorf("parse preferred_workout_time: %w", parseErr)
+		}
+		prefs.PreferredWorkoutTime = &at
+	}
 	return prefs, nil
 }
 
@@ -76,6 +100,10 @@ func (r *sqlitePreferencesRepository) Set(ctx context.Context, prefs domain.Pref
 	if !prefs.MesocycleAnchor.IsZero() {
 		anchorStr = sql.NullString{Valid: true, String: formatDate(prefs.MesocycleAnchor)}
 	}
+	var preferredAt sql.NullString
+	if prefs.PreferredWorkoutTime != nil {
+		preferredAt = sql.NullString{Valid: true, String: prefs.PreferredWorkoutTime.String()}
+	}
 	length := prefs.MesocycleLength
 	if length == 0 {
 		length = 5
@@ -84,8 +112,10 @@ func (r *sqlitePreferencesRepository) Set(ctx context.Context, prefs domain.Pref
 		INSERT INTO workout_preferences (
 			user_id, monday_minutes, tuesday_minutes, wednesday_minutes, thursday_minutes,
 			friday_minutes, saturday_minutes, sunday_minutes, rest_notifications_enabled,
-			deload_enabled, mesocycle_length, mesocycle_anchor
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+			deload_enabled, mesocycle_length, mesocycle_anchor, max_exercises_per_session,
+			lighter_weekends, preserve_exercise_order, round_weights_down, progression_aggressiveness,
+			preferred_workout_time, deload_interval, beginner
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 		ON CONFLICT (user_id) DO UPDATE SET
 			monday_minutes = excluded.monday_minutes,
 			tuesday_minutes = excluded.tuesday_minutes,
@@ -97,16 +127,99 @@ func (r *sqlitePreferencesRepository) Set(ctx context.Context, prefs domain.Pref
 			rest_notifications_enabled = excluded.rest_notifications_enabled,
 			deload_enabled = excluded.deload_enabled,
 			mesocycle_length = excluded.mesocycle_length,
-			mesocycle_anchor = excluded.mesocycle_anchor`,
+			mesocycle_anchor = excluded.mesocycle_anchor,
+			max_exercises_per_session = excluded.max_exercises_per_session,
+			lighter_weekends = excluded.lighter_weekends,
+			preserve_exercise_order = excluded.preserve_exercise_order,
+			round_weights_down = excluded.round_weights_down,
+			progression_aggressiveness = excluded.progression_aggressiveness,
+			preferred_workout_time = excluded.preferred_workout_time,
+			deload_interval = excluded.deload_interval,
+			beginner = excluded.beginner`,
 		userID,
 		prefs.Minutes[time.Monday], prefs.Minutes[time.Tuesday],
 		prefs.Minutes[time.Wednesday], prefs.Minutes[time.Thursday],
 		prefs.Minutes[time.Friday], prefs.Minutes[time.Saturday],
 		prefs.Minutes[time.Sunday],
 		prefs.RestNotificationsEnabled,
-		prefs.DeloadEnabled, length, anchorStr,
+		prefs.DeloadEnabled, length, anchorStr, prefs.MaxExercisesPerSession,
+		prefs.LighterWeekends, prefs.PreserveExerciseOrder, prefs.RoundWeightsDown,
+		prefs.EffectiveProgressionAggressiveness(), preferredAt, prefs.DeloadInterval, prefs.Beginner,
 	); err != nil {
 		return fmt.Errorf("save workout preferences: %w", err)
 	}
 	return nil
 }
+
+// ListReminders returns a reminder for every user, across all users, who has a
+// preferred workout time and date's weekday as a workout day, and has not yet
+// started that day's workout. Whether each is due yet is left to the caller;
+// see domain.Reminder.DueBy.
+func (r *sqlitePreferencesRepository) ListReminders(
+	ctx context.Context, date time.Time,
+) (_ []domain.Reminder, err error) {
+	day := formatDate(date)
+	rows, err := r.db.ReadOnly.QueryContext(ctx, `
+		SELECT p.user_id, p.preferred_workout_time
+		FROM workout_preferences p
+		WHERE p.preferred_workout_time IS NOT NULL
+		  AND CASE ?
+		          WHEN 0 THEN p.sunday_minutes
+		          WHEN 1 THEN p.monday_minutes
+		          WHEN 2 THEN p.tuesday_minutes
+		          WHEN 3 THEN p.wednesday_minutes
+		          WHEN 4 THEN p.thursday_minutes
+		          WHEN 5 THEN p.friday_minutes
+		          ELSE p.saturday_minutes
+		      END > 0
+		  AND NOT EXISTS (SELECT 1
+		                  FROM workout_sessions ws
+		                  WHERE ws.user_id = p.user_id
+		                    AND ws.workout_date = ?
+		                    AND ws.started_at IS NOT NULL)
+		ORDER BY p.user_id`, int(date.Weekday()), day)
+	if err != nil {
+		return nil, fmt.Errorf("query reminders on %s: %w", day, err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close reminder rows: %w", closeErr))
+		}
+	}()
+
+	var reminders []domain.Reminder
+	for rows.Next() {
+		var (
+			userID int
+			raw    string
+		)
+		if err = rows.Scan(&userID, &raw); err != nil {
+			return nil, fmt.Errorf("scan reminder: %w", err)
+		}
+		at, parseErr := domain.ParseTimeOfDay(raw)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse preferred_workout_time of user %d: %w", userID, parseErr)
+		}
+		reminders = append(reminders, domain.Reminder{UserID: userID, Date: date, At: at.On(date)})
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterate reminders: %w", err)
+	}
+	return reminders, nil
+}
+
+// SetRestOverride upserts the authenticated user's inter-set rest for
+// exerciseID. The schema CHECK mirrors domain.ValidateRestOverride, so callers
+// validate first to get a user-facing message instead of a constraint error.
+func (r *sqlitePreferencesRepository) SetRestOverride(ctx context.Context, exerciseID, seconds int) error {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	if _, err := r.db.ReadWrite.ExecContext(ctx, `
+		INSERT INTO exercise_rest_overrides (user_id, exercise_id, rest_seconds)
+		VALUES (?, ?, ?)
+		ON CONFLICT (user_id, exercise_id) DO UPDATE SET rest_seconds = excluded.rest_seconds`,
+		userID, exerciseID, seconds,
+	); err != nil {
+		return fmt.Errorf("save rest override for exercise %d: %w", exerciseID, err)
+	}
+	return nil
+}
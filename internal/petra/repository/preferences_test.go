@@ -17,8 +17,9 @@ func TestPreferencesRepository_GetEmptyReturnsZeroValue(t *testing.T) {
 		t.Fatalf("Get on empty: %v", err)
 	}
 	want := domain.Preferences{ //nolint:exhaustruct // Weekday minutes still zero by design.
-		RestNotificationsEnabled: true,
-		MesocycleLength:          5,
+		RestNotificationsEnabled:  true,
+		MesocycleLength:           5,
+		ProgressionAggressiveness: domain.DefaultProgressionAggressiveness,
 	}
 	if got != want {
 		t.Errorf("empty Get: want %+v, got %+v", want, got)
@@ -72,9 +73,10 @@ func TestPreferencesRepository_SetThenGetRoundTrip(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Get: %v", err)
 	}
-	// MesocycleLength defaults to 5 when not explicitly set.
+	// MesocycleLength defaults to 5 and ProgressionAggressiveness to 1 when not explicitly set.
 	want := set
 	want.MesocycleLength = 5
+	want.ProgressionAggressiveness = domain.DefaultProgressionAggressiveness
 	if got != want {
 		t.Errorf("round-trip: want %+v, got %+v", want, got)
 	}
@@ -101,9 +103,10 @@ func TestPreferencesRepository_SetUpdatesExisting(t *testing.T) {
 	if err != nil {
 		t.Fatalf("Get: %v", err)
 	}
-	// MesocycleLength defaults to 5 when not explicitly set.
+	// MesocycleLength defaults to 5 and ProgressionAggressiveness to 1 when not explicitly set.
 	want := updated
 	want.MesocycleLength = 5
+	want.ProgressionAggressiveness = domain.DefaultProgressionAggressiveness
 	if got != want {
 		t.Errorf("after upsert: want %+v, got %+v", want, got)
 	}
@@ -119,6 +122,7 @@ func TestPreferencesRepository_DeloadFields(t *testing.T) {
 		DeloadEnabled:   true,
 		MesocycleLength: 4,
 		MesocycleAnchor: anchor,
+		DeloadInterval:  6,
 	}
 	if err := repos.Preferences.Set(ctx, prefs); err != nil {
 		t.Fatalf("Set: %v", err)
@@ -136,4 +140,156 @@ func TestPreferencesRepository_DeloadFields(t *testing.T) {
 	if !got.MesocycleAnchor.Equal(anchor) {
 		t.Errorf("MesocycleAnchor = %s, want %s", got.MesocycleAnchor, anchor)
 	}
+	if got.DeloadInterval != 6 {
+		t.Errorf("DeloadInterval = %d, want 6", got.DeloadInterval)
+	}
+}
+
+func TestPreferencesRepository_MaxExercisesPerSession(t *testing.T) {
+	t.Parallel()
+
+	ctx, repos := setupTestRepos(t)
+
+	got, err := repos.Preferences.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get before Set: %v", err)
+	}
+	if got.MaxExercisesPerSession != 0 {
+		t.Errorf("default MaxExercisesPerSession = %d, want 0 (no cap)", got.MaxExercisesPerSession)
+	}
+
+	prefs := domain.Preferences{MaxExercisesPerSession: 3} //nolint:exhaustruct // only the cap is exercised here
+	if err = repos.Preferences.Set(ctx, prefs); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, err = repos.Preferences.Get(ctx); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.MaxExercisesPerSession != 3 {
+		t.Errorf("MaxExercisesPerSession = %d, want 3", got.MaxExercisesPerSession)
+	}
+}
+
+func TestPreferencesRepository_Toggles(t *testing.T) {
+	t.Parallel()
+
+	ctx, repos := setupTestRepos(t)
+
+	got, err := repos.Preferences.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get before Set: %v", err)
+	}
+	if got.LighterWeekends || got.PreserveExerciseOrder || got.RoundWeightsDown || got.Beginner {
+		t.Errorf("defaults: LighterWeekends = %t, PreserveExerciseOrder = %t, RoundWeightsDown = %t, "+
+			"Beginner = %t, want all false",
+			got.LighterWeekends, got.PreserveExerciseOrder, got.RoundWeightsDown, got.Beginner)
+	}
+
+	//nolint:exhaustruct // only the toggles are exercised here
+	prefs := domain.Preferences{
+		LighterWeekends: true, PreserveExerciseOrder: true, RoundWeightsDown: true, Beginner: true,
+	}
+	if err = repos.Preferences.Set(ctx, prefs); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if got, err = repos.Preferences.Get(ctx); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if !got.LighterWeekends || !got.PreserveExerciseOrder || !got.RoundWeightsDown || !got.Beginner {
+		t.Errorf("after saving true: LighterWeekends = %t, PreserveExerciseOrder = %t, RoundWeightsDown = %t, "+
+			"Beginner = %t, want all true",
+			got.LighterWeekends, got.PreserveExerciseOrder, got.RoundWeightsDown, got.Beginner)
+	}
+}
+
+func TestPreferencesRepository_ProgressionAggressiveness(t *testing.T) {
+	t.Parallel()
+
+	ctx, repos := setupTestRepos(t)
+
+	got, err := repos.Preferences.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get before Set: %v", err)
+	}
+	if got.ProgressionAggressiveness != domain.DefaultProgressionAggressiveness {
+		t.Errorf("default ProgressionAggressiveness = %v, want %v",
+			got.ProgressionAggressiveness, domain.DefaultProgressionAggressiveness)
+	}
+
+	// Unset (zero) saves as the default; out-of-band values are clamped.
+	for _, tt := range []struct{ saved, want float64 }{{1.5, 1.5}, {0, 1}, {9, 2}} {
+		prefs := domain.Preferences{ProgressionAggressiveness: tt.saved} //nolint:exhaustruct // only the multiplier.
+		if err = repos.Preferences.Set(ctx, prefs); err != nil {
+			t.Fatalf("Set %v: %v", tt.saved, err)
+		}
+		if got, err = repos.Preferences.Get(ctx); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if got.ProgressionAggressiveness != tt.want {
+			t.Errorf("saved %v: ProgressionAggressiveness = %v, want %v", tt.saved, got.ProgressionAggressiveness, tt.want)
+		}
+	}
+}
+
+func TestPreferencesRepository_PreferredWorkoutTime(t *testing.T) {
+	t.Parallel()
+
+	ctx, repos := setupTestRepos(t)
+
+	at := domain.TimeOfDay(18*60 + 5)
+	prefs := domain.Preferences{PreferredWorkoutTime: &at} //nolint:exhaustruct // only the time.
+	if err := repos.Preferences.Set(ctx, prefs); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	got, err := repos.Preferences.Get(ctx)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.PreferredWorkoutTime == nil || *got.PreferredWorkoutTime != at {
+		t.Errorf("PreferredWorkoutTime = %v, want %s", got.PreferredWorkoutTime, at)
+	}
+
+	prefs.PreferredWorkoutTime = nil
+	if err = repos.Preferences.Set(ctx, prefs); err != nil {
+		t.Fatalf("Set nil: %v", err)
+	}
+	if got, err = repos.Preferences.Get(ctx); err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.PreferredWorkoutTime != nil {
+		t.Errorf("PreferredWorkoutTime after clearing = %s, want nil", *got.PreferredWorkoutTime)
+	}
+}
+
+func TestPreferencesRepository_SetRestOverrideHydratesSlot(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, repos := setupTestReposWithDB(t)
+	date, pos := seedExerciseSlot(ctx, t, db)
+
+	sess, err := repos.Sessions.Get(ctx, date)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got := sess.Slots[pos].RestOverrideSeconds; got != nil {
+		t.Fatalf("RestOverrideSeconds before override = %d, want nil", *got)
+	}
+
+	exerciseID := sess.Slots[pos].Exercise.ID
+	for _, seconds := range []int{90, 240} { // second write exercises the upsert
+		if err = repos.Preferences.SetRestOverride(ctx, exerciseID, seconds); err != nil {
+			t.Fatalf("SetRestOverride(%d): %v", seconds, err)
+		}
+		if sess, err = repos.Sessions.Get(ctx, date); err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		got := sess.Slots[pos].RestOverrideSeconds
+		if got == nil || *got != seconds {
+			t.Errorf("RestOverrideSeconds = %v, want %d", got, seconds)
+		}
+	}
+
+	if err = repos.Preferences.SetRestOverride(ctx, exerciseID, 5); err == nil {
+		t.Error("SetRestOverride(5) succeeded, want the schema CHECK to reject it")
+	}
 }
@@ -0,0 +1,67 @@
+//nolint:testpackage // explains the unexported query constants the repository runs; needs the internal package.
+package repository
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestQueryPlans_ConsistencyQueriesUseIndexes pins the access paths of the
+// queries behind the dashboard's streak and consistency figures (Sessions.List
+// and the muscle-group hydration it triggers). The composite primary keys of
+// workout_sessions, exercise_slots, exercise_sets and exercise_muscle_groups
+// lead with the user/date or exercise columns these queries filter on, so no
+// extra index is needed; this test fails if a schema change turns any of them
+// into a full table scan. It explains the very query text the repository
+// runs, so the two cannot drift apart.
+func TestQueryPlans_ConsistencyQueriesUseIndexes(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, _ := seedAggregateSession(t)
+
+	tests := []struct {
+		name  string
+		query string
+		args  []any
+	}{
+		{name: "session rows since a date", query: listSessionRowsQuery, args: []any{1, "2026-01-01"}},
+		{name: "slots and sets since a date", query: loadExerciseSetsSinceQuery, args: []any{1, "2026-01-01"}},
+		{
+			name:  "muscle groups of a batch of exercises",
+			query: muscleGroupsByExerciseIDQuery(3),
+			args:  []any{1, 2, 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			rows, err := db.ReadOnly.QueryContext(ctx, "EXPLAIN QUERY PLAN "+tt.query, tt.args...)
+			if err != nil {
+				t.Fatalf("explain: %v", err)
+			}
+			defer func() { _ = rows.Close() }()
+			var plan []string
+			for rows.Next() {
+				var (
+					id, parent, notUsed int
+					detail              string
+				)
+				if err = rows.Scan(&id, &parent, &notUsed, &detail); err != nil {
+					t.Fatalf("scan plan row: %v", err)
+				}
+				plan = append(plan, detail)
+			}
+			if err = rows.Err(); err != nil {
+				t.Fatalf("plan rows: %v", err)
+			}
+			if len(plan) == 0 {
+				t.Fatal("EXPLAIN QUERY PLAN returned no steps")
+			}
+			for _, step := range plan {
+				if strings.HasPrefix(step, "SCAN ") {
+					t.Errorf("plan step %q is a full scan; plan:\n%s", step, strings.Join(plan, "\n"))
+				}
+			}
+		})
+	}
+}
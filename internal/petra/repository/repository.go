@@ -30,11 +30,16 @@ type Repositories struct {
 	MuscleTargets     *sqliteMuscleGroupTargetRepository
 	PushSubscriptions *sqlitePushSubscriptionRepository
 	ScheduledPushes   *sqliteScheduledPushRepository
+	OpenAIUsage       *sqliteOpenAIUsageRepository
+	Templates         *sqliteWorkoutTemplateRepository
+
+	db *sqlitekit.Database
 }
 
-// New constructs all eight SQLite-backed repositories. The session repository
+// New constructs all ten SQLite-backed repositories. The session repository
 // hydrates ExerciseSlot.Exercise inline by joining `exercises` and batching
-// muscle-group lookups, so it does not depend on the exercise repository.
+// muscle-group lookups, so it does not depend on the exercise repository; the
+// template repository reuses the exercise repository to hydrate its exercises.
 func New(db *sqlitekit.Database) *Repositories {
 	prefs := newSQLitePreferencesRepository(db)
 	muscleTargets := newSQLiteMuscleGroupTargetRepository(db)
@@ -44,6 +49,8 @@ func New(db *sqlitekit.Database) *Repositories {
 	weekPlans := newSQLiteWeekPlanRepository(db)
 	pushSubs := newSQLitePushSubscriptionRepository(db)
 	scheduledPushes := newSQLiteScheduledPushRepository(db)
+	openAIUsage := newSQLiteOpenAIUsageRepository(db)
+	templates := newSQLiteWorkoutTemplateRepository(db, exercises)
 	return &Repositories{
 		Preferences:       prefs,
 		MuscleTargets:     muscleTargets,
@@ -53,5 +60,8 @@ func New(db *sqlitekit.Database) *Repositories {
 		WeekPlans:         weekPlans,
 		PushSubscriptions: pushSubs,
 		ScheduledPushes:   scheduledPushes,
+		OpenAIUsage:       openAIUsage,
+		Templates:         templates,
+		db:                db,
 	}
 }
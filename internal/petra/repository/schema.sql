@@ -21,7 +21,23 @@ CREATE TABLE workout_preferences
     deload_enabled             INTEGER NOT NULL DEFAULT 0 CHECK (deload_enabled IN (0, 1)),
     mesocycle_length           INTEGER NOT NULL DEFAULT 5 CHECK (mesocycle_length BETWEEN 4 AND 7),
     mesocycle_anchor           TEXT CHECK (mesocycle_anchor IS NULL
-                                           OR STRFTIME('%Y-%m-%d', mesocycle_anchor) = mesocycle_anchor)
+                                           OR STRFTIME('%Y-%m-%d', mesocycle_anchor) = mesocycle_anchor),
+    -- 0 means no cap; the upper bound mirrors domain.MaxExercisesPerSessionLimit.
+    max_exercises_per_session  INTEGER NOT NULL DEFAULT 0 CHECK (max_exercises_per_session BETWEEN 0 AND 5),
+    lighter_weekends           INTEGER NOT NULL DEFAULT 0 CHECK (lighter_weekends IN (0, 1)),
+    preserve_exercise_order    INTEGER NOT NULL DEFAULT 0 CHECK (preserve_exercise_order IN (0, 1)),
+    round_weights_down         INTEGER NOT NULL DEFAULT 0 CHECK (round_weights_down IN (0, 1)),
+    -- The bounds mirror domain.MinProgressionAggressiveness and MaxProgressionAggressiveness.
+    progression_aggressiveness REAL    NOT NULL DEFAULT 1.0 CHECK (progression_aggressiveness BETWEEN 0.5 AND 2.0),
+    -- HH:MM in UTC, or NULL for no workout reminders.
+    preferred_workout_time     TEXT CHECK (preferred_workout_time IS NULL
+                                           OR STRFTIME('%H:%M', preferred_workout_time) = preferred_workout_time),
+    -- Weeks of straight progression before an early deload; 0 means automatic. The bounds mirror
+    -- domain.MinDeloadInterval and MaxDeloadInterval.
+    deload_interval            INTEGER NOT NULL DEFAULT 0 CHECK (deload_interval = 0
+                                                                 OR deload_interval BETWEEN 4 AND 6),
+    -- New to strength training: the planner prefers low-complexity exercises.
+    beginner                   INTEGER NOT NULL DEFAULT 0 CHECK (beginner IN (0, 1))
 ) STRICT;
 
 CREATE TABLE exercises
@@ -35,6 +51,11 @@ CREATE TABLE exercises
     default_starting_seconds INTEGER CHECK (default_starting_seconds IS NULL OR default_starting_seconds > 0),
     rep_min                  INTEGER CHECK (rep_min IS NULL OR (rep_min >= 1 AND rep_min <= 50)),
     rep_max                  INTEGER CHECK (rep_max IS NULL OR (rep_max >= 1 AND rep_max <= 50)),
+    default_start_weight_kg  REAL CHECK (default_start_weight_kg IS NULL OR default_start_weight_kg >= 0),
+    -- Fewest days the planner leaves between two sessions with this exercise; 0 = no extra spacing.
+    min_days_between         INTEGER NOT NULL DEFAULT 0 CHECK (min_days_between BETWEEN 0 AND 14),
+    -- Technique demand from 1 (simple) to 5 (highly technical); 0 = unrated.
+    complexity               INTEGER NOT NULL DEFAULT 0 CHECK (complexity BETWEEN 0 AND 5),
     CHECK (exercise_type <> 'time_based' OR default_starting_seconds IS NOT NULL),
     CHECK (exercise_type =  'time_based' OR (rep_min IS NOT NULL AND rep_max IS NOT NULL)),
     CHECK (rep_min IS NULL OR rep_max IS NULL OR rep_min <= rep_max)
@@ -85,12 +106,67 @@ CREATE TABLE exercise_sets
     completed_at    TEXT CHECK (completed_at IS NULL OR
                                 STRFTIME('%Y-%m-%dT%H:%M:%fZ', completed_at) = completed_at),
     signal          TEXT CHECK (signal IS NULL OR signal IN ('too_heavy', 'on_target', 'too_light')),
+    side            TEXT CHECK (side IS NULL OR side IN ('left', 'right', 'both')),
+    -- Circuit sets only: the timed window the rounds are performed in.
+    duration_seconds INTEGER CHECK (duration_seconds IS NULL OR duration_seconds > 0),
+    -- Reps reached but form broke down; progression holds the weight.
+    technical_failure INTEGER NOT NULL DEFAULT 0 CHECK (technical_failure IN (0, 1)),
+    -- A warmup or back-off set, left out of record and 1RM analyses.
+    warmup            INTEGER NOT NULL DEFAULT 0 CHECK (warmup IN (0, 1)),
 
     PRIMARY KEY (workout_user_id, workout_date, position, set_number),
     FOREIGN KEY (workout_user_id, workout_date, position)
         REFERENCES exercise_slots (workout_user_id, workout_date, position) ON DELETE CASCADE
 ) WITHOUT ROWID, STRICT;
 
+-- A user's own inter-set rest for an exercise, replacing the goal-derived rest
+-- wherever that exercise is planned or performed.
+CREATE TABLE exercise_rest_overrides
+(
+    user_id      INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    exercise_id  INTEGER NOT NULL REFERENCES exercises (id) ON DELETE CASCADE,
+    rest_seconds INTEGER NOT NULL CHECK (rest_seconds BETWEEN 10 AND 600),
+
+    PRIMARY KEY (user_id, exercise_id)
+) WITHOUT ROWID, STRICT;
+
+-- Named routines saved from a session (domain.WorkoutTemplate). The name
+-- bound mirrors domain.MaxTemplateNameLength.
+CREATE TABLE workout_templates
+(
+    id           INTEGER PRIMARY KEY,
+    user_id      INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    name         TEXT    NOT NULL CHECK (LENGTH(name) BETWEEN 1 AND 64),
+    session_goal TEXT    NOT NULL CHECK (session_goal IN ('strength', 'hypertrophy')),
+    created_at   TEXT    NOT NULL DEFAULT (STRFTIME('%Y-%m-%dT%H:%M:%fZ'))
+        CHECK (STRFTIME('%Y-%m-%dT%H:%M:%fZ', created_at) = created_at),
+
+    UNIQUE (user_id, name)
+) STRICT;
+
+CREATE TABLE workout_template_exercises
+(
+    template_id INTEGER NOT NULL REFERENCES workout_templates (id) ON DELETE CASCADE,
+    position    INTEGER NOT NULL CHECK (position >= 0),
+    exercise_id INTEGER NOT NULL REFERENCES exercises (id) DEFERRABLE INITIALLY DEFERRED,
+    set_count   INTEGER NOT NULL CHECK (set_count > 0),
+
+    PRIMARY KEY (template_id, position),
+    UNIQUE (template_id, exercise_id)
+) WITHOUT ROWID, STRICT;
+
+-- Approximate OpenAI token spend per user and calendar month (UTC, YYYY-MM),
+-- summed from the API's usage fields. Backs the monthly AI spend cap; a new
+-- month starts a new row, so the cap resets without a cleanup job.
+CREATE TABLE openai_usage
+(
+    user_id INTEGER NOT NULL REFERENCES users (id) ON DELETE CASCADE,
+    month   TEXT    NOT NULL CHECK (LENGTH(month) = 7),
+    tokens  INTEGER NOT NULL CHECK (tokens >= 0),
+
+    PRIMARY KEY (user_id, month)
+) WITHOUT ROWID, STRICT;
+
 CREATE TABLE muscle_groups
 (
     name TEXT NOT NULL PRIMARY KEY CHECK (LENGTH(name) < 64)
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"time"
@@ -27,9 +28,15 @@ func newSQLiteSessionRepository(db *sqlitekit.Database) *sqliteSessionRepository
 // batched query (plus one muscle-group query), so List issues three queries
 // total regardless of how many sessions it returns — see loadExerciseSetsSince.
 func (r *sqliteSessionRepository) List(ctx context.Context, sinceDate time.Time) ([]domain.Session, error) {
+	return r.list(ctx, r.db.ReadOnly, sinceDate)
+}
+
+// list reads the sessions List returns through q, so Snapshot can read them
+// inside its transaction.
+func (r *sqliteSessionRepository) list(ctx context.Context, q queryer, sinceDate time.Time) ([]domain.Session, error) {
 	userID := contexthelpers.AuthenticatedUserID(ctx)
 
-	sessions, err := r.listSessionRows(ctx, userID, sinceDate)
+	sessions, err := r.listSessionRows(ctx, q, userID, sinceDate)
 	if err != nil {
 		return nil, err
 	}
@@ -37,7 +44,7 @@ func (r *sqliteSessionRepository) List(ctx context.Context, sinceDate time.Time)
 		return sessions, nil
 	}
 
-	setsByDate, err := r.loadExerciseSetsSince(ctx, r.db.ReadOnly, userID, sinceDate)
+	setsByDate, err := r.loadExerciseSetsSince(ctx, q, userID, sinceDate)
 	if err != nil {
 		return nil, err
 	}
@@ -47,8 +54,11 @@ func (r *sqliteSessionRepository) List(ctx context.Context, sinceDate time.Time)
 	return sessions, nil
 }
 
+// Get returns the user's session on date with every slot, set, exercise
+// definition and muscle group, read in one query by getAggregate. It is on the
+// hot path of every workout page.
 func (r *sqliteSessionRepository) Get(ctx context.Context, date time.Time) (domain.Session, error) {
-	return r.get(ctx, r.db.ReadOnly, date)
+	return r.getAggregate(ctx, r.db.ReadOnly, date)
 }
 
 // parseSessionRow converts the workout_sessions row scalars into a partial
@@ -86,7 +96,8 @@ func parseSessionRow(
 // loadExerciseSetsRow holds one scanned row of the exercise_slots /
 // exercise_sets / exercises join consumed by scanExerciseSetRows. Exercise
 // columns join from `exercises` so we don't issue a per-slot follow-up
-// query for the base exercise.
+// query for the base exercise; the user's rest override LEFT-JOINs from
+// `exercise_rest_overrides` the same way.
 type loadExerciseSetsRow struct {
 	position               int
 	exerciseID             int
@@ -97,6 +108,10 @@ type loadExerciseSetsRow struct {
 	completedValue         sql.NullInt32
 	completedAtStr         sql.NullString
 	signalStr              sql.NullString
+	sideStr                sql.NullString
+	durationSeconds        sql.NullInt64
+	technicalFailure       sql.NullBool
+	warmup                 sql.NullBool
 	exerciseName           string
 	exerciseCategory       domain.Category
 	exerciseType           domain.ExerciseType
@@ -104,6 +119,10 @@ type loadExerciseSetsRow struct {
 	defaultStartingSeconds sql.NullInt64
 	repMin                 sql.NullInt64
 	repMax                 sql.NullInt64
+	defaultStartWeightKg   sql.NullFloat64
+	minDaysBetween         int
+	complexity             int
+	restOverrideSeconds    sql.NullInt64
 }
 
 // scanExerciseSetRows consumes the exercise_slots / exercise_sets /
@@ -135,9 +154,11 @@ func scanExerciseSetRows(rows *sql.Rows) ([]domain.ExerciseSlot, []string, error
 		)
 		if err = rows.Scan(&workoutDateStr, &row.position, &row.exerciseID, &row.warmupCompletedAtStr,
 			&row.setNumber, &row.weightKg, &row.targetValue,
-			&row.completedValue, &row.completedAtStr, &row.signalStr,
+			&row.completedValue, &row.completedAtStr, &row.signalStr, &row.sideStr,
+			&row.durationSeconds, &row.technicalFailure, &row.warmup,
 			&row.exerciseName, &row.exerciseCategory, &row.exerciseType, &row.exerciseContent,
-			&row.defaultStartingSeconds, &row.repMin, &row.repMax); err != nil {
+			&row.defaultStartingSeconds, &row.repMin, &row.repMax, &row.defaultStartWeightKg,
+			&row.minDaysBetween, &row.complexity, &row.restOverrideSeconds); err != nil {
 			return nil, nil, fmt.Errorf("scan exercise set: %w", err)
 		}
 
@@ -209,10 +230,12 @@ func startExerciseSet(row loadExerciseSetsRow) (domain.ExerciseSlot, error) {
 		return domain.ExerciseSlot{}, err
 	}
 	exercise := domain.Exercise{ //nolint:exhaustruct // muscle groups filled in by hydrateMuscleGroups.
-		ID:           row.exerciseID,
-		Name:         row.exerciseName,
-		Category:     row.exerciseCategory,
-		ExerciseType: row.exerciseType,
+		ID:             row.exerciseID,
+		Name:           row.exerciseName,
+		Category:       row.exerciseCategory,
+		ExerciseType:   row.exerciseType,
+		MinDaysBetween: row.minDaysBetween,
+		Complexity:     row.complexity,
 	}
 	if err = unmarshalExerciseContent(row.exerciseContent, &exercise); err != nil {
 		return domain.ExerciseSlot{}, err
@@ -229,15 +252,25 @@ func startExerciseSet(row loadExerciseSetsRow) (domain.ExerciseSlot, error) {
 		v := int(row.repMax.Int64)
 		exercise.RepMax = &v
 	}
+	if row.defaultStartWeightKg.Valid {
+		v := row.defaultStartWeightKg.Float64
+		exercise.DefaultStartWeightKg = &v
+	}
+	var restOverride *int
+	if row.restOverrideSeconds.Valid {
+		v := int(row.restOverrideSeconds.Int64)
+		restOverride = &v
+	}
 	return domain.ExerciseSlot{
-		Exercise:          exercise,
-		Sets:              []domain.Set{},
-		WarmupCompletedAt: warmupCompletedAt,
+		Exercise:            exercise,
+		Sets:                []domain.Set{},
+		WarmupCompletedAt:   warmupCompletedAt,
+		RestOverrideSeconds: restOverride,
 	}, nil
 }
 
 func buildSet(row loadExerciseSetsRow) (domain.Set, error) {
-	set := domain.Set{ //nolint:exhaustruct // CompletedValue, CompletedAt, Signal populated below.
+	set := domain.Set{ //nolint:exhaustruct // Nullable fields populated below.
 		TargetValue: int(row.targetValue.Int32),
 	}
 	if row.weightKg.Valid {
@@ -255,6 +288,16 @@ func buildSet(row loadExerciseSetsRow) (domain.Set, error) {
 		s := domain.Signal(row.signalStr.String)
 		set.Signal = &s
 	}
+	if row.sideStr.Valid {
+		sd := domain.Side(row.sideStr.String)
+		set.Side = &sd
+	}
+	if row.durationSeconds.Valid {
+		d := int(row.durationSeconds.Int64)
+		set.DurationSeconds = &d
+	}
+	set.TechnicalFailure = row.technicalFailure.Bool
+	set.Warmup = row.warmup.Bool
 	return set, nil
 }
 
@@ -286,6 +329,9 @@ func parseWarmupCompletedAtTimestamp(warmupCompletedAtStr sql.NullString) (*time
 	return &warmupTime, nil
 }
 
+// ListSetsForExerciseSince returns the user's sets of exerciseID on or after
+// sinceDate, newest date first. Circuit sets are left out: their completed
+// value counts rounds, which no reps-based history reader expects.
 func (r *sqliteSessionRepository) ListSetsForExerciseSince(
 	ctx context.Context,
 	exerciseID int,
@@ -296,13 +342,14 @@ func (r *sqliteSessionRepository) ListSetsForExerciseSince(
 
 	rows, err := r.db.ReadOnly.QueryContext(ctx, `
 		SELECT we.workout_date, es.weight_kg, es.target_value,
-		       es.completed_value, es.completed_at, es.signal
+		       es.completed_value, es.completed_at, es.signal, es.side, es.warmup
 		FROM exercise_slots we
 		JOIN exercise_sets es
 		    ON  es.workout_user_id = we.workout_user_id
 		    AND es.workout_date    = we.workout_date
 		    AND es.position        = we.position
 		WHERE we.workout_user_id = ? AND we.exercise_id = ? AND we.workout_date >= ?
+		  AND es.duration_seconds IS NULL
 		ORDER BY we.workout_date DESC, es.set_number`,
 		userID, exerciseID, sinceDateStr)
 	if err != nil {
@@ -348,15 +395,25 @@ func (r *sqliteSessionRepository) ListSetsForExerciseSince(
 	return result, nil
 }
 
-func scanHistoryRow(rows *sql.Rows) (string, domain.Set, error) {
+// historyRowColumns is the number of columns scanHistoryRow reads itself.
+const historyRowColumns = 8
+
+// scanHistoryRow scans a (workout_date, weight_kg, target_value,
+// completed_value, completed_at, signal, side, warmup) row. lead receives any columns
+// selected ahead of workout_date.
+func scanHistoryRow(rows *sql.Rows, lead ...any) (string, domain.Set, error) {
 	var (
 		workoutDateStr string
 		set            domain.Set
 		completedAtStr sql.NullString
 		signalStr      sql.NullString
+		sideStr        sql.NullString
 	)
-	if err := rows.Scan(&workoutDateStr, &set.WeightKg, &set.TargetValue,
-		&set.CompletedValue, &completedAtStr, &signalStr); err != nil {
+	dest := make([]any, 0, len(lead)+historyRowColumns)
+	dest = append(dest, lead...)
+	dest = append(dest, &workoutDateStr, &set.WeightKg, &set.TargetValue,
+		&set.CompletedValue, &completedAtStr, &signalStr, &sideStr, &set.Warmup)
+	if err := rows.Scan(dest...); err != nil {
 		return "", domain.Set{}, fmt.Errorf("scan exercise set row: %w", err)
 	}
 	if err := parseCompletedAtTimestamp(completedAtStr, &set); err != nil {
@@ -366,9 +423,66 @@ func scanHistoryRow(rows *sql.Rows) (string, domain.Set, error) {
 		s := domain.Signal(signalStr.String)
 		set.Signal = &s
 	}
+	if sideStr.Valid {
+		sd := domain.Side(sideStr.String)
+		set.Side = &sd
+	}
 	return workoutDateStr, set, nil
 }
 
+// ListCompletedSetsByExercise returns every completed set the user has
+// logged, grouped by exercise ID and then by workout date (oldest first).
+// Sets never completed are left out, as are circuit sets, whose value counts
+// rounds rather than reps.
+func (r *sqliteSessionRepository) ListCompletedSetsByExercise(
+	ctx context.Context,
+) (_ map[int][]domain.ExerciseSetHistory, err error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	rows, err := r.db.ReadOnly.QueryContext(ctx, `
+		SELECT we.exercise_id, we.workout_date, es.weight_kg, es.target_value,
+		       es.completed_value, es.completed_at, es.signal, es.side, es.warmup
+		FROM exercise_slots we
+		JOIN exercise_sets es
+		    ON  es.workout_user_id = we.workout_user_id
+		    AND es.workout_date    = we.workout_date
+		    AND es.position        = we.position
+		WHERE we.workout_user_id = ? AND es.completed_value IS NOT NULL
+		  AND es.duration_seconds IS NULL
+		ORDER BY we.exercise_id, we.workout_date, es.set_number`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query completed sets: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close rows: %w", closeErr))
+		}
+	}()
+
+	result := make(map[int][]domain.ExerciseSetHistory)
+	for rows.Next() {
+		var exerciseID int
+		workoutDateStr, set, scanErr := scanHistoryRow(rows, &exerciseID)
+		if scanErr != nil {
+			return nil, scanErr
+		}
+		date, parseErr := time.Parse(dateFormat, workoutDateStr)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parse workout date: %w", parseErr)
+		}
+		histories := result[exerciseID]
+		if n := len(histories); n == 0 || !histories[n-1].Date.Equal(date) {
+			histories = append(histories, domain.ExerciseSetHistory{Date: date, Sets: nil})
+		}
+		last := &histories[len(histories)-1]
+		last.Sets = append(last.Sets, set)
+		result[exerciseID] = histories
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+	return result, nil
+}
+
 func (r *sqliteSessionRepository) GetLatestStartingWeightBefore(
 	ctx context.Context,
 	exerciseID int,
@@ -397,6 +511,7 @@ func (r *sqliteSessionRepository) GetLatestStartingWeightBefore(
 		  AND ws.is_deload = 0
 		  AND es.completed_value IS NOT NULL
 		  AND es.weight_kg IS NOT NULL
+		  AND es.warmup = 0
 		  AND es.signal IN ('on_target', 'too_light')
 		ORDER BY we.workout_date DESC, es.set_number DESC
 		LIMIT 1`,
@@ -435,6 +550,7 @@ func (r *sqliteSessionRepository) GetLatestSuccessfulSecondsBefore(
 		  AND we.workout_date < ?
 		  AND ws.is_deload = 0
 		  AND es.completed_value IS NOT NULL
+		  AND es.warmup = 0
 		  AND es.signal IN ('on_target', 'too_light')
 		ORDER BY we.workout_date DESC, es.set_number DESC
 		LIMIT 1`,
@@ -448,20 +564,25 @@ func (r *sqliteSessionRepository) GetLatestSuccessfulSecondsBefore(
 	return seconds, nil
 }
 
+// listSessionRowsQuery selects a user's workout_sessions rows on or after a
+// date, newest first. Shared with the query-plan test, which pins it to the
+// primary key.
+const listSessionRowsQuery = `
+		SELECT workout_date, difficulty_rating, started_at, completed_at, session_goal, is_deload
+		FROM workout_sessions
+		WHERE user_id = ? AND workout_date >= ?
+		ORDER BY workout_date DESC`
+
 // listSessionRows scans the workout_sessions scalar rows for a user on or
 // after sinceDate, newest first. Slots is left nil — List hydrates it
 // in a single batched follow-up query.
 func (r baseRepository) listSessionRows(
 	ctx context.Context,
+	q queryer,
 	userID int,
 	sinceDate time.Time,
 ) (_ []domain.Session, err error) {
-	rows, err := r.db.ReadOnly.QueryContext(ctx, `
-		SELECT workout_date, difficulty_rating, started_at, completed_at, session_goal, is_deload
-		FROM workout_sessions
-		WHERE user_id = ? AND workout_date >= ?
-		ORDER BY workout_date DESC`,
-		userID, formatDate(sinceDate))
+	rows, err := q.QueryContext(ctx, listSessionRowsQuery, userID, formatDate(sinceDate))
 	if err != nil {
 		return nil, fmt.Errorf("query workout history: %w", err)
 	}
@@ -555,76 +676,54 @@ func (r baseRepository) listSessionRowsBetween(
 	return sessions, nil
 }
 
-func (r *sqliteSessionRepository) get(ctx context.Context, q queryer, date time.Time) (domain.Session, error) {
-	userID := contexthelpers.AuthenticatedUserID(ctx)
-	dateStr := formatDate(date)
-
-	var (
-		workoutDateStr   string
-		difficultyRating sql.NullInt32
-		startedAtStr     sql.NullString
-		completedAtStr   sql.NullString
-		goal             domain.SessionGoal
-		isDeload         bool
-	)
-	err := q.QueryRowContext(ctx, `
-		SELECT workout_date, difficulty_rating, started_at, completed_at, session_goal, is_deload
-		FROM workout_sessions
-		WHERE user_id = ? AND workout_date = ?`,
-		userID, dateStr).Scan(
-		&workoutDateStr, &difficultyRating, &startedAtStr, &completedAtStr, &goal, &isDeload)
-	if errors.Is(err, sql.ErrNoRows) {
-		return domain.Session{}, domain.ErrNotFound
-	}
-	if err != nil {
-		return domain.Session{}, fmt.Errorf("query session: %w", err)
-	}
-
-	session, err := parseSessionRow(
-		workoutDateStr, difficultyRating, startedAtStr, completedAtStr, goal, isDeload,
-	)
-	if err != nil {
-		return domain.Session{}, err
-	}
-
-	exerciseSlots, err := r.loadExerciseSets(ctx, q, userID, session.Date)
-	if err != nil {
-		return domain.Session{}, err
-	}
-	session.Slots = exerciseSlots
-
-	return session, nil
-}
-
-// loadExerciseSets fetches all exercise slots for a single session, including
-// ones with no sets yet (e.g. just-swapped exercises). The driving table is
-// exercise_slots so empty slots still appear; sets are LEFT-JOINed in and
-// the base exercise definition is JOINed from `exercises`. Muscle groups are
-// hydrated in one follow-up query. Used by Get, including inside the Update
-// transaction, where a single-date point read is what we want.
-func (r *sqliteSessionRepository) loadExerciseSets(
+// getAggregate loads the session on date in a single query. The session row
+// drives the query so a session without slots still comes back; slots, sets,
+// exercise definitions and the user's rest overrides are LEFT-JOINed in, and
+// each exercise's muscle groups arrive as JSON arrays from correlated
+// subqueries. Returns domain.ErrNotFound when there is no session on date.
+func (r *sqliteSessionRepository) getAggregate(
 	ctx context.Context,
 	q queryer,
-	userID int,
 	date time.Time,
-) (_ []domain.ExerciseSlot, err error) {
+) (_ domain.Session, err error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
 	rows, err := q.QueryContext(ctx, `
-		SELECT we.workout_date, we.position, we.exercise_id, we.warmup_completed_at,
+		SELECT ws.workout_date, ws.difficulty_rating, ws.started_at, ws.completed_at,
+		       ws.session_goal, ws.is_deload,
+		       we.position, we.exercise_id, we.warmup_completed_at,
 		       es.set_number, es.weight_kg, es.target_value,
-		       es.completed_value, es.completed_at, es.signal,
+		       es.completed_value, es.completed_at, es.signal, es.side, es.duration_seconds,
+		       es.technical_failure, es.warmup,
 		       e.name, e.category, e.exercise_type, e.content,
-		       e.default_starting_seconds, e.rep_min, e.rep_max
-		FROM exercise_slots we
+		       e.default_starting_seconds, e.rep_min, e.rep_max, e.default_start_weight_kg,
+		       e.min_days_between, e.complexity, ro.rest_seconds,
+		       (SELECT json_group_array(name) FROM (
+		            SELECT emg.muscle_group_name AS name
+		            FROM exercise_muscle_groups emg
+		            WHERE emg.exercise_id = we.exercise_id AND emg.is_primary = 1
+		            ORDER BY emg.muscle_group_name)),
+		       (SELECT json_group_array(name) FROM (
+		            SELECT emg.muscle_group_name AS name
+		            FROM exercise_muscle_groups emg
+		            WHERE emg.exercise_id = we.exercise_id AND emg.is_primary = 0
+		            ORDER BY emg.muscle_group_name))
+		FROM workout_sessions ws
+		LEFT JOIN exercise_slots we
+		    ON  we.workout_user_id = ws.user_id
+		    AND we.workout_date    = ws.workout_date
 		LEFT JOIN exercise_sets es
 		    ON  es.workout_user_id = we.workout_user_id
 		    AND es.workout_date    = we.workout_date
 		    AND es.position        = we.position
-		JOIN exercises e ON e.id = we.exercise_id
-		WHERE we.workout_user_id = ? AND we.workout_date = ?
+		LEFT JOIN exercises e ON e.id = we.exercise_id
+		LEFT JOIN exercise_rest_overrides ro
+		    ON  ro.user_id     = we.workout_user_id
+		    AND ro.exercise_id = we.exercise_id
+		WHERE ws.user_id = ? AND ws.workout_date = ?
 		ORDER BY we.position, es.set_number`,
 		userID, formatDate(date))
 	if err != nil {
-		return nil, fmt.Errorf("query exercise sets: %w", err)
+		return domain.Session{}, fmt.Errorf("query session aggregate: %w", err)
 	}
 	defer func() {
 		if closeErr := rows.Close(); closeErr != nil {
@@ -632,43 +731,175 @@ func (r *sqliteSessionRepository) loadExerciseSets(
 		}
 	}()
 
-	slots, _, err := scanExerciseSetRows(rows)
-	if err != nil {
-		return nil, err
+	var (
+		session    domain.Session
+		found      bool
+		current    *domain.ExerciseSlot
+		currentPos = -1
+	)
+	for rows.Next() {
+		var row aggregateRow
+		if err = row.scan(rows); err != nil {
+			return domain.Session{}, err
+		}
+		if !found {
+			if session, err = parseSessionRow(row.workoutDateStr, row.difficultyRating,
+				row.startedAtStr, row.completedAtStr, row.goal, row.isDeload); err != nil {
+				return domain.Session{}, err
+			}
+			found = true
+		}
+		// A session without slots yields a single row of NULL slot columns.
+		if !row.position.Valid {
+			continue
+		}
+		slotRow := row.slotRow()
+		if current == nil || slotRow.position != currentPos {
+			if current != nil {
+				session.Slots = append(session.Slots, *current)
+			}
+			started, startErr := startExerciseSet(slotRow)
+			if startErr != nil {
+				return domain.Session{}, startErr
+			}
+			if startErr = row.hydrateMuscleGroups(&started.Exercise); startErr != nil {
+				return domain.Session{}, startErr
+			}
+			current = &started
+			currentPos = slotRow.position
+		}
+		if !slotRow.setNumber.Valid {
+			continue
+		}
+		set, buildErr := buildSet(slotRow)
+		if buildErr != nil {
+			return domain.Session{}, buildErr
+		}
+		current.Sets = append(current.Sets, set)
 	}
-	if err = hydrateMuscleGroups(ctx, q, slots); err != nil {
-		return nil, err
+	if err = rows.Err(); err != nil {
+		return domain.Session{}, fmt.Errorf("rows error: %w", err)
 	}
-	return slots, nil
+	if !found {
+		return domain.Session{}, domain.ErrNotFound
+	}
+	if current != nil {
+		session.Slots = append(session.Slots, *current)
+	}
+	return session, nil
 }
 
-// loadExerciseSetsSince fetches every exercise slot (with its sets) for the
-// user's sessions on or after sinceDate in one query and returns them grouped
-// by workout-date string. Muscle groups are hydrated in a single further
-// query across all slots. This is the batched equivalent of loadExerciseSets
-// used by List: the whole date range costs this one query plus one muscle-
-// group query, replacing the prior per-session 1 + 2N N+1.
-func (r baseRepository) loadExerciseSetsSince(
-	ctx context.Context,
-	q queryer,
-	userID int,
-	sinceDate time.Time,
-) (_ map[string][]domain.ExerciseSlot, err error) {
-	rows, err := q.QueryContext(ctx, `
+// aggregateRow holds one scanned row of getAggregate's query. The slot and
+// exercise columns are nullable here because the session LEFT-JOINs its slots.
+type aggregateRow struct {
+	workoutDateStr   string
+	difficultyRating sql.NullInt32
+	startedAtStr     sql.NullString
+	completedAtStr   sql.NullString
+	goal             domain.SessionGoal
+	isDeload         bool
+
+	position         sql.NullInt64
+	exerciseID       sql.NullInt64
+	exerciseName     sql.NullString
+	exerciseCategory sql.NullString
+	exerciseType     sql.NullString
+	exerciseContent  sql.NullString
+	minDaysBetween   sql.NullInt64
+	complexity       sql.NullInt64
+	primaryJSON      sql.NullString
+	secondaryJSON    sql.NullString
+	slot             loadExerciseSetsRow
+}
+
+func (a *aggregateRow) scan(rows *sql.Rows) error {
+	if err := rows.Scan(&a.workoutDateStr, &a.difficultyRating, &a.startedAtStr, &a.completedAtStr,
+		&a.goal, &a.isDeload,
+		&a.position, &a.exerciseID, &a.slot.warmupCompletedAtStr,
+		&a.slot.setNumber, &a.slot.weightKg, &a.slot.targetValue,
+		&a.slot.completedValue, &a.slot.completedAtStr, &a.slot.signalStr, &a.slot.sideStr,
+		&a.slot.durationSeconds, &a.slot.technicalFailure, &a.slot.warmup,
+		&a.exerciseName, &a.exerciseCategory, &a.exerciseType, &a.exerciseContent,
+		&a.slot.defaultStartingSeconds, &a.slot.repMin, &a.slot.repMax, &a.slot.defaultStartWeightKg,
+		&a.minDaysBetween, &a.complexity, &a.slot.restOverrideSeconds,
+		&a.primaryJSON, &a.secondaryJSON); err != nil {
+		return fmt.Errorf("scan session aggregate: %w", err)
+	}
+	return nil
+}
+
+// slotRow returns the slot columns in the shape startExerciseSet and buildSet
+// consume. Only meaningful when position is valid.
+func (a *aggregateRow) slotRow() loadExerciseSetsRow {
+	row := a.slot
+	row.position = int(a.position.Int64)
+	row.exerciseID = int(a.exerciseID.Int64)
+	row.exerciseName = a.exerciseName.String
+	row.exerciseCategory = domain.Category(a.exerciseCategory.String)
+	row.exerciseType = domain.ExerciseType(a.exerciseType.String)
+	row.exerciseContent = a.exerciseContent.String
+	row.minDaysBetween = int(a.minDaysBetween.Int64)
+	row.complexity = int(a.complexity.Int64)
+	return row
+}
+
+// hydrateMuscleGroups decodes the row's muscle-group arrays onto ex, leaving
+// a side nil when it has no groups, as fetchMuscleGroupsByExerciseID does.
+func (a *aggregateRow) hydrateMuscleGroups(ex *domain.Exercise) error {
+	for _, side := range []struct {
+		raw sql.NullString
+		dst *[]string
+	}{
+		{a.primaryJSON, &ex.PrimaryMuscleGroups},
+		{a.secondaryJSON, &ex.SecondaryMuscleGroups},
+	} {
+		var groups []string
+		if err := json.Unmarshal([]byte(side.raw.String), &groups); err != nil {
+			return fmt.Errorf("unmarshal muscle groups: %w", err)
+		}
+		if len(groups) > 0 {
+			*side.dst = groups
+		}
+	}
+	return nil
+}
+
+// loadExerciseSetsSinceQuery selects a user's exercise slots on or after a
+// date with their sets, exercises and rest overrides. Shared with the
+// query-plan test, which pins it to the primary keys.
+const loadExerciseSetsSinceQuery = `
 		SELECT we.workout_date, we.position, we.exercise_id, we.warmup_completed_at,
 		       es.set_number, es.weight_kg, es.target_value,
-		       es.completed_value, es.completed_at, es.signal,
+		       es.completed_value, es.completed_at, es.signal, es.side, es.duration_seconds,
+		       es.technical_failure, es.warmup,
 		       e.name, e.category, e.exercise_type, e.content,
-		       e.default_starting_seconds, e.rep_min, e.rep_max
+		       e.default_starting_seconds, e.rep_min, e.rep_max, e.default_start_weight_kg,
+		       e.min_days_between, e.complexity, ro.rest_seconds
 		FROM exercise_slots we
 		LEFT JOIN exercise_sets es
 		    ON  es.workout_user_id = we.workout_user_id
 		    AND es.workout_date    = we.workout_date
 		    AND es.position        = we.position
 		JOIN exercises e ON e.id = we.exercise_id
+		LEFT JOIN exercise_rest_overrides ro
+		    ON  ro.user_id     = we.workout_user_id
+		    AND ro.exercise_id = we.exercise_id
 		WHERE we.workout_user_id = ? AND we.workout_date >= ?
-		ORDER BY we.workout_date DESC, we.position, es.set_number`,
-		userID, formatDate(sinceDate))
+		ORDER BY we.workout_date DESC, we.position, es.set_number`
+
+// loadExerciseSetsSince fetches every exercise slot (with its sets) for the
+// user's sessions on or after sinceDate in one query and returns them grouped
+// by workout-date string. Muscle groups are hydrated in a single further
+// query across all slots. List uses it so the whole date range costs this one
+// query plus one muscle-group query, replacing the prior per-session 1 + 2N
+// N+1.
+func (r baseRepository) loadExerciseSetsSince(
+	ctx context.Context,
+	q queryer,
+	userID int,
+	sinceDate time.Time,
+) (_ map[string][]domain.ExerciseSlot, err error) {
+	rows, err := q.QueryContext(ctx, loadExerciseSetsSinceQuery, userID, formatDate(sinceDate))
 	if err != nil {
 		return nil, fmt.Errorf("query exercise sets: %w", err)
 	}
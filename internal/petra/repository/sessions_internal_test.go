@@ -0,0 +1,260 @@
+//nolint:testpackage // compares the unexported getThreeQuery and getAggregate loaders; needs the internal package.
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/auth"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+)
+
+// countingQueryer counts the queries issued through it.
+type countingQueryer struct {
+	q       queryer
+	queries int
+}
+
+func (c *countingQueryer) QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error) {
+	c.queries++
+	return c.q.QueryContext(ctx, query, args...)
+}
+
+func (c *countingQueryer) QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row {
+	c.queries++
+	return c.q.QueryRowContext(ctx, query, args...)
+}
+
+// seedAggregateSession creates a user with a session on 2026-03-02 holding
+// three slots: two with sets (one under a rest override) and one without.
+func seedAggregateSession(tb testing.TB) (context.Context, *sqlitekit.Database, *sqliteSessionRepository) {
+	tb.Helper()
+	ctx := tb.Context()
+	db, err := sqlitekit.NewDatabase(ctx, sqlitekit.Config{
+		URL:          ":memory:",
+		Schema:       auth.SchemaSQL + "\n" + SchemaSQL,
+		Fixtures:     FixturesSQL,
+		Logger:       slog.New(slog.DiscardHandler),
+		Premigration: nil,
+	})
+	if err != nil {
+		tb.Fatalf("create test database: %v", err)
+	}
+	tb.Cleanup(func() { _ = db.Close() })
+
+	var userID int
+	if err = db.ReadWrite.QueryRowContext(ctx,
+		"INSERT INTO users (webauthn_user_id, display_name) VALUES (?, ?) RETURNING id",
+		[]byte("aggregate-user"), "Aggregate User").Scan(&userID); err != nil {
+		tb.Fatalf("insert user: %v", err)
+	}
+	for _, stmt := range []string{
+		`INSERT INTO workout_sessions (user_id, workout_date, started_at)
+		 VALUES (:user, '2026-03-02', '2026-03-02T17:00:00.000Z')`,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id, warmup_completed_at)
+		 SELECT :user, '2026-03-02', 0, id, '2026-03-02T17:05:00.000Z' FROM exercises WHERE name = 'Deadlift'`,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		 SELECT :user, '2026-03-02', 1, MIN(id) FROM exercises WHERE exercise_type = 'bodyweight'`,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		 SELECT :user, '2026-03-02', 2, MIN(id) FROM exercises WHERE exercise_type = 'time_based'`,
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+		                           weight_kg, target_value, completed_value, completed_at, signal)
+		 VALUES (:user, '2026-03-02', 0, 1, 100, 5, 5, '2026-03-02T17:10:00.000Z', 'on_target'),
+		        (:user, '2026-03-02', 0, 2, 100, 5, NULL, NULL, NULL),
+		        (:user, '2026-03-02', 1, 1, NULL, 12, 12, '2026-03-02T17:20:00.000Z', NULL)`,
+		`INSERT INTO exercise_rest_overrides (user_id, exercise_id, rest_seconds)
+		 SELECT :user, id, 150 FROM exercises WHERE name = 'Deadlift'`,
+	} {
+		if _, err = db.ReadWrite.ExecContext(ctx, stmt, sql.Named("user", userID)); err != nil {
+			tb.Fatalf("seed: %v", err)
+		}
+	}
+	return contexthelpers.WithAuthenticatedUserID(ctx, userID), db, newSQLiteSessionRepository(db)
+}
+
+func TestSessionRepository_GetAggregateMatchesMultiQueryLoader(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, r := seedAggregateSession(t)
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+
+	multi := &countingQueryer{q: db.ReadOnly, queries: 0}
+	want, err := r.getThreeQuery(ctx, multi, date)
+	if err != nil {
+		t.Fatalf("getThreeQuery: %v", err)
+	}
+	single := &countingQueryer{q: db.ReadOnly, queries: 0}
+	got, err := r.getAggregate(ctx, single, date)
+	if err != nil {
+		t.Fatalf("getAggregate: %v", err)
+	}
+
+	if len(got.Slots) != 3 || len(got.Slots[0].Sets) != 2 || len(got.Slots[2].Sets) != 0 {
+		t.Fatalf("getAggregate slots = %+v, want 3 slots with 2, 1 and 0 sets", got.Slots)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("getAggregate = %+v\nwant %+v", got, want)
+	}
+	if single.queries != 1 {
+		t.Errorf("getAggregate issued %d queries, want 1", single.queries)
+	}
+	if multi.queries <= single.queries {
+		t.Errorf("getThreeQuery issued %d queries, want more than getAggregate's %d", multi.queries, single.queries)
+	}
+	t.Logf("queries per session load: getThreeQuery %d, getAggregate %d", multi.queries, single.queries)
+}
+
+func TestSessionRepository_GetAggregateSessionWithoutSlots(t *testing.T) {
+	t.Parallel()
+
+	ctx, db, r := seedAggregateSession(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	if _, err := db.ReadWrite.ExecContext(ctx,
+		`INSERT INTO workout_sessions (user_id, workout_date) VALUES (?, '2026-03-04')`, userID); err != nil {
+		t.Fatalf("seed empty session: %v", err)
+	}
+
+	got, err := r.getAggregate(ctx, db.ReadOnly, time.Date(2026, 3, 4, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("getAggregate: %v", err)
+	}
+	if len(got.Slots) != 0 || !got.StartedAt.IsZero() {
+		t.Errorf("empty session = %+v, want no slots and not started", got)
+	}
+}
+
+// BenchmarkSessionLoad compares the three-query loader with the single-query
+// one on the same seeded session.
+func BenchmarkSessionLoad(b *testing.B) {
+	ctx, db, r := seedAggregateSession(b)
+	date := time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC)
+	loaders := []struct {
+		name string
+		load func(context.Context, queryer, time.Time) error
+	}{
+		{"getThreeQuery", func(ctx context.Context, q queryer, d time.Time) error {
+			_, err := r.getThreeQuery(ctx, q, d)
+			return err
+		}},
+		{"getAggregate", func(ctx context.Context, q queryer, d time.Time) error {
+			_, err := r.getAggregate(ctx, q, d)
+			return err
+		}},
+	}
+	for _, l := range loaders {
+		b.Run(l.name, func(b *testing.B) {
+			q := &countingQueryer{q: db.ReadOnly, queries: 0}
+			for b.Loop() {
+				if err := l.load(ctx, q, date); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(q.queries)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+// getThreeQuery is the session loader Get used before getAggregate: the
+// session row, then its slots and sets, then their muscle groups. It lives on
+// as the reference the single-query loader is checked and benchmarked
+// against.
+func (r *sqliteSessionRepository) getThreeQuery(
+	ctx context.Context, q queryer, date time.Time,
+) (domain.Session, error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	dateStr := formatDate(date)
+
+	var (
+		workoutDateStr   string
+		difficultyRating sql.NullInt32
+		startedAtStr     sql.NullString
+		completedAtStr   sql.NullString
+		goal             domain.SessionGoal
+		isDeload         bool
+	)
+	err := q.QueryRowContext(ctx, `
+		SELECT workout_date, difficulty_rating, started_at, completed_at, session_goal, is_deload
+		FROM workout_sessions
+		WHERE user_id = ? AND workout_date = ?`,
+		userID, dateStr).Scan(
+		&workoutDateStr, &difficultyRating, &startedAtStr, &completedAtStr, &goal, &isDeload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.Session{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.Session{}, fmt.Errorf("query session: %w", err)
+	}
+
+	session, err := parseSessionRow(
+		workoutDateStr, difficultyRating, startedAtStr, completedAtStr, goal, isDeload,
+	)
+	if err != nil {
+		return domain.Session{}, err
+	}
+
+	exerciseSlots, err := r.loadSlotsThreeQuery(ctx, q, userID, session.Date)
+	if err != nil {
+		return domain.Session{}, err
+	}
+	session.Slots = exerciseSlots
+
+	return session, nil
+}
+
+// loadSlotsThreeQuery fetches all exercise slots for a single session for
+// getThreeQuery, including ones with no sets yet. The driving table is
+// exercise_slots so empty slots still appear; sets are LEFT-JOINed in and
+// the base exercise definition is JOINed from `exercises`. Muscle groups are
+// hydrated in one follow-up query.
+func (r *sqliteSessionRepository) loadSlotsThreeQuery(
+	ctx context.Context,
+	q queryer,
+	userID int,
+	date time.Time,
+) (_ []domain.ExerciseSlot, err error) {
+	rows, err := q.QueryContext(ctx, `
+		SELECT we.workout_date, we.position, we.exercise_id, we.warmup_completed_at,
+		       es.set_number, es.weight_kg, es.target_value,
+		       es.completed_value, es.completed_at, es.signal, es.side, es.duration_seconds,
+		       es.technical_failure, es.warmup,
+		       e.name, e.category, e.exercise_type, e.content,
+		       e.default_starting_seconds, e.rep_min, e.rep_max, e.default_start_weight_kg,
+		       e.min_days_between, e.complexity, ro.rest_seconds
+		FROM exercise_slots we
+		LEFT JOIN exercise_sets es
+		    ON  es.workout_user_id = we.workout_user_id
+		    AND es.workout_date    = we.workout_date
+		    AND es.position        = we.position
+		JOIN exercises e ON e.id = we.exercise_id
+		LEFT JOIN exercise_rest_overrides ro
+		    ON  ro.user_id     = we.workout_user_id
+		    AND ro.exercise_id = we.exercise_id
+		WHERE we.workout_user_id = ? AND we.workout_date = ?
+		ORDER BY we.position, es.set_number`,
+		userID, formatDate(date))
+	if err != nil {
+		return nil, fmt.Errorf("query exercise sets: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close rows: %w", closeErr))
+		}
+	}()
+
+	slots, _, err := scanExerciseSetRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if err = hydrateMuscleGroups(ctx, q, slots); err != nil {
+		return nil, err
+	}
+	return slots, nil
+}
@@ -262,6 +262,59 @@ func TestSessionRepository_StartingWeight_SkipsDeloadSessions(t *testing.T) {
 	}
 }
 
+func TestSessionRepository_StartingWeight_SkipsWarmupSets(t *testing.T) {
+	t.Parallel()
+
+	ctx, repos := setupTestRepos(t)
+
+	exercise, err := repos.Exercises.Create(ctx, newTestExerciseFor(t))
+	if err != nil {
+		t.Fatalf("Create exercise: %v", err)
+	}
+
+	monday := time.Date(2026, time.April, 27, 0, 0, 0, 0, time.UTC)
+	onTarget := domain.SignalOnTarget
+	completedAt := time.Date(2026, time.April, 27, 10, 0, 0, 0, time.UTC)
+	set := func(weight float64, warmup bool) domain.Set {
+		return domain.Set{ //nolint:exhaustruct // only fields relevant to the warmup skip test
+			TargetValue:    10,
+			WeightKg:       &weight,
+			CompletedValue: new(10),
+			CompletedAt:    &completedAt,
+			Signal:         &onTarget,
+			Warmup:         warmup,
+		}
+	}
+
+	// A lighter back-off set, flagged as a warmup, follows the working sets.
+	wp := domain.WeekPlan{Monday: monday} //nolint:exhaustruct // Sessions initialised below.
+	for i := range 7 {
+		//nolint:exhaustruct // rest-day placeholder; only Date is meaningful.
+		wp.Sessions[i] = domain.Session{Date: monday.AddDate(0, 0, i)}
+	}
+	wp.Sessions[0] = domain.Session{ //nolint:exhaustruct // only fields relevant to the warmup skip test
+		Date: monday,
+		Goal: domain.SessionGoalHypertrophy,
+		Slots: []domain.ExerciseSlot{
+			{ //nolint:exhaustruct // ID and WarmupCompletedAt not needed for this test
+				Exercise: exercise,
+				Sets:     []domain.Set{set(100, false), set(100, false), set(60, true)},
+			},
+		},
+	}
+	if err = repos.WeekPlans.Create(ctx, wp); err != nil {
+		t.Fatalf("WeekPlans.Create: %v", err)
+	}
+
+	got, err := repos.Sessions.GetLatestStartingWeightBefore(ctx, exercise.ID, monday.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatalf("GetLatestStartingWeightBefore: %v", err)
+	}
+	if got.WeightKg != 100.0 {
+		t.Errorf("WeightKg = %v, want 100.0 (warmup set must be excluded)", got.WeightKg)
+	}
+}
+
 func TestGetLatestSuccessfulSecondsBefore_NoRows_ReturnsNotFound(t *testing.T) {
 	t.Parallel()
 	ctx, repos := setupTestRepos(t)
@@ -44,19 +44,11 @@ func fetchMuscleGroupsByExerciseID(
 		return map[int]muscleGroups{}, nil
 	}
 
-	placeholders := strings.Repeat("?,", len(ids))
-	placeholders = placeholders[:len(placeholders)-1] // trim trailing comma
 	args := make([]any, len(ids))
 	for i, id := range ids {
 		args[i] = id
 	}
-	query := `
-		SELECT emg.exercise_id, mg.name, emg.is_primary
-		FROM exercise_muscle_groups emg
-		JOIN muscle_groups mg ON emg.muscle_group_name = mg.name
-		WHERE emg.exercise_id IN (` + placeholders + `)`
-
-	rows, err := q.QueryContext(ctx, query, args...)
+	rows, err := q.QueryContext(ctx, muscleGroupsByExerciseIDQuery(len(ids)), args...)
 	if err != nil {
 		return nil, fmt.Errorf("query muscle groups: %w", err)
 	}
@@ -90,6 +82,19 @@ func fetchMuscleGroupsByExerciseID(
 	return byExercise, nil
 }
 
+// muscleGroupsByExerciseIDQuery selects the muscle groups of n exercises,
+// taking their IDs as n placeholder arguments. n must be positive. Shared
+// with the query-plan test, which pins it to the primary key.
+func muscleGroupsByExerciseIDQuery(n int) string {
+	placeholders := strings.Repeat("?,", n)
+	placeholders = placeholders[:len(placeholders)-1] // trim trailing comma
+	return `
+		SELECT emg.exercise_id, mg.name, emg.is_primary
+		FROM exercise_muscle_groups emg
+		JOIN muscle_groups mg ON emg.muscle_group_name = mg.name
+		WHERE emg.exercise_id IN (` + placeholders + `)`
+}
+
 // baseRepository contains common functionality for all SQLite repositories.
 type baseRepository struct {
 	db *sqlitekit.Database
@@ -203,13 +208,19 @@ func (r baseRepository) saveOneSlotInTx(
 		if set.Signal != nil {
 			signalValue = string(*set.Signal)
 		}
+		var sideValue any
+		if set.Side != nil {
+			sideValue = string(*set.Side)
+		}
 		if _, err := tx.ExecContext(ctx, `
 			INSERT INTO exercise_sets (
 				workout_user_id, workout_date, position, set_number,
-				weight_kg, target_value, completed_value, completed_at, signal
-			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+				weight_kg, target_value, completed_value, completed_at, signal, side, duration_seconds,
+				technical_failure, warmup
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 			userID, dateStr, pos, i+1,
-			set.WeightKg, set.TargetValue, set.CompletedValue, completedAtStr, signalValue); err != nil {
+			set.WeightKg, set.TargetValue, set.CompletedValue, completedAtStr, signalValue, sideValue,
+			set.DurationSeconds, set.TechnicalFailure, set.Warmup); err != nil {
 			return fmt.Errorf("insert exercise set: %w", err)
 		}
 	}
@@ -0,0 +1,57 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// Snapshot reads several aggregates from one consistent view of the database.
+// It is only valid inside the ReadSnapshot callback that received it.
+type Snapshot struct {
+	repos *Repositories
+	tx    *sql.Tx
+}
+
+// ReadSnapshot calls fn with a Snapshot backed by a single read transaction.
+// Every read made through it sees the database as of its first query, so a
+// write committed in between, such as a set completion, is either wholly
+// visible or not at all. Use it when one decision depends on several reads
+// that must agree, like building a planner from preferences and history.
+func (r *Repositories) ReadSnapshot(ctx context.Context, fn func(*Snapshot) error) (err error) {
+	tx, err := r.db.ReadOnly.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("begin read transaction: %w", err)
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = errors.Join(err, fmt.Errorf("rollback read transaction: %w", rollbackErr))
+		}
+	}()
+
+	return fn(&Snapshot{repos: r, tx: tx})
+}
+
+// Preferences is Preferences.Get read from the snapshot.
+func (s *Snapshot) Preferences(ctx context.Context) (domain.Preferences, error) {
+	return s.repos.Preferences.get(ctx, s.tx)
+}
+
+// Exercises is Exercises.List read from the snapshot.
+func (s *Snapshot) Exercises(ctx context.Context) ([]domain.Exercise, error) {
+	return s.repos.Exercises.list(ctx, s.tx)
+}
+
+// MuscleTargets is MuscleTargets.List read from the snapshot.
+func (s *Snapshot) MuscleTargets(ctx context.Context) ([]domain.MuscleGroupTarget, error) {
+	return s.repos.MuscleTargets.list(ctx, s.tx)
+}
+
+// Sessions is Sessions.List read from the snapshot.
+func (s *Snapshot) Sessions(ctx context.Context, sinceDate time.Time) ([]domain.Session, error) {
+	return s.repos.Sessions.list(ctx, s.tx, sinceDate)
+}
@@ -0,0 +1,110 @@
+package repository_test
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/petra/repository"
+	"github.com/myrjola/petrapp/internal/platform/auth"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// Test_ReadSnapshot_HidesConcurrentWrite completes a set, and starts its
+// session, in one write committed while a snapshot is open. Reads through the
+// snapshot must keep seeing neither change, not the set without the session.
+// Snapshot isolation needs WAL, which in-memory databases lack, so this test
+// uses a database file.
+func Test_ReadSnapshot_HidesConcurrentWrite(t *testing.T) {
+	t.Parallel()
+	ctx := t.Context()
+	db, err := sqlitekit.NewDatabase(ctx, sqlitekit.Config{
+		URL:          filepath.Join(t.TempDir(), "petra.sqlite3"),
+		Schema:       auth.SchemaSQL + "\n" + repository.SchemaSQL,
+		Fixtures:     repository.FixturesSQL,
+		Logger:       testkit.NewLogger(testkit.NewWriter(t)),
+		Premigration: nil,
+	})
+	if err != nil {
+		t.Fatalf("create test database: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	var userID int
+	if err = db.ReadWrite.QueryRowContext(ctx,
+		"INSERT INTO users (webauthn_user_id, display_name) VALUES (?, ?) RETURNING id",
+		[]byte("test-user"), "Test User").Scan(&userID); err != nil {
+		t.Fatalf("insert test user: %v", err)
+	}
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
+	repos := repository.New(db)
+
+	date, _ := seedExerciseSlot(ctx, t, db)
+	dateStr := date.Format(time.DateOnly)
+	if _, err = db.ReadWrite.ExecContext(ctx,
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number, target_value, weight_kg)
+		 VALUES (?, ?, 0, 1, 5, 60.0)`, userID, dateStr); err != nil {
+		t.Fatalf("insert exercise_set: %v", err)
+	}
+
+	completeSet := func() {
+		tx, txErr := db.ReadWrite.BeginTx(ctx, nil)
+		if txErr != nil {
+			t.Fatalf("begin write: %v", txErr)
+		}
+		defer func() { _ = tx.Rollback() }()
+		if _, txErr = tx.ExecContext(ctx,
+			`UPDATE exercise_sets SET completed_value = 5, completed_at = '2026-05-13T10:00:00.000Z'
+			 WHERE workout_user_id = ? AND workout_date = ?`, userID, dateStr); txErr != nil {
+			t.Fatalf("complete set: %v", txErr)
+		}
+		if _, txErr = tx.ExecContext(ctx,
+			`UPDATE workout_sessions SET started_at = '2026-05-13T09:55:00.000Z'
+			 WHERE user_id = ? AND workout_date = ?`, userID, dateStr); txErr != nil {
+			t.Fatalf("start session: %v", txErr)
+		}
+		if txErr = tx.Commit(); txErr != nil {
+			t.Fatalf("commit write: %v", txErr)
+		}
+	}
+	completed := func(sessions []domain.Session) (bool, bool) {
+		if len(sessions) != 1 || len(sessions[0].Slots) != 1 || len(sessions[0].Slots[0].Sets) != 1 {
+			t.Fatalf("sessions = %+v, want one session with one set", sessions)
+		}
+		return sessions[0].Slots[0].Sets[0].CompletedAt != nil, !sessions[0].StartedAt.IsZero()
+	}
+
+	err = repos.ReadSnapshot(ctx, func(snap *repository.Snapshot) error {
+		before, listErr := snap.Sessions(ctx, date)
+		if listErr != nil {
+			return listErr
+		}
+		if setDone, started := completed(before); setDone || started {
+			t.Fatalf("before write: set completed %t, session started %t; want neither", setDone, started)
+		}
+
+		completeSet()
+
+		after, listErr := snap.Sessions(ctx, date)
+		if listErr != nil {
+			return listErr
+		}
+		if setDone, started := completed(after); setDone || started {
+			t.Errorf("snapshot after write: set completed %t, session started %t; want neither", setDone, started)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadSnapshot: %v", err)
+	}
+
+	sessions, err := repos.Sessions.List(ctx, date)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if setDone, started := completed(sessions); !setDone || !started {
+		t.Errorf("after snapshot: set completed %t, session started %t; want both", setDone, started)
+	}
+}
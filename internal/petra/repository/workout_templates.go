@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	sqlite3 "github.com/mattn/go-sqlite3"
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
+)
+
+type sqliteWorkoutTemplateRepository struct {
+	baseRepository
+	exercises *sqliteExerciseRepository
+}
+
+func newSQLiteWorkoutTemplateRepository(
+	db *sqlitekit.Database, exercises *sqliteExerciseRepository,
+) *sqliteWorkoutTemplateRepository {
+	return &sqliteWorkoutTemplateRepository{baseRepository: newBaseRepository(db), exercises: exercises}
+}
+
+// Create saves t for the authenticated user and returns it with its ID set.
+// Returns domain.ErrAlreadyExists (wrapped) when the user already has a
+// template with the same name.
+func (r *sqliteWorkoutTemplateRepository) Create(
+	ctx context.Context, t domain.WorkoutTemplate,
+) (_ domain.WorkoutTemplate, err error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	tx, err := r.db.ReadWrite.BeginTx(ctx, nil)
+	if err != nil {
+		return domain.WorkoutTemplate{}, fmt.Errorf("begin transaction: %w", err)
+	}
+	defer func() {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil && !errors.Is(rollbackErr, sql.ErrTxDone) {
+			err = errors.Join(err, fmt.Errorf("rollback transaction: %w", rollbackErr))
+		}
+	}()
+
+	if err = tx.QueryRowContext(ctx, `
+		INSERT INTO workout_templates (user_id, name, session_goal)
+		VALUES (?, ?, ?)
+		RETURNING id`,
+		userID, t.Name, t.Goal,
+	).Scan(&t.ID); err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return domain.WorkoutTemplate{}, fmt.Errorf("create template %q: %w", t.Name, domain.ErrAlreadyExists)
+		}
+		return domain.WorkoutTemplate{}, fmt.Errorf("insert workout template: %w", err)
+	}
+	for pos, te := range t.Exercises {
+		if _, err = tx.ExecContext(ctx, `
+			INSERT INTO workout_template_exercises (template_id, position, exercise_id, set_count)
+			VALUES (?, ?, ?, ?)`,
+			t.ID, pos, te.Exercise.ID, te.Sets,
+		); err != nil {
+			return domain.WorkoutTemplate{}, fmt.Errorf("insert template exercise %d: %w", te.Exercise.ID, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return domain.WorkoutTemplate{}, fmt.Errorf("commit template: %w", err)
+	}
+	return t, nil
+}
+
+// Get returns the authenticated user's template with id, its exercises in
+// order. Returns domain.ErrNotFound when there is none, including when the
+// template belongs to another user.
+func (r *sqliteWorkoutTemplateRepository) Get(ctx context.Context, id int) (domain.WorkoutTemplate, error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	t := domain.WorkoutTemplate{ID: id, Name: "", Goal: "", Exercises: nil}
+	err := r.db.ReadOnly.QueryRowContext(ctx, `
+		SELECT name, session_goal
+		FROM workout_templates
+		WHERE id = ? AND user_id = ?`, id, userID).Scan(&t.Name, &t.Goal)
+	if errors.Is(err, sql.ErrNoRows) {
+		return domain.WorkoutTemplate{}, domain.ErrNotFound
+	}
+	if err != nil {
+		return domain.WorkoutTemplate{}, fmt.Errorf("query workout template: %w", err)
+	}
+	if t.Exercises, err = r.listExercises(ctx, t.ID); err != nil {
+		return domain.WorkoutTemplate{}, err
+	}
+	return t, nil
+}
+
+// List returns the authenticated user's templates sorted by name, each with
+// its exercises in order.
+func (r *sqliteWorkoutTemplateRepository) List(ctx context.Context) (_ []domain.WorkoutTemplate, err error) {
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	rows, err := r.db.ReadOnly.QueryContext(ctx, `
+		SELECT id, name, session_goal
+		FROM workout_templates
+		WHERE user_id = ?
+		ORDER BY name`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("query workout templates: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close rows: %w", closeErr))
+		}
+	}()
+
+	var templates []domain.WorkoutTemplate
+	for rows.Next() {
+		t := domain.WorkoutTemplate{ID: 0, Name: "", Goal: "", Exercises: nil}
+		if err = rows.Scan(&t.ID, &t.Name, &t.Goal); err != nil {
+			return nil, fmt.Errorf("scan workout template: %w", err)
+		}
+		templates = append(templates, t)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for i := range templates {
+		if templates[i].Exercises, err = r.listExercises(ctx, templates[i].ID); err != nil {
+			return nil, err
+		}
+	}
+	return templates, nil
+}
+
+// listExercises loads a template's exercises in position order, each
+// hydrated from the catalog.
+func (r *sqliteWorkoutTemplateRepository) listExercises(
+	ctx context.Context, templateID int,
+) (_ []domain.TemplateExercise, err error) {
+	rows, err := r.db.ReadOnly.QueryContext(ctx, `
+		SELECT exercise_id, set_count
+		FROM workout_template_exercises
+		WHERE template_id = ?
+		ORDER BY position`, templateID)
+	if err != nil {
+		return nil, fmt.Errorf("query template exercises: %w", err)
+	}
+	defer func() {
+		if closeErr := rows.Close(); closeErr != nil {
+			err = errors.Join(err, fmt.Errorf("close rows: %w", closeErr))
+		}
+	}()
+
+	var exercises []domain.TemplateExercise
+	for rows.Next() {
+		var te domain.TemplateExercise
+		if err = rows.Scan(&te.Exercise.ID, &te.Sets); err != nil {
+			return nil, fmt.Errorf("scan template exercise: %w", err)
+		}
+		exercises = append(exercises, te)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("rows error: %w", err)
+	}
+
+	for i := range exercises {
+		if exercises[i].Exercise, err = r.exercises.get(ctx, r.db.ReadOnly, exercises[i].Exercise.ID); err != nil {
+			return nil, fmt.Errorf("get template exercise %d: %w", exercises[i].Exercise.ID, err)
+		}
+	}
+	return exercises, nil
+}
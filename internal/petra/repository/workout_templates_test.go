@@ -0,0 +1,79 @@
+package repository_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+func TestWorkoutTemplateRepository_CreateGetList(t *testing.T) {
+	t.Parallel()
+	ctx, db, repos := setupTestReposWithDB(t)
+
+	exercises, err := repos.Exercises.List(ctx)
+	if err != nil || len(exercises) < 2 {
+		t.Fatalf("List exercises: %v (got %d)", err, len(exercises))
+	}
+	dayA := domain.WorkoutTemplate{
+		ID:   0,
+		Name: "Day A",
+		Goal: domain.SessionGoalStrength,
+		Exercises: []domain.TemplateExercise{
+			{Exercise: exercises[1], Sets: 5},
+			{Exercise: exercises[0], Sets: 3},
+		},
+	}
+	created, err := repos.Templates.Create(ctx, dayA)
+	if err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if created.ID == 0 {
+		t.Fatal("Create returned no ID")
+	}
+	if _, err = repos.Templates.Create(ctx, dayA); !errors.Is(err, domain.ErrAlreadyExists) {
+		t.Errorf("Create duplicate name error = %v, want ErrAlreadyExists", err)
+	}
+	dayB := dayA
+	dayB.Name, dayB.Goal = "Day B", domain.SessionGoalHypertrophy
+	dayB.Exercises = dayA.Exercises[1:]
+	if _, err = repos.Templates.Create(ctx, dayB); err != nil {
+		t.Fatalf("Create Day B: %v", err)
+	}
+
+	got, err := repos.Templates.Get(ctx, created.ID)
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Name != "Day A" || got.Goal != domain.SessionGoalStrength || len(got.Exercises) != 2 {
+		t.Fatalf("Get = %+v, want Day A, strength, two exercises", got)
+	}
+	first := got.Exercises[0]
+	if first.Exercise.ID != exercises[1].ID || first.Exercise.Name != exercises[1].Name || first.Sets != 5 {
+		t.Errorf("first exercise = %s (%d) x%d, want %s x5 in saved order",
+			first.Exercise.Name, first.Exercise.ID, first.Sets, exercises[1].Name)
+	}
+
+	list, err := repos.Templates.List(ctx)
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(list) != 2 || list[0].Name != "Day A" || list[1].Name != "Day B" || len(list[1].Exercises) != 1 {
+		t.Errorf("List = %+v, want Day A then Day B with one exercise", list)
+	}
+
+	var otherID int
+	if err = db.ReadWrite.QueryRowContext(ctx,
+		"INSERT INTO users (webauthn_user_id, display_name) VALUES (?, ?) RETURNING id",
+		[]byte("other-user"), "Other User").Scan(&otherID); err != nil {
+		t.Fatalf("insert other user: %v", err)
+	}
+	otherCtx := contexthelpers.WithAuthenticatedUserID(ctx, otherID)
+	if _, err = repos.Templates.Get(otherCtx, created.ID); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("Get another user's template error = %v, want ErrNotFound", err)
+	}
+	if others, listErr := repos.Templates.List(otherCtx); listErr != nil || len(others) != 0 {
+		t.Errorf("List as another user = %+v, %v; want none", others, listErr)
+	}
+}
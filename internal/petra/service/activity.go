@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+// activityFeedWeeks bounds how far back the activity feed reaches.
+const activityFeedWeeks = 8
+
+// ActivityFeed returns userID's recent activity, newest first: completed and
+// missed workouts, rest days, skipped exercises and personal records. At most
+// limit items are returned; see domain.ActivityFeed for how they are merged.
+func (s *Service) ActivityFeed(ctx context.Context, userID int, limit int) ([]domain.ActivityItem, error) {
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	since := today.AddDate(0, 0, -7*activityFeedWeeks)
+	sessions, err := s.repos.Sessions.List(ctx, since)
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	records, err := s.ListPersonalRecords(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list personal records: %w", err)
+	}
+	recent := make([]domain.PersonalRecord, 0, len(records))
+	for _, pr := range records {
+		if !pr.Date.Before(since) {
+			recent = append(recent, pr)
+		}
+	}
+	return domain.ActivityFeed(sessions, recent, today, limit), nil
+}
@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+func Test_ActivityFeed(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	squatID, err := createTestExercise(ctx, t, db, "Activity Squat", "lower")
+	if err != nil {
+		t.Fatalf("create squat: %v", err)
+	}
+
+	// A completed squat workout three days ago beats the one from before the
+	// feed's window, which is left out, and sets the record. Two days ago was
+	// missed, yesterday was a rest day, and tomorrow is not activity yet.
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	day := func(offset int) string { return today.AddDate(0, 0, offset).Format(time.DateOnly) }
+	completedAt := now.UTC().Format("2006-01-02T15:04:05.000Z")
+	seeds := []struct {
+		date      string
+		slot      bool
+		completed bool
+		weightKg  float64
+	}{
+		{day(-3), true, true, 90},
+		{day(-2), true, false, 90},
+		{day(-1), false, false, 0},
+		{day(1), true, false, 90},
+		{day(-100), true, true, 80},
+	}
+	for _, s := range seeds {
+		doneAt := any(nil)
+		if s.completed {
+			doneAt = completedAt
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO workout_sessions (user_id, workout_date, started_at, completed_at) VALUES (?, ?, ?, ?)`,
+			userID, s.date, doneAt, doneAt); err != nil {
+			t.Fatalf("insert session %s: %v", s.date, err)
+		}
+		if !s.slot {
+			continue
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id) VALUES (?, ?, 0, ?)`,
+			userID, s.date, squatID); err != nil {
+			t.Fatalf("insert slot %s: %v", s.date, err)
+		}
+		completedValue := any(nil)
+		if s.completed {
+			completedValue = 5
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+			 weight_kg, target_value, completed_value, completed_at) VALUES (?, ?, 0, 1, ?, 5, ?, ?)`,
+			userID, s.date, s.weightKg, completedValue, doneAt); err != nil {
+			t.Fatalf("insert set %s: %v", s.date, err)
+		}
+	}
+
+	feed, err := svc.ActivityFeed(t.Context(), userID, 0)
+	if err != nil {
+		t.Fatalf("ActivityFeed: %v", err)
+	}
+	wants := []struct {
+		date string
+		kind domain.ActivityKind
+	}{
+		{day(-1), domain.ActivityRestDay},
+		{day(-2), domain.ActivityWorkoutMissed},
+		{day(-3), domain.ActivityWorkoutCompleted},
+		{day(-3), domain.ActivityPersonalRecord},
+	}
+	if len(feed) != len(wants) {
+		t.Fatalf("feed = %+v, want %d items", feed, len(wants))
+	}
+	for i, w := range wants {
+		if got := feed[i]; got.Date.Format(time.DateOnly) != w.date || got.Kind != w.kind {
+			t.Errorf("feed[%d] = (%s, %s), want (%s, %s)", i, got.Date.Format(time.DateOnly), got.Kind, w.date, w.kind)
+		}
+	}
+	if got := feed[2].PersonalRecordCount; got != 1 {
+		t.Errorf("completed workout PersonalRecordCount = %d, want 1", got)
+	}
+
+	limited, err := svc.ActivityFeed(t.Context(), userID, 1)
+	if err != nil {
+		t.Fatalf("ActivityFeed(limit 1): %v", err)
+	}
+	if len(limited) != 1 || limited[0].Kind != domain.ActivityRestDay {
+		t.Errorf("ActivityFeed(limit 1) = %+v, want yesterday's rest day", limited)
+	}
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+const (
+	// dashboardHistoryWeeks bounds how far back the dashboard reads sessions,
+	// and therefore the longest streak it can report.
+	dashboardHistoryWeeks = 52
+	// dashboardConsistencyWeeks is the window, ending today, that the
+	// consistency figures cover.
+	dashboardConsistencyWeeks = 4
+	// dashboardRecentRecordDays is how recent a personal record must be to
+	// show on the dashboard.
+	dashboardRecentRecordDays = 30
+)
+
+// DashboardSummary is everything the dashboard shows, gathered in one call.
+type DashboardSummary struct {
+	// StreakWeeks is the run of consecutive weeks with a completed workout;
+	// see domain.CompletedWeekStreak.
+	StreakWeeks int
+	// NextWorkout is the next planned, uncompleted workout from today on, or
+	// nil when none is planned yet.
+	NextWorkout *domain.Session
+	// RecentRecords are the personal records set in the last
+	// dashboardRecentRecordDays days, sorted by exercise name.
+	RecentRecords []domain.PersonalRecord
+	// CompletedSessions and PlannedSessions cover the workouts planned in the
+	// last dashboardConsistencyWeeks weeks, up to but excluding today unless
+	// the service counts today; see WithAnalysisIncludeToday.
+	CompletedSessions int
+	PlannedSessions   int
+	// TrainingGap is the time since the last completed workout against the
+	// user's own cadence, or nil before their first one.
+	TrainingGap *domain.TrainingGap
+}
+
+// DashboardSummary aggregates the authenticated user's streak, next workout,
+// recent personal records and consistency. It reads the last year of
+// sessions once and the completed-set history once, rather than a round-trip
+// per figure.
+func (s *Service) DashboardSummary(ctx context.Context) (DashboardSummary, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	sessions, err := s.repos.Sessions.List(ctx, domain.MondayOf(today).AddDate(0, 0, -7*dashboardHistoryWeeks))
+	if err != nil {
+		return DashboardSummary{}, fmt.Errorf("list sessions: %w", err)
+	}
+	records, err := s.ListPersonalRecords(ctx)
+	if err != nil {
+		return DashboardSummary{}, fmt.Errorf("list personal records: %w", err)
+	}
+
+	summary := DashboardSummary{
+		StreakWeeks:       domain.CompletedWeekStreak(sessions, today),
+		NextWorkout:       nil,
+		RecentRecords:     make([]domain.PersonalRecord, 0, len(records)),
+		CompletedSessions: 0,
+		PlannedSessions:   0,
+		TrainingGap:       nil,
+	}
+	if next, ok := domain.NextWorkout(sessions, today); ok {
+		summary.NextWorkout = &next
+	}
+	recentSince := today.AddDate(0, 0, -dashboardRecentRecordDays)
+	for _, pr := range records {
+		if !pr.Date.Before(recentSince) {
+			summary.RecentRecords = append(summary.RecentRecords, pr)
+		}
+	}
+	if gap, ok := domain.TrainingGapFor(sessions, today); ok {
+		summary.TrainingGap = &gap
+	}
+	summary.CompletedSessions, summary.PlannedSessions = domain.SessionConsistency(
+		sessions, today.AddDate(0, 0, -7*dashboardConsistencyWeeks), s.analysisEnd(today))
+	return summary, nil
+}
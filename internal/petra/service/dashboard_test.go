@@ -0,0 +1,137 @@
+package service_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+func Test_DashboardSummary(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	squatID, err := createTestExercise(ctx, t, db, "Dashboard Squat", "lower")
+	if err != nil {
+		t.Fatalf("create squat: %v", err)
+	}
+	rowID, err := createTestExercise(ctx, t, db, "Dashboard Row", "upper")
+	if err != nil {
+		t.Fatalf("create row: %v", err)
+	}
+
+	// Completed squat sessions on the Mondays of the last two weeks make a
+	// two-week streak; a missed Wednesday three weeks back ends it and counts
+	// against consistency. The row was last done two months ago, so its
+	// record is too old to be recent. Tomorrow's session is the next workout.
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	monday := domain.MondayOf(today)
+	day := func(t time.Time) string { return t.Format(time.DateOnly) }
+	seeds := []struct {
+		date       string
+		exerciseID int
+		completed  bool
+		reps       int
+	}{
+		{day(monday.AddDate(0, 0, -14)), squatID, true, 5},
+		{day(monday.AddDate(0, 0, -7)), squatID, true, 6},
+		{day(monday.AddDate(0, 0, -19)), squatID, false, 0},
+		{day(monday.AddDate(0, 0, -60)), rowID, true, 10},
+		{day(today.AddDate(0, 0, 1)), rowID, false, 0},
+	}
+	for _, s := range seeds {
+		completedAt := any(nil)
+		if s.completed {
+			completedAt = now.UTC().Format("2006-01-02T15:04:05.000Z")
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO workout_sessions (user_id, workout_date, started_at, completed_at) VALUES (?, ?, ?, ?)`,
+			userID, s.date, completedAt, completedAt); err != nil {
+			t.Fatalf("insert session %s: %v", s.date, err)
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id) VALUES (?, ?, 0, ?)`,
+			userID, s.date, s.exerciseID); err != nil {
+			t.Fatalf("insert slot %s: %v", s.date, err)
+		}
+		completedValue := any(nil)
+		if s.completed {
+			completedValue = s.reps
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+			 weight_kg, target_value, completed_value) VALUES (?, ?, 0, 1, 80.0, 5, ?)`,
+			userID, s.date, completedValue); err != nil {
+			t.Fatalf("insert set %s: %v", s.date, err)
+		}
+	}
+
+	got, err := svc.DashboardSummary(ctx)
+	if err != nil {
+		t.Fatalf("DashboardSummary: %v", err)
+	}
+	if got.StreakWeeks != 2 {
+		t.Errorf("StreakWeeks = %d, want 2", got.StreakWeeks)
+	}
+	if got.NextWorkout == nil {
+		t.Error("NextWorkout = nil, want tomorrow's session")
+	} else if want := today.AddDate(0, 0, 1); !got.NextWorkout.Date.Equal(want) {
+		t.Errorf("NextWorkout.Date = %s, want %s", day(got.NextWorkout.Date), day(want))
+	}
+	if len(got.RecentRecords) != 1 || got.RecentRecords[0].Exercise.ID != squatID || got.RecentRecords[0].Value != 6 {
+		t.Errorf("RecentRecords = %+v, want the squat's 6-rep record only", got.RecentRecords)
+	}
+	if got.CompletedSessions != 2 || got.PlannedSessions != 3 {
+		t.Errorf("consistency = %d/%d, want 2/3", got.CompletedSessions, got.PlannedSessions)
+	}
+	if want := monday.AddDate(0, 0, -7); got.TrainingGap == nil || !got.TrainingGap.LastWorkout.Equal(want) {
+		t.Errorf("TrainingGap = %+v, want the gap since %s", got.TrainingGap, day(want))
+	}
+}
+
+// Test_DashboardSummary_IncludeToday checks that an unfinished workout today
+// stays out of the consistency figures by default and counts once the service
+// is told to include today.
+func Test_DashboardSummary_IncludeToday(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	squatID, err := createTestExercise(ctx, t, db, "Today Squat", "lower")
+	if err != nil {
+		t.Fatalf("create squat: %v", err)
+	}
+	now := time.Now()
+	today := now.UTC().Format(time.DateOnly)
+	if _, err = db.ReadWrite.ExecContext(ctx,
+		`INSERT INTO workout_sessions (user_id, workout_date, started_at) VALUES (?, ?, ?)`,
+		userID, today, now.UTC().Format("2006-01-02T15:04:05.000Z")); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+	if _, err = db.ReadWrite.ExecContext(ctx,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id) VALUES (?, ?, 0, ?)`,
+		userID, today, squatID); err != nil {
+		t.Fatalf("insert slot: %v", err)
+	}
+
+	got, err := svc.DashboardSummary(ctx)
+	if err != nil {
+		t.Fatalf("DashboardSummary: %v", err)
+	}
+	if got.CompletedSessions != 0 || got.PlannedSessions != 0 {
+		t.Errorf("default consistency = %d/%d, want 0/0 with today excluded", got.CompletedSessions, got.PlannedSessions)
+	}
+
+	got, err = svc.WithAnalysisIncludeToday(true).DashboardSummary(ctx)
+	if err != nil {
+		t.Fatalf("DashboardSummary including today: %v", err)
+	}
+	if got.CompletedSessions != 0 || got.PlannedSessions != 1 {
+		t.Errorf("consistency including today = %d/%d, want 0/1", got.CompletedSessions, got.PlannedSessions)
+	}
+}
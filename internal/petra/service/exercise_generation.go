@@ -141,19 +141,61 @@ type exerciseGenerator struct {
 	httpClient   *http.Client
 	logger       *slog.Logger
 	muscleGroups []string
+	// model is the model every call tries first. fallbackModel, when set, is
+	// tried once instead when OpenAI rate-limits model or reports the quota
+	// spent; see respond.
+	model         openai.ChatModel
+	fallbackModel openai.ChatModel
+	// tokens totals the usage reported by every completed OpenAI call, so
+	// the caller can charge it to the user's monthly allowance.
+	tokens int64
 }
 
 // newExerciseGenerator creates a new exercise generator.
 func newExerciseGenerator(openaiAPIKey string, muscleGroups []string, logger *slog.Logger) *exerciseGenerator {
 	client := openai.NewClient(option.WithAPIKey(openaiAPIKey))
 	return &exerciseGenerator{
-		client:       client,
-		httpClient:   &http.Client{Timeout: resourceURLValidationTimeout},
-		logger:       logger,
-		muscleGroups: muscleGroups,
+		client:        client,
+		httpClient:    &http.Client{Timeout: resourceURLValidationTimeout},
+		logger:        logger,
+		muscleGroups:  muscleGroups,
+		model:         openai.ChatModelGPT5_4,
+		fallbackModel: "",
+		tokens:        0,
 	}
 }
 
+// respond sends params to the Responses API with eg.model. When OpenAI
+// answers 429, which covers both rate limiting and an exhausted quota, and a
+// fallback model is configured, the call is retried once with the fallback
+// and the downgrade is logged.
+//
+// The user's own monthly allowance is deliberately not a fallback trigger:
+// ErrMonthlyAILimit is raised by checkOpenAIBudget before any model is
+// called, and the fallback model spends tokens too, so retrying with it would
+// let a user past the cap meant to bound their spend.
+func (eg *exerciseGenerator) respond(
+	ctx context.Context, params responses.ResponseNewParams,
+) (*responses.Response, error) {
+	params.Model = eg.model
+	resp, err := eg.client.Responses.New(ctx, params)
+	var apiErr *openai.Error
+	if err == nil || eg.fallbackModel == "" ||
+		!errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusTooManyRequests {
+		return resp, err //nolint:wrapcheck // Callers wrap with the call's purpose.
+	}
+	eg.logger.LogAttrs(ctx, slog.LevelWarn, "openai model downgraded",
+		slog.String("model", eg.model),
+		slog.String("fallback_model", eg.fallbackModel),
+		slog.String("code", apiErr.Code))
+	params.Model = eg.fallbackModel
+	resp, err = eg.client.Responses.New(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("fallback model %s: %w", eg.fallbackModel, err)
+	}
+	return resp, nil
+}
+
 // Generate generates a new exercise based on the given name.
 func (eg *exerciseGenerator) Generate(ctx context.Context, name string) (domain.Exercise, error) {
 	if name == "" {
@@ -234,9 +276,8 @@ func (eg *exerciseGenerator) generateBaseExercise(ctx context.Context, name stri
 	prompt := eg.baseExercisePrompt(name)
 
 	// Query the Responses API with strict structured-output JSON schema.
-	resp, err := eg.client.Responses.New(ctx,
+	resp, err := eg.respond(ctx,
 		responses.ResponseNewParams{
-			Model: openai.ChatModelGPT5_4,
 			Input: responses.ResponseNewParamsInputUnion{
 				OfString: openai.String(prompt),
 			},
@@ -255,10 +296,19 @@ func (eg *exerciseGenerator) generateBaseExercise(ctx context.Context, name stri
 	if err != nil {
 		return domain.Exercise{}, fmt.Errorf("responses completion: %w", err)
 	}
+	eg.tokens += resp.Usage.TotalTokens
+
+	// Validate against the request schema first so a drifted response fails
+	// with a field-level error instead of a generic unmarshal type mismatch.
+	output := []byte(resp.OutputText())
+	schema := exerciseJSONSchema{muscleGroups: eg.muscleGroups}
+	if err = schema.validate(output); err != nil {
+		return domain.Exercise{}, fmt.Errorf("validate exercise response: %w", err)
+	}
 
 	// Parse the response
 	var exercise domain.Exercise
-	err = json.Unmarshal([]byte(resp.OutputText()), &exercise)
+	err = json.Unmarshal(output, &exercise)
 	if err != nil {
 		return domain.Exercise{}, fmt.Errorf("parse exercise response: %w", err)
 	}
@@ -309,9 +359,8 @@ Return only the JSON object.`, exercise.Name)
 
 	// Attach the built-in web_search tool so the model returns real, live URLs
 	// rather than ones recalled from training data.
-	resp, err := eg.client.Responses.New(ctx,
+	resp, err := eg.respond(ctx,
 		responses.ResponseNewParams{
-			Model: openai.ChatModelGPT5_4,
 			Input: responses.ResponseNewParamsInputUnion{
 				OfString: openai.String(prompt),
 			},
@@ -325,6 +374,7 @@ Return only the JSON object.`, exercise.Name)
 	if err != nil {
 		return fmt.Errorf("web search completion: %w", err)
 	}
+	eg.tokens += resp.Usage.TotalTokens
 
 	// Parse resources from response. With a hosted tool in play the model may
 	// wrap the JSON in prose or a markdown code fence, so extract the object
@@ -486,10 +536,17 @@ func (eg *exerciseGenerator) validateMuscleGroups(groups []string) error {
 //
 // In case of errors, it persists a minimal exercise that the user can fill in later.
 // The returned exercise is guaranteed to have at least Name and ID fields set.
+// Once the user has used up their monthly OpenAI allowance it persists nothing
+// and returns ErrMonthlyAILimit.
 func (s *Service) GenerateExercise(ctx context.Context, name string) (domain.Exercise, error) {
 	if name == "" {
 		return domain.Exercise{}, domain.ValidationError{Message: "Exercise name is required."}
 	}
+	if s.openaiAPIKey != "" {
+		if err := s.checkOpenAIBudget(ctx); err != nil {
+			return domain.Exercise{}, err
+		}
+	}
 	exercise := s.generateExerciseContent(ctx, name)
 
 	persisted, err := s.repos.Exercises.Create(ctx, exercise)
@@ -514,7 +571,9 @@ func (s *Service) generateExerciseContent(ctx context.Context, name string) doma
 	}
 
 	generator := newExerciseGenerator(s.openaiAPIKey, muscleGroups, s.logger)
+	generator.fallbackModel = s.openAIFallbackModel
 	generated, err := generator.Generate(ctx, name)
+	s.recordOpenAIUsage(ctx, generator.tokens)
 	if err != nil {
 		s.logger.LogAttrs(ctx, slog.LevelWarn, "failed to generate exercise details",
 			slog.Any("error", err), slog.String("name", name))
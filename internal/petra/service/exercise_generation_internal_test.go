@@ -2,16 +2,20 @@ package service
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
 	"github.com/myrjola/petrapp/internal/platform/testkit"
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
 )
 
 // TestExerciseGenerator_PromptCoversSchema asserts the prompt instructs the AI
@@ -354,6 +358,74 @@ func TestExtractJSONObject(t *testing.T) {
 	}
 }
 
+// TestExerciseJSONSchema_validate asserts a response that drifts from the
+// schema fails with errSchemaViolation naming the field, rather than reaching
+// json.Unmarshal and failing with a generic type error.
+func TestExerciseJSONSchema_validate(t *testing.T) {
+	t.Parallel()
+
+	const valid = `{"id": -1, "name": "Plank", "category": "full_body", "exercise_type": "time_based",
+		"default_starting_seconds": 30, "instructions": ["Brace."], "common_mistakes": ["Sagging hips."],
+		"primary_muscle_groups": ["quadriceps"], "secondary_muscle_groups": []}`
+	tests := []struct {
+		name     string
+		in       string
+		wantPath string
+	}{
+		{name: "valid", in: valid, wantPath: ""},
+		{
+			name:     "string where integer expected",
+			in:       strings.Replace(valid, `"default_starting_seconds": 30`, `"default_starting_seconds": "30"`, 1),
+			wantPath: "$.default_starting_seconds",
+		},
+		{
+			name:     "fractional integer",
+			in:       strings.Replace(valid, `"default_starting_seconds": 30`, `"default_starting_seconds": 30.5`, 1),
+			wantPath: "$.default_starting_seconds",
+		},
+		{
+			name:     "enum mismatch",
+			in:       strings.Replace(valid, `"full_body"`, `"core"`, 1),
+			wantPath: "$.category",
+		},
+		{
+			name:     "unknown muscle group in array",
+			in:       strings.Replace(valid, `["quadriceps"]`, `["quadriceps", "biceps"]`, 1),
+			wantPath: "$.primary_muscle_groups[1]",
+		},
+		{
+			name:     "missing required field",
+			in:       strings.Replace(valid, `"name": "Plank", `, "", 1),
+			wantPath: "$.name",
+		},
+		{
+			name:     "unexpected field",
+			in:       strings.Replace(valid, `"id": -1`, `"id": -1, "reps": 10`, 1),
+			wantPath: "$.reps",
+		},
+	}
+	schema := exerciseJSONSchema{muscleGroups: []string{"quadriceps"}}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := schema.validate([]byte(tt.in))
+			if tt.wantPath == "" {
+				if err != nil {
+					t.Fatalf("validate() = %v, want nil", err)
+				}
+				return
+			}
+			if !errors.Is(err, errSchemaViolation) {
+				t.Fatalf("validate() = %v, want errSchemaViolation", err)
+			}
+			if !strings.Contains(err.Error(), tt.wantPath+":") {
+				t.Errorf("validate() = %q, want it to name %s", err, tt.wantPath)
+			}
+		})
+	}
+}
+
 func TestCreateMinimalExercise(t *testing.T) {
 	t.Parallel()
 
@@ -380,3 +452,87 @@ func TestCreateMinimalExercise(t *testing.T) {
 			ex.Instructions, ex.CommonMistakes, ex.Resources)
 	}
 }
+
+// TestExerciseGenerator_FallbackModel stubs the Responses API so the primary
+// model is rate-limited and the fallback answers, and checks that generation
+// downgrades to the fallback rather than failing, and that without a fallback
+// the 429 still fails.
+func TestExerciseGenerator_FallbackModel(t *testing.T) {
+	t.Parallel()
+
+	const fallback = "gpt-fallback-test"
+	exerciseJSON, err := json.Marshal(map[string]any{
+		"id": -1, "name": "Bench Press", "category": "upper", "exercise_type": "weighted",
+		"default_starting_seconds": nil,
+		"instructions":             []string{"Lie on the bench.", "Lower the bar.", "Press it up."},
+		"common_mistakes":          []string{"Bouncing the bar off the chest; lower it under control."},
+		"primary_muscle_groups":    []string{"Chest"},
+		"secondary_muscle_groups":  []string{},
+	})
+	if err != nil {
+		t.Fatalf("marshal exercise: %v", err)
+	}
+	var models []string
+	var mu sync.Mutex
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Model string `json:"model"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		models = append(models, body.Model)
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		if body.Model != fallback {
+			w.WriteHeader(http.StatusTooManyRequests)
+			_, _ = w.Write([]byte(`{"error":{"message":"Rate limit reached","type":"requests",` +
+				`"code":"rate_limit_exceeded","param":null}}`))
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"id": "resp_test", "object": "response", "status": "completed", "model": fallback,
+			"output": []any{map[string]any{
+				"type": "message", "id": "msg_test", "role": "assistant", "status": "completed",
+				"content": []any{map[string]any{"type": "output_text", "text": string(exerciseJSON), "annotations": []any{}}},
+			}},
+			"usage": map[string]any{"input_tokens": 10, "output_tokens": 20, "total_tokens": 30},
+		})
+	}))
+	t.Cleanup(srv.Close)
+
+	newGenerator := func(fallbackModel string) *exerciseGenerator {
+		eg := newExerciseGenerator("dummy-key", []string{"Chest"}, testkit.NewLogger(testkit.NewWriter(t)))
+		eg.client = openai.NewClient(option.WithAPIKey("dummy-key"), option.WithBaseURL(srv.URL),
+			option.WithMaxRetries(0))
+		eg.fallbackModel = openai.ChatModel(fallbackModel)
+		return eg
+	}
+
+	eg := newGenerator(fallback)
+	exercise, err := eg.generateBaseExercise(t.Context(), "Bench Press")
+	if err != nil {
+		t.Fatalf("generateBaseExercise with fallback: %v", err)
+	}
+	if exercise.Name != "Bench Press" {
+		t.Errorf("exercise name = %q, want Bench Press", exercise.Name)
+	}
+	if eg.tokens != 30 {
+		t.Errorf("tokens = %d, want the fallback call's 30", eg.tokens)
+	}
+	mu.Lock()
+	got := slices.Clone(models)
+	models = nil
+	mu.Unlock()
+	if want := []string{string(openai.ChatModelGPT5_4), fallback}; !slices.Equal(got, want) {
+		t.Errorf("models called = %v, want %v", got, want)
+	}
+
+	if _, err = newGenerator("").generateBaseExercise(t.Context(), "Bench Press"); err == nil {
+		t.Error("generateBaseExercise without fallback: err = nil, want the 429")
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(models) != 1 {
+		t.Errorf("models called without fallback = %v, want only the primary", models)
+	}
+}
@@ -0,0 +1,149 @@
+package service
+
+// This file validates a raw AI response against exerciseJSONSchema before it
+// is decoded into a domain.Exercise. Strict structured outputs should already
+// guarantee conformance, but a model or API regression would otherwise surface
+// as a confusing json.Unmarshal type error (or, worse, a silently zeroed
+// field). Validating against the same schemaMap the request sends keeps the
+// two from drifting and yields errors that name the offending field.
+//
+// Only the subset of JSON Schema that schemaMap uses is supported: type
+// (single or union), enum, required, properties, additionalProperties: false,
+// and items.
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+)
+
+// errSchemaViolation marks a response that parsed as JSON but does not match
+// the exercise schema. Callers can errors.Is against it to tell schema drift
+// apart from transport or syntax failures.
+var errSchemaViolation = errors.New("schema violation")
+
+// validate checks raw against the schema and returns an error wrapping
+// errSchemaViolation that names the first offending field.
+func (ejs exerciseJSONSchema) validate(raw []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var value any
+	if err := dec.Decode(&value); err != nil {
+		return fmt.Errorf("decode response: %w", err)
+	}
+	return validateSchemaValue("$", ejs.schemaMap(), value)
+}
+
+// validateSchemaValue validates value against schema, recursing into object
+// properties and array items. path is the JSON path used in error messages.
+func validateSchemaValue(path string, schema map[string]any, value any) error {
+	types := schemaTypes(schema["type"])
+	actual := jsonTypeOf(value)
+	if len(types) > 0 && !slices.ContainsFunc(types, func(t string) bool { return jsonTypeMatches(t, actual) }) {
+		return fmt.Errorf("%w: %s: expected %s, got %s",
+			errSchemaViolation, path, strings.Join(types, " or "), actual)
+	}
+
+	if enum, ok := schema["enum"].([]string); ok {
+		s, isString := value.(string)
+		if !isString || !slices.Contains(enum, s) {
+			return fmt.Errorf("%w: %s: %v is not one of %s",
+				errSchemaViolation, path, value, strings.Join(enum, ", "))
+		}
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		return validateSchemaObject(path, schema, v)
+	case []any:
+		items, ok := schema[schemaKeyItems].(map[string]any)
+		if !ok {
+			return nil
+		}
+		for i, item := range v {
+			if err := validateSchemaValue(fmt.Sprintf("%s[%d]", path, i), items, item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// validateSchemaObject enforces required, properties, and
+// additionalProperties: false for a decoded JSON object.
+func validateSchemaObject(path string, schema map[string]any, obj map[string]any) error {
+	if required, ok := schema["required"].([]string); ok {
+		for _, key := range required {
+			if _, present := obj[key]; !present {
+				return fmt.Errorf("%w: %s.%s: required field missing", errSchemaViolation, path, key)
+			}
+		}
+	}
+	properties, _ := schema["properties"].(map[string]any)
+	// Iterate in sorted order so the reported violation is deterministic.
+	keys := make([]string, 0, len(obj))
+	for key := range obj {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		propSchema, known := properties[key].(map[string]any)
+		if !known {
+			if schema["additionalProperties"] == false {
+				return fmt.Errorf("%w: %s.%s: unexpected field", errSchemaViolation, path, key)
+			}
+			continue
+		}
+		if err := validateSchemaValue(path+"."+key, propSchema, obj[key]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// schemaTypes normalises the "type" keyword, which schemaMap writes either as
+// a single string or as a []string union such as {"integer", "null"}.
+func schemaTypes(raw any) []string {
+	switch t := raw.(type) {
+	case string:
+		return []string{t}
+	case []string:
+		return t
+	default:
+		return nil
+	}
+}
+
+// jsonTypeOf names the JSON type of a value decoded with UseNumber. Whole
+// numbers report "integer" so they satisfy both integer and number schemas.
+func jsonTypeOf(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case string:
+		return "string"
+	case json.Number:
+		if f, err := v.Float64(); err == nil && f == math.Trunc(f) {
+			return "integer"
+		}
+		return "number"
+	case []any:
+		return schemaTypeArray
+	case map[string]any:
+		return "object"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// jsonTypeMatches reports whether a value of JSON type actual satisfies the
+// schema type want. Every integer is also a number.
+func jsonTypeMatches(want, actual string) bool {
+	return want == actual || (want == "number" && actual == "integer")
+}
@@ -1,9 +1,14 @@
 package service_test
 
 import (
+	"errors"
 	"testing"
+	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/petra/service"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+	"github.com/myrjola/petrapp/internal/platform/testkit"
 )
 
 // Test_GenerateExercise_FallsBackWithoutAPIKey asserts that when the service
@@ -45,3 +50,39 @@ func Test_GenerateExercise_FallsBackWithoutAPIKey(t *testing.T) {
 		t.Errorf("round-trip Name = %q, want %q", round.Name, "Cossack Squat")
 	}
 }
+
+// Test_GenerateExercise_MonthlyAILimit asserts that a user who has spent
+// their monthly OpenAI allowance gets ErrMonthlyAILimit and no exercise,
+// before any request would reach OpenAI.
+func Test_GenerateExercise_MonthlyAILimit(t *testing.T) {
+	t.Parallel()
+
+	ctx, _, db := setupTestServiceWithDB(t)
+	svc := service.NewService(db, testkit.NewLogger(testkit.NewWriter(t)), "sk-test-never-sent").
+		WithOpenAIMonthlyTokenCap(1000)
+
+	if _, err := db.ReadWrite.ExecContext(ctx,
+		`INSERT INTO openai_usage (user_id, month, tokens) VALUES (?, ?, ?)`,
+		contexthelpers.AuthenticatedUserID(ctx), time.Now().UTC().Format("2006-01"), 1000); err != nil {
+		t.Fatalf("seed usage: %v", err)
+	}
+
+	_, err := svc.GenerateExercise(ctx, "Cossack Squat")
+	if !errors.Is(err, service.ErrMonthlyAILimit) {
+		t.Fatalf("GenerateExercise error = %v, want ErrMonthlyAILimit", err)
+	}
+	var ve domain.ValidationError
+	if !errors.As(err, &ve) {
+		t.Errorf("error %T is not a domain.ValidationError; handlers would render a 500", err)
+	}
+
+	exercises, err := svc.ListExercises(ctx)
+	if err != nil {
+		t.Fatalf("ListExercises: %v", err)
+	}
+	for _, ex := range exercises {
+		if ex.Name == "Cossack Squat" {
+			t.Errorf("exercise %q was persisted despite the limit", ex.Name)
+		}
+	}
+}
@@ -72,7 +72,7 @@ func (s *Service) SwapExercise(
 		return fmt.Errorf("get new exercise: %w", err)
 	}
 
-	historicalSets, err := s.findHistoricalSets(ctx, date, newExerciseID)
+	historicalSets, err := s.findHistoricalSets(ctx, date, newExercise)
 	if err != nil {
 		return fmt.Errorf("find historical sets: %w", err)
 	}
@@ -81,7 +81,7 @@ func (s *Service) SwapExercise(
 	if err != nil {
 		return fmt.Errorf("get preferences: %w", err)
 	}
-	weekSets := prefs.SetCountFor(date)
+	weekSets := prefs.SetCountOn(date)
 
 	err = s.repos.WeekPlans.Update(ctx, domain.MondayOf(date), func(wp *domain.WeekPlan) error {
 		sess := wp.SessionOn(date)
@@ -138,19 +138,39 @@ func (s *Service) PreviousPerformance(
 	return domain.ExerciseSetHistory{}, false, nil
 }
 
-// findHistoricalSets retrieves set data from the most recent usage of an
+// findHistoricalSets retrieves set data from the most recent usage of
 // exercise within the last three months, excluding date's own session.
 // Returns nil when no usable history is found. Sets are returned as-is;
 // domain.BuildSetsForAdd reads only WeightKg from them.
-func (s *Service) findHistoricalSets(ctx context.Context, date time.Time, exerciseID int) ([]domain.Set, error) {
-	history, ok, err := s.PreviousPerformance(ctx, date, exerciseID)
+//
+// For an exercise that takes weight, a session without any recorded weight
+// cannot seed one, so it is skipped in favour of the next older session and
+// the seed falls back to the exercise's start weight only when none has a
+// weight. A skipped session with completed sets is inconsistent data (a bad
+// import, say) and is logged.
+func (s *Service) findHistoricalSets(
+	ctx context.Context,
+	date time.Time,
+	exercise domain.Exercise,
+) ([]domain.Set, error) {
+	histories, err := s.repos.Sessions.ListSetsForExerciseSince(ctx, exercise.ID, date.AddDate(0, -3, 0))
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("list sets for exercise: %w", err)
 	}
-	if !ok {
-		return nil, nil
+	for _, h := range histories {
+		if h.Date.Equal(date) {
+			continue
+		}
+		if !exercise.HasWeight() || h.HasRecordedWeight() {
+			return h.Sets, nil
+		}
+		if h.HasCompletedSet() {
+			s.logger.LogAttrs(ctx, slog.LevelWarn, "skipping completed sets without weight for weighted exercise",
+				slog.Int("exercise_id", exercise.ID),
+				slog.String("date", h.Date.Format(time.DateOnly)))
+		}
 	}
-	return history.Sets, nil
+	return nil, nil
 }
 
 // ListSwapCandidates returns the exercises eligible to replace the slot at
@@ -237,7 +257,7 @@ func (s *Service) AddExercise(ctx context.Context, date time.Time, exerciseID in
 		return 0, fmt.Errorf("get exercise: %w", err)
 	}
 
-	historicalSets, err := s.findHistoricalSets(ctx, date, exerciseID)
+	historicalSets, err := s.findHistoricalSets(ctx, date, exercise)
 	if err != nil {
 		return 0, fmt.Errorf("find historical sets: %w", err)
 	}
@@ -247,7 +267,7 @@ func (s *Service) AddExercise(ctx context.Context, date time.Time, exerciseID in
 	if err != nil {
 		return 0, fmt.Errorf("get preferences: %w", err)
 	}
-	weekSets := prefs.SetCountFor(monday)
+	weekSets := prefs.SetCountOn(date)
 	plan, getErr := s.repos.WeekPlans.Get(ctx, monday)
 	if getErr != nil && !errors.Is(getErr, domain.ErrNotFound) {
 		return 0, fmt.Errorf("check session existence: %w", getErr)
@@ -1,7 +1,6 @@
 package service_test
 
 import (
-	"context"
 	"errors"
 	"strings"
 	"testing"
@@ -123,8 +122,7 @@ func Test_UpdateExercise_PreservesExerciseSets(t *testing.T) {
 	}
 
 	// 4. Update the exercise
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	repMin, repMax := 5, 10
 	updatedExercise := domain.Exercise{ //nolint:exhaustruct // DefaultStartingSeconds not needed for this test.
@@ -308,8 +306,7 @@ func Test_AddExercise(t *testing.T) {
 	}
 
 	// Create a context with the user ID for service calls
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	// Test adding a new exercise
 	t.Run("Add exercise to existing workout", func(t *testing.T) {
@@ -459,8 +456,7 @@ func Test_AddExercise_UsesMostRecentHistoricalWeight(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	svc := service.NewService(db, logger, "")
 
@@ -533,6 +529,72 @@ func Test_AddExercise_UsesMostRecentHistoricalWeight(t *testing.T) {
 	}
 }
 
+// Test_AddExercise_SkipsWeightlessHistory verifies that a weighted exercise's
+// most recent session holding completed sets without any weight (corrupt
+// import data) does not reset the seed: the next older session's weight is
+// used instead of the start weight.
+func Test_AddExercise_SkipsWeightlessHistory(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	for _, group := range []string{"Quads", "Glutes", "Hamstrings", "Core"} {
+		if err := tryInsertMuscleGroup(ctx, t, db, group); err != nil {
+			t.Fatalf("insert muscle group: %v", err)
+		}
+	}
+	exerciseID, err := createTestExercise(ctx, t, db, "Corrupt History Squat", "lower")
+	if err != nil {
+		t.Fatalf("create exercise: %v", err)
+	}
+
+	today := time.Now()
+	insertCompletedSession := func(daysAgo int, weight *float64) {
+		t.Helper()
+		dateStr := today.AddDate(0, 0, -daysAgo).Format(time.DateOnly)
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			"INSERT INTO workout_sessions (user_id, workout_date) VALUES (?, ?)",
+			userID, dateStr); err != nil {
+			t.Fatalf("insert session %d days ago: %v", daysAgo, err)
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+			 VALUES (?, ?, 0, ?)`,
+			userID, dateStr, exerciseID); err != nil {
+			t.Fatalf("insert exercise_slots %d days ago: %v", daysAgo, err)
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+			                            weight_kg, target_value, completed_value)
+			 VALUES (?, ?, 0, 1, ?, 8, 8)`,
+			userID, dateStr, weight); err != nil {
+			t.Fatalf("insert exercise_set %d days ago: %v", daysAgo, err)
+		}
+	}
+	insertCompletedSession(56, new(70.0))
+	insertCompletedSession(7, nil)
+
+	if _, err = db.ReadWrite.ExecContext(ctx,
+		"INSERT INTO workout_sessions (user_id, workout_date) VALUES (?, ?)",
+		userID, today.Format(time.DateOnly)); err != nil {
+		t.Fatalf("insert today's session: %v", err)
+	}
+	pos, err := svc.AddExercise(ctx, today, exerciseID)
+	if err != nil {
+		t.Fatalf("AddExercise: %v", err)
+	}
+
+	session, err := svc.GetSession(ctx, today)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	for i, set := range session.Slots[pos].Sets {
+		if set.WeightKg == nil || *set.WeightKg != 70.0 {
+			t.Errorf("set %d weight = %v, want 70 kg from the last session with a recorded weight", i, set.WeightKg)
+		}
+	}
+}
+
 // Test_AddExercise_TimeBased_NoHistory_SeedsDefaultStartingSeconds verifies
 // that adding a time-based exercise with no usable history produces three
 // sets pre-seeded with the exercise's DefaultStartingSeconds rather than
@@ -567,8 +629,7 @@ func Test_AddExercise_TimeBased_NoHistory_SeedsDefaultStartingSeconds(t *testing
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	svc := service.NewService(db, logger, "")
 
@@ -668,8 +729,7 @@ func Test_SwapExercise_ToTimeBased_NoHistory_SeedsDefaultStartingSeconds(t *test
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	svc := service.NewService(db, logger, "")
 
@@ -815,8 +875,7 @@ func Test_AddExercise_DerivesTargetValueFromSessionGoal(t *testing.T) {
 			if err != nil {
 				t.Fatalf("insert user: %v", err)
 			}
-			ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-			ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+			ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 			svc := service.NewService(db, logger, "")
 
@@ -901,8 +960,7 @@ func Test_ReplaceExerciseInSession_DerivesTargetValueFromSessionGoal(t *testing.
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	svc := service.NewService(db, logger, "")
 
@@ -1206,8 +1264,7 @@ func Test_AddExercise_UsesMesocycleWeekSetCount(t *testing.T) {
 		t.Fatalf("Failed to insert exercise set: %v", err)
 	}
 
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	// --- anchor a length-4 mesocycle so today's week is the last training week
 	//     (peak). MondayOf(today) - 14d → block-week index 2 of 4 → peak. ---
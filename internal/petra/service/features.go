@@ -0,0 +1,37 @@
+package service
+
+// Features switches off behaviour a deployment may not want, fixed at startup
+// from PETRAPP_FEATURE_* environment variables. Unlike domain.FeatureFlag,
+// which an admin toggles at runtime, these are operator settings: turning one
+// off falls back to how the app behaved before the feature existed.
+type Features struct {
+	// Circuits allows converting a planned workout into a circuit of timed
+	// rounds; see ConvertToCircuit.
+	Circuits bool
+	// MuscleGroupRecency biases the planner away from the primary muscle
+	// groups of the previous session; see
+	// domain.Planner.MuscleGroupRecencyPenalty.
+	MuscleGroupRecency bool
+}
+
+// AllFeatures returns Features with everything on, the default.
+func AllFeatures() Features {
+	return Features{
+		Circuits:           true,
+		MuscleGroupRecency: true,
+	}
+}
+
+// WithFeatures returns a copy of the service with only the given features
+// on.
+func (s *Service) WithFeatures(features Features) *Service {
+	cp := *s
+	cp.features = features
+	return &cp
+}
+
+// Features returns the deployment's feature switches, so handlers can hide
+// controls for what is off.
+func (s *Service) Features() Features {
+	return s.features
+}
@@ -0,0 +1,57 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/petra/service"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+// Test_WithFeatures_DisabledFeaturesKeepBaseline checks that switching a
+// feature off puts back the behaviour from before it existed.
+func Test_WithFeatures_DisabledFeaturesKeepBaseline(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	svc = svc.WithFeatures(service.Features{Circuits: false, MuscleGroupRecency: false})
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := plan.Sessions[0].Date
+
+	if err = svc.ConvertToCircuit(ctx, monday, 20*60); !errors.Is(err, domain.ErrFeatureDisabled) {
+		t.Errorf("ConvertToCircuit with circuits off: error = %v, want ErrFeatureDisabled", err)
+	}
+	sess, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if sess.IsCircuit() {
+		t.Error("Monday became a circuit with circuits off; want it left as sets")
+	}
+
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	for _, tt := range []struct {
+		features service.Features
+		want     float64
+	}{
+		{service.Features{Circuits: true, MuscleGroupRecency: false}, 0},
+		{service.AllFeatures(), domain.DefaultMuscleGroupRecencyPenalty},
+	} {
+		data, snapErr := svc.WithFeatures(tt.features).PlannerSnapshot(t.Context(), userID)
+		if snapErr != nil {
+			t.Fatalf("PlannerSnapshot: %v", snapErr)
+		}
+		snapshot, snapErr := domain.DecodePlannerSnapshot(data)
+		if snapErr != nil {
+			t.Fatalf("DecodePlannerSnapshot: %v", snapErr)
+		}
+		if snapshot.MuscleGroupRecencyPenalty != tt.want {
+			t.Errorf("%+v: planner recency penalty = %v, want %v",
+				tt.features, snapshot.MuscleGroupRecencyPenalty, tt.want)
+		}
+	}
+}
@@ -49,8 +49,7 @@ func setupTestServiceWithDB(t *testing.T) (context.Context, *service.Service, *s
 	if err != nil {
 		t.Fatalf("insert test user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	// Set preferences: Mon, Wed, Fri at 60 min.
 	svc := service.NewService(db, logger, "")
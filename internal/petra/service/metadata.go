@@ -0,0 +1,48 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// CatalogMetadata lists the canonical values clients need to build exercise
+// filters. Categories double as workout types: a session's type is the
+// category of its exercises (see domain.Session.WorkoutType).
+type CatalogMetadata struct {
+	MuscleGroups  []string              `json:"muscle_groups"`
+	Categories    []domain.Category     `json:"categories"`
+	ExerciseTypes []domain.ExerciseType `json:"exercise_types"`
+	SessionGoals  []domain.SessionGoal  `json:"session_goals"`
+}
+
+// metadataCache memoises CatalogMetadata for the life of the process. Muscle
+// groups are seeded from fixtures.sql at boot and nothing writes them at
+// runtime, and the remaining lists are compile-time constants, so the first
+// successful load never goes stale. Failed loads are not cached.
+type metadataCache struct {
+	state atomic.Pointer[CatalogMetadata]
+}
+
+// CatalogMetadata returns the muscle groups, categories, exercise types, and
+// session goals. The result is cached after the first successful call;
+// callers must not mutate the returned slices.
+func (s *Service) CatalogMetadata(ctx context.Context) (CatalogMetadata, error) {
+	if cached := s.metadataCache.state.Load(); cached != nil {
+		return *cached, nil
+	}
+	groups, err := s.repos.Exercises.ListMuscleGroups(ctx)
+	if err != nil {
+		return CatalogMetadata{}, fmt.Errorf("list muscle groups: %w", err)
+	}
+	md := CatalogMetadata{
+		MuscleGroups:  groups,
+		Categories:    domain.Categories(),
+		ExerciseTypes: domain.ExerciseTypes(),
+		SessionGoals:  domain.SessionGoals(),
+	}
+	s.metadataCache.state.Store(&md)
+	return md, nil
+}
@@ -0,0 +1,44 @@
+package service_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_CatalogMetadata_ListsEnumsAndCachesMuscleGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+
+	md, err := svc.CatalogMetadata(ctx)
+	if err != nil {
+		t.Fatalf("CatalogMetadata: %v", err)
+	}
+	if !slices.Contains(md.MuscleGroups, "Chest") {
+		t.Errorf("MuscleGroups = %v, want it to contain Chest", md.MuscleGroups)
+	}
+	if !slices.Equal(md.Categories, domain.Categories()) {
+		t.Errorf("Categories = %v, want %v", md.Categories, domain.Categories())
+	}
+	if !slices.Equal(md.ExerciseTypes, domain.ExerciseTypes()) {
+		t.Errorf("ExerciseTypes = %v, want %v", md.ExerciseTypes, domain.ExerciseTypes())
+	}
+	if !slices.Equal(md.SessionGoals, domain.SessionGoals()) {
+		t.Errorf("SessionGoals = %v, want %v", md.SessionGoals, domain.SessionGoals())
+	}
+
+	// A muscle group written behind the service's back is not observed: the
+	// first successful load is served from the cache.
+	if _, err = db.ReadWrite.ExecContext(ctx, "INSERT INTO muscle_groups (name) VALUES ('Cache Probe')"); err != nil {
+		t.Fatalf("insert muscle group: %v", err)
+	}
+	cached, err := svc.CatalogMetadata(ctx)
+	if err != nil {
+		t.Fatalf("CatalogMetadata (cached): %v", err)
+	}
+	if slices.Contains(cached.MuscleGroups, "Cache Probe") {
+		t.Errorf("MuscleGroups = %v, want cached list without Cache Probe", cached.MuscleGroups)
+	}
+}
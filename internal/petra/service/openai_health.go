@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/openai/openai-go/v3"
+	"github.com/openai/openai-go/v3/option"
+)
+
+// OpenAIStatus is the OpenAI reachability reported by the readiness probe.
+type OpenAIStatus string
+
+const (
+	// OpenAIStatusOK means the API key authenticated and the API answered.
+	OpenAIStatusOK OpenAIStatus = "ok"
+	// OpenAIStatusUnavailable means the key was rejected or the API could
+	// not be reached.
+	OpenAIStatusUnavailable OpenAIStatus = "unavailable"
+	// OpenAIStatusUnknown means the probe has no verdict: the API rate
+	// limited it, the probe ran out of time, or the first probe is still
+	// running.
+	OpenAIStatusUnknown OpenAIStatus = "unknown"
+	// OpenAIStatusDisabled means no API key is configured, so exercise
+	// generation is off and there is nothing to check.
+	OpenAIStatusDisabled OpenAIStatus = "disabled"
+)
+
+// openAIHealthTTL is how long a probe verdict is reused. The readiness
+// endpoint is polled every few seconds; a models-list call per poll would
+// burn rate limit for no benefit, and key revocation is rare enough that a
+// five-minute lag is fine.
+const openAIHealthTTL = 5 * time.Minute
+
+// openAIProbeTimeout bounds a single models-list probe. The probe runs in the
+// background, so this only limits how long a hung API keeps the verdict at
+// "unknown", never how long the readiness endpoint takes.
+const openAIProbeTimeout = 10 * time.Second
+
+// openAIHealth memoises the OpenAI probe. The mutex guards the cache only; it
+// is never held across the API call. probing coalesces refreshes so at most
+// one probe is in flight.
+type openAIHealth struct {
+	mu      sync.Mutex
+	enabled bool // Off unless WithOpenAIHealthCheck turned it on.
+	status  OpenAIStatus
+	expires time.Time
+	probing bool
+	timeout time.Duration
+	baseURL string // Empty uses the SDK default; tests point it at a stub.
+}
+
+func newOpenAIHealth(enabled bool, baseURL string) *openAIHealth {
+	return &openAIHealth{
+		mu:      sync.Mutex{},
+		enabled: enabled,
+		status:  "",
+		expires: time.Time{},
+		probing: false,
+		timeout: openAIProbeTimeout,
+		baseURL: baseURL,
+	}
+}
+
+// WithOpenAIHealthCheck returns a copy of the service whose readiness probe
+// reports OpenAI reachability when enabled, with a fresh probe cache. The
+// check is off by default: each probe is an authenticated API call.
+func (s *Service) WithOpenAIHealthCheck(enabled bool) *Service {
+	cp := *s
+	cp.openAIHealth = newOpenAIHealth(enabled, s.openAIHealth.baseURL)
+	return &cp
+}
+
+// WithOpenAIBaseURL returns a copy of the service whose OpenAI health probe
+// targets baseURL instead of the public API, with a fresh probe cache. Tests
+// use it to point the probe at a stub server.
+func (s *Service) WithOpenAIBaseURL(baseURL string) *Service {
+	cp := *s
+	cp.openAIHealth = newOpenAIHealth(s.openAIHealth.enabled, baseURL)
+	return &cp
+}
+
+// OpenAIHealth reports whether the configured OpenAI key works, by listing
+// models — the cheapest authenticated call. It returns at once with the cached
+// verdict, "unknown" until the first probe finishes, and starts a background
+// probe when the verdict is missing or older than openAIHealthTTL. It returns
+// "" when the check is not enabled. It never returns an error: the readiness
+// probe reports the status alongside the database check but does not fail on
+// it, because the app serves workouts fine without OpenAI.
+func (s *Service) OpenAIHealth(ctx context.Context) OpenAIStatus {
+	h := s.openAIHealth
+	if !h.enabled {
+		return ""
+	}
+	if s.openaiAPIKey == "" {
+		return OpenAIStatusDisabled
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	status := h.status
+	if status == "" {
+		status = OpenAIStatusUnknown
+	}
+	if h.probing || (h.status != "" && time.Now().Before(h.expires)) {
+		return status
+	}
+	h.probing = true
+	// The probe outlives the readiness request that started it.
+	go s.probeOpenAI(context.WithoutCancel(ctx))
+	return status
+}
+
+// probeOpenAI lists models under its own timeout and caches the verdict. A
+// probe that times out caches "unknown" like any other verdict, so a hung API
+// is retried once per openAIHealthTTL rather than on every poll.
+func (s *Service) probeOpenAI(ctx context.Context) {
+	h := s.openAIHealth
+	ctx, cancel := context.WithTimeout(ctx, h.timeout)
+	defer cancel()
+
+	opts := []option.RequestOption{option.WithAPIKey(s.openaiAPIKey), option.WithMaxRetries(0)}
+	if h.baseURL != "" {
+		opts = append(opts, option.WithBaseURL(h.baseURL))
+	}
+	client := openai.NewClient(opts...)
+	_, err := client.Models.List(ctx)
+	status := classifyOpenAIProbe(err)
+	if status == OpenAIStatusUnavailable {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "openai health check failed", slog.Any("error", err))
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.status = status
+	h.expires = time.Now().Add(openAIHealthTTL)
+	h.probing = false
+}
+
+// classifyOpenAIProbe maps a models-list result to a status. Rate limiting
+// and cancellation are "unknown" rather than "unavailable": neither says the
+// key is bad.
+func classifyOpenAIProbe(err error) OpenAIStatus {
+	if err == nil {
+		return OpenAIStatusOK
+	}
+	var apiErr *openai.Error
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusTooManyRequests {
+		return OpenAIStatusUnknown
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return OpenAIStatusUnknown
+	}
+	return OpenAIStatusUnavailable
+}
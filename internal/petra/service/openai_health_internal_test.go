@@ -0,0 +1,67 @@
+package service
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/platform/testkit"
+)
+
+// TestOpenAIHealth_HungProbeCachesUnknown asserts that a hung OpenAI API
+// neither delays the readiness probe nor gets probed again on every poll: the
+// probe times out in the background and its "unknown" verdict is cached.
+func TestOpenAIHealth_HungProbeCachesUnknown(t *testing.T) {
+	t.Parallel()
+
+	var hits atomic.Int32
+	release := make(chan struct{})
+	stub := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	t.Cleanup(stub.Close)
+	t.Cleanup(func() { close(release) })
+
+	h := newOpenAIHealth(true, stub.URL)
+	h.timeout = 50 * time.Millisecond
+	s := &Service{ //nolint:exhaustruct // The health probe only needs these.
+		logger:       testkit.NewLogger(testkit.NewWriter(t)),
+		openaiAPIKey: "test-key",
+		openAIHealth: h,
+	}
+
+	start := time.Now()
+	if got := s.OpenAIHealth(t.Context()); got != OpenAIStatusUnknown {
+		t.Errorf("first OpenAIHealth = %q, want %q", got, OpenAIStatusUnknown)
+	}
+	if elapsed := time.Since(start); elapsed > h.timeout {
+		t.Errorf("first OpenAIHealth took %v, want it to return without waiting for the probe", elapsed)
+	}
+
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		h.mu.Lock()
+		done := h.status != ""
+		h.mu.Unlock()
+		if done {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("probe never recorded a verdict")
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	if got := s.OpenAIHealth(t.Context()); got != OpenAIStatusUnknown {
+		t.Errorf("cached OpenAIHealth = %q, want %q", got, OpenAIStatusUnknown)
+	}
+	if got := hits.Load(); got != 1 {
+		t.Errorf("stub hits = %d, want 1 (the timed-out verdict should be cached)", got)
+	}
+}
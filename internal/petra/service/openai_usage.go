@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// ErrMonthlyAILimit is returned by AI-backed features once the user has spent
+// their monthly OpenAI token allowance. It is a validation error so handlers
+// surface it through userError as a flash rather than a server error.
+var ErrMonthlyAILimit = domain.ValidationError{ //nolint:gochecknoglobals // Sentinel error.
+	Message: "Monthly AI limit reached. AI features are available again next month.",
+}
+
+// WithOpenAIMonthlyTokenCap returns a copy of the service that stops calling
+// OpenAI for a user once they have spent tokens in the current UTC calendar
+// month. Zero or less disables the cap.
+func (s *Service) WithOpenAIMonthlyTokenCap(tokens int64) *Service {
+	cp := *s
+	cp.openAIMonthlyTokenCap = tokens
+	return &cp
+}
+
+// WithOpenAIFallbackModel returns a copy of the service that retries an
+// OpenAI call once with model when the primary model answers 429, whether
+// rate-limited or out of quota, instead of failing. Empty disables the
+// fallback. A user over their monthly token cap gets ErrMonthlyAILimit, not
+// the fallback model; see exerciseGenerator.respond.
+func (s *Service) WithOpenAIFallbackModel(model string) *Service {
+	cp := *s
+	cp.openAIFallbackModel = model
+	return &cp
+}
+
+// checkOpenAIBudget returns ErrMonthlyAILimit when the authenticated user has
+// reached the monthly token cap. The check runs before a request is sent, so
+// the request that crosses the cap still completes; the cap bounds spend to
+// roughly one request over the allowance.
+func (s *Service) checkOpenAIBudget(ctx context.Context) error {
+	if s.openAIMonthlyTokenCap <= 0 {
+		return nil
+	}
+	used, err := s.repos.OpenAIUsage.Get(ctx, time.Now())
+	if err != nil {
+		return fmt.Errorf("get openai usage: %w", err)
+	}
+	if used >= s.openAIMonthlyTokenCap {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "monthly openai token cap reached",
+			slog.Int64("used", used), slog.Int64("cap", s.openAIMonthlyTokenCap))
+		return ErrMonthlyAILimit
+	}
+	return nil
+}
+
+// recordOpenAIUsage adds tokens to the authenticated user's monthly total.
+// A failed write is logged, not returned: the OpenAI work is already done
+// and paid for, and losing one increment only loosens the cap slightly.
+func (s *Service) recordOpenAIUsage(ctx context.Context, tokens int64) {
+	if tokens <= 0 {
+		return
+	}
+	if err := s.repos.OpenAIUsage.Add(ctx, time.Now(), tokens); err != nil {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "failed to record openai usage",
+			slog.Any("error", err), slog.Int64("tokens", tokens))
+	}
+}
@@ -0,0 +1,40 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// ComparePlanToActual holds the authenticated user's session on date up
+// against what the planner would prescribe for that day, and returns the
+// differences with coaching notes. The prescription is planned afresh
+// against the rest of the stored week, with date itself left out, so it sees
+// the same no-repeat set and weekly volume the original plan did. Nothing is
+// persisted, logged or timed: the planner's warnings and latency metric
+// belong to real planning, not to a comparison. Returns domain.ErrNotFound
+// when no session is planned on date.
+func (s *Service) ComparePlanToActual(ctx context.Context, date time.Time) (domain.PlanComparison, error) {
+	date = domain.StartOfDay(date)
+	plan, err := s.repos.WeekPlans.Get(ctx, domain.MondayOf(date))
+	if err != nil {
+		return domain.PlanComparison{}, fmt.Errorf("get week plan: %w", err)
+	}
+	actual := plan.SessionOn(date)
+	if actual == nil || len(actual.Slots) == 0 {
+		return domain.PlanComparison{}, fmt.Errorf("session %s: %w", date.Format(time.DateOnly), domain.ErrNotFound)
+	}
+	logged := *actual
+	*actual = domain.Session{Date: date} //nolint:exhaustruct // Blanked so the day does not plan against itself.
+	planner, err := s.loadPlanner(ctx, date)
+	if err != nil {
+		return domain.PlanComparison{}, err
+	}
+	prescribed, _, err := planDay(planner, date, plan, "")
+	if err != nil {
+		return domain.PlanComparison{}, err
+	}
+	return domain.ComparePlanToActual(prescribed, logged), nil
+}
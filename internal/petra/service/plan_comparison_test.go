@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+func Test_ComparePlanToActual_HighlightsDivergence(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := plan.Sessions[0].Date
+	if len(plan.Sessions[0].Slots) < 2 {
+		t.Fatalf("Monday has %d exercises, want at least 2", len(plan.Sessions[0].Slots))
+	}
+
+	if _, err = svc.ComparePlanToActual(ctx, plan.Sessions[1].Date); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("ComparePlanToActual on a rest day: error = %v, want ErrNotFound", err)
+	}
+
+	// Log a single set at half its target, then stop: the rest of the
+	// workout and every other exercise's muscles go untrained.
+	if err = svc.StartSession(ctx, monday); err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	first := plan.Sessions[0].Slots[0]
+	weight := 20.0
+	signal := domain.SignalTooHeavy
+	reps := first.Sets[0].TargetValue / 2
+	if err = svc.RecordSet(ctx, monday, 0, 0, &signal, &weight, reps, domain.SetFlags{}); err != nil {
+		t.Fatalf("RecordSet: %v", err)
+	}
+
+	got, err := svc.ComparePlanToActual(ctx, monday)
+	if err != nil {
+		t.Fatalf("ComparePlanToActual: %v", err)
+	}
+	if got.CompletedSets != 1 || got.PlannedSets <= 1 {
+		t.Errorf("sets = %d/%d, want 1 completed of several planned", got.CompletedSets, got.PlannedSets)
+	}
+	if len(got.MissedMuscleGroups) == 0 {
+		t.Error("MissedMuscleGroups is empty, want the muscles of the skipped exercises")
+	}
+	if got.Attainment >= 0.9 {
+		t.Errorf("Attainment = %v, want below 0.9 for a set at half its target", got.Attainment)
+	}
+	notes := strings.Join(got.Notes, "\n")
+	for _, want := range []string{"You completed 1 of the", "Planned but not trained", "of their targets"} {
+		if !strings.Contains(notes, want) {
+			t.Errorf("Notes = %q, want one containing %q", got.Notes, want)
+		}
+	}
+
+	// Comparing plans the day afresh but is not real planning, so it must
+	// not show up in the planning latency metric.
+	var b strings.Builder
+	if err = svc.WriteMetrics(&b); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+	if !strings.Contains(b.String(), "petra_plan_day_duration_seconds_count 0\n") {
+		t.Errorf("ComparePlanToActual recorded a plan-day latency; metrics:\n%s", b.String())
+	}
+}
@@ -14,9 +14,11 @@ import (
 // the most recent qualifying session strictly before beforeDate, then converts the
 // load via Epley 1RM-equivalence when that session's goal differs from
 // targetType so the relative intensity carries across rep schemes (e.g. 100 kg x5
-// strength → ~92 kg x8 hypertrophy). Using a cutoff keeps the starting weight
-// stable when earlier sets of beforeDate's session are edited. Returns 0 if no
-// successful history exists.
+// strength → ~92 kg x8 hypertrophy), rounding down when the user's
+// RoundWeightsDown preference is on. Using a cutoff keeps the starting weight
+// stable when earlier sets of beforeDate's session are edited. Returns the
+// exercise's seeded DefaultStartWeightKg (0 when unset) if no successful
+// history exists.
 func (s *Service) GetStartingWeight(
 	ctx context.Context,
 	exerciseID int,
@@ -27,13 +29,17 @@ func (s *Service) GetStartingWeight(
 	if err != nil {
 		return 0, fmt.Errorf("get latest starting weight: %w", err)
 	}
-	if prev.Goal == "" || prev.Goal == targetType {
+	if prev.Goal == targetType {
 		return prev.WeightKg, nil
 	}
 	exercise, err := s.repos.Exercises.Get(ctx, exerciseID)
 	if err != nil {
 		return 0, fmt.Errorf("get exercise for rep range: %w", err)
 	}
+	if prev.Goal == "" {
+		// No qualifying history: first-ever session of this exercise.
+		return exercise.StartWeightKg(), nil
+	}
 	if exercise.RepMin == nil || exercise.RepMax == nil {
 		// time-based exercises don't carry a rep range and shouldn't reach
 		// this path (their starting value is seconds via GetStartingSeconds);
@@ -50,7 +56,11 @@ func (s *Service) GetStartingWeight(
 		targetType,
 		false,
 	).TargetReps
-	return domain.ConvertWeight(prev.WeightKg, fromReps, toReps), nil
+	prefs, err := s.repos.Preferences.Get(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get preferences: %w", err)
+	}
+	return domain.ConvertWeight(prev.WeightKg, fromReps, toReps, prefs.RoundWeightsDown), nil
 }
 
 // GetStartingSeconds returns the seconds target to seed a new session for
@@ -89,8 +99,10 @@ func (s *Service) GetStartingSeconds(
 // NextSetTarget returns the progression's recommendation for the next set of
 // the given exercise on the given date, owning the load-model switch so callers
 // never branch on it. Weighted/timed exercises consult their respective
-// engines; bodyweight and unknown load models have no progression and return
-// the zero SetTarget (the stored target is used as-is).
+// engines. A bodyweight exercise returns the added load to log in WeightKg
+// once it has been outgrown (see nextAddedLoad); until then, and for unknown
+// load models, the zero SetTarget is returned and the stored target is used
+// as-is.
 func (s *Service) NextSetTarget(
 	ctx context.Context,
 	date time.Time,
@@ -113,12 +125,76 @@ func (s *Service) NextSetTarget(
 			return domain.SetTarget{}, fmt.Errorf("build timed progression: %w", err)
 		}
 		return progression.CurrentSet(), nil
-	case domain.LoadBodyweight, domain.LoadUnknown:
+	case domain.LoadBodyweight:
+		kg, loaded, loadErr := s.nextAddedLoad(ctx, date, exercise)
+		if loadErr != nil {
+			return domain.SetTarget{}, fmt.Errorf("next added load: %w", loadErr)
+		}
+		if loaded {
+			return domain.SetTarget{WeightKg: kg, TargetValue: 0, Stalled: false}, nil
+		}
+	case domain.LoadUnknown:
 		// No progression engine — the stored target is used as-is.
 	}
 	return domain.SetTarget{}, nil
 }
 
+// nextAddedLoad returns the external load in kg (a vest, belt, or dumbbell)
+// to prescribe for a bodyweight exercise on date, and false while it is done
+// at bodyweight alone. It reads completed sessions strictly before date
+// within the last three months; see domain.NextAddedLoad for the rule.
+// Returns false for an exercise without a rep range.
+func (s *Service) nextAddedLoad(
+	ctx context.Context,
+	date time.Time,
+	exercise domain.Exercise,
+) (float64, bool, error) {
+	if exercise.RepMax == nil {
+		return 0, false, nil
+	}
+	histories, err := s.repos.Sessions.ListSetsForExerciseSince(ctx, exercise.ID, date.AddDate(0, -3, 0))
+	if err != nil {
+		return 0, false, fmt.Errorf("list sets for exercise: %w", err)
+	}
+	prior := make([]domain.ExerciseSetHistory, 0, len(histories))
+	for _, h := range histories {
+		if h.Date.Before(date) {
+			prior = append(prior, h)
+		}
+	}
+	kg, loaded := domain.NextAddedLoad(*exercise.RepMax, prior)
+	return kg, loaded, nil
+}
+
+// sideImbalanceLookbackWeeks is how far back SideImbalance compares the two
+// sides: long enough to smooth out one off day, short enough that an
+// imbalance already trained away stops being reported.
+const sideImbalanceLookbackWeeks = 4
+
+// SideImbalance compares the left and right sides of a unilateral exercise
+// over the sideImbalanceLookbackWeeks up to and including date. ok is false
+// when the window does not hold completed sets for both sides; see
+// domain.DetectSideImbalance for how the gap is measured and flagged.
+func (s *Service) SideImbalance(
+	ctx context.Context,
+	date time.Time,
+	exerciseID int,
+) (domain.SideImbalance, bool, error) {
+	histories, err := s.repos.Sessions.ListSetsForExerciseSince(
+		ctx, exerciseID, date.AddDate(0, 0, -7*sideImbalanceLookbackWeeks))
+	if err != nil {
+		return domain.SideImbalance{}, false, fmt.Errorf("list sets for exercise: %w", err)
+	}
+	window := make([]domain.ExerciseSetHistory, 0, len(histories))
+	for _, h := range histories {
+		if !h.Date.After(date) {
+			window = append(window, h)
+		}
+	}
+	imbalance, ok := domain.DetectSideImbalance(window)
+	return imbalance, ok, nil
+}
+
 // buildWeightedProgression constructs a domain.Progression for the given exercise
 // in the given session, ready to call CurrentSet() for the next set recommendation.
 func (s *Service) buildWeightedProgression(
@@ -148,6 +224,10 @@ func (s *Service) buildWeightedProgression(
 	if err != nil {
 		return nil, fmt.Errorf("get starting weight: %w", err)
 	}
+	prefs, err := s.repos.Preferences.Get(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get preferences: %w", err)
+	}
 
 	config := domain.Config{
 		Type:           sess.Goal,
@@ -155,6 +235,10 @@ func (s *Service) buildWeightedProgression(
 		RepMax:         *exercise.RepMax,
 		StartingWeight: startingWeight,
 		IsDeload:       sess.IsDeload,
+		RoundDown:      prefs.RoundWeightsDown,
+		Aggressiveness: prefs.EffectiveProgressionAggressiveness(),
+		// The domain default; there is no per-user setting for it yet.
+		MaxConsecutiveDecreases: 0,
 	}
 
 	return domain.NewProgressionFromHistory(config, collectWeightedHistory(sess, exerciseID)), nil
@@ -187,9 +271,10 @@ func collectWeightedHistory(sess domain.Session, exerciseID int) []domain.SetRes
 				sig = *set.Signal
 			}
 			completed = append(completed, domain.SetResult{
-				ActualValue: *set.CompletedValue,
-				Signal:      sig,
-				WeightKg:    kg,
+				ActualValue:      *set.CompletedValue,
+				Signal:           sig,
+				WeightKg:         kg,
+				TechnicalFailure: set.TechnicalFailure,
 			})
 		}
 		break
@@ -261,9 +346,10 @@ func (s *Service) buildTimedProgression(
 				continue
 			}
 			completed = append(completed, domain.SetResult{
-				ActualValue: *set.CompletedValue,
-				Signal:      *set.Signal,
-				WeightKg:    0, // timed holds carry no weight
+				ActualValue:      *set.CompletedValue,
+				Signal:           *set.Signal,
+				WeightKg:         0, // timed holds carry no weight
+				TechnicalFailure: set.TechnicalFailure,
 			})
 		}
 		break
@@ -274,3 +360,87 @@ func (s *Service) buildTimedProgression(
 		completed,
 	), nil
 }
+
+// targetLookbackWeeks is the window PlanToTarget estimates the current
+// one-rep max from: recent enough to reflect today's strength after a layoff.
+const targetLookbackWeeks = 8
+
+// PlanToTarget works out the weekly estimated-1RM gain the user needs to
+// lift targetKg on exerciseID by byDate, starting from the best estimate in
+// their last targetLookbackWeeks of sets, and whether that pace is realistic
+// (see domain.PlanToTarget). Returns a ValidationError for a non-positive
+// target, an exercise that isn't weighted, or no recent loaded sets to
+// estimate from.
+func (s *Service) PlanToTarget(
+	ctx context.Context,
+	exerciseID int,
+	targetKg float64,
+	byDate time.Time,
+) (weeklyIncrement float64, feasible bool, err error) {
+	if targetKg <= 0 {
+		return 0, false, domain.ValidationError{Message: "Target weight must be positive."}
+	}
+	exercise, err := s.repos.Exercises.Get(ctx, exerciseID)
+	if err != nil {
+		return 0, false, fmt.Errorf("get exercise: %w", err)
+	}
+	if exercise.ExerciseType != domain.ExerciseTypeWeighted {
+		return 0, false, domain.ValidationError{Message: "Weight targets apply to weighted exercises only."}
+	}
+	now := time.Now()
+	histories, err := s.repos.Sessions.ListSetsForExerciseSince(
+		ctx, exerciseID, now.AddDate(0, 0, -7*targetLookbackWeeks))
+	if err != nil {
+		return 0, false, fmt.Errorf("list sets for exercise: %w", err)
+	}
+	current, ok := domain.EstimatedOneRepMax(histories)
+	if !ok {
+		return 0, false, domain.ValidationError{
+			Message: "Log a few sets of this exercise first so there is a current max to plan from.",
+		}
+	}
+	weeklyIncrement, feasible = domain.PlanToTarget(current, targetKg, now, byDate)
+	return weeklyIncrement, feasible, nil
+}
+
+// SimulateWeight projects how the user would fare at weightKg on exerciseID:
+// the reps expected there by reverse Epley from the best estimated one-rep
+// max in their last targetLookbackWeeks of sets, judged against the
+// exercise's rep range (see domain.ProjectWeight). Returns a ValidationError
+// for a non-positive weight, an exercise that isn't weighted or has no rep
+// range, or no recent loaded sets to estimate from.
+func (s *Service) SimulateWeight(
+	ctx context.Context,
+	exerciseID int,
+	weightKg float64,
+) (domain.WeightProjection, error) {
+	if weightKg <= 0 {
+		return domain.WeightProjection{}, domain.ValidationError{Message: "Weight must be positive."}
+	}
+	exercise, err := s.repos.Exercises.Get(ctx, exerciseID)
+	if err != nil {
+		return domain.WeightProjection{}, fmt.Errorf("get exercise: %w", err)
+	}
+	if exercise.ExerciseType != domain.ExerciseTypeWeighted {
+		return domain.WeightProjection{}, domain.ValidationError{
+			Message: "Weight simulations apply to weighted exercises only.",
+		}
+	}
+	if exercise.RepMin == nil || exercise.RepMax == nil {
+		return domain.WeightProjection{}, domain.ValidationError{
+			Message: "This exercise has no rep range to project against.",
+		}
+	}
+	histories, err := s.repos.Sessions.ListSetsForExerciseSince(
+		ctx, exerciseID, time.Now().AddDate(0, 0, -7*targetLookbackWeeks))
+	if err != nil {
+		return domain.WeightProjection{}, fmt.Errorf("list sets for exercise: %w", err)
+	}
+	current, ok := domain.EstimatedOneRepMax(histories)
+	if !ok {
+		return domain.WeightProjection{}, domain.ValidationError{
+			Message: "Log a few sets of this exercise first so there is recent performance to project from.",
+		}
+	}
+	return domain.ProjectWeight(current, weightKg, *exercise.RepMin, *exercise.RepMax), nil
+}
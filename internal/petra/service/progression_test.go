@@ -1,7 +1,9 @@
 package service_test
 
 import (
-	"context"
+	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
@@ -38,8 +40,7 @@ func Test_GetStartingWeight(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	_, err = db.ReadWrite.ExecContext(ctx,
 		"INSERT INTO exercises (name, category, content, rep_min, rep_max) VALUES (?, ?, ?, ?, ?)",
@@ -215,8 +216,7 @@ func Test_GetStartingWeight_Assisted(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	_, err = db.ReadWrite.ExecContext(ctx,
 		"INSERT INTO exercises (name, category, content, rep_min, rep_max) VALUES (?, ?, ?, ?, ?)",
@@ -303,8 +303,7 @@ func Test_GetStartingSeconds(t *testing.T) {
 		[]byte("ts-user"), "TS User").Scan(&userID); err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	// Insert a time_based exercise with default 30s.
 	if _, err = db.ReadWrite.ExecContext(ctx, `
@@ -413,8 +412,7 @@ func Test_BuildTimedProgression(t *testing.T) {
 		[]byte("btp-user"), "BTP User").Scan(&userID); err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	if _, err = db.ReadWrite.ExecContext(ctx, `
 		INSERT INTO exercises (name, category, exercise_type, default_starting_seconds, content)
@@ -506,8 +504,7 @@ func Test_BuildProgression(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	_, err = db.ReadWrite.ExecContext(ctx,
 		"INSERT INTO exercises (name, category, content, rep_min, rep_max) VALUES (?, ?, ?, ?, ?)",
@@ -563,7 +560,7 @@ func Test_BuildProgression(t *testing.T) {
 	// Record set 0 as TooLight at 0kg.
 	weight := 0.0
 	sig := domain.SignalTooLight
-	if err = svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 8); err != nil {
+	if err = svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 8, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
@@ -607,8 +604,7 @@ func Test_BuildProgression_DeloadCarriesOverride(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	var exerciseID int
 	err = db.ReadWrite.QueryRowContext(ctx,
@@ -649,7 +645,7 @@ func Test_BuildProgression_DeloadCarriesOverride(t *testing.T) {
 	// User completes set 0 with an override weight of 60 kg and no signal
 	// (the deload form sends no signal field).
 	override := 60.0
-	if err = svc.RecordSet(ctx, date, pos, 0, nil, &override, 8); err != nil {
+	if err = svc.RecordSet(ctx, date, pos, 0, nil, &override, 8, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
@@ -686,8 +682,7 @@ func Test_BuildProgression_CrossSessionGoalConversion(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	_, err = db.ReadWrite.ExecContext(ctx,
 		"INSERT INTO exercises (name, category, content, rep_min, rep_max) VALUES (?, ?, ?, ?, ?)",
@@ -789,8 +784,7 @@ func Test_GetStartingWeight_DeloadAppliesNinetyPercent(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	_, err = db.ReadWrite.ExecContext(ctx,
 		"INSERT INTO exercises (name, category, content, rep_min, rep_max) VALUES (?, ?, ?, ?, ?)",
@@ -873,8 +867,7 @@ func Test_GetDeloadStartingWeight_FloorsFractionalResult(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	var exerciseID int
 	err = db.ReadWrite.QueryRowContext(ctx,
@@ -957,8 +950,7 @@ func Test_GetDeloadStartingWeight_Assisted(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	var exerciseID int
 	err = db.ReadWrite.QueryRowContext(ctx,
@@ -1033,8 +1025,7 @@ func Test_BuildProgression_CurrentSetUsesDeriveScheme(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	// Deadlift-like exercise: rep_min=3, rep_max=6.
 	var exerciseID int
@@ -1102,3 +1093,296 @@ func Test_BuildProgression_CurrentSetUsesDeriveScheme(t *testing.T) {
 		t.Errorf("strength NextSetTarget TargetValue: want 3, got %d", got)
 	}
 }
+
+// Test_NextSetTarget_FirstSessionUsesSeededStartWeight verifies a user's
+// first-ever Bench Press session starts at the fixture-seeded
+// default_start_weight_kg instead of an empty 0 kg, both for the progression's
+// first-set recommendation and for the sets seeded by AddExercise.
+func Test_NextSetTarget_FirstSessionUsesSeededStartWeight(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	var (
+		benchID    int
+		seededKg   float64
+		dateStr    = "2026-03-02" // a Monday, so the test prefs schedule it
+		date, _    = time.Parse(time.DateOnly, dateStr)
+		startedSQL = `INSERT INTO workout_sessions (user_id, workout_date, started_at, session_goal)
+		              VALUES (?, ?, STRFTIME('%Y-%m-%dT%H:%M:%fZ'), 'strength')`
+	)
+	if err := db.ReadOnly.QueryRowContext(ctx,
+		"SELECT id, default_start_weight_kg FROM exercises WHERE name = 'Bench Press'",
+	).Scan(&benchID, &seededKg); err != nil {
+		t.Fatalf("look up Bench Press: %v", err)
+	}
+	if seededKg <= 0 {
+		t.Fatalf("Bench Press default_start_weight_kg = %v, want a positive fixture seed", seededKg)
+	}
+	if _, err := db.ReadWrite.ExecContext(ctx, startedSQL, userID, dateStr); err != nil {
+		t.Fatalf("insert session: %v", err)
+	}
+
+	if _, err := svc.AddExercise(ctx, date, benchID); err != nil {
+		t.Fatalf("AddExercise: %v", err)
+	}
+	sess, err := svc.GetSession(ctx, date)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if len(sess.Slots) != 1 || len(sess.Slots[0].Sets) == 0 {
+		t.Fatalf("expected one Bench Press slot with sets, got %+v", sess.Slots)
+	}
+	for i, set := range sess.Slots[0].Sets {
+		if set.WeightKg == nil || *set.WeightKg != seededKg {
+			t.Errorf("set[%d].WeightKg = %v, want %v", i, set.WeightKg, seededKg)
+		}
+	}
+
+	target, err := svc.NextSetTarget(ctx, date, benchID)
+	if err != nil {
+		t.Fatalf("NextSetTarget: %v", err)
+	}
+	if target.WeightKg != seededKg {
+		t.Errorf("first set weight = %v, want seeded %v (not 0)", target.WeightKg, seededKg)
+	}
+}
+
+// Test_NextSetTarget_AddedLoadForOutgrownBodyweightExercise drives high-rep
+// Push-Ups (bodyweight, rep range 5–10) across sessions and checks that the
+// target switches to added load once two consecutive sessions top out the
+// range, then carries that load forward and steps it up like a weighted lift.
+func Test_NextSetTarget_AddedLoadForOutgrownBodyweightExercise(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	var pushUpID int
+	if err := db.ReadOnly.QueryRowContext(ctx,
+		"SELECT id FROM exercises WHERE name = 'Push-Up'").Scan(&pushUpID); err != nil {
+		t.Fatalf("look up Push-Up: %v", err)
+	}
+
+	// seed stores a completed Push-Up session; a zero load leaves weight_kg
+	// NULL as for plain bodyweight sets.
+	seed := func(dateStr string, loadKg float64, reps ...int) {
+		t.Helper()
+		if _, err := db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO workout_sessions (user_id, workout_date, started_at, completed_at)
+			 VALUES (?, ?, STRFTIME('%Y-%m-%dT%H:%M:%fZ'), STRFTIME('%Y-%m-%dT%H:%M:%fZ'))`,
+			userID, dateStr); err != nil {
+			t.Fatalf("insert session %s: %v", dateStr, err)
+		}
+		if _, err := db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id, warmup_completed_at)
+			 VALUES (?, ?, 0, ?, STRFTIME('%Y-%m-%dT%H:%M:%fZ'))`,
+			userID, dateStr, pushUpID); err != nil {
+			t.Fatalf("insert slot %s: %v", dateStr, err)
+		}
+		var weight *float64
+		if loadKg > 0 {
+			weight = &loadKg
+		}
+		for i, r := range reps {
+			if _, err := db.ReadWrite.ExecContext(ctx,
+				`INSERT INTO exercise_sets
+				   (workout_user_id, workout_date, position, set_number,
+				    weight_kg, target_value, completed_value, completed_at, signal)
+				 VALUES (?, ?, 0, ?, ?, 10, ?, STRFTIME('%Y-%m-%dT%H:%M:%fZ'), 'on_target')`,
+				userID, dateStr, i+1, weight, r); err != nil {
+				t.Fatalf("insert set %s/%d: %v", dateStr, i+1, err)
+			}
+		}
+	}
+
+	check := func(dateStr string, wantKg float64) {
+		t.Helper()
+		date, _ := time.Parse(time.DateOnly, dateStr)
+		target, err := svc.NextSetTarget(ctx, date, pushUpID)
+		if err != nil {
+			t.Fatalf("NextSetTarget(%s): %v", dateStr, err)
+		}
+		if target.WeightKg != wantKg {
+			t.Errorf("NextSetTarget(%s).WeightKg = %v, want %v", dateStr, target.WeightKg, wantKg)
+		}
+	}
+
+	seed("2026-03-02", 0, 8, 8, 7)
+	check("2026-03-04", 0)
+	seed("2026-03-04", 0, 10, 10, 10)
+	check("2026-03-06", 0) // one topped-out session is not enough
+	seed("2026-03-06", 0, 12, 11, 10)
+	check("2026-03-09", domain.AddedLoadStartKg)
+	// The session being viewed never counts towards its own target.
+	check("2026-03-06", 0)
+
+	// Once loaded, the load holds until every set reaches the top again.
+	seed("2026-03-09", domain.AddedLoadStartKg, 8, 7, 6)
+	check("2026-03-11", domain.AddedLoadStartKg)
+	seed("2026-03-11", domain.AddedLoadStartKg, 10, 10, 10)
+	check("2026-03-13", 2*domain.AddedLoadStartKg)
+}
+
+func Test_SideImbalance_FlagsLopsidedUnilateralSets(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+
+	weekPlan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := weekPlan.Sessions[0].Date
+	if err = svc.StartSession(ctx, monday); err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	sess, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	slot := sess.Slots[0]
+	if len(slot.Sets) < 2 {
+		t.Fatalf("slot has %d sets, need at least 2", len(slot.Sets))
+	}
+
+	if _, ok, _ := svc.SideImbalance(ctx, monday, slot.Exercise.ID); ok {
+		t.Fatal("SideImbalance ok = true before any per-side set was logged")
+	}
+
+	// Left arm falls well short of the right at the same load.
+	weight := 20.0
+	signal := domain.SignalOnTarget
+	for i, side := range []domain.Side{domain.SideLeft, domain.SideRight} {
+		reps := 10
+		if side == domain.SideLeft {
+			reps = 6
+		}
+		flags := domain.SetFlags{TechnicalFailure: false, Warmup: false, Side: &side}
+		if err = svc.RecordSet(ctx, monday, 0, i, &signal, &weight, reps, flags); err != nil {
+			t.Fatalf("RecordSet(%d): %v", i, err)
+		}
+	}
+
+	var ve domain.ValidationError
+	middle := domain.Side("middle")
+	flags := domain.SetFlags{TechnicalFailure: false, Warmup: false, Side: &middle}
+	if err = svc.RecordSet(ctx, monday, 0, 0, &signal, &weight, 6, flags); !errors.As(err, &ve) {
+		t.Errorf("RecordSet(side middle) = %v, want ValidationError", err)
+	}
+
+	imbalance, ok, err := svc.SideImbalance(ctx, monday, slot.Exercise.ID)
+	if err != nil {
+		t.Fatalf("SideImbalance: %v", err)
+	}
+	if !ok || !imbalance.Imbalanced() {
+		t.Fatalf("SideImbalance = (%+v, %t), want a flagged imbalance", imbalance, ok)
+	}
+	if imbalance.WeakerSide != domain.SideLeft {
+		t.Errorf("WeakerSide = %q, want %q", imbalance.WeakerSide, domain.SideLeft)
+	}
+}
+
+func Test_PlanToTarget_FlagsUnreachableTargets(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+
+	weekPlan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := weekPlan.Sessions[0].Date
+	if err = svc.StartSession(ctx, monday); err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	sess, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	pos := slices.IndexFunc(sess.Slots, func(s domain.ExerciseSlot) bool {
+		return s.Exercise.ExerciseType == domain.ExerciseTypeWeighted
+	})
+	if pos < 0 {
+		t.Fatal("no weighted exercise in Monday's session")
+	}
+	exerciseID := sess.Slots[pos].Exercise.ID
+
+	var ve domain.ValidationError
+	if _, _, err = svc.PlanToTarget(ctx, exerciseID, 100, time.Now().AddDate(0, 3, 0)); !errors.As(err, &ve) {
+		t.Fatalf("PlanToTarget without history = %v, want ValidationError", err)
+	}
+
+	// 80 kg × 5 → Epley estimate ≈ 93.3 kg.
+	weight := 80.0
+	signal := domain.SignalOnTarget
+	if err = svc.RecordSet(ctx, monday, pos, 0, &signal, &weight, 5, domain.SetFlags{}); err != nil {
+		t.Fatalf("RecordSet: %v", err)
+	}
+
+	weekly, feasible, err := svc.PlanToTarget(ctx, exerciseID, 100, time.Now().AddDate(0, 0, 7*12))
+	if err != nil {
+		t.Fatalf("PlanToTarget(reachable): %v", err)
+	}
+	if !feasible || weekly <= 0 || weekly > 1 {
+		t.Errorf("100 kg in 12 weeks = (%.2f kg/week, %t), want a feasible pace under 1 kg/week", weekly, feasible)
+	}
+
+	weekly, feasible, err = svc.PlanToTarget(ctx, exerciseID, 150, time.Now().AddDate(0, 0, 7*4))
+	if err != nil {
+		t.Fatalf("PlanToTarget(unreachable): %v", err)
+	}
+	if feasible || weekly < 14 {
+		t.Errorf("150 kg in 4 weeks = (%.2f kg/week, %t), want an infeasible pace of ~14 kg/week", weekly, feasible)
+	}
+}
+
+func Test_SimulateWeight_FlagsTooHeavyWeight(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+
+	weekPlan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := weekPlan.Sessions[0].Date
+	if err = svc.StartSession(ctx, monday); err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	sess, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	pos := slices.IndexFunc(sess.Slots, func(s domain.ExerciseSlot) bool {
+		return s.Exercise.ExerciseType == domain.ExerciseTypeWeighted && s.Exercise.RepMin != nil
+	})
+	if pos < 0 {
+		t.Fatal("no weighted exercise with a rep range in Monday's session")
+	}
+	exercise := sess.Slots[pos].Exercise
+
+	var ve domain.ValidationError
+	if _, err = svc.SimulateWeight(ctx, exercise.ID, 100); !errors.As(err, &ve) {
+		t.Fatalf("SimulateWeight without history = %v, want ValidationError", err)
+	}
+
+	// 80 kg × 5 → Epley estimate ≈ 93.3 kg, a single at most at 93 kg.
+	weight := 80.0
+	signal := domain.SignalOnTarget
+	if err = svc.RecordSet(ctx, monday, pos, 0, &signal, &weight, 5, domain.SetFlags{}); err != nil {
+		t.Fatalf("RecordSet: %v", err)
+	}
+
+	got, err := svc.SimulateWeight(ctx, exercise.ID, 93)
+	if err != nil {
+		t.Fatalf("SimulateWeight: %v", err)
+	}
+	if got.Feasible || !strings.Contains(got.Note, "too heavy for target reps") {
+		t.Errorf("93 kg for %s = %+v, want an infeasible too-heavy note", exercise.TargetRangeText(), got)
+	}
+	if got.RepMin != *exercise.RepMin || got.RepMax != *exercise.RepMax {
+		t.Errorf("projected against %d–%d, want the exercise's %s", got.RepMin, got.RepMax, exercise.TargetRangeText())
+	}
+}
@@ -3,6 +3,8 @@ package service
 import (
 	"context"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
 )
@@ -62,3 +64,17 @@ func (s *Service) DeletePushSubscriptionByID(ctx context.Context, id int) error
 	}
 	return nil
 }
+
+// UpcomingReminders returns the workout reminders due by now, across all
+// users: one for each user whose preferred workout time today has passed on a
+// workout day and who has not started today's workout yet. It only computes
+// them; nothing is delivered.
+func (s *Service) UpcomingReminders(ctx context.Context, now time.Time) ([]domain.Reminder, error) {
+	now = now.UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	reminders, err := s.repos.Preferences.ListReminders(ctx, today)
+	if err != nil {
+		return nil, fmt.Errorf("list reminders: %w", err)
+	}
+	return slices.DeleteFunc(reminders, func(r domain.Reminder) bool { return !r.DueBy(now) }), nil
+}
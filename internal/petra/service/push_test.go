@@ -1,9 +1,12 @@
 package service_test
 
 import (
+	"slices"
 	"testing"
+	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
 )
 
 func TestDeletePushSubscription_EmptyEndpointRemovesAll(t *testing.T) {
@@ -33,3 +36,74 @@ func TestDeletePushSubscription_EmptyEndpointRemovesAll(t *testing.T) {
 		t.Errorf("after delete-all: count = %d, want 0", count)
 	}
 }
+
+func TestUpcomingReminders(t *testing.T) {
+	t.Parallel()
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	// The test user trains on Mondays from 07:00. A second user with the same
+	// schedule has already started Monday's workout, and a third has no
+	// preferred time, so neither gets a reminder.
+	monday := time.Date(2026, 5, 11, 0, 0, 0, 0, time.UTC)
+	at := domain.TimeOfDay(7 * 60)
+	if err := svc.SaveUserPreferences(ctx, domain.Preferences{ //nolint:exhaustruct // Monday workouts only.
+		Minutes:              [7]int{time.Monday: 60},
+		PreferredWorkoutTime: &at,
+	}); err != nil {
+		t.Fatalf("save preferences: %v", err)
+	}
+	for _, other := range []struct {
+		name      string
+		preferred any
+		started   bool
+	}{
+		{"started", "07:00", true},
+		{"no-time", nil, false},
+	} {
+		var otherID int
+		if err := db.ReadWrite.QueryRowContext(ctx,
+			"INSERT INTO users (webauthn_user_id, display_name) VALUES (?, ?) RETURNING id",
+			[]byte(other.name), other.name).Scan(&otherID); err != nil {
+			t.Fatalf("insert user %s: %v", other.name, err)
+		}
+		if _, err := db.ReadWrite.ExecContext(ctx,
+			"INSERT INTO workout_preferences (user_id, monday_minutes, preferred_workout_time) VALUES (?, 60, ?)",
+			otherID, other.preferred); err != nil {
+			t.Fatalf("insert preferences for %s: %v", other.name, err)
+		}
+		if other.started {
+			if _, err := db.ReadWrite.ExecContext(ctx,
+				"INSERT INTO workout_sessions (user_id, workout_date, started_at) VALUES (?, ?, ?)",
+				otherID, monday.Format(time.DateOnly), "2026-05-11T06:30:00.000Z"); err != nil {
+				t.Fatalf("insert session for %s: %v", other.name, err)
+			}
+		}
+	}
+
+	tests := []struct {
+		name string
+		now  time.Time
+		want []int
+	}{
+		{"after the preferred time", monday.Add(8 * time.Hour), []int{userID}},
+		{"before the preferred time", monday.Add(6 * time.Hour), nil},
+		{"on a rest day", monday.AddDate(0, 0, 1).Add(8 * time.Hour), nil},
+	}
+	for _, tt := range tests {
+		reminders, err := svc.UpcomingReminders(ctx, tt.now)
+		if err != nil {
+			t.Fatalf("%s: UpcomingReminders: %v", tt.name, err)
+		}
+		var got []int
+		for _, r := range reminders {
+			got = append(got, r.UserID)
+			if want := monday.Add(7 * time.Hour); !r.At.Equal(want) {
+				t.Errorf("%s: reminder At = %s, want %s", tt.name, r.At, want)
+			}
+		}
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("%s: reminded users = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -1,11 +1,16 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
 )
 
 // GetSessionsWithExerciseSince retrieves all sessions since a given date that contain the specified exercise.
@@ -81,3 +86,116 @@ func (s *Service) WeeklyMuscleGroupVolume(
 	}
 	return domain.WeeklyMuscleGroupVolume(sessions, targets, groupNames), nil
 }
+
+// MuscleBalance returns the muscle-balance radar over the days days ending
+// yesterday, or today when the service counts today (see
+// WithAnalysisIncludeToday): every known muscle group's completed volume
+// across the sessions in that window, normalised by domain.MuscleBalanceScores and sorted like
+// WeeklyMuscleGroupVolume. Groups the user has not trained score 0, and groups
+// no catalog exercise trains are marked with domain.MarkCatalogGaps.
+func (s *Service) MuscleBalance(ctx context.Context, days int) ([]domain.MuscleBalanceScore, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	end := s.analysisEnd(today)
+	sessions, err := s.repos.Sessions.List(ctx, end.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	sessions = slices.DeleteFunc(sessions, func(sess domain.Session) bool { return !sess.Date.Before(end) })
+	volumes, err := s.WeeklyMuscleGroupVolume(ctx, sessions)
+	if err != nil {
+		return nil, err
+	}
+	exercises, err := s.repos.Exercises.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list exercises: %w", err)
+	}
+	scores := domain.MuscleBalanceScores(volumes)
+	domain.MarkCatalogGaps(scores, exercises)
+	return scores, nil
+}
+
+// AdherenceReport returns, per exercise, how many of userID's prescribed
+// working sets over the days days ending yesterday were completed within the
+// rep range; see domain.AdherenceByExercise. Like MuscleBalance, the window
+// covers today only when the service counts today.
+func (s *Service) AdherenceReport(ctx context.Context, userID int, days int) ([]domain.ExerciseAdherence, error) {
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	end := s.analysisEnd(today)
+	sessions, err := s.repos.Sessions.List(ctx, end.AddDate(0, 0, -days))
+	if err != nil {
+		return nil, fmt.Errorf("list sessions: %w", err)
+	}
+	sessions = slices.DeleteFunc(sessions, func(sess domain.Session) bool { return !sess.Date.Before(end) })
+	return domain.AdherenceByExercise(sessions), nil
+}
+
+// SuggestWorkout recommends today's split, or rest, for the authenticated
+// user from the sessions trained over the last few days. See
+// domain.SuggestWorkout for the rules.
+func (s *Service) SuggestWorkout(ctx context.Context) (domain.WorkoutSuggestion, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	sessions, err := s.repos.Sessions.List(ctx, today.AddDate(0, 0, -domain.SuggestionLookbackDays))
+	if err != nil {
+		return domain.WorkoutSuggestion{}, fmt.Errorf("list sessions: %w", err)
+	}
+	return domain.SuggestWorkout(sessions, today), nil
+}
+
+// WorkoutCategory returns the category the planner gives the authenticated
+// user's workout on date, and why. It explains the plan rather than reading
+// the stored session back, so swaps and added exercises do not change it. See
+// domain.Planner.DecideCategory for the rules.
+func (s *Service) WorkoutCategory(ctx context.Context, date time.Time) (domain.CategoryDecision, error) {
+	planner, err := s.newPlanner(ctx, date)
+	if err != nil {
+		return domain.CategoryDecision{}, err
+	}
+	return planner.DecideCategory(date), nil
+}
+
+// EstimateCalories estimates the calories burned in the authenticated user's
+// completed session on date for a user of bodyweightKg. See
+// domain.EstimateCalories for the formula and its caveats.
+func (s *Service) EstimateCalories(
+	ctx context.Context, date time.Time, bodyweightKg float64,
+) (domain.CalorieEstimate, error) {
+	sess, err := s.GetSession(ctx, date)
+	if err != nil {
+		return domain.CalorieEstimate{}, err
+	}
+	if _, skewed := sess.Duration(); skewed {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "clock skew: stored workout completed before it started",
+			slog.String("date", date.Format(time.DateOnly)),
+			slog.Time("started_at", sess.StartedAt), slog.Time("completed_at", sess.CompletedAt))
+	}
+	return domain.EstimateCalories(sess, bodyweightKg)
+}
+
+// ListPersonalRecords returns the authenticated user's current personal record
+// on every exercise they have completed a set of, sorted by exercise name. See
+// domain.PersonalRecordFor for what counts as a record on each exercise type.
+func (s *Service) ListPersonalRecords(ctx context.Context) ([]domain.PersonalRecord, error) {
+	byExercise, err := s.repos.Sessions.ListCompletedSetsByExercise(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list completed sets: %w", err)
+	}
+	exercises, err := s.repos.Exercises.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list exercises: %w", err)
+	}
+
+	records := make([]domain.PersonalRecord, 0, len(byExercise))
+	for _, ex := range exercises {
+		if pr, ok := domain.PersonalRecordFor(ex, byExercise[ex.ID]); ok {
+			records = append(records, pr)
+		}
+	}
+	slices.SortFunc(records, func(a, b domain.PersonalRecord) int {
+		return cmp.Or(strings.Compare(a.Exercise.Name, b.Exercise.Name), cmp.Compare(a.Exercise.ID, b.Exercise.ID))
+	})
+	return records, nil
+}
@@ -5,6 +5,7 @@ import (
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
 )
 
 func Test_WeeklyMuscleGroupVolume_AggregatesPrimaryAndSecondary(t *testing.T) {
@@ -136,3 +137,87 @@ func Test_WeeklyMuscleGroupVolume_EmptyWeek(t *testing.T) {
 		}
 	}
 }
+
+func Test_ListPersonalRecords(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	squatID, err := createTestExercise(ctx, t, db, "PR Squat", "lower")
+	if err != nil {
+		t.Fatalf("create squat: %v", err)
+	}
+	pushUpID, err := createTestExercise(ctx, t, db, "PR Push-up", "upper")
+	if err != nil {
+		t.Fatalf("create push-up: %v", err)
+	}
+	if _, err = db.ReadWrite.ExecContext(ctx,
+		"UPDATE exercises SET exercise_type = 'bodyweight' WHERE id = ?", pushUpID); err != nil {
+		t.Fatalf("make push-up bodyweight: %v", err)
+	}
+
+	// Three sessions. The squat peaks at 100kg × 5 in week 2 and only matches
+	// it in week 3; the push-up peaks at 20 reps in week 3. The 110kg squat
+	// set in week 3 was never completed and must not count.
+	now := time.Now()
+	week := func(n int) string { return now.AddDate(0, 0, -7*(3-n)-1).Format(time.DateOnly) }
+	for _, date := range []string{week(1), week(2), week(3)} {
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO workout_sessions (user_id, workout_date, completed_at) VALUES (?, ?, STRFTIME('%Y-%m-%dT%H:%M:%fZ'))`,
+			userID, date); err != nil {
+			t.Fatalf("insert session %s: %v", date, err)
+		}
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+			 VALUES (?, ?, 0, ?), (?, ?, 1, ?)`,
+			userID, date, squatID, userID, date, pushUpID); err != nil {
+			t.Fatalf("insert slots %s: %v", date, err)
+		}
+	}
+	sets := []struct {
+		date      string
+		position  int
+		setNumber int
+		weightKg  any
+		completed any
+	}{
+		{week(1), 0, 1, 90.0, 5},
+		{week(1), 0, 2, 95.0, 3},
+		{week(1), 1, 1, nil, 15},
+		{week(2), 0, 1, 100.0, 5},
+		{week(2), 1, 1, nil, 12},
+		{week(3), 0, 1, 100.0, 5},
+		{week(3), 0, 2, 110.0, nil},
+		{week(3), 1, 1, nil, 20},
+	}
+	for _, s := range sets {
+		if _, err = db.ReadWrite.ExecContext(ctx,
+			`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number,
+			 weight_kg, target_value, completed_value) VALUES (?, ?, ?, ?, ?, 5, ?)`,
+			userID, s.date, s.position, s.setNumber, s.weightKg, s.completed); err != nil {
+			t.Fatalf("insert set %+v: %v", s, err)
+		}
+	}
+
+	records, err := svc.ListPersonalRecords(ctx)
+	if err != nil {
+		t.Fatalf("ListPersonalRecords: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2: %+v", len(records), records)
+	}
+
+	// Sorted by name: push-up before squat.
+	pushUp, squat := records[0], records[1]
+	if pushUp.Exercise.ID != pushUpID || squat.Exercise.ID != squatID {
+		t.Fatalf("record exercises = [%d %d], want [%d %d]", pushUp.Exercise.ID, squat.Exercise.ID, pushUpID, squatID)
+	}
+	if got := pushUp.Date.Format(time.DateOnly); got != week(3) || pushUp.Value != 20 || pushUp.WeightKg != nil {
+		t.Errorf("push-up record = %s × %d (weight %v), want %s × 20 unweighted", got, pushUp.Value, pushUp.WeightKg, week(3))
+	}
+	if got := squat.Date.Format(time.DateOnly); got != week(2) || squat.Value != 5 ||
+		squat.WeightKg == nil || *squat.WeightKg != 100 {
+		t.Errorf("squat record = %s × %d at %v kg, want %s × 5 at 100 kg", got, squat.Value, squat.WeightKg, week(2))
+	}
+}
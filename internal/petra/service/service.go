@@ -8,11 +8,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
+	"sync/atomic"
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
 	"github.com/myrjola/petrapp/internal/petra/repository"
+	"github.com/myrjola/petrapp/internal/platform/obs/metrics"
 	"github.com/myrjola/petrapp/internal/platform/sqlitekit"
 )
 
@@ -36,6 +39,24 @@ type Service struct {
 	openaiAPIKey     string
 	scheduler        PushScheduler // nil-safe; methods no-op when nil.
 	maintenanceCache *maintenanceCache
+	metadataCache    *metadataCache
+	openAIHealth     *openAIHealth
+	// openAIMonthlyTokenCap is each user's monthly OpenAI token allowance;
+	// zero means uncapped. See WithOpenAIMonthlyTokenCap.
+	openAIMonthlyTokenCap int64
+	// openAIFallbackModel is the cheaper model tried when the primary one is
+	// rate-limited or out of quota; empty disables it. See
+	// WithOpenAIFallbackModel.
+	openAIFallbackModel string
+	// analysisIncludeToday extends analysis windows to cover today. See
+	// WithAnalysisIncludeToday.
+	analysisIncludeToday bool
+	// features holds the deployment's feature switches. See WithFeatures.
+	features Features
+	// planWeekLatency and planDayLatency time workout generation for a whole
+	// week and for a single ad-hoc day; see WriteMetrics.
+	planWeekLatency *metrics.Histogram
+	planDayLatency  *metrics.Histogram
 }
 
 // NewService creates a new workout service.
@@ -47,9 +68,33 @@ func NewService(db *sqlitekit.Database, logger *slog.Logger, openaiAPIKey string
 		openaiAPIKey:     openaiAPIKey,
 		scheduler:        nil,
 		maintenanceCache: newMaintenanceCache(),
+		metadataCache:    &metadataCache{state: atomic.Pointer[CatalogMetadata]{}},
+		openAIHealth:     newOpenAIHealth(false, ""),
+
+		openAIMonthlyTokenCap: 0,
+		analysisIncludeToday:  false,
+		features:              AllFeatures(),
+		openAIFallbackModel:   "",
+		planWeekLatency: metrics.NewHistogram("petra_plan_week_duration_seconds",
+			"Time to plan a week of workouts, from reading the planner inputs to the finished plan.",
+			metrics.DefaultLatencyBuckets()),
+		planDayLatency: metrics.NewHistogram("petra_plan_day_duration_seconds",
+			"Time to plan a single ad-hoc workout day.",
+			metrics.DefaultLatencyBuckets()),
 	}
 }
 
+// WriteMetrics writes the service's metrics in the Prometheus text
+// exposition format, for the /api/metrics endpoint.
+func (s *Service) WriteMetrics(w io.Writer) error {
+	for _, h := range []*metrics.Histogram{s.planWeekLatency, s.planDayLatency} {
+		if err := h.WriteText(w); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // Repos exposes the wired repositories so the notification.Scheduler can
 // reuse them at process startup without re-instantiating. Only intended
 // for main.go; HTTP handlers should call typed Service methods instead.
@@ -77,6 +122,26 @@ func (s *Service) WithScheduler(scheduler PushScheduler) *Service {
 	return &cp
 }
 
+// WithAnalysisIncludeToday returns a copy of the service whose analyses count
+// today. By default they end yesterday: today's workout may still be in
+// progress, and counting it half-done would skew consistency and balance
+// figures through the day. Analyses that honour this are the dashboard's
+// consistency figures and MuscleBalance.
+func (s *Service) WithAnalysisIncludeToday(include bool) *Service {
+	cp := *s
+	cp.analysisIncludeToday = include
+	return &cp
+}
+
+// analysisEnd returns the exclusive end of an analysis window for today: today
+// itself, or tomorrow when the service counts today.
+func (s *Service) analysisEnd(today time.Time) time.Time {
+	if s.analysisIncludeToday {
+		return today.AddDate(0, 0, 1)
+	}
+	return today
+}
+
 // GetUserPreferences retrieves the workout preferences for a user.
 func (s *Service) GetUserPreferences(ctx context.Context) (domain.Preferences, error) {
 	prefs, err := s.repos.Preferences.Get(ctx)
@@ -89,7 +154,15 @@ func (s *Service) GetUserPreferences(ctx context.Context) (domain.Preferences, e
 // SaveUserPreferences saves the workout preferences for a user.
 // If deload is being enabled and no anchor is provided, the anchor is snapped
 // to the next Monday so the first mesocycle starts with an accumulation week.
+// An out-of-range MaxExercisesPerSession is rejected with a
+// domain.ValidationError.
 func (s *Service) SaveUserPreferences(ctx context.Context, prefs domain.Preferences) error {
+	if err := domain.ValidateMaxExercisesPerSession(prefs.MaxExercisesPerSession); err != nil {
+		return err
+	}
+	if err := domain.ValidateDeloadInterval(prefs.DeloadInterval); err != nil {
+		return err
+	}
 	current, err := s.repos.Preferences.Get(ctx)
 	if err != nil {
 		return fmt.Errorf("load current preferences: %w", err)
@@ -109,6 +182,24 @@ func (s *Service) SaveUserPreferences(ctx context.Context, prefs domain.Preferen
 	return nil
 }
 
+// SetRestSeconds overrides the goal-derived inter-set rest for exerciseID with
+// the user's own choice. The override applies wherever the exercise appears —
+// the rest chip and the rest-over push both read it from the slot. Returns a
+// domain.ValidationError when seconds is outside the accepted range, and
+// domain.ErrNotFound (wrapped) for an unknown exercise.
+func (s *Service) SetRestSeconds(ctx context.Context, exerciseID, seconds int) error {
+	if err := domain.ValidateRestOverride(seconds); err != nil {
+		return err
+	}
+	if _, err := s.repos.Exercises.Get(ctx, exerciseID); err != nil {
+		return fmt.Errorf("get exercise %d: %w", exerciseID, err)
+	}
+	if err := s.repos.Preferences.SetRestOverride(ctx, exerciseID, seconds); err != nil {
+		return fmt.Errorf("set rest seconds: %w", err)
+	}
+	return nil
+}
+
 // RestartMesocycleAnchor snaps the mesocycle anchor to the next Monday,
 // effectively restarting the deload cycle from that date. Additionally
 // clears IsDeload on every current-week session dated today or later
@@ -1,6 +1,8 @@
 package service_test
 
 import (
+	"errors"
+	"strings"
 	"testing"
 	"time"
 
@@ -209,3 +211,133 @@ func Test_RestartMesocycleAnchor_LeavesCompletedSessionsAlone(t *testing.T) {
 			sessions[todayIdx].Date.Weekday())
 	}
 }
+
+func Test_SetRestSeconds_OverridesSlotRest(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+
+	weekPlan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := weekPlan.Sessions[0].Date
+	if err = svc.StartSession(ctx, monday); err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	sess, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if len(sess.Slots) == 0 {
+		t.Fatal("Monday session has no exercises")
+	}
+	exerciseID := sess.Slots[0].Exercise.ID
+
+	for _, bad := range []int{domain.MinRestOverrideSeconds - 1, domain.MaxRestOverrideSeconds + 1} {
+		var ve domain.ValidationError
+		if err = svc.SetRestSeconds(ctx, exerciseID, bad); !errors.As(err, &ve) {
+			t.Errorf("SetRestSeconds(%d) = %v, want ValidationError", bad, err)
+		}
+	}
+	if err = svc.SetRestSeconds(ctx, 999_999, 60); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("SetRestSeconds(unknown exercise) = %v, want ErrNotFound", err)
+	}
+
+	if err = svc.SetRestSeconds(ctx, exerciseID, 45); err != nil {
+		t.Fatalf("SetRestSeconds: %v", err)
+	}
+	if sess, err = svc.GetSession(ctx, monday); err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if got := sess.Slots[0].RestSeconds(sess.Goal, sess.IsDeload); got != 45 {
+		t.Errorf("RestSeconds = %d, want the 45 s override", got)
+	}
+}
+
+func Test_SaveUserPreferences_MaxExercisesPerSession(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	prefs, err := svc.GetUserPreferences(ctx)
+	if err != nil {
+		t.Fatalf("GetUserPreferences: %v", err)
+	}
+
+	var ve domain.ValidationError
+	for _, n := range []int{-1, domain.MaxExercisesPerSessionLimit + 1} {
+		prefs.MaxExercisesPerSession = n
+		if err = svc.SaveUserPreferences(ctx, prefs); !errors.As(err, &ve) {
+			t.Errorf("SaveUserPreferences(max %d): error = %v, want a ValidationError", n, err)
+		}
+	}
+
+	prefs.MaxExercisesPerSession = 2
+	if err = svc.SaveUserPreferences(ctx, prefs); err != nil {
+		t.Fatalf("SaveUserPreferences(max 2): %v", err)
+	}
+	if err = svc.RegenerateWeeklyPlanIfUnstarted(ctx); err != nil {
+		t.Fatalf("RegenerateWeeklyPlanIfUnstarted: %v", err)
+	}
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	for _, sess := range plan.Sessions {
+		if len(sess.Slots) > 2 {
+			t.Errorf("%s has %d exercises, want at most 2", sess.Date.Format(time.DateOnly), len(sess.Slots))
+		}
+	}
+}
+
+func Test_SaveUserPreferences_DeloadInterval(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	prefs, err := svc.GetUserPreferences(ctx)
+	if err != nil {
+		t.Fatalf("GetUserPreferences: %v", err)
+	}
+
+	var ve domain.ValidationError
+	for _, n := range []int{-1, domain.MinDeloadInterval - 1, domain.MaxDeloadInterval + 1} {
+		prefs.DeloadInterval = n
+		if err = svc.SaveUserPreferences(ctx, prefs); !errors.As(err, &ve) {
+			t.Errorf("SaveUserPreferences(interval %d): error = %v, want a ValidationError", n, err)
+		}
+	}
+
+	for _, n := range []int{0, domain.MinDeloadInterval, domain.MaxDeloadInterval} {
+		prefs.DeloadInterval = n
+		if err = svc.SaveUserPreferences(ctx, prefs); err != nil {
+			t.Errorf("SaveUserPreferences(interval %d): %v", n, err)
+		}
+	}
+}
+
+func Test_WriteMetrics_RecordsPlanningLatency(t *testing.T) {
+	t.Parallel()
+
+	// setupTestService schedules Mon/Wed/Fri, so Tuesday is planned ad hoc.
+	ctx, svc := setupTestService(t)
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	if err = svc.StartSession(ctx, plan.Sessions[0].Date.AddDate(0, 0, 1)); err != nil {
+		t.Fatalf("StartSession on Tuesday: %v", err)
+	}
+
+	var b strings.Builder
+	if err = svc.WriteMetrics(&b); err != nil {
+		t.Fatalf("WriteMetrics: %v", err)
+	}
+	for _, want := range []string{
+		"petra_plan_week_duration_seconds_count 1\n",
+		"petra_plan_day_duration_seconds_count 1\n",
+	} {
+		if !strings.Contains(b.String(), want) {
+			t.Errorf("metrics missing %q; got:\n%s", want, b.String())
+		}
+	}
+}
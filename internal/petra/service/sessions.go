@@ -2,12 +2,14 @@ package service
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log/slog"
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/petra/repository"
 	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
 )
 
@@ -70,25 +72,23 @@ func (s *Service) ResolveWeeklySchedule(ctx context.Context) (domain.WeekPlan, e
 }
 
 // planWeek builds an in-memory WeekPlan using the Planner and seeds deload
-// weights. Replaces the old generateWeeklyPlan helper.
+// weights. Replaces the old generateWeeklyPlan helper. Its latency, failed
+// attempts included, feeds the petra_plan_week_duration_seconds histogram.
 func (s *Service) planWeek(ctx context.Context, monday time.Time) (domain.WeekPlan, error) {
-	prefs, err := s.repos.Preferences.Get(ctx)
-	if err != nil {
-		return domain.WeekPlan{}, fmt.Errorf("get preferences: %w", err)
-	}
-	exercises, err := s.repos.Exercises.List(ctx)
-	if err != nil {
-		return domain.WeekPlan{}, fmt.Errorf("get exercises: %w", err)
-	}
-	targets, err := s.repos.MuscleTargets.List(ctx)
+	defer s.planWeekLatency.ObserveSince(time.Now())
+	planner, err := s.newPlanner(ctx, monday)
 	if err != nil {
-		return domain.WeekPlan{}, fmt.Errorf("get muscle group targets: %w", err)
+		return domain.WeekPlan{}, err
 	}
-	planner := domain.NewPlanner(prefs, exercises, targets)
 	plan, err := planner.Plan(monday)
 	if err != nil {
 		return domain.WeekPlan{}, fmt.Errorf("plan week: %w", err)
 	}
+	for i := range plan.Sessions {
+		if len(plan.Sessions[i].Slots) > 0 {
+			s.warnCategoryFallback(ctx, planner, plan.Sessions[i].Date)
+		}
+	}
 	for i := range plan.Sessions {
 		if !plan.Sessions[i].IsDeload || len(plan.Sessions[i].Slots) == 0 {
 			continue
@@ -100,6 +100,156 @@ func (s *Service) planWeek(ctx context.Context, monday time.Time) (domain.WeekPl
 	return plan, nil
 }
 
+// PlannerSnapshot exports userID's planner inputs for the current week as
+// JSON, for support to reproduce a generated plan offline with
+// domain.DecodePlannerSnapshot and PlannerSnapshot.Replay. It acts as userID
+// whoever is signed in, so callers must restrict it to admins.
+func (s *Service) PlannerSnapshot(ctx context.Context, userID int) ([]byte, error) {
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
+	monday := domain.MondayOf(time.Now())
+	planner, err := s.newPlanner(ctx, monday)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(planner.Snapshot(monday))
+	if err != nil {
+		return nil, fmt.Errorf("encode planner snapshot: %w", err)
+	}
+	return data, nil
+}
+
+// newPlanner is loadPlanner plus a warning for each exercise in the pool
+// without primary muscle groups.
+func (s *Service) newPlanner(ctx context.Context, before time.Time) (*domain.Planner, error) {
+	planner, err := s.loadPlanner(ctx, before)
+	if err != nil {
+		return nil, err
+	}
+	s.warnMissingMuscleGroups(ctx, planner.Exercises)
+	return planner, nil
+}
+
+// loadPlanner loads the authenticated user's planner inputs for planning days
+// from before on: preferences, the exercise pool, muscle-group targets, and
+// the recent history Exercise.MinDaysBetween and the muscle-group recency
+// bias need. They are read from one snapshot, so a set completed while the
+// planner is being built is either in the history or not, never half-applied.
+func (s *Service) loadPlanner(ctx context.Context, before time.Time) (*domain.Planner, error) {
+	var planner *domain.Planner
+	err := s.repos.ReadSnapshot(ctx, func(snap *repository.Snapshot) error {
+		prefs, err := snap.Preferences(ctx)
+		if err != nil {
+			return fmt.Errorf("get preferences: %w", err)
+		}
+		exercises, err := snap.Exercises(ctx)
+		if err != nil {
+			return fmt.Errorf("get exercises: %w", err)
+		}
+		targets, err := snap.MuscleTargets(ctx)
+		if err != nil {
+			return fmt.Errorf("get muscle group targets: %w", err)
+		}
+		planner = domain.NewPlanner(prefs, exercises, targets)
+		if !s.features.MuscleGroupRecency {
+			planner.MuscleGroupRecencyPenalty = 0
+		}
+		return loadPlannerHistory(ctx, snap, planner, before)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("read planner inputs: %w", err)
+	}
+	return planner, nil
+}
+
+// loadPlannerHistory fills in the history planner needs for days from before
+// on: when each exercise was last completed, for Exercise.MinDaysBetween,
+// when each muscle group was last trained, for the recency bias, and, for a
+// user with deloads on, the run of weeks their lifts have progressed.
+// It reads sessions back as far as the longest of those needs.
+func loadPlannerHistory(
+	ctx context.Context, snap *repository.Snapshot, planner *domain.Planner, before time.Time,
+) error {
+	autoDeload := planner.Prefs.DeloadEnabled
+	days := max(domain.MaxMinDaysBetween(planner.Exercises), domain.MuscleGroupRecencyLookbackDays)
+	if autoDeload {
+		// The threshold's weeks, the week the oldest of them is compared
+		// with, and up to a week of before's own week.
+		days = max(days, 7*(planner.AutoDeloadAfterWeeks+2))
+	}
+	sessions, err := snap.Sessions(ctx, before.AddDate(0, 0, -days))
+	if err != nil {
+		return fmt.Errorf("list recent sessions: %w", err)
+	}
+	planner.LastPerformed = domain.LastPerformedDates(sessions, before)
+	planner.MuscleGroupsLastTrained = domain.MuscleGroupsLastTrainedDates(sessions, before)
+	if autoDeload {
+		planner.ProgressionStreak = domain.ProgressionStreakWeeks(sessions, before)
+	}
+	return nil
+}
+
+// warnMissingMuscleGroups logs each exercise without primary muscle groups.
+// The planner still uses them, classing compound movement by category (see
+// domain.Exercise.IsCompound), but cannot score their volume, so the warning
+// flags a catalogue row to fix.
+func (s *Service) warnMissingMuscleGroups(ctx context.Context, exercises []domain.Exercise) {
+	for _, ex := range exercises {
+		if ex.MissingMuscleGroups() {
+			s.logger.LogAttrs(ctx, slog.LevelWarn, "exercise has no primary muscle groups",
+				slog.Int("exercise_id", ex.ID),
+				slog.String("exercise", ex.Name),
+				slog.String("category", string(ex.Category)))
+		}
+	}
+}
+
+// warnCategoryFallback logs when the planner had to plan date as full body
+// because the catalogue has no exercise for the day's derived category. The
+// workout is still produced; the warning flags a catalogue gap to fill.
+func (s *Service) warnCategoryFallback(ctx context.Context, planner *domain.Planner, date time.Time) {
+	if skipped, fellBack := planner.CategoryFallback(date); fellBack {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "no exercises for day category, planned full body instead",
+			slog.String("category", string(skipped)),
+			slog.String("date", date.Format(time.DateOnly)))
+	}
+}
+
+// warnVolumeCeiling logs each slot planDay trimmed to keep a muscle group
+// under its weekly ceiling (≈ MRV). Ad-hoc days are the only place this
+// bites: they are planned after the rest of the week already carries its
+// volume.
+func (s *Service) warnVolumeCeiling(ctx context.Context, sess domain.Session, warnings []domain.VolumeCeilingWarning) {
+	for _, w := range warnings {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "session would exceed weekly muscle group ceiling, sets reduced",
+			slog.String("muscle_group", w.MuscleGroup),
+			slog.Int("exercise_id", w.ExerciseID),
+			slog.Float64("prior_volume", w.PriorVolume),
+			slog.Int("max_sets", w.MaxSets),
+			slog.Int("planned_sets", w.PlannedSets),
+			slog.Int("kept_sets", w.KeptSets),
+			slog.String("date", sess.Date.Format(time.DateOnly)))
+	}
+}
+
+// GenerateWeek previews the workouts the planner would produce for the week
+// containing weekStart, keyed by session date. Only scheduled days appear;
+// rest days are omitted. Nothing is persisted and any stored plan for that
+// week is ignored, so the preview reflects the user's current preferences.
+func (s *Service) GenerateWeek(ctx context.Context, weekStart time.Time) (map[time.Time]domain.Session, error) {
+	plan, err := s.planWeek(ctx, domain.MondayOf(weekStart))
+	if err != nil {
+		return nil, err
+	}
+	week := make(map[time.Time]domain.Session)
+	for _, sess := range plan.Sessions {
+		if len(sess.Slots) == 0 {
+			continue
+		}
+		week[sess.Date] = sess
+	}
+	return week, nil
+}
+
 // seedDeloadWeights sets the per-set weight for every weighted exercise in a
 // deload session to GetDeloadStartingWeight (a fraction of the user's recent
 // working weight). Called for both weekly-plan generation and ad-hoc session
@@ -144,27 +294,45 @@ func usedExerciseIDs(plan domain.WeekPlan) map[int]bool {
 	return used
 }
 
-// planSingleDay builds a Session for date via the Planner, seeding deload
-// weights if needed. Pure in-memory; no DB writes. Returns the session ready
-// to be placed into a WeekPlan at the right offset. The supplied plan is
-// the current week's persisted state: planSingleDay derives the no-repeat
-// used-set and the per-MG volume seed from it so PlanDay's
-// target-aware selection sees what the rest of the week already covers.
+// planSingleDay builds a Session for date via planDay, seeding deload
+// weights if needed and logging what the planner had to work around. Pure
+// in-memory; no DB writes. Returns the session ready to be placed into a
+// WeekPlan at the right offset. Its latency feeds
+// petra_plan_day_duration_seconds.
 func (s *Service) planSingleDay(
-	ctx context.Context, date time.Time, plan domain.WeekPlan,
+	ctx context.Context, date time.Time, plan domain.WeekPlan, category domain.Category,
 ) (domain.Session, error) {
-	prefs, err := s.repos.Preferences.Get(ctx)
+	defer s.planDayLatency.ObserveSince(time.Now())
+	planner, err := s.newPlanner(ctx, date)
 	if err != nil {
-		return domain.Session{}, fmt.Errorf("get preferences: %w", err)
+		return domain.Session{}, err
 	}
-	exercises, err := s.repos.Exercises.List(ctx)
+	sess, warnings, err := planDay(planner, date, plan, category)
 	if err != nil {
-		return domain.Session{}, fmt.Errorf("get exercises: %w", err)
+		return domain.Session{}, err
 	}
-	targets, err := s.repos.MuscleTargets.List(ctx)
-	if err != nil {
-		return domain.Session{}, fmt.Errorf("get muscle group targets: %w", err)
+	if category == "" {
+		s.warnCategoryFallback(ctx, planner, date)
+	}
+	s.warnVolumeCeiling(ctx, sess, warnings)
+	if sess.IsDeload {
+		if err = s.seedDeloadWeights(ctx, &sess); err != nil {
+			return domain.Session{}, err
+		}
 	}
+	return sess, nil
+}
+
+// planDay plans date with planner and trims it to the weekly volume ceiling,
+// returning the trims made. The supplied plan is the current week's persisted
+// state: planDay derives the no-repeat used-set and the per-MG volume seed
+// from it so PlanDay's target-aware selection sees what the rest of the week
+// already covers. An empty category leaves the choice to the planner; any
+// other is planned as is via PlanDayAs. It neither logs, records metrics nor
+// seeds weights, so ComparePlanToActual can plan a day without side effects.
+func planDay(
+	planner *domain.Planner, date time.Time, plan domain.WeekPlan, category domain.Category,
+) (domain.Session, []domain.VolumeCeilingWarning, error) {
 	used := usedExerciseIDs(plan)
 	var sessions []domain.Session
 	for i := range plan.Sessions {
@@ -173,17 +341,19 @@ func (s *Service) planSingleDay(
 		}
 	}
 	weekLoad := domain.WeeklyPlannedVolume(sessions)
-	planner := domain.NewPlanner(prefs, exercises, targets)
-	sess, err := planner.PlanDay(date, used, weekLoad)
-	if err != nil {
-		return domain.Session{}, fmt.Errorf("plan day %s: %w", date.Format(time.DateOnly), err)
+	var (
+		sess domain.Session
+		err  error
+	)
+	if category == "" {
+		sess, err = planner.PlanDay(date, used, weekLoad)
+	} else {
+		sess, err = planner.PlanDayAs(date, category, used, weekLoad)
 	}
-	if sess.IsDeload {
-		if err = s.seedDeloadWeights(ctx, &sess); err != nil {
-			return domain.Session{}, err
-		}
+	if err != nil {
+		return domain.Session{}, nil, fmt.Errorf("plan day %s: %w", date.Format(time.DateOnly), err)
 	}
-	return sess, nil
+	return sess, planner.CapAtMaxVolume(&sess, weekLoad), nil
 }
 
 // createAdHocSession plans and persists a single session for date via the
@@ -191,16 +361,21 @@ func (s *Service) planSingleDay(
 // starts an unscheduled day (extra workout) or a day added to the schedule
 // mid-week after another in-week session was already started. plan is the
 // current week's persisted state; planSingleDay derives both the no-repeat
-// used-set and the per-MG volume seed from it.
+// used-set and the per-MG volume seed from it. category is passed on to
+// planSingleDay.
 //
 // The closure overwrites the rest-day placeholder at the right offset; the
 // single-pass reinsert in WeekPlanRepository.Update writes each slot's
 // array index into the exercise_slots.position column, so other
-// sessions' slot positions survive untouched.
+// sessions' slot positions survive untouched. If the offset already holds a
+// session with slots or a start time, the closure returns
+// domain.ErrAlreadyExists instead of replacing it.
 // Callers must ensure the week row exists first (StartSession does so via
 // WeekPlans.Create) — Update returns domain.ErrNotFound otherwise.
-func (s *Service) createAdHocSession(ctx context.Context, date time.Time, plan domain.WeekPlan) error {
-	sess, err := s.planSingleDay(ctx, date, plan)
+func (s *Service) createAdHocSession(
+	ctx context.Context, date time.Time, plan domain.WeekPlan, category domain.Category,
+) error {
+	sess, err := s.planSingleDay(ctx, date, plan, category)
 	if err != nil {
 		return err
 	}
@@ -213,6 +388,12 @@ func (s *Service) createAdHocSession(ctx context.Context, date time.Time, plan d
 				date.Format(time.DateOnly), monday.Format(time.DateOnly),
 			)
 		}
+		// A concurrent StartSession may have inserted (and even started) the
+		// day between our plan read and this transaction. Keep its session
+		// rather than clobbering it with a freshly planned one.
+		if existing := wp.Sessions[offset]; len(existing.Slots) > 0 || !existing.StartedAt.IsZero() {
+			return domain.ErrAlreadyExists
+		}
 		wp.Sessions[offset] = sess
 		return nil
 	})
@@ -229,31 +410,40 @@ func (s *Service) createAdHocSession(ctx context.Context, date time.Time, plan d
 // and inserted before the start mutation. If the whole week is missing the
 // existing weekly-plan generation path runs first; only then is the per-date
 // check applied.
+//
+// StartSession is retry-safe: concurrent or repeated calls for the same date
+// converge on one session. Losing the week-create or ad-hoc-insert race
+// surfaces as domain.ErrAlreadyExists and is tolerated, and a second Start
+// is a no-op via domain.ErrAlreadyStarted.
 func (s *Service) StartSession(ctx context.Context, date time.Time) error {
+	return s.startSession(ctx, date, "")
+}
+
+// StartAdHocSession is StartSession with the focus of a workout planned on
+// the spot chosen by the user, so an extra workout on a rest day can be upper
+// or lower body rather than whatever the schedule around it suggests. When
+// date already has a workout, that one is started and category is ignored.
+// Returns a domain.ValidationError when category is not a known Category.
+func (s *Service) StartAdHocSession(ctx context.Context, date time.Time, category domain.Category) error {
+	if !category.IsValid() {
+		return domain.ValidationError{Message: "Choose full body, upper body or lower body for the workout."}
+	}
+	return s.startSession(ctx, date, category)
+}
+
+// startSession implements StartSession and StartAdHocSession; an empty
+// category leaves the ad-hoc choice to the planner.
+func (s *Service) startSession(ctx context.Context, date time.Time, category domain.Category) error {
 	monday := domain.MondayOf(date)
-	plan, err := s.repos.WeekPlans.Get(ctx, monday)
-	if err != nil && !errors.Is(err, domain.ErrNotFound) {
-		return fmt.Errorf("get week of %s: %w", date.Format(time.DateOnly), err)
-	}
-	if errors.Is(err, domain.ErrNotFound) {
-		newPlan, planErr := s.planWeek(ctx, monday)
-		if planErr != nil {
-			return planErr
-		}
-		if createErr := s.repos.WeekPlans.Create(ctx, newPlan); createErr != nil &&
-			!errors.Is(createErr, domain.ErrAlreadyExists) {
-			return fmt.Errorf("create week for %s: %w", date.Format(time.DateOnly), createErr)
-		}
-		plan, err = s.repos.WeekPlans.Get(ctx, monday)
-		if err != nil {
-			return fmt.Errorf("re-get week for %s: %w", date.Format(time.DateOnly), err)
-		}
+	plan, err := s.ensureWeek(ctx, date)
+	if err != nil {
+		return err
 	}
 
 	sessOnDate := plan.SessionOn(date)
 	hasDate := sessOnDate != nil && len(sessOnDate.Slots) > 0
 	if !hasDate {
-		if err = s.createAdHocSession(ctx, date, plan); err != nil && !errors.Is(err, domain.ErrAlreadyExists) {
+		if err = s.createAdHocSession(ctx, date, plan, category); err != nil && !errors.Is(err, domain.ErrAlreadyExists) {
 			return fmt.Errorf("create ad-hoc %s: %w", date.Format(time.DateOnly), err)
 		}
 	}
@@ -270,6 +460,54 @@ func (s *Service) StartSession(ctx context.Context, date time.Time) error {
 	return nil
 }
 
+// ensureWeek returns the week plan holding date, planning and persisting the
+// week first when it has none. Losing the create race to a concurrent caller
+// is tolerated: the week it wrote is read back instead.
+func (s *Service) ensureWeek(ctx context.Context, date time.Time) (domain.WeekPlan, error) {
+	monday := domain.MondayOf(date)
+	plan, err := s.repos.WeekPlans.Get(ctx, monday)
+	if err == nil {
+		return plan, nil
+	}
+	if !errors.Is(err, domain.ErrNotFound) {
+		return domain.WeekPlan{}, fmt.Errorf("get week of %s: %w", date.Format(time.DateOnly), err)
+	}
+	newPlan, err := s.planWeek(ctx, monday)
+	if err != nil {
+		return domain.WeekPlan{}, err
+	}
+	if err = s.repos.WeekPlans.Create(ctx, newPlan); err != nil && !errors.Is(err, domain.ErrAlreadyExists) {
+		return domain.WeekPlan{}, fmt.Errorf("create week for %s: %w", date.Format(time.DateOnly), err)
+	}
+	if plan, err = s.repos.WeekPlans.Get(ctx, monday); err != nil {
+		return domain.WeekPlan{}, fmt.Errorf("re-get week for %s: %w", date.Format(time.DateOnly), err)
+	}
+	return plan, nil
+}
+
+// RescheduleWorkout moves the planned, unstarted workout on fromDate to
+// toDate, for a missed day whose workout should not be lost. The session
+// keeps its exercises, goal and deload flag, and fromDate becomes a rest day.
+// The move is a WeekPlan mutation, so both dates must fall in the same week;
+// otherwise a domain.ValidationError is returned. WeekPlan.Reschedule's
+// sentinels come back wrapped: domain.ErrAlreadyStarted when either day has
+// been started, domain.ErrAlreadyExists when toDate already has a workout,
+// and domain.ErrNotFound when fromDate has none or the week isn't planned.
+func (s *Service) RescheduleWorkout(ctx context.Context, fromDate, toDate time.Time) error {
+	monday := domain.MondayOf(fromDate)
+	if !domain.MondayOf(toDate).Equal(monday) {
+		return domain.ValidationError{Message: "A workout can only be moved to another day in the same week."}
+	}
+	err := s.repos.WeekPlans.Update(ctx, monday, func(wp *domain.WeekPlan) error {
+		return wp.Reschedule(fromDate, toDate)
+	})
+	if err != nil {
+		return fmt.Errorf("reschedule %s to %s: %w",
+			fromDate.Format(time.DateOnly), toDate.Format(time.DateOnly), err)
+	}
+	return nil
+}
+
 // CompleteSession marks a workout session as completed. When the session
 // has not been started yet — e.g. a user retroactively logging a workout
 // they performed in real life — Start is invoked first inside the same
@@ -286,21 +524,88 @@ func (s *Service) CompleteSession(ctx context.Context, date time.Time) error {
 				return fmt.Errorf("auto-start before complete: %w", err)
 			}
 		}
+		if sess.ClockSkewed(now) {
+			s.logger.LogAttrs(ctx, slog.LevelWarn, "clock skew: workout completed before it started",
+				slog.Time("started_at", sess.StartedAt), slog.Time("completed_at", now))
+		}
 		return sess.Complete(now)
 	}); err != nil {
 		return fmt.Errorf("update session %s: %w", date.Format(time.DateOnly), err)
 	}
-	if s.scheduler != nil {
-		userID := contexthelpers.AuthenticatedUserID(ctx)
-		if err := s.scheduler.CancelForWorkout(ctx, userID, date); err != nil {
-			s.logger.LogAttrs(ctx, slog.LevelWarn, "cancel pending pushes on workout complete",
-				slog.Any("error", err))
+	s.cancelPushesOnComplete(ctx, date)
+	return nil
+}
+
+// cancelPushesOnComplete drops the pending rest pushes for a workout that has
+// just been completed. A failure only leaves a stale push behind, so it is
+// logged rather than returned.
+func (s *Service) cancelPushesOnComplete(ctx context.Context, date time.Time) {
+	if s.scheduler == nil {
+		return
+	}
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+	if err := s.scheduler.CancelForWorkout(ctx, userID, date); err != nil {
+		s.logger.LogAttrs(ctx, slog.LevelWarn, "cancel pending pushes on workout complete",
+			slog.Any("error", err))
+	}
+}
+
+// ConvertToCircuit turns the planned, unstarted workout on date into a
+// circuit: timed rounds of durationSeconds through its exercises instead of
+// sets. See domain.Session.ConvertToCircuit for how the sets collapse; an
+// out-of-range duration or an empty session is a domain.ValidationError, and
+// a started session returns domain.ErrAlreadyStarted wrapped. Returns
+// domain.ErrFeatureDisabled wrapped when the deployment turned circuits off;
+// CompleteCircuit still accepts circuits converted before that.
+func (s *Service) ConvertToCircuit(ctx context.Context, date time.Time, durationSeconds int) error {
+	if !s.features.Circuits {
+		return fmt.Errorf("convert session %s to circuit: %w", date.Format(time.DateOnly), domain.ErrFeatureDisabled)
+	}
+	if err := s.repos.WeekPlans.Update(ctx, domain.MondayOf(date), func(wp *domain.WeekPlan) error {
+		return wp.ConvertToCircuit(date, durationSeconds)
+	}); err != nil {
+		return fmt.Errorf("convert session %s to circuit: %w", date.Format(time.DateOnly), err)
+	}
+	return nil
+}
+
+// CompleteCircuit is the circuit counterpart to CompleteSession: it records
+// the rounds finished within the window on every exercise and marks the
+// session completed, starting it first when the user logs after the fact.
+// Returns domain.ErrNotCircuit wrapped when the session is prescribed as sets.
+func (s *Service) CompleteCircuit(ctx context.Context, date time.Time, rounds int) error {
+	if err := s.repos.WeekPlans.Update(ctx, domain.MondayOf(date), func(wp *domain.WeekPlan) error {
+		sess := wp.SessionOn(date)
+		if sess == nil {
+			return domain.ErrNotFound
+		}
+		if !sess.IsCircuit() {
+			return domain.ErrNotCircuit
+		}
+		now := time.Now()
+		if sess.StartedAt.IsZero() {
+			if err := sess.Start(now); err != nil {
+				return fmt.Errorf("auto-start before complete: %w", err)
+			}
 		}
+		if err := sess.RecordCircuitRounds(rounds, now); err != nil {
+			return err
+		}
+		if sess.ClockSkewed(now) {
+			s.logger.LogAttrs(ctx, slog.LevelWarn, "clock skew: workout completed before it started",
+				slog.Time("started_at", sess.StartedAt), slog.Time("completed_at", now))
+		}
+		return sess.Complete(now)
+	}); err != nil {
+		return fmt.Errorf("complete circuit %s: %w", date.Format(time.DateOnly), err)
 	}
+	s.cancelPushesOnComplete(ctx, date)
 	return nil
 }
 
 // SaveFeedback saves the difficulty rating for a completed workout session.
+// A rating outside 1-5 is rejected with domain.ErrInvalidDifficultyRating
+// wrapped, leaving any earlier rating in place.
 func (s *Service) SaveFeedback(ctx context.Context, date time.Time, difficulty int) error {
 	if err := s.repos.WeekPlans.Update(ctx, domain.MondayOf(date), func(wp *domain.WeekPlan) error {
 		return wp.SetDifficulty(date, difficulty)
@@ -1,7 +1,6 @@
 package service_test
 
 import (
-	"context"
 	"errors"
 	"fmt"
 	"slices"
@@ -48,6 +47,42 @@ func Test_ResolveWeeklySchedule_GeneratesFullWeekOnFirstLoad(t *testing.T) {
 	}
 }
 
+// Test_GenerateWeek_PreviewsOnlyEnabledDaysWithoutPersisting previews next
+// week (no stored plan yet). Only the Mon/Wed/Fri days enabled in the test
+// preferences may appear, and the preview must not create a week plan.
+func Test_GenerateWeek_PreviewsOnlyEnabledDaysWithoutPersisting(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+
+	nextMonday := domain.MondayOf(time.Now()).AddDate(0, 0, 7)
+	// Any day in the week selects it; pass a Thursday to exercise that.
+	week, err := svc.GenerateWeek(ctx, nextMonday.AddDate(0, 0, 3))
+	if err != nil {
+		t.Fatalf("GenerateWeek: %v", err)
+	}
+
+	got := make([]time.Weekday, 0, len(week))
+	for date, sess := range week {
+		if !date.Equal(sess.Date) {
+			t.Errorf("key %s does not match session date %s", date, sess.Date)
+		}
+		if len(sess.Slots) == 0 {
+			t.Errorf("%s: previewed session has no exercises", date.Weekday())
+		}
+		got = append(got, date.Weekday())
+	}
+	slices.Sort(got)
+	want := []time.Weekday{time.Monday, time.Wednesday, time.Friday}
+	if !slices.Equal(got, want) {
+		t.Errorf("previewed weekdays = %v, want %v", got, want)
+	}
+
+	if _, err = svc.Repos().WeekPlans.Get(ctx, nextMonday); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("WeekPlans.Get after preview: err = %v, want ErrNotFound (preview must not persist)", err)
+	}
+}
+
 func Test_ResolveWeeklySchedule_DoesNotRegenerateExistingSessions(t *testing.T) {
 	t.Parallel()
 
@@ -314,6 +349,44 @@ func Test_StartSession_CreatesAdHocSessionForUnscheduledToday(t *testing.T) {
 	}
 }
 
+func Test_StartAdHocSession_PlansChosenCategory(t *testing.T) {
+	t.Parallel()
+
+	// With Mon/Wed/Fri preferences the planner would make Tuesday a lower
+	// body day, since Wednesday follows it. The user asks for upper body.
+	ctx, svc := setupTestService(t)
+
+	weekPlan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	tue := weekPlan.Sessions[0].Date.AddDate(0, 0, 1)
+
+	var ve domain.ValidationError
+	if err = svc.StartAdHocSession(ctx, tue, "arms"); !errors.As(err, &ve) {
+		t.Fatalf("StartAdHocSession(arms) error = %v, want a ValidationError", err)
+	}
+	if err = svc.StartAdHocSession(ctx, tue, domain.CategoryUpper); err != nil {
+		t.Fatalf("StartAdHocSession(upper): %v", err)
+	}
+
+	sess, err := svc.GetSession(ctx, tue)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if sess.StartedAt.IsZero() {
+		t.Error("StartedAt is zero — the ad-hoc session was not started")
+	}
+	if len(sess.Slots) == 0 {
+		t.Fatal("ad-hoc session has no exercises")
+	}
+	for _, slot := range sess.Slots {
+		if slot.Exercise.Category != domain.CategoryUpper {
+			t.Errorf("slot %q is %s, want only upper body exercises", slot.Exercise.Name, slot.Exercise.Category)
+		}
+	}
+}
+
 func Test_StartSession_CreatesNewlyScheduledMidWeekDay(t *testing.T) {
 	t.Parallel()
 
@@ -397,6 +470,75 @@ func Test_StartSession_DoubleStartIsIdempotent(t *testing.T) {
 	}
 }
 
+// Test_StartSession_ConcurrentStartsConvergeOnOneSession fires concurrent
+// starts for the same unscheduled date, as a double-tapped Start button does.
+// Every caller must succeed, exactly one workout_sessions row may exist, and
+// every caller must observe the same started session afterwards — a late
+// caller must not replace a session another caller already started.
+func Test_StartSession_ConcurrentStartsConvergeOnOneSession(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc, db := setupTestServiceWithDB(t)
+
+	weekPlan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	tue := weekPlan.Sessions[0].Date.AddDate(0, 0, 1)
+
+	const goroutines = 8
+	var (
+		wg       sync.WaitGroup
+		errs     = make(chan error, goroutines)
+		observed = make(chan time.Time, goroutines)
+	)
+	for range goroutines {
+		wg.Go(func() {
+			if startErr := svc.StartSession(ctx, tue); startErr != nil {
+				errs <- startErr
+				return
+			}
+			sess, getErr := svc.GetSession(ctx, tue)
+			if getErr != nil {
+				errs <- getErr
+				return
+			}
+			observed <- sess.StartedAt
+		})
+	}
+	wg.Wait()
+	close(errs)
+	close(observed)
+
+	for err = range errs {
+		t.Errorf("concurrent StartSession: %v", err)
+	}
+
+	var rows int
+	if err = db.ReadOnly.QueryRowContext(ctx,
+		"SELECT COUNT(*) FROM workout_sessions WHERE user_id = ? AND workout_date = ?",
+		contexthelpers.AuthenticatedUserID(ctx), tue.Format(time.DateOnly)).Scan(&rows); err != nil {
+		t.Fatalf("count sessions: %v", err)
+	}
+	if rows != 1 {
+		t.Errorf("workout_sessions rows for %s = %d, want 1", tue.Format(time.DateOnly), rows)
+	}
+
+	final, err := svc.GetSession(ctx, tue)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if final.StartedAt.IsZero() {
+		t.Fatal("StartedAt is zero after concurrent starts")
+	}
+	for startedAt := range observed {
+		if !startedAt.Equal(final.StartedAt) {
+			t.Errorf("a caller observed StartedAt %v, final session has %v (session was replaced)",
+				startedAt, final.StartedAt)
+		}
+	}
+}
+
 func Test_GenerateWorkout_SessionGoalTypeAlternatesAcrossSessions(t *testing.T) {
 	t.Parallel()
 
@@ -422,8 +564,7 @@ func Test_GenerateWorkout_SessionGoalTypeAlternatesAcrossSessions(t *testing.T)
 		t.Fatalf("insert user: %v", err)
 	}
 
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	svc := service.NewService(db, logger, "")
 
@@ -556,7 +697,7 @@ func Test_MarkWarmupComplete_AfterFirstSetComplete_SchedulesSet2(t *testing.T) {
 	weight := 100.0
 	sig := domain.SignalOnTarget
 	// Complete set 1 first.
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 	// Now click warmup-complete (out-of-order user behavior, but legal).
@@ -600,7 +741,7 @@ func Test_MarkWarmupComplete_AllSetsComplete_CancelsAndDoesNotSchedule(t *testin
 	weight := 100.0
 	sig := domain.SignalOnTarget
 	// Complete the only set, then call warmup-complete on an exhausted slot.
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 	fake.mu.Lock()
@@ -1151,3 +1292,193 @@ func Test_StartDeloadNow_BuildProgressionReturnsDeloadWeight(t *testing.T) {
 			weightAfter, weightBefore)
 	}
 }
+
+// Test_RescheduleWorkout_MovesPlannedSession moves Monday's planned workout
+// to the Tuesday rest day: it must then exist on Tuesday only. Moving Friday
+// onto a started Wednesday is refused and leaves both days as they were.
+func Test_RescheduleWorkout_MovesPlannedSession(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday, tuesday := plan.Sessions[0].Date, plan.Sessions[1].Date
+	wednesday, friday := plan.Sessions[2].Date, plan.Sessions[4].Date
+	wantIDs := extractExerciseIDs(plan.Sessions[0])
+	wantGoal := plan.Sessions[0].Goal
+
+	if err = svc.RescheduleWorkout(ctx, monday, tuesday); err != nil {
+		t.Fatalf("RescheduleWorkout: %v", err)
+	}
+
+	moved, err := svc.GetSession(ctx, tuesday)
+	if err != nil {
+		t.Fatalf("GetSession(tuesday): %v", err)
+	}
+	if got := extractExerciseIDs(moved); !slices.Equal(got, wantIDs) {
+		t.Errorf("tuesday exercises = %v, want monday's %v", got, wantIDs)
+	}
+	if moved.Goal != wantGoal {
+		t.Errorf("tuesday goal = %q, want %q", moved.Goal, wantGoal)
+	}
+	if left, getErr := svc.GetSession(ctx, monday); !errors.Is(getErr, domain.ErrNotFound) {
+		t.Errorf("GetSession(monday) = (%d slots, %v), want ErrNotFound", len(left.Slots), getErr)
+	}
+
+	if err = svc.StartSession(ctx, wednesday); err != nil {
+		t.Fatalf("StartSession(wednesday): %v", err)
+	}
+	if err = svc.RescheduleWorkout(ctx, friday, wednesday); !errors.Is(err, domain.ErrAlreadyStarted) {
+		t.Errorf("reschedule onto started session: error = %v, want ErrAlreadyStarted", err)
+	}
+	if fri, getErr := svc.GetSession(ctx, friday); getErr != nil || len(fri.Slots) == 0 {
+		t.Errorf("friday after refused move = (%d slots, %v), want its planned workout", len(fri.Slots), getErr)
+	}
+
+	var ve domain.ValidationError
+	if err = svc.RescheduleWorkout(ctx, friday, friday.AddDate(0, 0, 3)); !errors.As(err, &ve) {
+		t.Errorf("cross-week reschedule: error = %v, want a ValidationError", err)
+	}
+}
+
+func Test_CompleteCircuit_RecordsRounds(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday, wednesday := plan.Sessions[0].Date, plan.Sessions[2].Date
+	wantIDs := extractExerciseIDs(plan.Sessions[0])
+
+	if err = svc.CompleteCircuit(ctx, wednesday, 3); !errors.Is(err, domain.ErrNotCircuit) {
+		t.Errorf("CompleteCircuit on a set-based session: error = %v, want ErrNotCircuit", err)
+	}
+	var ve domain.ValidationError
+	if err = svc.ConvertToCircuit(ctx, monday, 30); !errors.As(err, &ve) {
+		t.Errorf("ConvertToCircuit(30s): error = %v, want a ValidationError", err)
+	}
+
+	if err = svc.ConvertToCircuit(ctx, monday, 20*60); err != nil {
+		t.Fatalf("ConvertToCircuit: %v", err)
+	}
+	circuit, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if !circuit.IsCircuit() || circuit.CircuitSeconds() != 20*60 {
+		t.Fatalf("IsCircuit = %t, CircuitSeconds = %d, want a 1200 s circuit",
+			circuit.IsCircuit(), circuit.CircuitSeconds())
+	}
+	if got := extractExerciseIDs(circuit); !slices.Equal(got, wantIDs) {
+		t.Errorf("circuit exercises = %v, want the planned %v", got, wantIDs)
+	}
+	for _, slot := range circuit.Slots {
+		if len(slot.Sets) != 1 {
+			t.Errorf("exercise %d has %d sets, want 1 round prescription", slot.Exercise.ID, len(slot.Sets))
+		}
+	}
+
+	if err = svc.CompleteCircuit(ctx, monday, 4); err != nil {
+		t.Fatalf("CompleteCircuit: %v", err)
+	}
+	done, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession after completion: %v", err)
+	}
+	if rounds, ok := done.CircuitRounds(); !ok || rounds != 4 {
+		t.Errorf("CircuitRounds = (%d, %t), want (4, true)", rounds, ok)
+	}
+	if done.Status() != domain.SessionCompleted {
+		t.Errorf("Status = %q, want %q", done.Status(), domain.SessionCompleted)
+	}
+	if err = svc.ConvertToCircuit(ctx, monday, 20*60); !errors.Is(err, domain.ErrAlreadyStarted) {
+		t.Errorf("ConvertToCircuit after completion: error = %v, want ErrAlreadyStarted", err)
+	}
+}
+
+func Test_SaveFeedback_RejectsOutOfRange(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	plan, err := svc.ResolveWeeklySchedule(ctx)
+	if err != nil {
+		t.Fatalf("ResolveWeeklySchedule: %v", err)
+	}
+	monday := plan.Sessions[0].Date
+	if err = svc.CompleteSession(ctx, monday); err != nil {
+		t.Fatalf("CompleteSession: %v", err)
+	}
+	if err = svc.SaveFeedback(ctx, monday, 4); err != nil {
+		t.Fatalf("SaveFeedback(4): %v", err)
+	}
+
+	for _, rating := range []int{0, 6, 7, -1} {
+		if err = svc.SaveFeedback(ctx, monday, rating); !errors.Is(err, domain.ErrInvalidDifficultyRating) {
+			t.Errorf("SaveFeedback(%d): error = %v, want ErrInvalidDifficultyRating", rating, err)
+		}
+	}
+	sess, err := svc.GetSession(ctx, monday)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if sess.DifficultyRating == nil || *sess.DifficultyRating != 4 {
+		t.Errorf("DifficultyRating = %v, want the earlier 4 kept", sess.DifficultyRating)
+	}
+}
+
+// Test_PlannerSnapshot_ReplaysCurrentWeek exports a snapshot without a signed-in
+// user in the context, decodes it and replays it offline; the replayed week
+// must match the one the service generates for the user.
+func Test_PlannerSnapshot_ReplaysCurrentWeek(t *testing.T) {
+	t.Parallel()
+
+	ctx, svc := setupTestService(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	data, err := svc.PlannerSnapshot(t.Context(), userID)
+	if err != nil {
+		t.Fatalf("PlannerSnapshot: %v", err)
+	}
+	snapshot, err := domain.DecodePlannerSnapshot(data)
+	if err != nil {
+		t.Fatalf("DecodePlannerSnapshot: %v", err)
+	}
+	monday := domain.MondayOf(time.Now())
+	if !snapshot.Monday.Equal(monday) {
+		t.Errorf("snapshot Monday = %v, want %v", snapshot.Monday, monday)
+	}
+	replayed, err := snapshot.Replay()
+	if err != nil {
+		t.Fatalf("Replay: %v", err)
+	}
+
+	want, err := svc.GenerateWeek(ctx, monday)
+	if err != nil {
+		t.Fatalf("GenerateWeek: %v", err)
+	}
+	got := 0
+	for _, sess := range replayed.Sessions {
+		if len(sess.Slots) == 0 {
+			continue
+		}
+		got++
+		w, ok := want[sess.Date]
+		if !ok {
+			t.Errorf("replay planned %s, which the service left as a rest day", sess.Date.Weekday())
+			continue
+		}
+		if !slices.Equal(extractExerciseIDs(sess), extractExerciseIDs(w)) {
+			t.Errorf("%s exercises = %v, want %v", sess.Date.Weekday(), extractExerciseIDs(sess), extractExerciseIDs(w))
+		}
+		if sess.Goal != w.Goal {
+			t.Errorf("%s goal = %v, want %v", sess.Date.Weekday(), sess.Goal, w.Goal)
+		}
+	}
+	if got != len(want) || got == 0 {
+		t.Errorf("replayed %d workouts, want %d", got, len(want))
+	}
+}
@@ -3,8 +3,10 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
 	"time"
 
 	"github.com/myrjola/petrapp/internal/petra/domain"
@@ -45,7 +47,10 @@ func (s *Service) UpdateCompletedValue(
 
 // RecordSet atomically persists the signal (nil for deload sets), weight
 // (nil for time-based sets), completed value (reps or seconds depending on
-// exercise type), and timestamp.
+// exercise type), flags, and timestamp. A technical failure holds the weight
+// on the next set; a warmup set is left out of records, 1RM estimates and the
+// next session's starting load; a side lets SideImbalance compare the two.
+// Returns a domain.ValidationError for an unknown side.
 func (s *Service) RecordSet(
 	ctx context.Context,
 	date time.Time,
@@ -54,7 +59,11 @@ func (s *Service) RecordSet(
 	signal *domain.Signal,
 	weightKg *float64,
 	completedValue int,
+	flags domain.SetFlags,
 ) error {
+	if flags.Side != nil && !flags.Side.Valid() {
+		return domain.ValidationError{Message: "Side must be left, right, or both."}
+	}
 	var (
 		wasComplete   bool
 		postSlot      domain.ExerciseSlot
@@ -83,6 +92,9 @@ func (s *Service) RecordSet(
 			// the outer `if err != nil` wraps for diagnostic context.
 			return recErr //nolint:wrapcheck // outer fmt.Errorf wraps with date context.
 		}
+		if flagErr := sess.SetFlags(pos, setIndex, flags); flagErr != nil {
+			return flagErr //nolint:wrapcheck // outer fmt.Errorf wraps with date context.
+		}
 		if pos >= 0 && pos < len(sess.Slots) {
 			postSlot = sess.Slots[pos]
 			postSlotOK = true
@@ -100,6 +112,44 @@ func (s *Service) RecordSet(
 	return nil
 }
 
+// errSetBatchRejected aborts the week-plan update in RecordSetBatch so that a
+// batch with an invalid item persists nothing.
+var errSetBatchRejected = errors.New("set batch rejected")
+
+// RecordSetBatch records completions for the workout on date in one
+// transaction: either every item is saved or, when any item is invalid, none
+// is. itemErrs holds one entry per item (nil when valid) whenever the batch
+// reached validation. Rest pushes are not scheduled, since batched sets are
+// typically synced after the fact.
+func (s *Service) RecordSetBatch(
+	ctx context.Context,
+	date time.Time,
+	items []domain.SetCompletion,
+) ([]error, error) {
+	if len(items) == 0 || len(items) > domain.MaxSetBatchSize {
+		return nil, domain.ValidationError{
+			Message: fmt.Sprintf("A batch must hold 1 to %d sets.", domain.MaxSetBatchSize),
+		}
+	}
+	var itemErrs []error
+	now := time.Now().UTC()
+	err := s.repos.WeekPlans.Update(ctx, domain.MondayOf(date), func(wp *domain.WeekPlan) error {
+		sess := wp.SessionOn(date)
+		if sess == nil {
+			return domain.ErrNotFound
+		}
+		itemErrs = sess.RecordSets(items, now)
+		if slices.ContainsFunc(itemErrs, func(err error) bool { return err != nil }) {
+			return errSetBatchRejected
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, errSetBatchRejected) {
+		return nil, fmt.Errorf("update session %s: %w", date.Format(time.DateOnly), err)
+	}
+	return itemErrs, nil
+}
+
 // applyRestPushDecision runs the rest-push policy against the post-mutation
 // slot and acts on the result. The completion itself is already persisted,
 // so failures here just mean the user won't get a notification — they are
@@ -44,8 +44,7 @@ func Test_RecordSetCompletion(t *testing.T) {
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	// Deadlift is pre-seeded by fixtures.sql; fetch its ID directly.
 	var exerciseID int
@@ -85,7 +84,7 @@ func Test_RecordSetCompletion(t *testing.T) {
 
 	weight := 102.5
 	sig := domain.SignalOnTarget
-	if err = svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err = svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
@@ -118,6 +117,31 @@ func Test_RecordSetCompletion(t *testing.T) {
 	if set.CompletedAt == nil {
 		t.Error("completed_at: want non-nil")
 	}
+	if set.TechnicalFailure {
+		t.Error("technical_failure: want false before flagging")
+	}
+
+	// Re-recording the set with flags writes them in the same update.
+	flags := domain.SetFlags{TechnicalFailure: true, Warmup: true, Side: nil}
+	if err = svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, flags); err != nil {
+		t.Fatalf("RecordSet with flags: %v", err)
+	}
+	sess, err = svc.GetSession(ctx, date)
+	if err != nil {
+		t.Fatalf("GetSession after flagging: %v", err)
+	}
+	if got := sess.Slots[0].Sets[0]; !got.TechnicalFailure || !got.Warmup {
+		t.Errorf("flags: got technical_failure=%t warmup=%t, want both true", got.TechnicalFailure, got.Warmup)
+	}
+	records, err := svc.ListPersonalRecords(ctx)
+	if err != nil {
+		t.Fatalf("ListPersonalRecords: %v", err)
+	}
+	for _, pr := range records {
+		if pr.Exercise.ID == es.Exercise.ID {
+			t.Errorf("personal record %+v counts the only set, which is marked warmup", pr)
+		}
+	}
 }
 
 // fakeScheduler captures Schedule/Cancel calls in test.
@@ -209,7 +233,7 @@ func Test_RecordSet_SchedulesRestPush(t *testing.T) {
 	weight := 100.0
 	date := time.Now().UTC().Truncate(24 * time.Hour)
 	sig := domain.SignalOnTarget
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
@@ -243,7 +267,7 @@ func Test_RecordSet_LastSetDoesNotSchedule(t *testing.T) {
 	weight := 100.0
 	date := time.Now().UTC().Truncate(24 * time.Hour)
 	sig2 := domain.SignalOnTarget
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig2, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig2, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
@@ -307,7 +331,7 @@ func Test_RecordSet_LastSetOfSlotWhileOtherSlotsIncomplete_Cancels(t *testing.T)
 	weight := 100.0
 	date := time.Now().UTC().Truncate(24 * time.Hour)
 	sig := domain.SignalOnTarget
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
@@ -354,7 +378,7 @@ func Test_UpdateCompletedValue_DoesNotTouchScheduler(t *testing.T) {
 	date := time.Now().UTC().Truncate(24 * time.Hour)
 	weight := 100.0
 	sig := domain.SignalOnTarget
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet (seed completion): %v", err)
 	}
 
@@ -450,7 +474,7 @@ func Test_RecordSet_RerecordCompletedSet_DoesNotReschedule(t *testing.T) {
 	date := time.Now().UTC().Truncate(24 * time.Hour)
 	weight := 100.0
 	sig := domain.SignalOnTarget
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet (first): %v", err)
 	}
 
@@ -463,7 +487,7 @@ func Test_RecordSet_RerecordCompletedSet_DoesNotReschedule(t *testing.T) {
 
 	// Re-record the same set with a different value. wasComplete is true now,
 	// so the policy must not be re-invoked.
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 6); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 6, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet (re-record): %v", err)
 	}
 
@@ -503,8 +527,7 @@ func setupSessionForRecordSet(t *testing.T) (context.Context, *sqlitekit.Databas
 	if err != nil {
 		t.Fatalf("insert user: %v", err)
 	}
-	ctx = context.WithValue(ctx, contexthelpers.AuthenticatedUserIDContextKey, userID)
-	ctx = context.WithValue(ctx, contexthelpers.IsAuthenticatedContextKey, true)
+	ctx = contexthelpers.WithAuthenticatedUserID(ctx, userID)
 
 	var exerciseID int
 	if err = db.ReadOnly.QueryRowContext(ctx,
@@ -574,7 +597,7 @@ func Test_RecordSet_FailedSchedule_LogsUserAndPosition(t *testing.T) {
 	weight := 100.0
 	sig := domain.SignalOnTarget
 	date := time.Now().UTC().Truncate(24 * time.Hour)
-	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5); err != nil {
+	if err := svc.RecordSet(ctx, date, pos, 0, &sig, &weight, 5, domain.SetFlags{}); err != nil {
 		t.Fatalf("RecordSet: %v", err)
 	}
 
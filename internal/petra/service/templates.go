@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+)
+
+// SaveTemplate saves the structure of the authenticated user's session on
+// date as a template called name; see domain.NewWorkoutTemplate for what is
+// kept. Returns a domain.ValidationError when the name is unusable or already
+// taken, or the session has nothing to save.
+func (s *Service) SaveTemplate(ctx context.Context, date time.Time, name string) (domain.WorkoutTemplate, error) {
+	sess, err := s.GetSession(ctx, date)
+	if err != nil {
+		return domain.WorkoutTemplate{}, err
+	}
+	tmpl, err := domain.NewWorkoutTemplate(name, sess)
+	if err != nil {
+		return domain.WorkoutTemplate{}, err
+	}
+	created, err := s.repos.Templates.Create(ctx, tmpl)
+	if errors.Is(err, domain.ErrAlreadyExists) {
+		return domain.WorkoutTemplate{}, domain.ValidationError{
+			Message: fmt.Sprintf("You already have a template called %q.", tmpl.Name),
+		}
+	}
+	if err != nil {
+		return domain.WorkoutTemplate{}, fmt.Errorf("create template: %w", err)
+	}
+	return created, nil
+}
+
+// ListTemplates returns the authenticated user's templates sorted by name.
+func (s *Service) ListTemplates(ctx context.Context) ([]domain.WorkoutTemplate, error) {
+	templates, err := s.repos.Templates.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list templates: %w", err)
+	}
+	return templates, nil
+}
+
+// InstantiateTemplate makes the authenticated user's workout on date the one
+// saved in the template with id, replacing the planned workout or rest day
+// there. Weighted exercises start at GetStartingWeight for the template's
+// goal, so the progression carries on from the user's latest successful sets
+// rather than from whatever weights the template was saved with. The week is
+// planned first when it has not been yet. Returns domain.ErrNotFound
+// (wrapped) when the user has no such template, and domain.ErrAlreadyStarted
+// (wrapped) when the workout on date has begun.
+func (s *Service) InstantiateTemplate(ctx context.Context, id int, date time.Time) error {
+	tmpl, err := s.repos.Templates.Get(ctx, id)
+	if err != nil {
+		return fmt.Errorf("get template %d: %w", id, err)
+	}
+	weights := make(map[int]float64)
+	for _, te := range tmpl.Exercises {
+		if !te.Exercise.HasWeight() {
+			continue
+		}
+		if weights[te.Exercise.ID], err = s.GetStartingWeight(ctx, te.Exercise.ID, date, tmpl.Goal); err != nil {
+			return fmt.Errorf("starting weight for exercise %d: %w", te.Exercise.ID, err)
+		}
+	}
+	sess := tmpl.Session(date, weights)
+
+	if _, err = s.ensureWeek(ctx, date); err != nil {
+		return err
+	}
+	err = s.repos.WeekPlans.Update(ctx, domain.MondayOf(date), func(wp *domain.WeekPlan) error {
+		return wp.PlaceSession(sess)
+	})
+	if err != nil {
+		return fmt.Errorf("place template %d on %s: %w", id, date.Format(time.DateOnly), err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package service_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/myrjola/petrapp/internal/petra/domain"
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+// Test_Templates_SaveAndInstantiate saves a completed strength session as a
+// template and starts it on a rest day the next week. The new workout keeps
+// the exercises, their order and working set counts, and seeds each weight
+// from the latest successful set rather than the exercise default.
+func Test_Templates_SaveAndInstantiate(t *testing.T) {
+	t.Parallel()
+	ctx, svc, db := setupTestServiceWithDB(t)
+	userID := contexthelpers.AuthenticatedUserID(ctx)
+
+	squatID, err := createTestExercise(ctx, t, db, "Template Squat", "lower")
+	if err != nil {
+		t.Fatalf("create squat: %v", err)
+	}
+	pressID, err := createTestExercise(ctx, t, db, "Template Press", "upper")
+	if err != nil {
+		t.Fatalf("create press: %v", err)
+	}
+	source := time.Date(2026, 5, 18, 0, 0, 0, 0, time.UTC)
+	sourceStr := source.Format(time.DateOnly)
+	seed := []string{
+		`INSERT INTO workout_sessions (user_id, workout_date, started_at, completed_at, session_goal)
+		 VALUES (?1, ?2, '2026-05-18T09:00:00.000Z', '2026-05-18T10:00:00.000Z', 'strength')`,
+		`INSERT INTO exercise_slots (workout_user_id, workout_date, position, exercise_id)
+		 VALUES (?1, ?2, 0, ?3), (?1, ?2, 1, ?4)`,
+		`INSERT INTO exercise_sets (workout_user_id, workout_date, position, set_number, weight_kg,
+		                            target_value, completed_value, completed_at, signal, warmup)
+		 VALUES (?1, ?2, 0, 1, 40, 5, 5, '2026-05-18T09:05:00.000Z', NULL, 1),
+		        (?1, ?2, 0, 2, 100, 5, 5, '2026-05-18T09:10:00.000Z', 'on_target', 0),
+		        (?1, ?2, 0, 3, 100, 5, 5, '2026-05-18T09:15:00.000Z', 'on_target', 0),
+		        (?1, ?2, 0, 4, 100, 5, 5, '2026-05-18T09:20:00.000Z', 'on_target', 0),
+		        (?1, ?2, 1, 1, 50, 5, 5, '2026-05-18T09:30:00.000Z', 'on_target', 0),
+		        (?1, ?2, 1, 2, 50, 5, 5, '2026-05-18T09:35:00.000Z', 'on_target', 0)`,
+	}
+	for _, q := range seed {
+		if _, err = db.ReadWrite.ExecContext(ctx, q, userID, sourceStr, squatID, pressID); err != nil {
+			t.Fatalf("seed source session: %v", err)
+		}
+	}
+
+	tmpl, err := svc.SaveTemplate(ctx, source, "Day A")
+	if err != nil {
+		t.Fatalf("SaveTemplate: %v", err)
+	}
+	var ve domain.ValidationError
+	if _, err = svc.SaveTemplate(ctx, source, " Day A "); !errors.As(err, &ve) {
+		t.Errorf("SaveTemplate with a taken name = %v, want a ValidationError", err)
+	}
+
+	// Tuesday is a rest day in the test preferences, and the week is unplanned.
+	target := time.Date(2026, 5, 26, 0, 0, 0, 0, time.UTC)
+	if err = svc.InstantiateTemplate(ctx, tmpl.ID, target); err != nil {
+		t.Fatalf("InstantiateTemplate: %v", err)
+	}
+	sess, err := svc.GetSession(ctx, target)
+	if err != nil {
+		t.Fatalf("GetSession: %v", err)
+	}
+	if sess.Goal != domain.SessionGoalStrength || len(sess.Slots) != 2 {
+		t.Fatalf("session = %+v, want a strength session with the template's two exercises", sess)
+	}
+	wants := []struct {
+		id     int
+		sets   int
+		weight float64
+	}{{squatID, 3, 100}, {pressID, 2, 50}}
+	for i, want := range wants {
+		slot := sess.Slots[i]
+		if slot.Exercise.ID != want.id || len(slot.Sets) != want.sets {
+			t.Errorf("slot %d = exercise %d x%d, want %d x%d", i, slot.Exercise.ID, len(slot.Sets), want.id, want.sets)
+			continue
+		}
+		for _, set := range slot.Sets {
+			if set.WeightKg == nil || *set.WeightKg != want.weight || set.CompletedAt != nil {
+				t.Errorf("slot %d set = %+v, want an open set at %.0f kg", i, set, want.weight)
+			}
+		}
+	}
+
+	if err = svc.StartSession(ctx, target); err != nil {
+		t.Fatalf("StartSession: %v", err)
+	}
+	if err = svc.InstantiateTemplate(ctx, tmpl.ID, target); !errors.Is(err, domain.ErrAlreadyStarted) {
+		t.Errorf("InstantiateTemplate over a started workout = %v, want ErrAlreadyStarted", err)
+	}
+	if err = svc.InstantiateTemplate(ctx, tmpl.ID+1, target); !errors.Is(err, domain.ErrNotFound) {
+		t.Errorf("InstantiateTemplate with an unknown template = %v, want ErrNotFound", err)
+	}
+}
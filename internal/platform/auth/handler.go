@@ -9,6 +9,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/alexedwards/scs/v2"
@@ -36,6 +37,10 @@ type WebAuthnHandler struct {
 	webAuthn       *webauthn.WebAuthn
 	sessionManager *scs.SessionManager
 	store          Store
+	lockout        LockoutConfig
+	// attempts counts failed logins per session and client IP. Nil when the
+	// lockout is disabled.
+	attempts *loginAttempts
 
 	// InternalErrorHandler, when set, owns the response on any internal
 	// failure inside this package (DB lookup errors, etc.). Wired by the
@@ -119,12 +124,28 @@ func New(
 		return nil, fmt.Errorf("new webauthn: %w", err)
 	}
 
-	return &WebAuthnHandler{ //nolint:exhaustruct // InternalErrorHandler is wired by the caller after construction.
+	h := &WebAuthnHandler{ //nolint:exhaustruct // InternalErrorHandler is wired by the caller after construction.
 		logger:         logger,
 		webAuthn:       webAuthn,
 		sessionManager: sessionManager,
 		store:          store,
-	}, nil
+	}
+	h.ConfigureLockout(LockoutConfig{
+		Threshold:        DefaultLockoutThreshold,
+		Cooldown:         DefaultLockoutCooldown,
+		TrustFlyClientIP: false,
+	})
+	return h, nil
+}
+
+// ConfigureLockout replaces the failed-login lockout settings and forgets all
+// recorded failures. Call it before serving requests.
+func (h *WebAuthnHandler) ConfigureLockout(cfg LockoutConfig) {
+	h.lockout = cfg
+	h.attempts = nil
+	if cfg.Threshold > 0 {
+		h.attempts = newLoginAttempts(cfg)
+	}
 }
 
 func (h *WebAuthnHandler) BeginRegistration(ctx context.Context) ([]byte, error) {
@@ -212,7 +233,38 @@ func (h *WebAuthnHandler) BeginLogin(r *http.Request) ([]byte, error) {
 	return out, nil
 }
 
+// FinishLogin validates a passkey assertion and signs the user in. Failed
+// attempts are counted per session and client IP; once either reaches the
+// configured threshold, attempts fail with *LockedOutError until the cooldown
+// has passed. Failures and lockouts are logged as audit events.
 func (h *WebAuthnHandler) FinishLogin(r *http.Request) error {
+	if h.attempts == nil {
+		return h.finishLogin(r)
+	}
+	ctx := r.Context()
+	keys := h.lockoutKeys(r)
+	ip := h.clientIP(r)
+	if retryAfter := h.attempts.lockedFor(keys...); retryAfter > 0 {
+		h.logger.LogAttrs(ctx, slog.LevelWarn, "audit: webauthn login refused during lockout",
+			slog.String("client_ip", ip), slog.Duration("retry_after", retryAfter))
+		return &LockedOutError{RetryAfter: retryAfter}
+	}
+	err := h.finishLogin(r)
+	if err == nil {
+		h.attempts.succeed(keys...)
+		return nil
+	}
+	failures, locked := h.attempts.fail(keys...)
+	h.logger.LogAttrs(ctx, slog.LevelWarn, "audit: webauthn login failed",
+		slog.String("client_ip", ip), slog.Int("consecutive_failures", failures), slog.Any("error", err))
+	if locked {
+		h.logger.LogAttrs(ctx, slog.LevelWarn, "audit: webauthn login locked out",
+			slog.String("client_ip", ip), slog.Duration("cooldown", h.lockout.Cooldown))
+	}
+	return err
+}
+
+func (h *WebAuthnHandler) finishLogin(r *http.Request) error {
 	var (
 		session webauthn.SessionData
 		err     error
@@ -265,23 +317,63 @@ func (h *WebAuthnHandler) Logout(ctx context.Context) error {
 }
 
 func (h *WebAuthnHandler) DeleteUser(ctx context.Context) error {
-	userID := h.sessionManager.Get(ctx, string(userIDSessionKey))
-	if userID == nil {
-		return errors.New("no authenticated user in session")
+	userIDBytes, err := h.sessionUserID(ctx)
+	if err != nil {
+		return err
 	}
 
-	userIDBytes, ok := userID.([]byte)
-	if !ok {
-		return errors.New("invalid user ID type in session")
+	if err = h.store.deleteUser(ctx, userIDBytes); err != nil {
+		return fmt.Errorf("delete user: %w", err)
 	}
 
-	if err := h.store.deleteUser(ctx, userIDBytes); err != nil {
-		return fmt.Errorf("delete user: %w", err)
+	return nil
+}
+
+// DisplayName returns the authenticated user's display name.
+func (h *WebAuthnHandler) DisplayName(ctx context.Context) (string, error) {
+	userIDBytes, err := h.sessionUserID(ctx)
+	if err != nil {
+		return "", err
 	}
+	displayName, err := h.store.getDisplayName(ctx, userIDBytes)
+	if err != nil {
+		return "", fmt.Errorf("get display name: %w", err)
+	}
+	return displayName, nil
+}
 
+// SetDisplayName changes the authenticated user's display name. The name is
+// trimmed of surrounding whitespace first; an error wrapping
+// ErrInvalidDisplayName explains why a name was rejected.
+func (h *WebAuthnHandler) SetDisplayName(ctx context.Context, displayName string) error {
+	userIDBytes, err := h.sessionUserID(ctx)
+	if err != nil {
+		return err
+	}
+	displayName = strings.TrimSpace(displayName)
+	if err = ValidateDisplayName(displayName); err != nil {
+		return err
+	}
+	if err = h.store.setDisplayName(ctx, userIDBytes, displayName); err != nil {
+		return fmt.Errorf("set display name: %w", err)
+	}
 	return nil
 }
 
+// sessionUserID returns the WebAuthn user ID of the user signed in to ctx's session.
+func (h *WebAuthnHandler) sessionUserID(ctx context.Context) ([]byte, error) {
+	userID := h.sessionManager.Get(ctx, string(userIDSessionKey))
+	if userID == nil {
+		return nil, errors.New("no authenticated user in session")
+	}
+
+	userIDBytes, ok := userID.([]byte)
+	if !ok {
+		return nil, errors.New("invalid user ID type in session")
+	}
+	return userIDBytes, nil
+}
+
 func (h *WebAuthnHandler) parseWebAuthnSession(ctx context.Context) (webauthn.SessionData, error) {
 	var (
 		session webauthn.SessionData
@@ -0,0 +1,159 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultLockoutThreshold is how many failed logins a session or client IP
+	// may make in a row before further attempts are refused.
+	DefaultLockoutThreshold = 5
+	// DefaultLockoutCooldown is how long a lockout lasts.
+	DefaultLockoutCooldown = 15 * time.Minute
+)
+
+// LockoutConfig configures the failed-login lockout enforced by FinishLogin.
+type LockoutConfig struct {
+	// Threshold is the number of consecutive failed logins after which the
+	// session and client IP are locked out. Zero or less disables the lockout.
+	Threshold int
+	// Cooldown is how long a lockout lasts before attempts are accepted again.
+	Cooldown time.Duration
+	// TrustFlyClientIP reads the client IP from the Fly-Client-IP header, which
+	// the Fly.io proxy sets on every request. Leave it off elsewhere, since the
+	// header is then client-controlled and RemoteAddr is the real peer.
+	TrustFlyClientIP bool
+}
+
+// LockedOutError is returned by FinishLogin while the session or client IP is
+// locked out after too many failed logins.
+type LockedOutError struct {
+	RetryAfter time.Duration
+}
+
+func (e *LockedOutError) Error() string {
+	return fmt.Sprintf("too many failed logins: retry after %s", e.RetryAfter)
+}
+
+// loginAttempts tracks consecutive failed logins per key. Keys are a session
+// token or a client IP, prefixed so the two cannot collide. A count ages out
+// once a key has gone Cooldown without failing.
+type loginAttempts struct {
+	mu     sync.Mutex
+	cfg    LockoutConfig
+	now    func() time.Time
+	states map[string]*attemptState
+}
+
+type attemptState struct {
+	failures    int
+	lastFailure time.Time
+	lockedUntil time.Time
+}
+
+func newLoginAttempts(cfg LockoutConfig) *loginAttempts {
+	return &loginAttempts{
+		mu:     sync.Mutex{},
+		cfg:    cfg,
+		now:    time.Now,
+		states: make(map[string]*attemptState),
+	}
+}
+
+// lockedFor returns how long any of keys remains locked out, or 0 when none is.
+func (a *loginAttempts) lockedFor(keys ...string) time.Duration {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	var longest time.Duration
+	for _, key := range keys {
+		st, ok := a.states[key]
+		if !ok {
+			continue
+		}
+		if remaining := st.lockedUntil.Sub(now); remaining > longest {
+			longest = remaining
+		}
+	}
+	return longest
+}
+
+// fail records a failed login for each of keys and reports the highest failure
+// count and whether the failure started a lockout.
+func (a *loginAttempts) fail(keys ...string) (int, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := a.now()
+	a.pruneLocked(now)
+	var (
+		most   int
+		locked bool
+	)
+	for _, key := range keys {
+		st, ok := a.states[key]
+		if !ok {
+			st = &attemptState{failures: 0, lastFailure: time.Time{}, lockedUntil: time.Time{}}
+			a.states[key] = st
+		}
+		st.failures++
+		st.lastFailure = now
+		if st.failures >= a.cfg.Threshold {
+			// Start the next count afresh so one more failure after the
+			// cooldown does not immediately lock the key again.
+			st.failures = 0
+			st.lockedUntil = now.Add(a.cfg.Cooldown)
+			locked = true
+			most = max(most, a.cfg.Threshold)
+			continue
+		}
+		most = max(most, st.failures)
+	}
+	return most, locked
+}
+
+// succeed clears the failure history of keys after a successful login.
+func (a *loginAttempts) succeed(keys ...string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, key := range keys {
+		delete(a.states, key)
+	}
+}
+
+// pruneLocked drops keys that are not locked out and have not failed for
+// Cooldown, so stale counts age out and the map does not grow without bound
+// under a spray of one-off IPs.
+func (a *loginAttempts) pruneLocked(now time.Time) {
+	for key, st := range a.states {
+		if !now.Before(st.lockedUntil) && !now.Before(st.lastFailure.Add(a.cfg.Cooldown)) {
+			delete(a.states, key)
+		}
+	}
+}
+
+// lockoutKeys returns the attempt keys for r: its session token, when it has
+// one, and its client IP.
+func (h *WebAuthnHandler) lockoutKeys(r *http.Request) []string {
+	keys := make([]string, 0, 2) //nolint:mnd // session and IP.
+	if token := h.sessionManager.Token(r.Context()); token != "" {
+		keys = append(keys, "session:"+token)
+	}
+	return append(keys, "ip:"+h.clientIP(r))
+}
+
+func (h *WebAuthnHandler) clientIP(r *http.Request) string {
+	if h.lockout.TrustFlyClientIP {
+		if ip := r.Header.Get("Fly-Client-IP"); ip != "" {
+			return ip
+		}
+	}
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
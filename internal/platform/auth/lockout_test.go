@@ -0,0 +1,68 @@
+//nolint:testpackage // exercises the unexported loginAttempts counter; lives in-package by design.
+package auth
+
+import (
+	"testing"
+	"time"
+)
+
+func Test_loginAttempts_LocksAtThresholdAndExpires(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	a := newLoginAttempts(LockoutConfig{Threshold: 3, Cooldown: time.Minute, TrustFlyClientIP: false})
+	a.now = func() time.Time { return now }
+
+	for i := 1; i < 3; i++ {
+		if failures, locked := a.fail("ip:1"); failures != i || locked {
+			t.Fatalf("failure %d = (%d, %v), want (%d, false)", i, failures, locked, i)
+		}
+	}
+	if _, locked := a.fail("ip:1"); !locked {
+		t.Fatal("third failure did not lock out")
+	}
+	if got := a.lockedFor("session:x", "ip:1"); got != time.Minute {
+		t.Errorf("lockedFor = %s, want 1m", got)
+	}
+	if got := a.lockedFor("ip:2"); got != 0 {
+		t.Errorf("unrelated key lockedFor = %s, want 0", got)
+	}
+
+	now = now.Add(time.Minute)
+	if got := a.lockedFor("ip:1"); got != 0 {
+		t.Errorf("lockedFor after cooldown = %s, want 0", got)
+	}
+	if failures, locked := a.fail("ip:1"); failures != 1 || locked {
+		t.Errorf("first failure after cooldown = (%d, %v), want (1, false)", failures, locked)
+	}
+}
+
+func Test_loginAttempts_SuccessResetsCount(t *testing.T) {
+	t.Parallel()
+
+	a := newLoginAttempts(LockoutConfig{Threshold: 2, Cooldown: time.Minute, TrustFlyClientIP: false})
+	a.fail("ip:1")
+	a.succeed("ip:1")
+	if _, locked := a.fail("ip:1"); locked {
+		t.Error("failure after a successful login locked out, want count reset")
+	}
+}
+
+func Test_loginAttempts_FailuresAgeOutAfterCooldown(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.UTC)
+	a := newLoginAttempts(LockoutConfig{Threshold: 3, Cooldown: time.Minute, TrustFlyClientIP: false})
+	a.now = func() time.Time { return now }
+
+	a.fail("ip:1")
+	a.fail("ip:1")
+	a.fail("ip:2")
+	now = now.Add(time.Minute)
+	if failures, locked := a.fail("ip:1"); failures != 1 || locked {
+		t.Errorf("failure after a quiet cooldown = (%d, %v), want (1, false)", failures, locked)
+	}
+	if _, ok := a.states["ip:2"]; ok {
+		t.Error("ip:2 still tracked a cooldown after its only failure, want it pruned")
+	}
+}
@@ -27,6 +27,8 @@ type Store interface {
 	getUserRole(ctx context.Context, webAuthnID []byte) (role, error)
 	getUserIntegerID(ctx context.Context, webAuthnID []byte) (int, error)
 	deleteUser(ctx context.Context, webAuthnID []byte) error
+	getDisplayName(ctx context.Context, webAuthnID []byte) (string, error)
+	setDisplayName(ctx context.Context, webAuthnID []byte, displayName string) error
 }
 
 // SQLiteStore is the sqlitekit-backed implementation of Store.
@@ -234,3 +236,33 @@ func (s *SQLiteStore) deleteUser(ctx context.Context, webauthnUserID []byte) err
 	}
 	return nil
 }
+
+// getDisplayName returns the user's display name, or ErrUserNotFound if the user does not exist.
+func (s *SQLiteStore) getDisplayName(ctx context.Context, webauthnUserID []byte) (string, error) {
+	stmt := `SELECT display_name FROM users WHERE webauthn_user_id = ?`
+	var displayName string
+	if err := s.db.ReadOnly.QueryRowContext(ctx, stmt, webauthnUserID).Scan(&displayName); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUserNotFound
+		}
+		return "", fmt.Errorf("query display name: %w", err)
+	}
+	return displayName, nil
+}
+
+// setDisplayName updates the user's display name, or returns ErrUserNotFound if the user does not exist.
+func (s *SQLiteStore) setDisplayName(ctx context.Context, webauthnUserID []byte, displayName string) error {
+	stmt := `UPDATE users SET display_name = ? WHERE webauthn_user_id = ?`
+	result, err := s.db.ReadWrite.ExecContext(ctx, stmt, displayName, webauthnUserID)
+	if err != nil {
+		return fmt.Errorf("update display name: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("rows affected: %w", err)
+	}
+	if affected == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
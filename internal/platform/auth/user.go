@@ -2,8 +2,11 @@ package auth
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"time"
+	"unicode"
+	"unicode/utf8"
 
 	"github.com/go-webauthn/webauthn/webauthn"
 )
@@ -17,6 +20,32 @@ type user struct {
 
 const webauthnIDSize = 64
 
+// MaxDisplayNameLength is the longest display name, in characters, the users
+// table accepts.
+const MaxDisplayNameLength = 63
+
+// ErrInvalidDisplayName is wrapped by ValidateDisplayName's errors.
+var ErrInvalidDisplayName = errors.New("invalid display name")
+
+// ValidateDisplayName reports why displayName cannot be stored, or nil. A
+// name must be valid UTF-8, 1 to MaxDisplayNameLength characters long and
+// free of control characters such as newlines, which would let it spoof
+// other lines wherever it is shown or logged.
+func ValidateDisplayName(displayName string) error {
+	if !utf8.ValidString(displayName) {
+		return fmt.Errorf("%w: must be valid UTF-8", ErrInvalidDisplayName)
+	}
+	if n := utf8.RuneCountInString(displayName); n == 0 || n > MaxDisplayNameLength {
+		return fmt.Errorf("%w: must be 1 to %d characters", ErrInvalidDisplayName, MaxDisplayNameLength)
+	}
+	for _, r := range displayName {
+		if unicode.IsControl(r) {
+			return fmt.Errorf("%w: must not contain control characters", ErrInvalidDisplayName)
+		}
+	}
+	return nil
+}
+
 // newRandomUser initialises a new user with random ID and anonymous display name.
 func newRandomUser() (user, error) {
 	webAuthnUserID := make([]byte, webauthnIDSize)
@@ -0,0 +1,40 @@
+package auth_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/platform/auth"
+)
+
+func TestValidateDisplayName(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name        string
+		displayName string
+		wantErr     bool
+	}{
+		{"plain", "Alex Müller", false},
+		{"non-latin", "田中倫", false},
+		{"at the limit", strings.Repeat("é", auth.MaxDisplayNameLength), false},
+		{"empty", "", true},
+		{"too long", strings.Repeat("a", auth.MaxDisplayNameLength+1), true},
+		{"newline", "Alex\nadmin", true},
+		{"escape sequence", "Alex\x1b[31m", true},
+		{"invalid UTF-8", "Alex\xff", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			err := auth.ValidateDisplayName(tt.displayName)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("ValidateDisplayName(%q) = %v, want error %t", tt.displayName, err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, auth.ErrInvalidDisplayName) {
+				t.Errorf("error %v does not wrap ErrInvalidDisplayName", err)
+			}
+		})
+	}
+}
@@ -6,9 +6,7 @@ import (
 )
 
 func AuthenticateContext(r *http.Request, userID int, isAdmin bool) *http.Request {
-	ctx := r.Context()
-	ctx = context.WithValue(ctx, IsAuthenticatedContextKey, true)
-	ctx = context.WithValue(ctx, AuthenticatedUserIDContextKey, userID)
+	ctx := WithAuthenticatedUserID(r.Context(), userID)
 	ctx = context.WithValue(ctx, IsAdminContextKey, isAdmin)
 	return r.WithContext(ctx)
 }
@@ -24,3 +22,11 @@ func SetCSPNonce(r *http.Request, cspNonce string) *http.Request {
 	ctx = context.WithValue(ctx, CspNonceContextKey, cspNonce)
 	return r.WithContext(ctx)
 }
+
+// WithAuthenticatedUserID returns a copy of ctx authenticated as userID, as
+// AuthenticateContext does for requests. Background jobs and tests use it to
+// act on behalf of a user without going through the session middleware.
+func WithAuthenticatedUserID(ctx context.Context, userID int) context.Context {
+	ctx = context.WithValue(ctx, IsAuthenticatedContextKey, true)
+	return context.WithValue(ctx, AuthenticatedUserIDContextKey, userID)
+}
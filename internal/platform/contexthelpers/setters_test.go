@@ -0,0 +1,27 @@
+package contexthelpers_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/platform/contexthelpers"
+)
+
+func TestWithAuthenticatedUserID(t *testing.T) {
+	t.Parallel()
+
+	ctx := contexthelpers.WithAuthenticatedUserID(t.Context(), 42)
+	if got := contexthelpers.AuthenticatedUserID(ctx); got != 42 {
+		t.Errorf("AuthenticatedUserID = %d, want 42", got)
+	}
+	if !contexthelpers.IsAuthenticated(ctx) {
+		t.Error("IsAuthenticated = false, want true")
+	}
+
+	// A plain string key must not be mistaken for the typed one.
+	//nolint:staticcheck,revive // Deliberately uses a string key to prove it is ignored.
+	stringKeyed := context.WithValue(t.Context(), "authenticatedUserID", 42)
+	if got := contexthelpers.AuthenticatedUserID(stringKeyed); got != 0 {
+		t.Errorf("AuthenticatedUserID with a string key = %d, want 0", got)
+	}
+}
@@ -137,13 +137,20 @@ func (s *Service) CaptureSlowRequestTrace(ctx context.Context, duration time.Dur
 	s.captureTrace(ctx, "slow", slog.Duration("duration", duration))
 }
 
+// CapturePanicTrace captures a trace when a handler panics, so the
+// scheduling and GC activity leading up to the crash is on disk next to the
+// logged stack. Shares the cooldown with the other triggers.
+func (s *Service) CapturePanicTrace(ctx context.Context) {
+	s.captureTrace(ctx, "panic")
+}
+
 // baseCapturedTraceLogAttrs is the number of attributes always present on
 // the "captured trace" success log line (trigger, file, bytes). Used to
 // pre-size the slice that callers extend with trigger-specific attrs.
 const baseCapturedTraceLogAttrs = 3
 
-// captureTrace is the shared implementation behind CaptureTimeoutTrace and
-// CaptureSlowRequestTrace. prefix becomes the trace filename prefix and a
+// captureTrace is the shared implementation behind CaptureTimeoutTrace,
+// CaptureSlowRequestTrace, and CapturePanicTrace. prefix becomes the trace filename prefix and a
 // log attribute identifying which trigger fired. extraAttrs are appended
 // to the success log line so callers can surface trigger-specific context.
 func (s *Service) captureTrace(ctx context.Context, prefix string, extraAttrs ...slog.Attr) {
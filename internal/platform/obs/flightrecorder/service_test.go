@@ -90,6 +90,39 @@ func TestService_CaptureTimeoutTrace(t *testing.T) {
 	}
 }
 
+//nolint:paralleltest // runtime/trace.NewFlightRecorder is a process-global singleton.
+func TestService_CapturePanicTrace(t *testing.T) {
+	traceDir := t.TempDir()
+
+	logger := slog.New(slog.NewTextHandler(os.Stderr, nil))
+	service, err := flightrecorder.New(flightrecorder.Config{
+		Logger:          logger,
+		MinAge:          0, // Use default
+		MaxBytes:        0, // Use default
+		MaxFiles:        0,
+		TracesDirectory: traceDir,
+	})
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	ctx := context.Background()
+	if err = service.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+	defer service.Stop(ctx)
+
+	service.CapturePanicTrace(ctx)
+
+	entries, err := os.ReadDir(traceDir)
+	if err != nil {
+		t.Fatalf("failed to read trace directory: %v", err)
+	}
+	if len(entries) != 1 || !strings.HasPrefix(entries[0].Name(), "panic-") {
+		t.Errorf("expected one panic-*.trace file, got %v", entries)
+	}
+}
+
 //nolint:paralleltest // runtime/trace.NewFlightRecorder is a process-global singleton.
 func TestService_CooldownPreventsCapture(t *testing.T) {
 	// This test is simplified since we can't access private fields from external package
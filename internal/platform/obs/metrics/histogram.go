@@ -0,0 +1,106 @@
+// Package metrics holds in-process latency histograms and renders them in the
+// Prometheus text exposition format, so a scraper or an admin can follow how
+// an operation's duration shifts over time without a metrics dependency.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// DefaultLatencyBuckets are upper bounds in seconds from 1 ms to 2.5 s,
+// roughly tripling per step, fine enough at the low end to show a
+// millisecond-scale operation drifting slower.
+func DefaultLatencyBuckets() []float64 {
+	return []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5}
+}
+
+// Histogram counts observations into buckets with fixed upper bounds, plus
+// an implicit +Inf bucket. It is safe for concurrent use, and a nil
+// *Histogram ignores observations so callers need not check for one.
+type Histogram struct {
+	name   string
+	help   string
+	bounds []float64
+
+	mu     sync.Mutex
+	counts []uint64 // Per bucket, not cumulative; the last is +Inf.
+	sum    float64
+}
+
+// NewHistogram returns an empty histogram. bounds are bucket upper bounds in
+// the observed unit and are sorted; name and help follow Prometheus
+// conventions, e.g. "petra_plan_week_duration_seconds".
+func NewHistogram(name, help string, bounds []float64) *Histogram {
+	bounds = slices.Clone(bounds)
+	slices.Sort(bounds)
+	return &Histogram{
+		name:   name,
+		help:   help,
+		bounds: bounds,
+		mu:     sync.Mutex{},
+		counts: make([]uint64, len(bounds)+1),
+		sum:    0,
+	}
+}
+
+// Observe records v.
+func (h *Histogram) Observe(v float64) {
+	if h == nil {
+		return
+	}
+	i, _ := slices.BinarySearch(h.bounds, v)
+	h.mu.Lock()
+	h.counts[i]++
+	h.sum += v
+	h.mu.Unlock()
+}
+
+// ObserveSince records the seconds elapsed since start.
+func (h *Histogram) ObserveSince(start time.Time) {
+	h.Observe(time.Since(start).Seconds())
+}
+
+// Count returns the number of observations so far.
+func (h *Histogram) Count() uint64 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	var n uint64
+	for _, c := range h.counts {
+		n += c
+	}
+	return n
+}
+
+// WriteText writes the histogram in the Prometheus text exposition format:
+// HELP and TYPE lines, cumulative _bucket series, _sum and _count.
+func (h *Histogram) WriteText(w io.Writer) error {
+	h.mu.Lock()
+	counts := slices.Clone(h.counts)
+	sum := h.sum
+	h.mu.Unlock()
+
+	if _, err := fmt.Fprintf(w, "# HELP %s %s\n# TYPE %s histogram\n", h.name, h.help, h.name); err != nil {
+		return fmt.Errorf("write %s header: %w", h.name, err)
+	}
+	var cumulative uint64
+	for i, c := range counts {
+		cumulative += c
+		le := "+Inf"
+		if i < len(h.bounds) {
+			le = strconv.FormatFloat(h.bounds[i], 'g', -1, 64)
+		}
+		if _, err := fmt.Fprintf(w, "%s_bucket{le=%q} %d\n", h.name, le, cumulative); err != nil {
+			return fmt.Errorf("write %s bucket: %w", h.name, err)
+		}
+	}
+	if _, err := fmt.Fprintf(w, "%s_sum %s\n%s_count %d\n",
+		h.name, strconv.FormatFloat(sum, 'g', -1, 64), h.name, cumulative); err != nil {
+		return fmt.Errorf("write %s totals: %w", h.name, err)
+	}
+	return nil
+}
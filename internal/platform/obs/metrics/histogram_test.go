@@ -0,0 +1,45 @@
+package metrics_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/myrjola/petrapp/internal/platform/obs/metrics"
+)
+
+func TestHistogram_WriteText(t *testing.T) {
+	t.Parallel()
+
+	h := metrics.NewHistogram("op_duration_seconds", "How long op takes.", []float64{0.5, 0.1})
+	for _, v := range []float64{0.05, 0.1, 0.3, 2} {
+		h.Observe(v)
+	}
+	if got := h.Count(); got != 4 {
+		t.Errorf("Count() = %d, want 4", got)
+	}
+
+	var b strings.Builder
+	if err := h.WriteText(&b); err != nil {
+		t.Fatalf("WriteText: %v", err)
+	}
+	// Bounds are sorted, a value on a bound falls in that bucket, and
+	// buckets are cumulative.
+	want := `# HELP op_duration_seconds How long op takes.
+# TYPE op_duration_seconds histogram
+op_duration_seconds_bucket{le="0.1"} 2
+op_duration_seconds_bucket{le="0.5"} 3
+op_duration_seconds_bucket{le="+Inf"} 4
+op_duration_seconds_sum 2.45
+op_duration_seconds_count 4
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteText =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHistogram_NilIgnoresObservations(t *testing.T) {
+	t.Parallel()
+
+	var h *metrics.Histogram
+	h.Observe(1) // Must not panic.
+}
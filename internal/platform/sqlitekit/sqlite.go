@@ -14,6 +14,13 @@ import (
 	"github.com/mattn/go-sqlite3"
 )
 
+// Database holds the read-write and read-only connection pools for one SQLite
+// file. Callers must use the *Context variants (QueryContext, ExecContext,
+// BeginTx, ...): go-sqlite3 watches the context of an in-flight statement and
+// calls sqlite3_interrupt when it is cancelled, so a request that times out or
+// is abandoned aborts its running query instead of holding a connection until
+// the query finishes on its own. Lock waits are separately bounded by the
+// _busy_timeout DSN parameter set in connect.
 type Database struct {
 	ReadWrite *sql.DB
 	ReadOnly  *sql.DB
@@ -215,3 +215,45 @@ func TestNewDatabase_AppliesProvidedSchema(t *testing.T) {
 		t.Fatalf("want 1 seeded widget, got %d", n)
 	}
 }
+
+// TestNewDatabase_CancelInterruptsRunningQuery pins the driver-level
+// cancellation contract: cancelling the context of an in-flight statement must
+// interrupt it inside SQLite rather than letting it run to completion. The
+// recursive CTE below would otherwise count for minutes.
+func TestNewDatabase_CancelInterruptsRunningQuery(t *testing.T) {
+	t.Parallel()
+
+	logger := testkit.NewLogger(testkit.NewWriter(t))
+	db, err := sqlitekit.NewDatabase(t.Context(), sqlitekit.Config{
+		URL:          ":memory:",
+		Schema:       usersSchema,
+		Fixtures:     "",
+		Logger:       logger,
+		Premigration: nil,
+	})
+	if err != nil {
+		t.Fatalf("NewDatabase: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+
+	const (
+		cancelAfter = 50 * time.Millisecond
+		maxElapsed  = time.Second
+	)
+	ctx, cancel := context.WithTimeout(t.Context(), cancelAfter)
+	defer cancel()
+
+	start := time.Now()
+	var n int
+	err = db.ReadOnly.QueryRowContext(ctx, `
+		WITH RECURSIVE counter(n) AS (SELECT 1 UNION ALL SELECT n + 1 FROM counter)
+		SELECT MAX(n) FROM counter`).Scan(&n)
+	elapsed := time.Since(start)
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("QueryRowContext error = %v, want context.DeadlineExceeded", err)
+	}
+	if elapsed > maxElapsed {
+		t.Errorf("cancelled query returned after %v, want under %v", elapsed, maxElapsed)
+	}
+}